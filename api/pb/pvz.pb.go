@@ -11,6 +11,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -24,6 +25,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PvzStatus int32
+
+const (
+	PvzStatus_PVZ_STATUS_ACTIVE    PvzStatus = 0
+	PvzStatus_PVZ_STATUS_SUSPENDED PvzStatus = 1
+	PvzStatus_PVZ_STATUS_CLOSED    PvzStatus = 2
+)
+
+// Enum value maps for PvzStatus.
+var (
+	PvzStatus_name = map[int32]string{
+		0: "PVZ_STATUS_ACTIVE",
+		1: "PVZ_STATUS_SUSPENDED",
+		2: "PVZ_STATUS_CLOSED",
+	}
+	PvzStatus_value = map[string]int32{
+		"PVZ_STATUS_ACTIVE":    0,
+		"PVZ_STATUS_SUSPENDED": 1,
+		"PVZ_STATUS_CLOSED":    2,
+	}
+)
+
+func (x PvzStatus) Enum() *PvzStatus {
+	p := new(PvzStatus)
+	*p = x
+	return p
+}
+
+func (x PvzStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PvzStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_pvz_proto_enumTypes[0].Descriptor()
+}
+
+func (PvzStatus) Type() protoreflect.EnumType {
+	return &file_pvz_proto_enumTypes[0]
+}
+
+func (x PvzStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PvzStatus.Descriptor instead.
+func (PvzStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{0}
+}
+
 type ReceptionStatus int32
 
 const (
@@ -54,11 +104,11 @@ func (x ReceptionStatus) String() string {
 }
 
 func (ReceptionStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_pvz_proto_enumTypes[0].Descriptor()
+	return file_pvz_proto_enumTypes[1].Descriptor()
 }
 
 func (ReceptionStatus) Type() protoreflect.EnumType {
-	return &file_pvz_proto_enumTypes[0]
+	return &file_pvz_proto_enumTypes[1]
 }
 
 func (x ReceptionStatus) Number() protoreflect.EnumNumber {
@@ -67,21 +117,87 @@ func (x ReceptionStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ReceptionStatus.Descriptor instead.
 func (ReceptionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{1}
+}
+
+type OpeningHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weekday       string                 `protobuf:"bytes,1,opt,name=weekday,proto3" json:"weekday,omitempty"` // mon, tue, wed, thu, fri, sat, sun
+	Opens         string                 `protobuf:"bytes,2,opt,name=opens,proto3" json:"opens,omitempty"`     // HH:MM
+	Closes        string                 `protobuf:"bytes,3,opt,name=closes,proto3" json:"closes,omitempty"`   // HH:MM
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpeningHours) Reset() {
+	*x = OpeningHours{}
+	mi := &file_pvz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpeningHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpeningHours) ProtoMessage() {}
+
+func (x *OpeningHours) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpeningHours.ProtoReflect.Descriptor instead.
+func (*OpeningHours) Descriptor() ([]byte, []int) {
 	return file_pvz_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *OpeningHours) GetWeekday() string {
+	if x != nil {
+		return x.Weekday
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetOpens() string {
+	if x != nil {
+		return x.Opens
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetCloses() string {
+	if x != nil {
+		return x.Closes
+	}
+	return ""
+}
+
 type PVZ struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
 	City             string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Address          string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude         *float64               `protobuf:"fixed64,5,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude        *float64               `protobuf:"fixed64,6,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Phone            string                 `protobuf:"bytes,7,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status           PvzStatus              `protobuf:"varint,8,opt,name=status,proto3,enum=pvz.v1.PvzStatus" json:"status,omitempty"`
+	OpeningHours     []*OpeningHours        `protobuf:"bytes,9,rep,name=opening_hours,json=openingHours,proto3" json:"opening_hours,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *PVZ) Reset() {
 	*x = PVZ{}
-	mi := &file_pvz_proto_msgTypes[0]
+	mi := &file_pvz_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -93,7 +209,7 @@ func (x *PVZ) String() string {
 func (*PVZ) ProtoMessage() {}
 
 func (x *PVZ) ProtoReflect() protoreflect.Message {
-	mi := &file_pvz_proto_msgTypes[0]
+	mi := &file_pvz_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,7 +222,7 @@ func (x *PVZ) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PVZ.ProtoReflect.Descriptor instead.
 func (*PVZ) Descriptor() ([]byte, []int) {
-	return file_pvz_proto_rawDescGZIP(), []int{0}
+	return file_pvz_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *PVZ) GetId() string {
@@ -130,27 +246,167 @@ func (x *PVZ) GetCity() string {
 	return ""
 }
 
-type GetPVZListRequest struct {
+func (x *PVZ) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PVZ) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *PVZ) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *PVZ) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *PVZ) GetStatus() PvzStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PvzStatus_PVZ_STATUS_ACTIVE
+}
+
+func (x *PVZ) GetOpeningHours() []*OpeningHours {
+	if x != nil {
+		return x.OpeningHours
+	}
+	return nil
+}
+
+type Reception struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DateTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	PvzId    string                 `protobuf:"bytes,3,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Status   ReceptionStatus        `protobuf:"varint,4,opt,name=status,proto3,enum=pvz.v1.ReceptionStatus" json:"status,omitempty"`
+	// IDs of the employees who opened and closed the reception; empty for dummy-login users.
+	OpenedBy      string                 `protobuf:"bytes,5,opt,name=opened_by,json=openedBy,proto3" json:"opened_by,omitempty"`
+	ClosedBy      string                 `protobuf:"bytes,6,opt,name=closed_by,json=closedBy,proto3" json:"closed_by,omitempty"`
+	ClosedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reception) Reset() {
+	*x = Reception{}
+	mi := &file_pvz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reception) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reception) ProtoMessage() {}
+
+func (x *Reception) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reception.ProtoReflect.Descriptor instead.
+func (*Reception) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Reception) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reception) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Reception) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *Reception) GetStatus() ReceptionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS
+}
+
+func (x *Reception) GetOpenedBy() string {
+	if x != nil {
+		return x.OpenedBy
+	}
+	return ""
+}
+
+func (x *Reception) GetClosedBy() string {
+	if x != nil {
+		return x.ClosedBy
+	}
+	return ""
+}
+
+func (x *Reception) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+type Product struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DateTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	ReceptionId   string                 `protobuf:"bytes,4,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	Barcode       string                 `protobuf:"bytes,5,opt,name=barcode,proto3" json:"barcode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPVZListRequest) Reset() {
-	*x = GetPVZListRequest{}
-	mi := &file_pvz_proto_msgTypes[1]
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_pvz_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPVZListRequest) String() string {
+func (x *Product) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPVZListRequest) ProtoMessage() {}
+func (*Product) ProtoMessage() {}
 
-func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pvz_proto_msgTypes[1]
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,33 +417,69 @@ func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPVZListRequest.ProtoReflect.Descriptor instead.
-func (*GetPVZListRequest) Descriptor() ([]byte, []int) {
-	return file_pvz_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{3}
 }
 
-type GetPVZListResponse struct {
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Product) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Product) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *Product) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+type ReceptionInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pvzs          []*PVZ                 `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+	Reception     *Reception             `protobuf:"bytes,1,opt,name=reception,proto3" json:"reception,omitempty"`
+	Products      []*Product             `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPVZListResponse) Reset() {
-	*x = GetPVZListResponse{}
-	mi := &file_pvz_proto_msgTypes[2]
+func (x *ReceptionInfo) Reset() {
+	*x = ReceptionInfo{}
+	mi := &file_pvz_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPVZListResponse) String() string {
+func (x *ReceptionInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPVZListResponse) ProtoMessage() {}
+func (*ReceptionInfo) ProtoMessage() {}
 
-func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pvz_proto_msgTypes[2]
+func (x *ReceptionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,38 +490,1680 @@ func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
-func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
-	return file_pvz_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ReceptionInfo.ProtoReflect.Descriptor instead.
+func (*ReceptionInfo) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetPVZListResponse) GetPvzs() []*PVZ {
+func (x *ReceptionInfo) GetReception() *Reception {
 	if x != nil {
-		return x.Pvzs
+		return x.Reception
 	}
 	return nil
 }
 
-var File_pvz_proto protoreflect.FileDescriptor
+func (x *ReceptionInfo) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
 
-const file_pvz_proto_rawDesc = "" +
-	"\n" +
-	"\tpvz.proto\x12\x06pvz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"r\n" +
-	"\x03PVZ\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
-	"\x11registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x12\n" +
-	"\x04city\x18\x03 \x01(\tR\x04city\"\x13\n" +
-	"\x11GetPVZListRequest\"5\n" +
-	"\x12GetPVZListResponse\x12\x1f\n" +
-	"\x04pvzs\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x04pvzs*P\n" +
-	"\x0fReceptionStatus\x12 \n" +
-	"\x1cRECEPTION_STATUS_IN_PROGRESS\x10\x00\x12\x1b\n" +
-	"\x17RECEPTION_STATUS_CLOSED\x10\x012h\n" +
-	"\n" +
-	"PVZService\x12Z\n" +
+type PvzInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvz           *PVZ                   `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	Receptions    []*ReceptionInfo       `protobuf:"bytes,2,rep,name=receptions,proto3" json:"receptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PvzInfo) Reset() {
+	*x = PvzInfo{}
+	mi := &file_pvz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PvzInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PvzInfo) ProtoMessage() {}
+
+func (x *PvzInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PvzInfo.ProtoReflect.Descriptor instead.
+func (*PvzInfo) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PvzInfo) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *PvzInfo) GetReceptions() []*ReceptionInfo {
+	if x != nil {
+		return x.Receptions
+	}
+	return nil
+}
+
+type GetPVZListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page size; 0 returns all PVZs at once as before.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor from next_page_token of the previous page.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPVZListRequest) Reset() {
+	*x = GetPVZListRequest{}
+	mi := &file_pvz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPVZListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPVZListRequest) ProtoMessage() {}
+
+func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPVZListRequest.ProtoReflect.Descriptor instead.
+func (*GetPVZListRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPVZListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetPVZListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetPVZListResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pvzs  []*PVZ                 `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+	// Cursor of the next page, empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	HasMore       bool   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPVZListResponse) Reset() {
+	*x = GetPVZListResponse{}
+	mi := &file_pvz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPVZListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPVZListResponse) ProtoMessage() {}
+
+func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
+func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPVZListResponse) GetPvzs() []*PVZ {
+	if x != nil {
+		return x.Pvzs
+	}
+	return nil
+}
+
+func (x *GetPVZListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetPVZListResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type CreatePvzRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,3,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64               `protobuf:"fixed64,4,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	OpeningHours  []*OpeningHours        `protobuf:"bytes,6,rep,name=opening_hours,json=openingHours,proto3" json:"opening_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePvzRequest) Reset() {
+	*x = CreatePvzRequest{}
+	mi := &file_pvz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePvzRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePvzRequest) ProtoMessage() {}
+
+func (x *CreatePvzRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePvzRequest.ProtoReflect.Descriptor instead.
+func (*CreatePvzRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreatePvzRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CreatePvzRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreatePvzRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *CreatePvzRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *CreatePvzRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreatePvzRequest) GetOpeningHours() []*OpeningHours {
+	if x != nil {
+		return x.OpeningHours
+	}
+	return nil
+}
+
+type CreatePvzResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePvzResponse) Reset() {
+	*x = CreatePvzResponse{}
+	mi := &file_pvz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePvzResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePvzResponse) ProtoMessage() {}
+
+func (x *CreatePvzResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePvzResponse.ProtoReflect.Descriptor instead.
+func (*CreatePvzResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreatePvzResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPvzsInfoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page number, required unless page_token is set.
+	Page  int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Optional reception date filter, applied only when both bounds are set.
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Opaque cursor from next_page_token of the previous page; page is ignored when set.
+	PageToken     string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPvzsInfoRequest) Reset() {
+	*x = GetPvzsInfoRequest{}
+	mi := &file_pvz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPvzsInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPvzsInfoRequest) ProtoMessage() {}
+
+func (x *GetPvzsInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPvzsInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetPvzsInfoRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPvzsInfoRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPvzsInfoRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetPvzsInfoRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetPvzsInfoRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetPvzsInfoRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetPvzsInfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*PvzInfo             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Cursor of the next page, empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	HasMore       bool   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPvzsInfoResponse) Reset() {
+	*x = GetPvzsInfoResponse{}
+	mi := &file_pvz_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPvzsInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPvzsInfoResponse) ProtoMessage() {}
+
+func (x *GetPvzsInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPvzsInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetPvzsInfoResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPvzsInfoResponse) GetItems() []*PvzInfo {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetPvzsInfoResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetPvzsInfoResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type SearchNearbyPvzRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Latitude  float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Search radius in meters, 5000 if not set, at most 100000.
+	RadiusMeters float64 `protobuf:"fixed64,3,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	// Optional filters.
+	City   string     `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Status *PvzStatus `protobuf:"varint,5,opt,name=status,proto3,enum=pvz.v1.PvzStatus,oneof" json:"status,omitempty"`
+	// Maximum number of results, 20 if not set, at most 100.
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchNearbyPvzRequest) Reset() {
+	*x = SearchNearbyPvzRequest{}
+	mi := &file_pvz_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchNearbyPvzRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchNearbyPvzRequest) ProtoMessage() {}
+
+func (x *SearchNearbyPvzRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchNearbyPvzRequest.ProtoReflect.Descriptor instead.
+func (*SearchNearbyPvzRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchNearbyPvzRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *SearchNearbyPvzRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *SearchNearbyPvzRequest) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+func (x *SearchNearbyPvzRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *SearchNearbyPvzRequest) GetStatus() PvzStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return PvzStatus_PVZ_STATUS_ACTIVE
+}
+
+func (x *SearchNearbyPvzRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type NearbyPvz struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Pvz            *PVZ                   `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	DistanceMeters float64                `protobuf:"fixed64,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	// Whether the PVZ is open right now by its opening hours (Moscow time).
+	OpenNow       bool `protobuf:"varint,3,opt,name=open_now,json=openNow,proto3" json:"open_now,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyPvz) Reset() {
+	*x = NearbyPvz{}
+	mi := &file_pvz_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyPvz) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyPvz) ProtoMessage() {}
+
+func (x *NearbyPvz) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyPvz.ProtoReflect.Descriptor instead.
+func (*NearbyPvz) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NearbyPvz) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *NearbyPvz) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *NearbyPvz) GetOpenNow() bool {
+	if x != nil {
+		return x.OpenNow
+	}
+	return false
+}
+
+type SearchNearbyPvzResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*NearbyPvz           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchNearbyPvzResponse) Reset() {
+	*x = SearchNearbyPvzResponse{}
+	mi := &file_pvz_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchNearbyPvzResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchNearbyPvzResponse) ProtoMessage() {}
+
+func (x *SearchNearbyPvzResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchNearbyPvzResponse.ProtoReflect.Descriptor instead.
+func (*SearchNearbyPvzResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchNearbyPvzResponse) GetItems() []*NearbyPvz {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CreateReceptionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	PvzId string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	// If not set, the current time is used.
+	DateTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReceptionRequest) Reset() {
+	*x = CreateReceptionRequest{}
+	mi := &file_pvz_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReceptionRequest) ProtoMessage() {}
+
+func (x *CreateReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReceptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *CreateReceptionRequest) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+type CreateReceptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId   string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReceptionResponse) Reset() {
+	*x = CreateReceptionResponse{}
+	mi := &file_pvz_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReceptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReceptionResponse) ProtoMessage() {}
+
+func (x *CreateReceptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReceptionResponse.ProtoReflect.Descriptor instead.
+func (*CreateReceptionResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateReceptionResponse) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+type AddProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	PvzId string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Type  string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Optional barcode, unique among products currently at PVZs.
+	Barcode       string `protobuf:"bytes,3,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductRequest) Reset() {
+	*x = AddProductRequest{}
+	mi := &file_pvz_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductRequest) ProtoMessage() {}
+
+func (x *AddProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductRequest.ProtoReflect.Descriptor instead.
+func (*AddProductRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AddProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+type AddProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductResponse) Reset() {
+	*x = AddProductResponse{}
+	mi := &file_pvz_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductResponse) ProtoMessage() {}
+
+func (x *AddProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductResponse.ProtoReflect.Descriptor instead.
+func (*AddProductResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddProductResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type DeleteLastProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLastProductRequest) Reset() {
+	*x = DeleteLastProductRequest{}
+	mi := &file_pvz_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLastProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLastProductRequest) ProtoMessage() {}
+
+func (x *DeleteLastProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLastProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLastProductRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteLastProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type DeleteLastProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Barcode       string                 `protobuf:"bytes,3,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLastProductResponse) Reset() {
+	*x = DeleteLastProductResponse{}
+	mi := &file_pvz_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLastProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLastProductResponse) ProtoMessage() {}
+
+func (x *DeleteLastProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLastProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLastProductResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteLastProductResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteLastProductResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DeleteLastProductResponse) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+type GetProductByBarcodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Barcode       string                 `protobuf:"bytes,1,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByBarcodeRequest) Reset() {
+	*x = GetProductByBarcodeRequest{}
+	mi := &file_pvz_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByBarcodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByBarcodeRequest) ProtoMessage() {}
+
+func (x *GetProductByBarcodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByBarcodeRequest.ProtoReflect.Descriptor instead.
+func (*GetProductByBarcodeRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetProductByBarcodeRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+type GetProductByBarcodeResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	PvzId   string                 `protobuf:"bytes,2,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	// Product status: received, ready_for_pickup, issued, returned, in_return, shipped_back.
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByBarcodeResponse) Reset() {
+	*x = GetProductByBarcodeResponse{}
+	mi := &file_pvz_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByBarcodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByBarcodeResponse) ProtoMessage() {}
+
+func (x *GetProductByBarcodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByBarcodeResponse.ProtoReflect.Descriptor instead.
+func (*GetProductByBarcodeResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetProductByBarcodeResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *GetProductByBarcodeResponse) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *GetProductByBarcodeResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetProductByBarcodeResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CloseReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseReceptionRequest) Reset() {
+	*x = CloseReceptionRequest{}
+	mi := &file_pvz_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseReceptionRequest) ProtoMessage() {}
+
+func (x *CloseReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseReceptionRequest.ProtoReflect.Descriptor instead.
+func (*CloseReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CloseReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type CloseReceptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId   string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseReceptionResponse) Reset() {
+	*x = CloseReceptionResponse{}
+	mi := &file_pvz_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseReceptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseReceptionResponse) ProtoMessage() {}
+
+func (x *CloseReceptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseReceptionResponse.ProtoReflect.Descriptor instead.
+func (*CloseReceptionResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CloseReceptionResponse) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+type ExportPvzHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional bounds of the reception period.
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPvzHistoryRequest) Reset() {
+	*x = ExportPvzHistoryRequest{}
+	mi := &file_pvz_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPvzHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPvzHistoryRequest) ProtoMessage() {}
+
+func (x *ExportPvzHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPvzHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ExportPvzHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportPvzHistoryRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *ExportPvzHistoryRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type PvzExportRow struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PvzId               string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	PvzRegistrationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=pvz_registration_date,json=pvzRegistrationDate,proto3" json:"pvz_registration_date,omitempty"`
+	PvzCity             string                 `protobuf:"bytes,3,opt,name=pvz_city,json=pvzCity,proto3" json:"pvz_city,omitempty"`
+	PvzAddress          string                 `protobuf:"bytes,4,opt,name=pvz_address,json=pvzAddress,proto3" json:"pvz_address,omitempty"`
+	PvzStatus           PvzStatus              `protobuf:"varint,5,opt,name=pvz_status,json=pvzStatus,proto3,enum=pvz.v1.PvzStatus" json:"pvz_status,omitempty"`
+	// Reception fields are empty for a PVZ without receptions in the period.
+	ReceptionId       string                 `protobuf:"bytes,6,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	ReceptionDateTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=reception_date_time,json=receptionDateTime,proto3" json:"reception_date_time,omitempty"`
+	ReceptionStatus   *ReceptionStatus       `protobuf:"varint,8,opt,name=reception_status,json=receptionStatus,proto3,enum=pvz.v1.ReceptionStatus,oneof" json:"reception_status,omitempty"`
+	// Product fields are empty for a reception without products.
+	ProductId       string                 `protobuf:"bytes,9,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductDateTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=product_date_time,json=productDateTime,proto3" json:"product_date_time,omitempty"`
+	ProductType     string                 `protobuf:"bytes,11,opt,name=product_type,json=productType,proto3" json:"product_type,omitempty"`
+	ProductBarcode  string                 `protobuf:"bytes,12,opt,name=product_barcode,json=productBarcode,proto3" json:"product_barcode,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PvzExportRow) Reset() {
+	*x = PvzExportRow{}
+	mi := &file_pvz_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PvzExportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PvzExportRow) ProtoMessage() {}
+
+func (x *PvzExportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PvzExportRow.ProtoReflect.Descriptor instead.
+func (*PvzExportRow) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PvzExportRow) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *PvzExportRow) GetPvzRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PvzRegistrationDate
+	}
+	return nil
+}
+
+func (x *PvzExportRow) GetPvzCity() string {
+	if x != nil {
+		return x.PvzCity
+	}
+	return ""
+}
+
+func (x *PvzExportRow) GetPvzAddress() string {
+	if x != nil {
+		return x.PvzAddress
+	}
+	return ""
+}
+
+func (x *PvzExportRow) GetPvzStatus() PvzStatus {
+	if x != nil {
+		return x.PvzStatus
+	}
+	return PvzStatus_PVZ_STATUS_ACTIVE
+}
+
+func (x *PvzExportRow) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *PvzExportRow) GetReceptionDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceptionDateTime
+	}
+	return nil
+}
+
+func (x *PvzExportRow) GetReceptionStatus() ReceptionStatus {
+	if x != nil && x.ReceptionStatus != nil {
+		return *x.ReceptionStatus
+	}
+	return ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS
+}
+
+func (x *PvzExportRow) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PvzExportRow) GetProductDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProductDateTime
+	}
+	return nil
+}
+
+func (x *PvzExportRow) GetProductType() string {
+	if x != nil {
+		return x.ProductType
+	}
+	return ""
+}
+
+func (x *PvzExportRow) GetProductBarcode() string {
+	if x != nil {
+		return x.ProductBarcode
+	}
+	return ""
+}
+
+type StreamPvzActivityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// From 1 to 50 PVZ IDs.
+	PvzIds []string `protobuf:"bytes,1,rep,name=pvz_ids,json=pvzIds,proto3" json:"pvz_ids,omitempty"`
+	// Resume after this event id; if not set, only new events are streamed.
+	LastEventId   *int64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3,oneof" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPvzActivityRequest) Reset() {
+	*x = StreamPvzActivityRequest{}
+	mi := &file_pvz_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPvzActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPvzActivityRequest) ProtoMessage() {}
+
+func (x *StreamPvzActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPvzActivityRequest.ProtoReflect.Descriptor instead.
+func (*StreamPvzActivityRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StreamPvzActivityRequest) GetPvzIds() []string {
+	if x != nil {
+		return x.PvzIds
+	}
+	return nil
+}
+
+func (x *StreamPvzActivityRequest) GetLastEventId() int64 {
+	if x != nil && x.LastEventId != nil {
+		return *x.LastEventId
+	}
+	return 0
+}
+
+type PvzActivityEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event position, used as last_event_id on reconnect.
+	Id      int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId string `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// ReceptionOpened, ProductAdded, ProductRemoved or ReceptionClosed.
+	Type  string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	PvzId string `protobuf:"bytes,4,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	// ID of the reception or product the event is about.
+	AggregateId   string                 `protobuf:"bytes,5,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Payload       *structpb.Struct       `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PvzActivityEvent) Reset() {
+	*x = PvzActivityEvent{}
+	mi := &file_pvz_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PvzActivityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PvzActivityEvent) ProtoMessage() {}
+
+func (x *PvzActivityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PvzActivityEvent.ProtoReflect.Descriptor instead.
+func (*PvzActivityEvent) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PvzActivityEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PvzActivityEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PvzActivityEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PvzActivityEvent) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *PvzActivityEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *PvzActivityEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *PvzActivityEvent) GetPayload() *structpb.Struct {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+var File_pvz_proto protoreflect.FileDescriptor
+
+const file_pvz_proto_rawDesc = "" +
+	"\n" +
+	"\tpvz.proto\x12\x06pvz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"V\n" +
+	"\fOpeningHours\x12\x18\n" +
+	"\aweekday\x18\x01 \x01(\tR\aweekday\x12\x14\n" +
+	"\x05opens\x18\x02 \x01(\tR\x05opens\x12\x16\n" +
+	"\x06closes\x18\x03 \x01(\tR\x06closes\"\xe7\x02\n" +
+	"\x03PVZ\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
+	"\x11registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1f\n" +
+	"\blatitude\x18\x05 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x06 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12\x14\n" +
+	"\x05phone\x18\a \x01(\tR\x05phone\x12)\n" +
+	"\x06status\x18\b \x01(\x0e2\x11.pvz.v1.PvzStatusR\x06status\x129\n" +
+	"\ropening_hours\x18\t \x03(\v2\x14.pvz.v1.OpeningHoursR\fopeningHoursB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"\x8f\x02\n" +
+	"\tReception\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12\x15\n" +
+	"\x06pvz_id\x18\x03 \x01(\tR\x05pvzId\x12/\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x17.pvz.v1.ReceptionStatusR\x06status\x12\x1b\n" +
+	"\topened_by\x18\x05 \x01(\tR\bopenedBy\x12\x1b\n" +
+	"\tclosed_by\x18\x06 \x01(\tR\bclosedBy\x127\n" +
+	"\tclosed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\"\xa3\x01\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12!\n" +
+	"\freception_id\x18\x04 \x01(\tR\vreceptionId\x12\x18\n" +
+	"\abarcode\x18\x05 \x01(\tR\abarcode\"m\n" +
+	"\rReceptionInfo\x12/\n" +
+	"\treception\x18\x01 \x01(\v2\x11.pvz.v1.ReceptionR\treception\x12+\n" +
+	"\bproducts\x18\x02 \x03(\v2\x0f.pvz.v1.ProductR\bproducts\"_\n" +
+	"\aPvzInfo\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\x125\n" +
+	"\n" +
+	"receptions\x18\x02 \x03(\v2\x15.pvz.v1.ReceptionInfoR\n" +
+	"receptions\"O\n" +
+	"\x11GetPVZListRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"x\n" +
+	"\x12GetPVZListResponse\x12\x1f\n" +
+	"\x04pvzs\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x04pvzs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"\xf0\x01\n" +
+	"\x10CreatePvzRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1f\n" +
+	"\blatitude\x18\x03 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x04 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12\x14\n" +
+	"\x05phone\x18\x05 \x01(\tR\x05phone\x129\n" +
+	"\ropening_hours\x18\x06 \x03(\v2\x14.pvz.v1.OpeningHoursR\fopeningHoursB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"#\n" +
+	"\x11CreatePvzResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xcf\x01\n" +
+	"\x12GetPvzsInfoRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"\x7f\n" +
+	"\x13GetPvzsInfoResponse\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.pvz.v1.PvzInfoR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"\xdc\x01\n" +
+	"\x16SearchNearbyPvzRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12#\n" +
+	"\rradius_meters\x18\x03 \x01(\x01R\fradiusMeters\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12.\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x11.pvz.v1.PvzStatusH\x00R\x06status\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limitB\t\n" +
+	"\a_status\"n\n" +
+	"\tNearbyPvz\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\x12\x19\n" +
+	"\bopen_now\x18\x03 \x01(\bR\aopenNow\"B\n" +
+	"\x17SearchNearbyPvzResponse\x12'\n" +
+	"\x05items\x18\x01 \x03(\v2\x11.pvz.v1.NearbyPvzR\x05items\"h\n" +
+	"\x16CreateReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\"<\n" +
+	"\x17CreateReceptionResponse\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\"X\n" +
+	"\x11AddProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\abarcode\x18\x03 \x01(\tR\abarcode\"3\n" +
+	"\x12AddProductResponse\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"1\n" +
+	"\x18DeleteLastProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"n\n" +
+	"\x19DeleteLastProductResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x18\n" +
+	"\abarcode\x18\x03 \x01(\tR\abarcode\"6\n" +
+	"\x1aGetProductByBarcodeRequest\x12\x18\n" +
+	"\abarcode\x18\x01 \x01(\tR\abarcode\"\xb2\x01\n" +
+	"\x1bGetProductByBarcodeResponse\x12)\n" +
+	"\aproduct\x18\x01 \x01(\v2\x0f.pvz.v1.ProductR\aproduct\x12\x15\n" +
+	"\x06pvz_id\x18\x02 \x01(\tR\x05pvzId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\".\n" +
+	"\x15CloseReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\";\n" +
+	"\x16CloseReceptionResponse\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\"\x8b\x01\n" +
+	"\x17ExportPvzHistoryRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xe3\x04\n" +
+	"\fPvzExportRow\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12N\n" +
+	"\x15pvz_registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x13pvzRegistrationDate\x12\x19\n" +
+	"\bpvz_city\x18\x03 \x01(\tR\apvzCity\x12\x1f\n" +
+	"\vpvz_address\x18\x04 \x01(\tR\n" +
+	"pvzAddress\x120\n" +
+	"\n" +
+	"pvz_status\x18\x05 \x01(\x0e2\x11.pvz.v1.PvzStatusR\tpvzStatus\x12!\n" +
+	"\freception_id\x18\x06 \x01(\tR\vreceptionId\x12J\n" +
+	"\x13reception_date_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x11receptionDateTime\x12G\n" +
+	"\x10reception_status\x18\b \x01(\x0e2\x17.pvz.v1.ReceptionStatusH\x00R\x0freceptionStatus\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\t \x01(\tR\tproductId\x12F\n" +
+	"\x11product_date_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0fproductDateTime\x12!\n" +
+	"\fproduct_type\x18\v \x01(\tR\vproductType\x12'\n" +
+	"\x0fproduct_barcode\x18\f \x01(\tR\x0eproductBarcodeB\x13\n" +
+	"\x11_reception_status\"n\n" +
+	"\x18StreamPvzActivityRequest\x12\x17\n" +
+	"\apvz_ids\x18\x01 \x03(\tR\x06pvzIds\x12'\n" +
+	"\rlast_event_id\x18\x02 \x01(\x03H\x00R\vlastEventId\x88\x01\x01B\x10\n" +
+	"\x0e_last_event_id\"\xfb\x01\n" +
+	"\x10PvzActivityEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x15\n" +
+	"\x06pvz_id\x18\x04 \x01(\tR\x05pvzId\x12!\n" +
+	"\faggregate_id\x18\x05 \x01(\tR\vaggregateId\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x121\n" +
+	"\apayload\x18\a \x01(\v2\x17.google.protobuf.StructR\apayload*S\n" +
+	"\tPvzStatus\x12\x15\n" +
+	"\x11PVZ_STATUS_ACTIVE\x10\x00\x12\x18\n" +
+	"\x14PVZ_STATUS_SUSPENDED\x10\x01\x12\x15\n" +
+	"\x11PVZ_STATUS_CLOSED\x10\x02*P\n" +
+	"\x0fReceptionStatus\x12 \n" +
+	"\x1cRECEPTION_STATUS_IN_PROGRESS\x10\x00\x12\x1b\n" +
+	"\x17RECEPTION_STATUS_CLOSED\x10\x012\xc9\t\n" +
+	"\n" +
+	"PVZService\x12Z\n" +
+	"\n" +
+	"GetPVZList\x12\x19.pvz.v1.GetPVZListRequest\x1a\x1a.pvz.v1.GetPVZListResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/grpc/listPvz\x12V\n" +
+	"\tCreatePvz\x12\x18.pvz.v1.CreatePvzRequest\x1a\x19.pvz.v1.CreatePvzResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/grpc/pvz\x12Y\n" +
+	"\vGetPvzsInfo\x12\x1a.pvz.v1.GetPvzsInfoRequest\x1a\x1b.pvz.v1.GetPvzsInfoResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/grpc/pvz\x12l\n" +
+	"\x0fSearchNearbyPvz\x12\x1e.pvz.v1.SearchNearbyPvzRequest\x1a\x1f.pvz.v1.SearchNearbyPvzResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/grpc/pvz/nearby\x12o\n" +
+	"\x0fCreateReception\x12\x1e.pvz.v1.CreateReceptionRequest\x1a\x1f.pvz.v1.CreateReceptionResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/grpc/receptions\x12^\n" +
+	"\n" +
+	"AddProduct\x12\x19.pvz.v1.AddProductRequest\x1a\x1a.pvz.v1.AddProductResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/grpc/products\x12\x88\x01\n" +
+	"\x11DeleteLastProduct\x12 .pvz.v1.DeleteLastProductRequest\x1a!.pvz.v1.DeleteLastProductResponse\".\x82\xd3\xe4\x93\x02(\"&/grpc/pvz/{pvz_id}/delete_last_product\x12\x88\x01\n" +
+	"\x13GetProductByBarcode\x12\".pvz.v1.GetProductByBarcodeRequest\x1a#.pvz.v1.GetProductByBarcodeResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /grpc/products/barcode/{barcode}\x12\x80\x01\n" +
+	"\x0eCloseReception\x12\x1d.pvz.v1.CloseReceptionRequest\x1a\x1e.pvz.v1.CloseReceptionResponse\"/\x82\xd3\xe4\x93\x02)\"'/grpc/pvz/{pvz_id}/close_last_reception\x12e\n" +
+	"\x10ExportPvzHistory\x12\x1f.pvz.v1.ExportPvzHistoryRequest\x1a\x14.pvz.v1.PvzExportRow\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/grpc/pvz/export0\x01\x12m\n" +
+	"\x11StreamPvzActivity\x12 .pvz.v1.StreamPvzActivityRequest\x1a\x18.pvz.v1.PvzActivityEvent\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/grpc/pvz/activity0\x01B\xe0\x01\x92A\xd1\x01\x12&\n" +
+	"\x1fOrder Pick-Up Point gRPC server2\x031.0\x1a\x0elocalhost:3001Z\x84\x01\n" +
+	"\x81\x01\n" +
+	"\n" +
+	"BearerAuth\x12s\b\x02\x12^JWT token in the format 'Bearer <token>'. Obtain it via /login or /dummyLogin on the HTTP API.\x1a\rAuthorization \x02b\x10\n" +
+	"\x0e\n" +
 	"\n" +
-	"GetPVZList\x12\x19.pvz.v1.GetPVZListRequest\x1a\x1a.pvz.v1.GetPVZListResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/grpc/listPvzBF\x92A8\x12&\n" +
-	"\x1fOrder Pick-Up Point gRPC server2\x031.0\x1a\x0elocalhost:3001Z\tapi/pb;pbb\x06proto3"
+	"BearerAuth\x12\x00Z\tapi/pb;pbb\x06proto3"
 
 var (
 	file_pvz_proto_rawDescOnce sync.Once
@@ -243,25 +2177,102 @@ func file_pvz_proto_rawDescGZIP() []byte {
 	return file_pvz_proto_rawDescData
 }
 
-var file_pvz_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pvz_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_pvz_proto_goTypes = []any{
-	(ReceptionStatus)(0),          // 0: pvz.v1.ReceptionStatus
-	(*PVZ)(nil),                   // 1: pvz.v1.PVZ
-	(*GetPVZListRequest)(nil),     // 2: pvz.v1.GetPVZListRequest
-	(*GetPVZListResponse)(nil),    // 3: pvz.v1.GetPVZListResponse
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(PvzStatus)(0),                      // 0: pvz.v1.PvzStatus
+	(ReceptionStatus)(0),                // 1: pvz.v1.ReceptionStatus
+	(*OpeningHours)(nil),                // 2: pvz.v1.OpeningHours
+	(*PVZ)(nil),                         // 3: pvz.v1.PVZ
+	(*Reception)(nil),                   // 4: pvz.v1.Reception
+	(*Product)(nil),                     // 5: pvz.v1.Product
+	(*ReceptionInfo)(nil),               // 6: pvz.v1.ReceptionInfo
+	(*PvzInfo)(nil),                     // 7: pvz.v1.PvzInfo
+	(*GetPVZListRequest)(nil),           // 8: pvz.v1.GetPVZListRequest
+	(*GetPVZListResponse)(nil),          // 9: pvz.v1.GetPVZListResponse
+	(*CreatePvzRequest)(nil),            // 10: pvz.v1.CreatePvzRequest
+	(*CreatePvzResponse)(nil),           // 11: pvz.v1.CreatePvzResponse
+	(*GetPvzsInfoRequest)(nil),          // 12: pvz.v1.GetPvzsInfoRequest
+	(*GetPvzsInfoResponse)(nil),         // 13: pvz.v1.GetPvzsInfoResponse
+	(*SearchNearbyPvzRequest)(nil),      // 14: pvz.v1.SearchNearbyPvzRequest
+	(*NearbyPvz)(nil),                   // 15: pvz.v1.NearbyPvz
+	(*SearchNearbyPvzResponse)(nil),     // 16: pvz.v1.SearchNearbyPvzResponse
+	(*CreateReceptionRequest)(nil),      // 17: pvz.v1.CreateReceptionRequest
+	(*CreateReceptionResponse)(nil),     // 18: pvz.v1.CreateReceptionResponse
+	(*AddProductRequest)(nil),           // 19: pvz.v1.AddProductRequest
+	(*AddProductResponse)(nil),          // 20: pvz.v1.AddProductResponse
+	(*DeleteLastProductRequest)(nil),    // 21: pvz.v1.DeleteLastProductRequest
+	(*DeleteLastProductResponse)(nil),   // 22: pvz.v1.DeleteLastProductResponse
+	(*GetProductByBarcodeRequest)(nil),  // 23: pvz.v1.GetProductByBarcodeRequest
+	(*GetProductByBarcodeResponse)(nil), // 24: pvz.v1.GetProductByBarcodeResponse
+	(*CloseReceptionRequest)(nil),       // 25: pvz.v1.CloseReceptionRequest
+	(*CloseReceptionResponse)(nil),      // 26: pvz.v1.CloseReceptionResponse
+	(*ExportPvzHistoryRequest)(nil),     // 27: pvz.v1.ExportPvzHistoryRequest
+	(*PvzExportRow)(nil),                // 28: pvz.v1.PvzExportRow
+	(*StreamPvzActivityRequest)(nil),    // 29: pvz.v1.StreamPvzActivityRequest
+	(*PvzActivityEvent)(nil),            // 30: pvz.v1.PvzActivityEvent
+	(*timestamppb.Timestamp)(nil),       // 31: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),             // 32: google.protobuf.Struct
 }
 var file_pvz_proto_depIdxs = []int32{
-	4, // 0: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
-	1, // 1: pvz.v1.GetPVZListResponse.pvzs:type_name -> pvz.v1.PVZ
-	2, // 2: pvz.v1.PVZService.GetPVZList:input_type -> pvz.v1.GetPVZListRequest
-	3, // 3: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	31, // 0: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
+	0,  // 1: pvz.v1.PVZ.status:type_name -> pvz.v1.PvzStatus
+	2,  // 2: pvz.v1.PVZ.opening_hours:type_name -> pvz.v1.OpeningHours
+	31, // 3: pvz.v1.Reception.date_time:type_name -> google.protobuf.Timestamp
+	1,  // 4: pvz.v1.Reception.status:type_name -> pvz.v1.ReceptionStatus
+	31, // 5: pvz.v1.Reception.closed_at:type_name -> google.protobuf.Timestamp
+	31, // 6: pvz.v1.Product.date_time:type_name -> google.protobuf.Timestamp
+	4,  // 7: pvz.v1.ReceptionInfo.reception:type_name -> pvz.v1.Reception
+	5,  // 8: pvz.v1.ReceptionInfo.products:type_name -> pvz.v1.Product
+	3,  // 9: pvz.v1.PvzInfo.pvz:type_name -> pvz.v1.PVZ
+	6,  // 10: pvz.v1.PvzInfo.receptions:type_name -> pvz.v1.ReceptionInfo
+	3,  // 11: pvz.v1.GetPVZListResponse.pvzs:type_name -> pvz.v1.PVZ
+	2,  // 12: pvz.v1.CreatePvzRequest.opening_hours:type_name -> pvz.v1.OpeningHours
+	31, // 13: pvz.v1.GetPvzsInfoRequest.start_date:type_name -> google.protobuf.Timestamp
+	31, // 14: pvz.v1.GetPvzsInfoRequest.end_date:type_name -> google.protobuf.Timestamp
+	7,  // 15: pvz.v1.GetPvzsInfoResponse.items:type_name -> pvz.v1.PvzInfo
+	0,  // 16: pvz.v1.SearchNearbyPvzRequest.status:type_name -> pvz.v1.PvzStatus
+	3,  // 17: pvz.v1.NearbyPvz.pvz:type_name -> pvz.v1.PVZ
+	15, // 18: pvz.v1.SearchNearbyPvzResponse.items:type_name -> pvz.v1.NearbyPvz
+	31, // 19: pvz.v1.CreateReceptionRequest.date_time:type_name -> google.protobuf.Timestamp
+	5,  // 20: pvz.v1.GetProductByBarcodeResponse.product:type_name -> pvz.v1.Product
+	31, // 21: pvz.v1.GetProductByBarcodeResponse.expires_at:type_name -> google.protobuf.Timestamp
+	31, // 22: pvz.v1.ExportPvzHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
+	31, // 23: pvz.v1.ExportPvzHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
+	31, // 24: pvz.v1.PvzExportRow.pvz_registration_date:type_name -> google.protobuf.Timestamp
+	0,  // 25: pvz.v1.PvzExportRow.pvz_status:type_name -> pvz.v1.PvzStatus
+	31, // 26: pvz.v1.PvzExportRow.reception_date_time:type_name -> google.protobuf.Timestamp
+	1,  // 27: pvz.v1.PvzExportRow.reception_status:type_name -> pvz.v1.ReceptionStatus
+	31, // 28: pvz.v1.PvzExportRow.product_date_time:type_name -> google.protobuf.Timestamp
+	31, // 29: pvz.v1.PvzActivityEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	32, // 30: pvz.v1.PvzActivityEvent.payload:type_name -> google.protobuf.Struct
+	8,  // 31: pvz.v1.PVZService.GetPVZList:input_type -> pvz.v1.GetPVZListRequest
+	10, // 32: pvz.v1.PVZService.CreatePvz:input_type -> pvz.v1.CreatePvzRequest
+	12, // 33: pvz.v1.PVZService.GetPvzsInfo:input_type -> pvz.v1.GetPvzsInfoRequest
+	14, // 34: pvz.v1.PVZService.SearchNearbyPvz:input_type -> pvz.v1.SearchNearbyPvzRequest
+	17, // 35: pvz.v1.PVZService.CreateReception:input_type -> pvz.v1.CreateReceptionRequest
+	19, // 36: pvz.v1.PVZService.AddProduct:input_type -> pvz.v1.AddProductRequest
+	21, // 37: pvz.v1.PVZService.DeleteLastProduct:input_type -> pvz.v1.DeleteLastProductRequest
+	23, // 38: pvz.v1.PVZService.GetProductByBarcode:input_type -> pvz.v1.GetProductByBarcodeRequest
+	25, // 39: pvz.v1.PVZService.CloseReception:input_type -> pvz.v1.CloseReceptionRequest
+	27, // 40: pvz.v1.PVZService.ExportPvzHistory:input_type -> pvz.v1.ExportPvzHistoryRequest
+	29, // 41: pvz.v1.PVZService.StreamPvzActivity:input_type -> pvz.v1.StreamPvzActivityRequest
+	9,  // 42: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
+	11, // 43: pvz.v1.PVZService.CreatePvz:output_type -> pvz.v1.CreatePvzResponse
+	13, // 44: pvz.v1.PVZService.GetPvzsInfo:output_type -> pvz.v1.GetPvzsInfoResponse
+	16, // 45: pvz.v1.PVZService.SearchNearbyPvz:output_type -> pvz.v1.SearchNearbyPvzResponse
+	18, // 46: pvz.v1.PVZService.CreateReception:output_type -> pvz.v1.CreateReceptionResponse
+	20, // 47: pvz.v1.PVZService.AddProduct:output_type -> pvz.v1.AddProductResponse
+	22, // 48: pvz.v1.PVZService.DeleteLastProduct:output_type -> pvz.v1.DeleteLastProductResponse
+	24, // 49: pvz.v1.PVZService.GetProductByBarcode:output_type -> pvz.v1.GetProductByBarcodeResponse
+	26, // 50: pvz.v1.PVZService.CloseReception:output_type -> pvz.v1.CloseReceptionResponse
+	28, // 51: pvz.v1.PVZService.ExportPvzHistory:output_type -> pvz.v1.PvzExportRow
+	30, // 52: pvz.v1.PVZService.StreamPvzActivity:output_type -> pvz.v1.PvzActivityEvent
+	42, // [42:53] is the sub-list for method output_type
+	31, // [31:42] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_pvz_proto_init() }
@@ -269,13 +2280,18 @@ func file_pvz_proto_init() {
 	if File_pvz_proto != nil {
 		return
 	}
+	file_pvz_proto_msgTypes[1].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[8].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[12].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[26].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[27].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pvz_proto_rawDesc), len(file_pvz_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
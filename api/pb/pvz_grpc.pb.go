@@ -19,7 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PVZService_GetPVZList_FullMethodName = "/pvz.v1.PVZService/GetPVZList"
+	PVZService_GetPVZList_FullMethodName          = "/pvz.v1.PVZService/GetPVZList"
+	PVZService_CreatePvz_FullMethodName           = "/pvz.v1.PVZService/CreatePvz"
+	PVZService_GetPvzsInfo_FullMethodName         = "/pvz.v1.PVZService/GetPvzsInfo"
+	PVZService_SearchNearbyPvz_FullMethodName     = "/pvz.v1.PVZService/SearchNearbyPvz"
+	PVZService_CreateReception_FullMethodName     = "/pvz.v1.PVZService/CreateReception"
+	PVZService_AddProduct_FullMethodName          = "/pvz.v1.PVZService/AddProduct"
+	PVZService_DeleteLastProduct_FullMethodName   = "/pvz.v1.PVZService/DeleteLastProduct"
+	PVZService_GetProductByBarcode_FullMethodName = "/pvz.v1.PVZService/GetProductByBarcode"
+	PVZService_CloseReception_FullMethodName      = "/pvz.v1.PVZService/CloseReception"
+	PVZService_ExportPvzHistory_FullMethodName    = "/pvz.v1.PVZService/ExportPvzHistory"
+	PVZService_StreamPvzActivity_FullMethodName   = "/pvz.v1.PVZService/StreamPvzActivity"
 )
 
 // PVZServiceClient is the client API for PVZService service.
@@ -29,6 +39,37 @@ type PVZServiceClient interface {
 	// GetPVZList returns a list of PVZs.
 	// HTTP mapping: GET /listPvz
 	GetPVZList(ctx context.Context, in *GetPVZListRequest, opts ...grpc.CallOption) (*GetPVZListResponse, error)
+	// CreatePvz registers a new PVZ in one of the allowed cities.
+	// HTTP mapping: POST /pvz
+	CreatePvz(ctx context.Context, in *CreatePvzRequest, opts ...grpc.CallOption) (*CreatePvzResponse, error)
+	// GetPvzsInfo returns a paginated list of PVZs with their receptions and products.
+	// HTTP mapping: GET /pvz
+	GetPvzsInfo(ctx context.Context, in *GetPvzsInfoRequest, opts ...grpc.CallOption) (*GetPvzsInfoResponse, error)
+	// SearchNearbyPvz finds PVZs within a radius of a point, sorted by distance.
+	// HTTP mapping: GET /pvz/nearby
+	SearchNearbyPvz(ctx context.Context, in *SearchNearbyPvzRequest, opts ...grpc.CallOption) (*SearchNearbyPvzResponse, error)
+	// CreateReception opens a new reception for a PVZ.
+	// HTTP mapping: POST /receptions
+	CreateReception(ctx context.Context, in *CreateReceptionRequest, opts ...grpc.CallOption) (*CreateReceptionResponse, error)
+	// AddProduct adds a product to the open reception of a PVZ.
+	// HTTP mapping: POST /products
+	AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*AddProductResponse, error)
+	// DeleteLastProduct removes the last added product from the open reception (LIFO).
+	// HTTP mapping: POST /pvz/{pvzId}/delete_last_product
+	DeleteLastProduct(ctx context.Context, in *DeleteLastProductRequest, opts ...grpc.CallOption) (*DeleteLastProductResponse, error)
+	// GetProductByBarcode finds a product by its barcode, preferring the one currently at a PVZ.
+	// HTTP mapping: GET /products/barcode/{barcode}
+	GetProductByBarcode(ctx context.Context, in *GetProductByBarcodeRequest, opts ...grpc.CallOption) (*GetProductByBarcodeResponse, error)
+	// CloseReception closes the open reception of a PVZ.
+	// HTTP mapping: POST /pvz/{pvzId}/close_last_reception
+	CloseReception(ctx context.Context, in *CloseReceptionRequest, opts ...grpc.CallOption) (*CloseReceptionResponse, error)
+	// ExportPvzHistory streams PVZs with their receptions and products for a period, one row per product.
+	// HTTP mapping: GET /pvz/export
+	ExportPvzHistory(ctx context.Context, in *ExportPvzHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PvzExportRow], error)
+	// StreamPvzActivity streams reception and product events of PVZs as soon as they are committed.
+	// Pass the id of the last received event in last_event_id to get missed events first.
+	// HTTP mapping: GET /pvz/activity
+	StreamPvzActivity(ctx context.Context, in *StreamPvzActivityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PvzActivityEvent], error)
 }
 
 type pVZServiceClient struct {
@@ -49,6 +90,124 @@ func (c *pVZServiceClient) GetPVZList(ctx context.Context, in *GetPVZListRequest
 	return out, nil
 }
 
+func (c *pVZServiceClient) CreatePvz(ctx context.Context, in *CreatePvzRequest, opts ...grpc.CallOption) (*CreatePvzResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePvzResponse)
+	err := c.cc.Invoke(ctx, PVZService_CreatePvz_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) GetPvzsInfo(ctx context.Context, in *GetPvzsInfoRequest, opts ...grpc.CallOption) (*GetPvzsInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPvzsInfoResponse)
+	err := c.cc.Invoke(ctx, PVZService_GetPvzsInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) SearchNearbyPvz(ctx context.Context, in *SearchNearbyPvzRequest, opts ...grpc.CallOption) (*SearchNearbyPvzResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchNearbyPvzResponse)
+	err := c.cc.Invoke(ctx, PVZService_SearchNearbyPvz_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CreateReception(ctx context.Context, in *CreateReceptionRequest, opts ...grpc.CallOption) (*CreateReceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateReceptionResponse)
+	err := c.cc.Invoke(ctx, PVZService_CreateReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*AddProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddProductResponse)
+	err := c.cc.Invoke(ctx, PVZService_AddProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeleteLastProduct(ctx context.Context, in *DeleteLastProductRequest, opts ...grpc.CallOption) (*DeleteLastProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLastProductResponse)
+	err := c.cc.Invoke(ctx, PVZService_DeleteLastProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) GetProductByBarcode(ctx context.Context, in *GetProductByBarcodeRequest, opts ...grpc.CallOption) (*GetProductByBarcodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductByBarcodeResponse)
+	err := c.cc.Invoke(ctx, PVZService_GetProductByBarcode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CloseReception(ctx context.Context, in *CloseReceptionRequest, opts ...grpc.CallOption) (*CloseReceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseReceptionResponse)
+	err := c.cc.Invoke(ctx, PVZService_CloseReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ExportPvzHistory(ctx context.Context, in *ExportPvzHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PvzExportRow], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PVZService_ServiceDesc.Streams[0], PVZService_ExportPvzHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportPvzHistoryRequest, PvzExportRow]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PVZService_ExportPvzHistoryClient = grpc.ServerStreamingClient[PvzExportRow]
+
+func (c *pVZServiceClient) StreamPvzActivity(ctx context.Context, in *StreamPvzActivityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PvzActivityEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PVZService_ServiceDesc.Streams[1], PVZService_StreamPvzActivity_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPvzActivityRequest, PvzActivityEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PVZService_StreamPvzActivityClient = grpc.ServerStreamingClient[PvzActivityEvent]
+
 // PVZServiceServer is the server API for PVZService service.
 // All implementations must embed UnimplementedPVZServiceServer
 // for forward compatibility.
@@ -56,6 +215,37 @@ type PVZServiceServer interface {
 	// GetPVZList returns a list of PVZs.
 	// HTTP mapping: GET /listPvz
 	GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error)
+	// CreatePvz registers a new PVZ in one of the allowed cities.
+	// HTTP mapping: POST /pvz
+	CreatePvz(context.Context, *CreatePvzRequest) (*CreatePvzResponse, error)
+	// GetPvzsInfo returns a paginated list of PVZs with their receptions and products.
+	// HTTP mapping: GET /pvz
+	GetPvzsInfo(context.Context, *GetPvzsInfoRequest) (*GetPvzsInfoResponse, error)
+	// SearchNearbyPvz finds PVZs within a radius of a point, sorted by distance.
+	// HTTP mapping: GET /pvz/nearby
+	SearchNearbyPvz(context.Context, *SearchNearbyPvzRequest) (*SearchNearbyPvzResponse, error)
+	// CreateReception opens a new reception for a PVZ.
+	// HTTP mapping: POST /receptions
+	CreateReception(context.Context, *CreateReceptionRequest) (*CreateReceptionResponse, error)
+	// AddProduct adds a product to the open reception of a PVZ.
+	// HTTP mapping: POST /products
+	AddProduct(context.Context, *AddProductRequest) (*AddProductResponse, error)
+	// DeleteLastProduct removes the last added product from the open reception (LIFO).
+	// HTTP mapping: POST /pvz/{pvzId}/delete_last_product
+	DeleteLastProduct(context.Context, *DeleteLastProductRequest) (*DeleteLastProductResponse, error)
+	// GetProductByBarcode finds a product by its barcode, preferring the one currently at a PVZ.
+	// HTTP mapping: GET /products/barcode/{barcode}
+	GetProductByBarcode(context.Context, *GetProductByBarcodeRequest) (*GetProductByBarcodeResponse, error)
+	// CloseReception closes the open reception of a PVZ.
+	// HTTP mapping: POST /pvz/{pvzId}/close_last_reception
+	CloseReception(context.Context, *CloseReceptionRequest) (*CloseReceptionResponse, error)
+	// ExportPvzHistory streams PVZs with their receptions and products for a period, one row per product.
+	// HTTP mapping: GET /pvz/export
+	ExportPvzHistory(*ExportPvzHistoryRequest, grpc.ServerStreamingServer[PvzExportRow]) error
+	// StreamPvzActivity streams reception and product events of PVZs as soon as they are committed.
+	// Pass the id of the last received event in last_event_id to get missed events first.
+	// HTTP mapping: GET /pvz/activity
+	StreamPvzActivity(*StreamPvzActivityRequest, grpc.ServerStreamingServer[PvzActivityEvent]) error
 	mustEmbedUnimplementedPVZServiceServer()
 }
 
@@ -69,6 +259,36 @@ type UnimplementedPVZServiceServer struct{}
 func (UnimplementedPVZServiceServer) GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPVZList not implemented")
 }
+func (UnimplementedPVZServiceServer) CreatePvz(context.Context, *CreatePvzRequest) (*CreatePvzResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePvz not implemented")
+}
+func (UnimplementedPVZServiceServer) GetPvzsInfo(context.Context, *GetPvzsInfoRequest) (*GetPvzsInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPvzsInfo not implemented")
+}
+func (UnimplementedPVZServiceServer) SearchNearbyPvz(context.Context, *SearchNearbyPvzRequest) (*SearchNearbyPvzResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchNearbyPvz not implemented")
+}
+func (UnimplementedPVZServiceServer) CreateReception(context.Context, *CreateReceptionRequest) (*CreateReceptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReception not implemented")
+}
+func (UnimplementedPVZServiceServer) AddProduct(context.Context, *AddProductRequest) (*AddProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProduct not implemented")
+}
+func (UnimplementedPVZServiceServer) DeleteLastProduct(context.Context, *DeleteLastProductRequest) (*DeleteLastProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLastProduct not implemented")
+}
+func (UnimplementedPVZServiceServer) GetProductByBarcode(context.Context, *GetProductByBarcodeRequest) (*GetProductByBarcodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductByBarcode not implemented")
+}
+func (UnimplementedPVZServiceServer) CloseReception(context.Context, *CloseReceptionRequest) (*CloseReceptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseReception not implemented")
+}
+func (UnimplementedPVZServiceServer) ExportPvzHistory(*ExportPvzHistoryRequest, grpc.ServerStreamingServer[PvzExportRow]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportPvzHistory not implemented")
+}
+func (UnimplementedPVZServiceServer) StreamPvzActivity(*StreamPvzActivityRequest, grpc.ServerStreamingServer[PvzActivityEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPvzActivity not implemented")
+}
 func (UnimplementedPVZServiceServer) mustEmbedUnimplementedPVZServiceServer() {}
 func (UnimplementedPVZServiceServer) testEmbeddedByValue()                    {}
 
@@ -108,6 +328,172 @@ func _PVZService_GetPVZList_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PVZService_CreatePvz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePvzRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CreatePvz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CreatePvz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CreatePvz(ctx, req.(*CreatePvzRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_GetPvzsInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPvzsInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetPvzsInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetPvzsInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetPvzsInfo(ctx, req.(*GetPvzsInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_SearchNearbyPvz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchNearbyPvzRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).SearchNearbyPvz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_SearchNearbyPvz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).SearchNearbyPvz(ctx, req.(*SearchNearbyPvzRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CreateReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CreateReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CreateReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CreateReception(ctx, req.(*CreateReceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_AddProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).AddProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_AddProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).AddProduct(ctx, req.(*AddProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeleteLastProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLastProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeleteLastProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeleteLastProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeleteLastProduct(ctx, req.(*DeleteLastProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_GetProductByBarcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductByBarcodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetProductByBarcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetProductByBarcode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetProductByBarcode(ctx, req.(*GetProductByBarcodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CloseReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseReceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CloseReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CloseReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CloseReception(ctx, req.(*CloseReceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ExportPvzHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportPvzHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PVZServiceServer).ExportPvzHistory(m, &grpc.GenericServerStream[ExportPvzHistoryRequest, PvzExportRow]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PVZService_ExportPvzHistoryServer = grpc.ServerStreamingServer[PvzExportRow]
+
+func _PVZService_StreamPvzActivity_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPvzActivityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PVZServiceServer).StreamPvzActivity(m, &grpc.GenericServerStream[StreamPvzActivityRequest, PvzActivityEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PVZService_StreamPvzActivityServer = grpc.ServerStreamingServer[PvzActivityEvent]
+
 // PVZService_ServiceDesc is the grpc.ServiceDesc for PVZService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -119,7 +505,50 @@ var PVZService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPVZList",
 			Handler:    _PVZService_GetPVZList_Handler,
 		},
+		{
+			MethodName: "CreatePvz",
+			Handler:    _PVZService_CreatePvz_Handler,
+		},
+		{
+			MethodName: "GetPvzsInfo",
+			Handler:    _PVZService_GetPvzsInfo_Handler,
+		},
+		{
+			MethodName: "SearchNearbyPvz",
+			Handler:    _PVZService_SearchNearbyPvz_Handler,
+		},
+		{
+			MethodName: "CreateReception",
+			Handler:    _PVZService_CreateReception_Handler,
+		},
+		{
+			MethodName: "AddProduct",
+			Handler:    _PVZService_AddProduct_Handler,
+		},
+		{
+			MethodName: "DeleteLastProduct",
+			Handler:    _PVZService_DeleteLastProduct_Handler,
+		},
+		{
+			MethodName: "GetProductByBarcode",
+			Handler:    _PVZService_GetProductByBarcode_Handler,
+		},
+		{
+			MethodName: "CloseReception",
+			Handler:    _PVZService_CloseReception_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportPvzHistory",
+			Handler:       _PVZService_ExportPvzHistory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamPvzActivity",
+			Handler:       _PVZService_StreamPvzActivity_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pvz.proto",
 }
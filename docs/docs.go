@@ -22,106 +22,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/dummyLogin": {
-            "post": {
-                "description": "Get a JWT token by passing a desired user role (client, employee, moderator) through dummy login.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Dummy login for testing",
-                "parameters": [
-                    {
-                        "description": "Dummy login request with role",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.DummyLoginPostRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "JWT token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.TokenResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized: invalid role or error during dummy login",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error"
-                        }
-                    }
-                }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Login a user using email and password. Returns a JWT token if credentials are valid.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Public keys (JWK Set, RFC 7517) used to verify access tokens. Tokens carry the key id in the ` + "`" + `kid` + "`" + ` header. Keys being rotated out stay in the set until the tokens they signed expire. The set is empty when tokens are signed with a shared HS256 secret.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Login a user",
-                "parameters": [
-                    {
-                        "description": "User login data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginPostRequest"
-                        }
-                    }
-                ],
+                "summary": "Public signing keys",
                 "responses": {
                     "200": {
-                        "description": "JWT token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.TokenResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized: invalid credentials",
+                        "description": "JWK Set",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error"
+                            "$ref": "#/definitions/jwt.JWKS"
                         }
                     }
                 }
             }
         },
-        "/products": {
-            "post": {
+        "/analytics/products/types": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new product to the last open reception for a given PVZ. Only employees can add products.",
+                "description": "Count products of each type received by each PVZ in receptions of the period. Aggregation runs in the database. Available for employees and moderators.",
                 "consumes": [
                     "application/json"
                 ],
@@ -129,35 +57,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "analytics"
                 ],
-                "summary": "Add a product to the current reception",
+                "summary": "Products per type per PVZ",
                 "parameters": [
                     {
-                        "description": "Product addition data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ProductsPostRequest"
-                        }
+                        "type": "string",
+                        "example": "\"Moscow\"",
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by PVZ ID",
+                        "name": "pvzId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-01T00:00:00Z\"",
+                        "description": "Start of the reception period in RFC3339 format",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-30T23:59:59Z\"",
+                        "description": "End of the reception period in RFC3339 format",
+                        "name": "endDate",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Product added, returning its ID",
+                    "200": {
+                        "description": "Product counts sorted by city, PVZ and type",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProductsPostResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ProductTypeCountDTO"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid dates, PVZ ID or city is not allowed",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized: missing token or insufficient privileges",
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
@@ -171,14 +126,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/pvz": {
+        "/analytics/receptions/daily": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a paginated list of PVZ with reception and product details.",
+                "description": "Count receptions of each PVZ by day (Moscow time). Only days with receptions are returned. Aggregation runs in the database. Available for employees and moderators.",
                 "consumes": [
                     "application/json"
                 ],
@@ -186,59 +141,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "analytics"
                 ],
-                "summary": "Get PVZ information",
+                "summary": "Receptions per PVZ per day",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "example": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "example": "\"Moscow\"",
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "example": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "Filter by PVZ ID",
+                        "name": "pvzId",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "\"2025-04-09T00:00:00Z\"",
-                        "description": "Filter: start date in RFC3339 format",
+                        "example": "\"2025-04-01T00:00:00Z\"",
+                        "description": "Start of the reception period in RFC3339 format",
                         "name": "startDate",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "\"2025-04-09T23:59:59Z\"",
-                        "description": "Filter: end date in RFC3339 format",
+                        "example": "\"2025-04-30T23:59:59Z\"",
+                        "description": "End of the reception period in RFC3339 format",
                         "name": "endDate",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of PVZ information",
+                        "description": "Reception counts sorted by city, PVZ and day",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.PvzGet200ResponseInner"
+                                "$ref": "#/definitions/dto.DailyReceptionsDTO"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid dates, PVZ ID or city is not allowed",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized: missing token or insufficient privileges",
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
@@ -250,14 +208,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/analytics/receptions/stats": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new PVZ. Only users with a moderator role can create a PVZ.",
+                "description": "For each PVZ: number of receptions, closed receptions and products, average reception duration from opening to closing and average number of products per reception (empty receptions included). Receptions closed before close times were recorded do not count towards the duration. Aggregation runs in the database. Available for employees and moderators.",
                 "consumes": [
                     "application/json"
                 ],
@@ -265,35 +225,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "analytics"
                 ],
-                "summary": "Create a new PVZ",
+                "summary": "Reception summary per PVZ",
                 "parameters": [
                     {
-                        "description": "PVZ creation data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreatePvzPostRequest"
-                        }
+                        "type": "string",
+                        "example": "\"Moscow\"",
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by PVZ ID",
+                        "name": "pvzId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-01T00:00:00Z\"",
+                        "description": "Start of the reception period in RFC3339 format",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-30T23:59:59Z\"",
+                        "description": "End of the reception period in RFC3339 format",
+                        "name": "endDate",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Newly created PVZ information",
+                    "200": {
+                        "description": "Reception summaries sorted by city and PVZ",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreatePvzResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ReceptionStatsDTO"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid dates, PVZ ID or city is not allowed",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized: missing token or insufficient privileges",
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
@@ -307,14 +294,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/pvz/optimized": {
+        "/audit": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Optimized method to retrieve a paginated list of PVZ with all related receptions and products.",
+                "description": "Returns audit log entries from newest to oldest. Every PVZ, reception, product, order, return and registration change is recorded in the same transaction as the change itself, with the actor, before/after state and request/trace IDs. Pass the X-Next-Cursor header value as cursor to get the next page. Available only for moderators.",
                 "consumes": [
                     "application/json"
                 ],
@@ -322,59 +309,104 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "audit"
                 ],
-                "summary": "Get PVZ info with receptions and products (optimized)",
+                "summary": "Audit log of state-changing operations",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "example": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "Filter by actor user ID",
+                        "name": "actorId",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "example": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "example": "\"reception.close\"",
+                        "description": "Filter by action",
+                        "name": "action",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "\"2025-04-09T00:00:00Z\"",
-                        "description": "Filter: start date in RFC3339 format",
+                        "example": "\"reception\"",
+                        "description": "Filter by entity type: pvz, reception, product, return, return_item, user",
+                        "name": "entityType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by entity ID",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by PVZ ID",
+                        "name": "pvzId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-05-01T00:00:00Z\"",
+                        "description": "Start of the period in RFC3339 format",
                         "name": "startDate",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "\"2025-04-09T23:59:59Z\"",
-                        "description": "Filter: end date in RFC3339 format",
+                        "example": "\"2025-05-31T23:59:59Z\"",
+                        "description": "End of the period in RFC3339 format",
                         "name": "endDate",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 50,
+                        "description": "Page size, 50 by default, at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from X-Next-Cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of PVZ information (optimized)",
+                        "description": "Audit log entries",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.PvzGet200ResponseInner"
+                                "$ref": "#/definitions/dto.AuditEntryDTO"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            },
+                            "X-Has-More": {
+                                "type": "boolean",
+                                "description": "Whether there are older entries after this page"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid filters or cursor",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized: missing token or insufficient privileges",
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
@@ -388,14 +420,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/pvz/{pvzId}/close_last_reception": {
+        "/dummyLogin": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Close the last open reception for a specified PVZ, finalizing the reception process. Only employees can close receptions.",
+                "description": "Get a JWT token by passing a desired user role (client, employee, moderator) through dummy login. The endpoint exists only in environments listed in ` + "`" + `dummy_login.enabled_envs` + "`" + ` and may be restricted to allow-listed networks or a shared secret. Issued tokens carry the ` + "`" + `dummy` + "`" + ` claim.",
                 "consumes": [
                     "application/json"
                 ],
@@ -403,38 +430,115 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "auth"
                 ],
-                "summary": "Close the current reception",
+                "summary": "Dummy login for testing",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"pvz123\"",
-                        "description": "PVZ ID",
-                        "name": "pvzId",
-                        "in": "path",
-                        "required": true
+                        "description": "Shared secret, required when dummy_login.secret is set",
+                        "name": "X-Dummy-Login-Secret",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dummy login request with role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DummyLoginPostRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Closed reception details with its ID",
+                        "description": "JWT token",
                         "schema": {
-                            "$ref": "#/definitions/dto.CloseReceptionResponse"
+                            "$ref": "#/definitions/dto.TokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request: missing pvzId",
+                        "description": "Invalid request body or role is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Client network is not allowed or the secret is wrong",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Login a user using email and password. Returns a short-lived JWT access token and a refresh token for /token/refresh if credentials are valid. After failed attempts the next attempt for the same email or from the same IP is delayed progressively, and after too many failures login is locked for a while; such attempts get 429 with the Retry-After header whether or not the email exists. Deactivated users get 403 after entering the correct password. After a password reset by a moderator the response carries passwordChangeRequired.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login a user",
+                "parameters": [
+                    {
+                        "description": "User login data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized: missing token or insufficient privileges",
+                        "description": "Unauthorized: invalid credentials",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
+                    "403": {
+                        "description": "User is deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts, retry after the Retry-After header value",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the next attempt is allowed"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -444,14 +548,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/pvz/{pvzId}/delete_last_product": {
+        "/logout": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete the last product that was added to an open reception for a given PVZ (LIFO order). Only employees can delete products.",
+                "description": "Revoke the access token of the request and the session it belongs to: its refresh tokens can no longer be exchanged.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "204": {
+                        "description": "Logged out"
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing, invalid or revoked token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/my/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get parcels assigned to the authenticated client across all PVZs with their status, PVZ city and storage deadline. Pickup codes are not returned: a new code can be requested via POST /my/orders/{productId}/pickup_code. Only clients can use this endpoint; the recipient is always taken from the token.",
                 "consumes": [
                     "application/json"
                 ],
@@ -459,34 +594,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "orders"
                 ],
-                "summary": "Delete the last added product from the current reception",
+                "summary": "List orders of the current client",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"pvz123\"",
-                        "description": "PVZ ID",
-                        "name": "pvzId",
-                        "in": "path",
-                        "required": true
+                        "enum": [
+                            "ready_for_pickup",
+                            "issued",
+                            "returned"
+                        ],
+                        "description": "Order status filter",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deletion success message",
+                        "description": "Orders of the current client",
                         "schema": {
-                            "$ref": "#/definitions/dto.DeleteProductResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ClientOrderDTO"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad request: missing pvzId",
+                        "description": "Unknown status filter",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized: missing token or insufficient privileges",
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: not a registered client",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
@@ -500,14 +647,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/receptions": {
-            "post": {
+        "/my/orders/{productId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Initiate a new reception for a specified PVZ. Only employees can create a reception.",
+                "description": "Get a single parcel assigned to the authenticated client. Orders of other users are reported as not found.",
                 "consumes": [
                     "application/json"
                 ],
@@ -515,35 +662,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "orders"
                 ],
-                "summary": "Create a new reception for goods",
+                "summary": "Get an order of the current client",
                 "parameters": [
                     {
-                        "description": "Reception creation data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateReceptionRequest"
-                        }
+                        "type": "string",
+                        "example": "\"prod123\"",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Reception created, returning its ID",
+                    "200": {
+                        "description": "Order of the current client",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateReceptionResponse"
+                            "$ref": "#/definitions/dto.ClientOrderDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid identifiers",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized: missing token or insufficient privileges",
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: not a registered client",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
@@ -557,9 +715,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
+        "/my/orders/{productId}/pickup_code": {
             "post": {
-                "description": "Register a new user with email, password and role (client, employee, moderator).",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a pickup code for a parcel of the authenticated client that is ready for pickup. The recipient shows this code at the PVZ to collect the parcel. A previous code stops working and the limit of invalid codes entered at the PVZ is reset. Codes are stored hashed, so the new code is returned only in this response.",
                 "consumes": [
                     "application/json"
                 ],
@@ -567,33 +730,125 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "orders"
                 ],
-                "summary": "Register a new user",
+                "summary": "Issue a pickup code for an order of the current client",
                 "parameters": [
                     {
-                        "description": "User registration data",
+                        "type": "string",
+                        "example": "\"prod123\"",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order of the current client with the pickup code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ClientOrderDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifiers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: not a registered client",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not ready for pickup",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/my/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the password of the current user. The current session stays valid, all other sessions of the user are revoked. A wrong current password counts as a failed login for the user's email, so repeated failures are delayed and locked like login attempts and get 429 with the Retry-After header. Not available for dummy tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Change own password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterPostRequest"
+                            "$ref": "#/definitions/dto.ChangePasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User registration success response with user ID",
+                    "204": {
+                        "description": "Password changed"
+                    },
+                    "400": {
+                        "description": "Invalid request body, new password or wrong current password",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterResponse"
+                            "$ref": "#/definitions/dto.Error"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: dummy token",
                         "schema": {
                             "$ref": "#/definitions/dto.Error"
                         }
                     },
+                    "429": {
+                        "description": "Too many failed attempts, retry after the Retry-After header value",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the next attempt is allowed"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -602,105 +857,3962 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CloseReceptionResponse": {
-            "description": "Response returned after successfully closing a reception.",
-            "type": "object",
-            "properties": {
-                "receptionId": {
-                    "type": "string",
-                    "example": "recv456"
-                }
-            }
-        },
-        "dto.CreatePvzPostRequest": {
-            "description": "Request payload for creating a new PVZ.",
-            "type": "object",
-            "required": [
-                "city"
-            ],
-            "properties": {
-                "city": {
-                    "type": "string",
-                    "example": "Moscow"
-                }
-            }
         },
-        "dto.CreatePvzResponse": {
-            "description": "Response returned after successfully creating a PVZ.",
+        "/products": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new product to the last open reception for a given PVZ. An optional barcode must be unique among products currently at PVZs. Only employees can add products.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Add a product to the current reception",
+                "parameters": [
+                    {
+                        "description": "Product addition data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product added, returning its ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsPostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, product type is not allowed or invalid barcode",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Product with this barcode is already at a PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "No open reception for this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/barcode/{barcode}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Look up a product by scanned barcode or tracking number. If the barcode was reused, the product currently at a PVZ is returned, otherwise the most recent one. Available for employees and moderators.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Find a product by its barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"4607001234567\"",
+                        "description": "Product barcode",
+                        "name": "barcode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product with its PVZ and status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid barcode",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Product with this barcode not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a batch of products to the open reception of a PVZ in a single transaction. In \"atomic\" mode (default) any invalid item rejects the whole batch, in \"partial\" mode only valid items are saved. Results are returned per item in request order. Only employees can add products.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Add many products to the current reception",
+                "parameters": [
+                    {
+                        "description": "Products batch",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "All products added",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsBatchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Partial mode: some products added, see per-item errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, empty or too large batch, body over 1 MiB",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "No products added: batch rejected or every item is invalid (no open reception is reported as dto.Error)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsBatchResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/batch/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Same as /products/batch, but products are read from an uploaded CSV file with columns \"type,barcode\" (barcode is optional, a header row is allowed). Only employees can add products.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Upload a CSV file of products to the current reception",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz789\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "enum": [
+                            "atomic",
+                            "partial"
+                        ],
+                        "default": "atomic",
+                        "description": "Batch mode",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV file with products",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "All products added",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsBatchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Partial mode: some products added, see per-item errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid form, malformed CSV, empty or too large batch, body over 1 MiB",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "No products added: batch rejected or every item is invalid (no open reception is reported as dto.Error)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsBatchResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of PVZ with reception and product details, newest first. Pages can be requested by number (page/limit) or by the cursor returned in the X-Next-Cursor header, which is stable when PVZs are created while scrolling.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Get PVZ information",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Page number, required unless cursor is set",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from the X-Next-Cursor header of the previous page; page is ignored when set",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-09T00:00:00Z\"",
+                        "description": "Filter: start date in RFC3339 format",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-09T23:59:59Z\"",
+                        "description": "Filter: end date in RFC3339 format",
+                        "name": "endDate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of PVZ information",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PvzGet200ResponseInner"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            },
+                            "X-Has-More": {
+                                "type": "boolean",
+                                "description": "Whether there are more PVZs after this page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new PVZ with optional address, coordinates, contact phone and opening hours per weekday. New PVZs are active. Only users with a moderator role can create a PVZ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Create a new PVZ",
+                "parameters": [
+                    {
+                        "description": "PVZ creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePvzPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Newly created PVZ information",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePvzResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, city is not allowed or invalid PVZ details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/activity": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-sent events stream of reception and product events (ReceptionOpened, ProductAdded, ProductRemoved, ReceptionClosed) of one or more PVZs as soon as they are committed, on any application replica. Each event has the SSE id, event type and JSON data. After a reconnect, pass the last received id in the Last-Event-ID header (EventSource does it automatically) or in lastEventId to get missed events first. Delivery is at least once: after a reconnect some events may repeat, including events with a smaller id committed after the last received one, so deduplicate by eventId. If the server interrupts the stream (slow client, shutdown), it sends an error event and closes the connection; reconnect with the last id. Comment lines are sent every 15 seconds as a heartbeat. Available for employees and moderators.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Live activity of PVZs",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "PVZ IDs, from 1 to 50",
+                        "name": "pvzId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event id",
+                        "name": "lastEventId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event id, takes precedence over lastEventId",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream; data of each event",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PvzActivityEventDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid PVZ IDs or event id",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "PVZ not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream all PVZs with their receptions in the period and the products of those receptions as CSV, newline-delimited JSON or XLSX, one row per product (PVZs without receptions and receptions without products get a row with empty fields). Rows are read through a server-side cursor in a single consistent snapshot, so memory does not grow with the export size. XLSX is sent after the whole workbook is built and is split into sheets of 1048576 rows. If the export fails after the first row was sent, the connection is dropped instead of completing the response. Only moderators can export.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Export PVZ history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "xlsx"
+                        ],
+                        "default": "csv",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-01T00:00:00Z\"",
+                        "description": "Start of the reception period in RFC3339 format",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-30T23:59:59Z\"",
+                        "description": "End of the reception period in RFC3339 format",
+                        "name": "endDate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export rows; CSV and XLSX have the same columns in snake_case",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PvzExportRowDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format or dates",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/nearby": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Find PVZs within a radius of the given coordinates, sorted by distance. Each result contains the distance in meters and whether the PVZ is open right now by its opening hours (Moscow time). PVZs without coordinates are never returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Find PVZs near a point",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "55.7558",
+                        "description": "Latitude of the point",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "37.6173",
+                        "description": "Longitude of the point",
+                        "name": "lon",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "3000",
+                        "description": "Search radius in meters (default 5000, max 100000)",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Moscow\"",
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "enum": [
+                            "active",
+                            "suspended",
+                            "closed"
+                        ],
+                        "description": "Filter by PVZ status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Maximum number of results (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PVZs sorted by distance",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.NearbyPvzDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coordinates, radius, limit, city or status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/optimized": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Optimized method to retrieve a paginated list of PVZ with all related receptions and products. Supports the same page/limit and cursor pagination as /pvz.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Get PVZ info with receptions and products (optimized)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Page number, required unless cursor is set",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from the X-Next-Cursor header of the previous page; page is ignored when set",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-09T00:00:00Z\"",
+                        "description": "Filter: start date in RFC3339 format",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-04-09T23:59:59Z\"",
+                        "description": "Filter: end date in RFC3339 format",
+                        "name": "endDate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of PVZ information (optimized)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PvzGet200ResponseInner"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            },
+                            "X-Has-More": {
+                                "type": "boolean",
+                                "description": "Whether there are more PVZs after this page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Partially update address, coordinates, contact phone, status (active, suspended, closed) and opening hours of a PVZ. Closed PVZs do not accept new receptions. Only moderators can update a PVZ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Update PVZ details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePvzRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PvzDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or invalid PVZ details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "PVZ not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}/close_last_reception": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close the last open reception for a specified PVZ, finalizing the reception process. Only employees can close receptions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Close the current reception",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Closed reception details with its ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloseReceptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request: missing pvzId",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Reception not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "No open reception for this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}/close_last_return": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close the open return shipment of a PVZ; all its products are marked as shipped back to the seller. Only employees can close return shipments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Close the current return shipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Closed return shipment with its ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloseReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request: missing pvzId",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "No open return shipment for this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}/delete_last_product": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the last product that was added to an open reception for a given PVZ (LIFO order). Only employees can delete products.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Delete the last added product from the current reception",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted product ID and barcode",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request: missing pvzId",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "No open reception or no products to delete",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}/delete_last_return_item": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the last product added to the open return shipment of a PVZ (LIFO order) and restore its previous status. Only employees can delete return items.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Delete the last added product from the current return shipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Return item deletion success message",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteReturnItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request: missing pvzId",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "No open return shipment or no items to delete",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Look up products that are ready for pickup by the given recipient at the PVZ. Only employees can look up orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "List orders waiting for a recipient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"user123\"",
+                        "description": "Recipient user ID",
+                        "name": "recipientId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders ready for pickup",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.OrderDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifiers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign a received product from a closed reception to a recipient (client). The pickup code is not returned to the employee: the recipient requests it via POST /my/orders/{productId}/pickup_code. Only employees can prepare orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Prepare a product for pickup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product and recipient",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PrepareOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order ready for pickup",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PrepareOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or identifiers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found in this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Product status does not allow this transition",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Recipient not found or is not a client",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}/orders/{productId}/issue": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Verify the pickup code and mark the product as issued by the current employee. After 5 invalid codes the order can be issued only with a new code requested by the recipient. Only employees can issue orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Issue an order to its recipient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"prod123\"",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pickup code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.IssueOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Issued order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or identifiers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges or pickup code does not match",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found in this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Product is not ready for pickup",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many invalid pickup codes: the recipient must request a new code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}/orders/{productId}/return": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a product that is ready for pickup as returned (not collected by the recipient). Only employees can return orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Mark an order as returned",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"prod123\"",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returned order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifiers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found in this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Product is not ready for pickup",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pvz/{pvzId}/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get products of the PVZ whose storage period has expired and which are still waiting (not issued and not moved to a return shipment). Available for employees and moderators.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "List overdue products at a PVZ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"pvz123\"",
+                        "description": "PVZ ID",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Overdue products ordered by storage deadline",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.OrderDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifiers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/receptions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Initiate a new reception for a specified PVZ. Only employees can create a reception.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Create a new reception for goods",
+                "parameters": [
+                    {
+                        "description": "Reception creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReceptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Reception created, returning its ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReceptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "PVZ not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Open reception already exists for this PVZ or PVZ is closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Register a new user with email, password and role (client, employee, moderator).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User registration success response with user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, email, password or role",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "User with this email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open a new return shipment to the seller for a specified PVZ. Only one return shipment can be open per PVZ. Only employees can open return shipments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Open a return shipment",
+                "parameters": [
+                    {
+                        "description": "Return shipment creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Return shipment opened, returning its ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "PVZ not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Open return shipment already exists for this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a refused, expired or damaged product of the PVZ into its open return shipment. Issued products cannot be returned. Only employees can add return items.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Add a product to the current return shipment",
+                "parameters": [
+                    {
+                        "description": "Product and return reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddReturnItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product added to the return shipment",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReturnItemDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or return reason",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found in this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Product status does not allow returning it",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "No open return shipment for this PVZ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a new refresh token. Each refresh token can be used only once; presenting an already used token revokes the whole session, including its access tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Refresh token is invalid, expired, revoked or reused",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns users from newest to oldest. Pass the X-Next-Cursor header value as cursor to get the next page. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"example.com\"",
+                        "description": "Case-insensitive substring of the email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"employee\"",
+                        "description": "Filter by role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by account status",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 50,
+                        "description": "Page size, 50 by default, at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from X-Next-Cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.User"
+                            }
+                        },
+                        "headers": {
+                            "X-Has-More": {
+                                "type": "boolean",
+                                "description": "Whether there are older users after this page"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a user by ID. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User",
+                        "schema": {
+                            "$ref": "#/definitions/dto.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deactivates a user account: all sessions of the user are revoked, and login and token refresh are rejected until the account is reactivated. Moderators cannot deactivate their own account. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Deactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or an attempt to deactivate the own account",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reactivates a deactivated user account. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or an attempt to reactivate the own account",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/reset_password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the user's password with a temporary one and returns it once. All sessions of the user are revoked and the login lockout of the user's email is lifted. Tokens issued for the temporary password carry passwordChangeRequired until the user changes the password via /my/password. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Force password reset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Temporary password",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PasswordResetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/role": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the role of a user. All sessions of the user are revoked, so the new role applies from the next login. Moderators cannot change their own role. Available only for moderators.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Change user role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangeRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID, request body or role, or an attempt to change the own role",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resets failed login attempts for the user's email and lifts its temporary lockout. Lockouts of client IPs expire on their own. Available only for moderators.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Unlock user login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Login unlocked"
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns all webhook subscriptions in creation order. Secrets are not returned. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "Webhook subscriptions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribes an external URL to domain events (PvzCreated, ReceptionOpened, ProductAdded, ProductRemoved, ReceptionClosed, OrderIssued, OrderReturned). Every delivery is a POST with the event JSON signed by HMAC-SHA256 in the X-Webhook-Signature header. If secret is omitted, a random one is generated; the secret is returned only in this response. Available only for moderators.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Webhook subscription",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created subscription with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL, event types or secret",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a webhook subscription without its secret. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook subscription ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook subscription",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a webhook subscription together with its delivery history. Available only for moderators.",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook subscription ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Subscription deleted"
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Partially updates URL, event types and the active flag. A paused subscription (active=false) keeps collecting deliveries, they are sent after it is reactivated. Available only for moderators.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook subscription ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated subscription",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, URL or event types",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns deliveries of a subscription from newest to oldest. A delivery is pending until the receiver answers 2xx (delivered) or attempts run out (failed). Pass the X-Next-Cursor header value as cursor to get the next page. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delivery history of a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook subscription ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"failed\"",
+                        "description": "Filter by status: pending, delivered, failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 50,
+                        "description": "Page size, 50 by default, at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from X-Next-Cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deliveries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            },
+                            "X-Has-More": {
+                                "type": "boolean",
+                                "description": "Whether there are older deliveries after this page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookId}/deliveries/{deliveryId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a delivery with the history of all attempts: time, duration, response status and error. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook delivery with its attempts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook subscription ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 42,
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery with attempts history",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook or delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookId}/deliveries/{deliveryId}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a failed delivery to the queue with a fresh attempt budget; it is sent by the next worker pass. Only failed deliveries can be retried. Available only for moderators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry a failed webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook subscription ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 42,
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery queued again",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook or delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: insufficient privileges",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery is not in failed status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AddReturnItemRequest": {
+            "description": "Request payload for moving a product into the open return shipment of a PVZ.",
+            "type": "object",
+            "required": [
+                "productId",
+                "pvzId",
+                "reason"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string",
+                    "example": "prod123"
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "refused",
+                        "expired",
+                        "damaged"
+                    ],
+                    "example": "refused"
+                }
+            }
+        },
+        "dto.AuditEntryDTO": {
+            "description": "Audit log entry. The actor is absent for registration and dummy-login tokens, the latter are marked with actorDummy; before and after hold the entity state around the change.",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "reception.close"
+                },
+                "actorDummy": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "actorId": {
+                    "type": "string",
+                    "example": "7c2b8d1e-4f3a-4b5c-9d6e-1a2b3c4d5e44"
+                },
+                "actorRole": {
+                    "type": "string",
+                    "example": "employee"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-05-01T10:00:00Z"
+                },
+                "entityId": {
+                    "type": "string",
+                    "example": "rec123"
+                },
+                "entityType": {
+                    "type": "string",
+                    "example": "reception"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "9f1c2d3e-4b5a-6c7d-8e9f-0a1b2c3d4e5f"
+                },
+                "traceId": {
+                    "type": "string",
+                    "example": "4bf92f3577b34da6a3ce929d0e0e4736"
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "description": "Request payload for changing the password of the current user.",
+            "type": "object",
+            "required": [
+                "currentPassword",
+                "newPassword"
+            ],
+            "properties": {
+                "currentPassword": {
+                    "type": "string",
+                    "example": "oldpassword123"
+                },
+                "newPassword": {
+                    "type": "string",
+                    "example": "newpassword456"
+                }
+            }
+        },
+        "dto.ChangeRoleRequest": {
+            "description": "Request payload for changing a user's role.",
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "employee"
+                }
+            }
+        },
+        "dto.ClientOrderDTO": {
+            "description": "Represents a parcel of the current client: where it is waiting, its status and the storage deadline.",
+            "type": "object",
+            "properties": {
+                "issuedAt": {
+                    "type": "string",
+                    "example": "2025-04-12T10:00:00Z"
+                },
+                "pickupCode": {
+                    "type": "string",
+                    "example": "042917"
+                },
+                "productId": {
+                    "type": "string",
+                    "example": "prod123"
+                },
+                "pvzAddress": {
+                    "type": "string",
+                    "example": "ul. Tverskaya, 7"
+                },
+                "pvzCity": {
+                    "type": "string",
+                    "example": "Moscow"
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready_for_pickup"
+                },
+                "storageDeadline": {
+                    "type": "string",
+                    "example": "2025-04-19T15:04:05Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "electronics"
+                }
+            }
+        },
+        "dto.CloseReceptionResponse": {
+            "description": "Response returned after successfully closing a reception.",
+            "type": "object",
+            "properties": {
+                "receptionId": {
+                    "type": "string",
+                    "example": "recv456"
+                }
+            }
+        },
+        "dto.CloseReturnResponse": {
+            "description": "Response returned after successfully closing a return shipment.",
+            "type": "object",
+            "properties": {
+                "returnId": {
+                    "type": "string",
+                    "example": "ret456"
+                }
+            }
+        },
+        "dto.CreatePvzPostRequest": {
+            "description": "Request payload for creating a new PVZ.",
+            "type": "object",
+            "required": [
+                "city"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "ul. Tverskaya, 7"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Moscow"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 55.757
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 37.6136
+                },
+                "openingHours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OpeningHoursDTO"
+                    }
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+7 495 123-45-67"
+                }
+            }
+        },
+        "dto.CreatePvzResponse": {
+            "description": "Response returned after successfully creating a PVZ.",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "pvz789"
+                }
+            }
+        },
+        "dto.CreateReceptionRequest": {
+            "description": "Request payload for creating a new reception.",
+            "type": "object",
+            "required": [
+                "pvzId"
+            ],
+            "properties": {
+                "dateTime": {
+                    "type": "string",
+                    "example": "2025-04-09T15:04:05Z"
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                }
+            }
+        },
+        "dto.CreateReceptionResponse": {
+            "description": "Response returned after a reception is created.",
+            "type": "object",
+            "properties": {
+                "receptionId": {
+                    "type": "string",
+                    "example": "recv456"
+                }
+            }
+        },
+        "dto.CreateReturnRequest": {
+            "description": "Request payload for opening a return shipment at a PVZ.",
+            "type": "object",
+            "required": [
+                "pvzId"
+            ],
+            "properties": {
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                }
+            }
+        },
+        "dto.CreateReturnResponse": {
+            "description": "Response returned after a return shipment is opened.",
+            "type": "object",
+            "properties": {
+                "returnId": {
+                    "type": "string",
+                    "example": "ret456"
+                }
+            }
+        },
+        "dto.CreateWebhookRequest": {
+            "description": "Webhook subscription. If secret is omitted, a random one is generated and returned once in the response.",
+            "type": "object",
+            "required": [
+                "eventTypes",
+                "url"
+            ],
+            "properties": {
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ProductAdded",
+                        "OrderIssued"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_3f1c9a7e2b4d6f8a0c1e3b5d7f9a1c3e"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example.com/hooks/pvz"
+                }
+            }
+        },
+        "dto.DailyReceptionsDTO": {
+            "description": "Number of receptions of a PVZ during one day (Moscow time).",
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Moscow"
+                },
+                "day": {
+                    "type": "string",
+                    "example": "2025-04-09"
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "receptions": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.DeleteProductResponse": {
+            "description": "Response returned after successful deletion of the product.",
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "4607001234567"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "product deleted successfully"
+                },
+                "productId": {
+                    "type": "string",
+                    "example": "prod123"
+                }
+            }
+        },
+        "dto.DeleteReturnItemResponse": {
+            "description": "Response returned after the last product is removed from the return shipment.",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "return item deleted successfully"
+                }
+            }
+        },
+        "dto.DummyLoginPostRequest": {
+            "description": "Request payload for dummy login. Provide a desired user role (\"client\", \"employee\", \"moderator\") to obtain a JWT token.",
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "employee"
+                }
+            }
+        },
+        "dto.Error": {
+            "description": "Standard error response containing a machine-readable error code and a human-readable message.",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request body"
+                }
+            }
+        },
+        "dto.IssueOrderRequest": {
+            "description": "Request payload for issuing an order to its recipient.",
+            "type": "object",
+            "required": [
+                "pickupCode"
+            ],
+            "properties": {
+                "pickupCode": {
+                    "type": "string",
+                    "example": "042917"
+                }
+            }
+        },
+        "dto.LoginPostRequest": {
+            "description": "Request payload for login using email and password.",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "strongpassword123"
+                }
+            }
+        },
+        "dto.NearbyPvzDTO": {
+            "description": "PVZ found near the requested point with the distance to it and whether it is open right now.",
+            "type": "object",
+            "properties": {
+                "distanceMeters": {
+                    "type": "number",
+                    "example": 742.5
+                },
+                "openNow": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "pvz": {
+                    "$ref": "#/definitions/dto.PvzDTO"
+                }
+            }
+        },
+        "dto.OpeningHoursDTO": {
+            "description": "Opening hours of a PVZ on one weekday. Days without an entry are days off.",
+            "type": "object",
+            "required": [
+                "closes",
+                "opens",
+                "weekday"
+            ],
+            "properties": {
+                "closes": {
+                    "type": "string",
+                    "example": "21:00"
+                },
+                "opens": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "type": "string",
+                    "enum": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri",
+                        "sat",
+                        "sun"
+                    ],
+                    "example": "mon"
+                }
+            }
+        },
+        "dto.OrderDTO": {
+            "description": "Represents a product assigned to a recipient for pickup at a PVZ.",
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "4607001234567"
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2025-04-16T15:04:05Z"
+                },
+                "issuedAt": {
+                    "type": "string",
+                    "example": "2025-04-12T10:00:00Z"
+                },
+                "issuedBy": {
+                    "type": "string",
+                    "example": "user456"
+                },
+                "productId": {
+                    "type": "string",
+                    "example": "prod123"
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "receivedAt": {
+                    "type": "string",
+                    "example": "2025-04-09T15:04:05Z"
+                },
+                "recipientId": {
+                    "type": "string",
+                    "example": "user123"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready_for_pickup"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "electronics"
+                }
+            }
+        },
+        "dto.PasswordResetResponse": {
+            "description": "Temporary password set by a moderator. It is shown only once.",
+            "type": "object",
+            "properties": {
+                "temporaryPassword": {
+                    "type": "string",
+                    "example": "k3Jd9QmX2vLp0aTz"
+                }
+            }
+        },
+        "dto.PrepareOrderRequest": {
+            "description": "Request payload for assigning a received product to a recipient (a user with the client role).",
+            "type": "object",
+            "required": [
+                "productId",
+                "recipientId"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string",
+                    "example": "prod123"
+                },
+                "recipientId": {
+                    "type": "string",
+                    "example": "user123"
+                }
+            }
+        },
+        "dto.PrepareOrderResponse": {
+            "description": "Response returned after a product is ready for pickup. The pickup code is issued only to the recipient.",
+            "type": "object",
+            "properties": {
+                "order": {
+                    "$ref": "#/definitions/dto.OrderDTO"
+                }
+            }
+        },
+        "dto.ProductDTO": {
+            "description": "Represents a product with its details.",
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "4607001234567"
+                },
+                "dateTime": {
+                    "type": "string",
+                    "example": "2025-04-09T15:04:05Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "prod123"
+                },
+                "receptionId": {
+                    "type": "string",
+                    "example": "recv456"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "electronics"
+                }
+            }
+        },
+        "dto.ProductTypeCountDTO": {
+            "description": "Number of products of one type received by a PVZ.",
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Moscow"
+                },
+                "products": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "electronics"
+                }
+            }
+        },
+        "dto.ProductsBatchItem": {
+            "description": "A single product of a batch intake.",
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "4607001234567"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "electronics"
+                }
+            }
+        },
+        "dto.ProductsBatchItemResult": {
+            "description": "Result of a single batch item: created, failed (with error) or skipped because the atomic batch was rejected.",
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "4607001234567"
+                },
+                "error": {
+                    "$ref": "#/definitions/dto.Error"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "productId": {
+                    "type": "string",
+                    "example": "prod123"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "failed",
+                        "skipped"
+                    ],
+                    "example": "created"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "electronics"
+                }
+            }
+        },
+        "dto.ProductsBatchRequest": {
+            "description": "Request payload for adding many products to the open reception at once. Mode \"atomic\" (default) rejects the whole batch if any item is invalid, \"partial\" saves only valid items.",
+            "type": "object",
+            "required": [
+                "products",
+                "pvzId"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "partial"
+                    ],
+                    "example": "atomic"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductsBatchItem"
+                    }
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                }
+            }
+        },
+        "dto.ProductsBatchResponse": {
+            "description": "Response of a batch intake with per-item results in request order.",
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 398
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductsBatchItemResult"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "atomic"
+                }
+            }
+        },
+        "dto.ProductsPostRequest": {
+            "description": "Request payload for adding a product to a reception.",
+            "type": "object",
+            "required": [
+                "pvzId",
+                "type"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "4607001234567"
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "clothes"
+                }
+            }
+        },
+        "dto.ProductsPostResponse": {
+            "description": "Response returned after successfully adding a product.",
+            "type": "object",
+            "properties": {
+                "productId": {
+                    "type": "string",
+                    "example": "prod123"
+                }
+            }
+        },
+        "dto.PvzActivityEventDTO": {
+            "description": "Reception or product event of a PVZ. id is the position in the feed: pass the last received id to resume after a reconnect.",
+            "type": "object",
+            "properties": {
+                "aggregateId": {
+                    "type": "string",
+                    "example": "prod123"
+                },
+                "eventId": {
+                    "type": "string",
+                    "example": "9f1c2d3e-4b5a-6c7d-8e9f-0a1b2c3d4e5f"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1042
+                },
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2025-05-04T10:00:00Z"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "ReceptionOpened",
+                        "ProductAdded",
+                        "ProductRemoved",
+                        "ReceptionClosed"
+                    ],
+                    "example": "ProductAdded"
+                }
+            }
+        },
+        "dto.PvzDTO": {
+            "description": "Represents a PVZ (pickup point) with its information.",
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "ul. Tverskaya, 7"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Moscow"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 55.757
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 37.6136
+                },
+                "openingHours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OpeningHoursDTO"
+                    }
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+7 495 123-45-67"
+                },
+                "registrationDate": {
+                    "type": "string",
+                    "example": "2025-04-09T12:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "suspended",
+                        "closed"
+                    ],
+                    "example": "active"
+                }
+            }
+        },
+        "dto.PvzExportRowDTO": {
+            "description": "One row of the PVZ history export: a PVZ, one of its receptions in the period and one product of that reception. Reception and product fields are empty when there are none.",
+            "type": "object",
+            "properties": {
+                "productBarcode": {
+                    "type": "string",
+                    "example": "4601234567890"
+                },
+                "productDateTime": {
+                    "type": "string",
+                    "example": "2025-04-09T15:10:00Z"
+                },
+                "productId": {
+                    "type": "string",
+                    "example": "prod456"
+                },
+                "productType": {
+                    "type": "string",
+                    "example": "electronics"
+                },
+                "pvzAddress": {
+                    "type": "string",
+                    "example": "ul. Tverskaya, 7"
+                },
+                "pvzCity": {
+                    "type": "string",
+                    "example": "Moscow"
+                },
+                "pvzId": {
+                    "type": "string",
+                    "example": "pvz789"
+                },
+                "pvzRegistrationDate": {
+                    "type": "string",
+                    "example": "2025-04-09T12:00:00Z"
+                },
+                "pvzStatus": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "suspended",
+                        "closed"
+                    ],
+                    "example": "active"
+                },
+                "receptionDateTime": {
+                    "type": "string",
+                    "example": "2025-04-09T15:04:05Z"
+                },
+                "receptionId": {
+                    "type": "string",
+                    "example": "rec123"
+                },
+                "receptionStatus": {
+                    "type": "string",
+                    "example": "close"
+                }
+            }
+        },
+        "dto.PvzGet200ResponseInner": {
+            "description": "Response model for retrieving PVZ information, including receptions and products.",
+            "type": "object",
+            "properties": {
+                "pvz": {
+                    "$ref": "#/definitions/dto.PvzDTO"
+                },
+                "receptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PvzGet200ResponseInnerReceptionsInner"
+                    }
+                }
+            }
+        },
+        "dto.PvzGet200ResponseInnerReceptionsInner": {
+            "description": "Represents a reception within a PVZ, including its associated products.",
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "pvz789"
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductDTO"
+                    }
+                },
+                "reception": {
+                    "$ref": "#/definitions/dto.ReceptionDTO"
                 }
             }
         },
-        "dto.CreateReceptionRequest": {
-            "description": "Request payload for creating a new reception.",
+        "dto.ReceptionDTO": {
+            "description": "Represents a reception record for goods. openedBy and closedBy are the IDs of the employees who opened and closed the reception; they are absent for dummy-login users and for receptions recorded before these fields existed.",
             "type": "object",
-            "required": [
-                "pvzId"
-            ],
             "properties": {
+                "closedAt": {
+                    "type": "string",
+                    "example": "2025-04-09T16:30:00Z"
+                },
+                "closedBy": {
+                    "type": "string",
+                    "example": "user456"
+                },
                 "dateTime": {
                     "type": "string",
                     "example": "2025-04-09T15:04:05Z"
                 },
+                "id": {
+                    "type": "string",
+                    "example": "recv456"
+                },
+                "openedBy": {
+                    "type": "string",
+                    "example": "user123"
+                },
                 "pvzId": {
                     "type": "string",
                     "example": "pvz789"
-                }
-            }
-        },
-        "dto.CreateReceptionResponse": {
-            "description": "Response returned after a reception is created.",
-            "type": "object",
-            "properties": {
-                "receptionId": {
+                },
+                "status": {
                     "type": "string",
-                    "example": "recv456"
+                    "example": "in_progress"
                 }
             }
         },
-        "dto.DeleteProductResponse": {
-            "description": "Response returned after successful deletion of the product.",
+        "dto.ReceptionStatsDTO": {
+            "description": "Reception summary of a PVZ. The average duration is computed over receptions with a known close time and is absent when there are none.",
             "type": "object",
             "properties": {
-                "message": {
+                "avgDurationSeconds": {
+                    "type": "number",
+                    "example": 1830.5
+                },
+                "avgProductsPerReception": {
+                    "type": "number",
+                    "example": 12
+                },
+                "city": {
                     "type": "string",
-                    "example": "product deleted successfully"
-                }
-            }
-        },
-        "dto.DummyLoginPostRequest": {
-            "description": "Request payload for dummy login. Provide a desired user role (\"client\", \"employee\", \"moderator\") to obtain a JWT token.",
-            "type": "object",
-            "properties": {
-                "role": {
+                    "example": "Moscow"
+                },
+                "closedReceptions": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "products": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "pvzId": {
                     "type": "string",
-                    "example": "employee"
+                    "example": "pvz789"
+                },
+                "receptions": {
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "dto.Error": {
-            "description": "Standard error response containing an error message.",
+        "dto.RefreshTokenRequest": {
+            "description": "Request payload for exchanging a refresh token for a new token pair.",
             "type": "object",
+            "required": [
+                "refreshToken"
+            ],
             "properties": {
-                "message": {
+                "refreshToken": {
                     "type": "string",
-                    "example": "invalid request body"
+                    "example": "q3Vh0lJY9mX2w6c8n1pT4rE7sK5dA0bZgF2hU9jL3oQ"
                 }
             }
         },
-        "dto.LoginPostRequest": {
-            "description": "Request payload for login using email and password.",
+        "dto.RegisterPostRequest": {
+            "description": "Request payload for user registration.",
             "type": "object",
             "required": [
                 "email",
-                "password"
+                "password",
+                "role"
             ],
             "properties": {
                 "email": {
@@ -710,11 +4822,25 @@ const docTemplate = `{
                 "password": {
                     "type": "string",
                     "example": "strongpassword123"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "moderator"
                 }
             }
         },
-        "dto.ProductDTO": {
-            "description": "Represents a product with its details.",
+        "dto.RegisterResponse": {
+            "description": "Response returned after successful user registration.",
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "string",
+                    "example": "user123"
+                }
+            }
+        },
+        "dto.ReturnItemDTO": {
+            "description": "Represents a product placed into a return shipment to the seller.",
             "type": "object",
             "properties": {
                 "dateTime": {
@@ -722,106 +4848,262 @@ const docTemplate = `{
                     "example": "2025-04-09T15:04:05Z"
                 },
                 "id": {
+                    "type": "string",
+                    "example": "item123"
+                },
+                "productId": {
                     "type": "string",
                     "example": "prod123"
                 },
-                "receptionId": {
+                "reason": {
                     "type": "string",
-                    "example": "recv456"
+                    "enum": [
+                        "refused",
+                        "expired",
+                        "damaged"
+                    ],
+                    "example": "refused"
                 },
-                "type": {
+                "returnId": {
                     "type": "string",
-                    "example": "electronics"
+                    "example": "ret456"
                 }
             }
         },
-        "dto.ProductsPostRequest": {
-            "description": "Request payload for adding a product to a reception.",
+        "dto.TokenResponse": {
+            "description": "Response containing a JWT access token. Login and refresh also return a refresh token and the access token lifetime in seconds; passwordChangeRequired is set after a moderator has reset the password.",
             "type": "object",
-            "required": [
-                "pvzId",
-                "type"
-            ],
             "properties": {
-                "pvzId": {
+                "expiresIn": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "passwordChangeRequired": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "refreshToken": {
                     "type": "string",
-                    "example": "pvz789"
+                    "example": "q3Vh0lJY9mX2w6c8n1pT4rE7sK5dA0bZgF2hU9jL3oQ"
                 },
-                "type": {
+                "token": {
                     "type": "string",
-                    "example": "clothes"
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 }
             }
         },
-        "dto.ProductsPostResponse": {
-            "description": "Response returned after successfully adding a product.",
+        "dto.UpdatePvzRequest": {
+            "description": "Partial update of PVZ details. Omitted fields are left unchanged; openingHours replaces the whole schedule. clearCoordinates removes the coordinates and cannot be combined with latitude and longitude.",
             "type": "object",
             "properties": {
-                "productId": {
+                "address": {
                     "type": "string",
-                    "example": "prod123"
+                    "example": "ul. Tverskaya, 9"
+                },
+                "clearCoordinates": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 55.757
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 37.6136
+                },
+                "openingHours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OpeningHoursDTO"
+                    }
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+7 495 123-45-67"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "suspended",
+                        "closed"
+                    ],
+                    "example": "suspended"
                 }
             }
         },
-        "dto.PvzDTO": {
-            "description": "Represents a PVZ (pickup point) with its information.",
+        "dto.UpdateWebhookRequest": {
+            "description": "Partial update of a webhook subscription. Omitted fields are left unchanged; eventTypes replaces the whole list.",
             "type": "object",
             "properties": {
-                "city": {
+                "active": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "OrderIssued"
+                    ]
+                },
+                "url": {
                     "type": "string",
-                    "example": "Moscow"
+                    "example": "https://partner.example.com/hooks/pvz-v2"
+                }
+            }
+        },
+        "dto.User": {
+            "description": "Represents a user in the system. Inactive users cannot log in; passwordChangeRequired is set after a moderator has reset the password.",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-05-01T10:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
                 },
                 "id": {
                     "type": "string",
-                    "example": "pvz789"
+                    "example": "user123"
                 },
-                "registrationDate": {
+                "passwordChangeRequired": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "role": {
                     "type": "string",
-                    "example": "2025-04-09T12:00:00Z"
+                    "example": "moderator"
                 }
             }
         },
-        "dto.PvzGet200ResponseInner": {
-            "description": "Response model for retrieving PVZ information, including receptions and products.",
+        "dto.WebhookDTO": {
+            "description": "Webhook subscription. The secret is present only in the response to creation.",
             "type": "object",
             "properties": {
-                "pvz": {
-                    "$ref": "#/definitions/dto.PvzDTO"
+                "active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "receptions": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-05-03T10:00:00Z"
+                },
+                "eventTypes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.PvzGet200ResponseInnerReceptionsInner"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "OrderIssued",
+                        "ProductAdded"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5f0c6a9e-2b7d-4c1e-8f3a-9d2b4c6e8a01"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_3f1c9a7e2b4d6f8a0c1e3b5d7f9a1c3e"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-05-03T10:00:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example.com/hooks/pvz"
                 }
             }
         },
-        "dto.PvzGet200ResponseInnerReceptionsInner": {
-            "description": "Represents a reception within a PVZ, including its associated products.",
+        "dto.WebhookDeliveryAttemptDTO": {
+            "description": "One delivery attempt. statusCode is absent when no response was received.",
             "type": "object",
             "properties": {
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ProductDTO"
-                    }
+                "attemptedAt": {
+                    "type": "string",
+                    "example": "2025-05-03T10:00:00Z"
                 },
-                "reception": {
-                    "$ref": "#/definitions/dto.ReceptionDTO"
+                "durationMs": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "error": {
+                    "type": "string",
+                    "example": "unexpected status 503: maintenance"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 503
                 }
             }
         },
-        "dto.ReceptionDTO": {
-            "description": "Represents a reception record for goods.",
+        "dto.WebhookDeliveryDTO": {
+            "description": "Delivery of one event to a webhook subscription. Attempts history is present only when a single delivery is requested.",
             "type": "object",
             "properties": {
-                "dateTime": {
+                "aggregateId": {
                     "type": "string",
-                    "example": "2025-04-09T15:04:05Z"
+                    "example": "prod123"
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-05-03T10:00:00Z"
+                },
+                "deliveredAt": {
+                    "type": "string",
+                    "example": "2025-05-03T10:00:01Z"
+                },
+                "eventId": {
+                    "type": "string",
+                    "example": "9f1c2d3e-4b5a-6c7d-8e9f-0a1b2c3d4e5f"
+                },
+                "eventType": {
+                    "type": "string",
+                    "example": "OrderIssued"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDeliveryAttemptDTO"
+                    }
                 },
                 "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "lastError": {
                     "type": "string",
-                    "example": "recv456"
+                    "example": "unexpected status 503: maintenance"
+                },
+                "lastStatusCode": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "nextAttemptAt": {
+                    "type": "string",
+                    "example": "2025-05-03T10:05:00Z"
+                },
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2025-05-03T10:00:00Z"
+                },
+                "payload": {
+                    "type": "object"
                 },
                 "pvzId": {
                     "type": "string",
@@ -829,50 +5111,64 @@ const docTemplate = `{
                 },
                 "status": {
                     "type": "string",
-                    "example": "in_progress"
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "failed"
+                    ],
+                    "example": "failed"
+                },
+                "webhookId": {
+                    "type": "string",
+                    "example": "5f0c6a9e-2b7d-4c1e-8f3a-9d2b4c6e8a01"
                 }
             }
         },
-        "dto.RegisterPostRequest": {
-            "description": "Request payload for user registration.",
+        "jwt.JWK": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "role"
-            ],
             "properties": {
-                "email": {
+                "alg": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "RS256"
                 },
-                "password": {
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
                     "type": "string",
-                    "example": "strongpassword123"
+                    "example": "AQAB"
                 },
-                "role": {
+                "kid": {
                     "type": "string",
-                    "example": "moderator"
-                }
-            }
-        },
-        "dto.RegisterResponse": {
-            "description": "Response returned after successful user registration.",
-            "type": "object",
-            "properties": {
-                "user_id": {
+                    "example": "2025-05"
+                },
+                "kty": {
                     "type": "string",
-                    "example": "user123"
+                    "example": "RSA"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
                 }
             }
         },
-        "dto.TokenResponse": {
-            "description": "Response containing a JWT token.",
+        "jwt.JWKS": {
             "type": "object",
             "properties": {
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jwt.JWK"
+                    }
                 }
             }
         }
@@ -0,0 +1,142 @@
+package activity
+
+import (
+	"errors"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"sync"
+)
+
+// DefaultBuffer — сколько событий подписчик может не забрать, прежде чем его отключат
+const DefaultBuffer = 256
+
+// Причины, по которым Hub закрывает подписку. Во всех случаях клиент переподключается
+// с последним полученным id и дочитывает пропущенное из БД.
+var (
+	ErrLagged = errors.New("subscriber did not keep up with the activity feed")
+	ErrResync = errors.New("activity feed reconnected, events may have been missed")
+	ErrClosed = errors.New("activity feed is shutting down")
+)
+
+var interruptReasons = map[error]string{
+	ErrLagged: "lagged",
+	ErrResync: "resync",
+	ErrClosed: "shutdown",
+}
+
+// Hub раздаёт события активности ПВЗ подписчикам внутри процесса. События в него
+// передаёт слушатель LISTEN/NOTIFY, поэтому каждая реплика получает все события.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	buffer int
+	closed bool
+}
+
+func NewHub(buffer int) *Hub {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &Hub{subs: make(map[*Subscription]struct{}), buffer: buffer}
+}
+
+// Subscription — подписка на события набора ПВЗ. Канал Events закрывается, когда
+// подписку закрыл клиент или Hub; причину во втором случае возвращает Err.
+type Subscription struct {
+	hub    *Hub
+	pvzIDs map[string]bool
+	events chan entity.OutboxEvent
+	err    error
+}
+
+func (s *Subscription) Events() <-chan entity.OutboxEvent {
+	return s.events
+}
+
+// Err возвращает причину закрытия подписки сервером. Читать после закрытия Events.
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+// Close отписывает клиента. Повторный вызов безопасен.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s, nil)
+}
+
+func (h *Hub) Subscribe(pvzIDs []string) *Subscription {
+	sub := &Subscription{
+		hub:    h,
+		pvzIDs: make(map[string]bool, len(pvzIDs)),
+		events: make(chan entity.OutboxEvent, h.buffer),
+	}
+	for _, id := range pvzIDs {
+		sub.pvzIDs[id] = true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		sub.err = ErrClosed
+		close(sub.events)
+		return sub
+	}
+	h.subs[sub] = struct{}{}
+	metrics.ActivitySubscribers.Inc()
+	return sub
+}
+
+// Publish передаёт событие подписчикам его ПВЗ. Publish не блокируется: подписчик
+// с заполненным буфером отключается с ErrLagged.
+func (h *Hub) Publish(event entity.OutboxEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		if !sub.pvzIDs[event.PvzID] {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(sub, ErrLagged)
+		}
+	}
+}
+
+// Resync отключает всех подписчиков. Вызывается после переподключения слушателя:
+// уведомления, пришедшие без соединения, потеряны, и клиенты дочитывают их из БД.
+func (h *Hub) Resync() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		h.remove(sub, ErrResync)
+	}
+}
+
+// Close отключает всех подписчиков и запрещает новые подписки. Без этого открытые
+// потоки не дали бы HTTP- и gRPC-серверам завершиться при graceful shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subs {
+		h.remove(sub, ErrClosed)
+	}
+}
+
+// remove вызывается под h.mu
+func (h *Hub) remove(sub *Subscription, reason error) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	sub.err = reason
+	close(sub.events)
+	metrics.ActivitySubscribers.Dec()
+	if reason != nil {
+		metrics.ActivityInterrupted(interruptReasons[reason])
+	}
+}
@@ -0,0 +1,87 @@
+package activity
+
+import (
+	"errors"
+	"order-pick-up-point/internal/models/entity"
+	"testing"
+)
+
+func drain(sub *Subscription) []int64 {
+	var ids []int64
+	for e := range sub.Events() {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestHub_PublishFiltersByPvz(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(10)
+	first := hub.Subscribe([]string{"pvz1", "pvz2"})
+	second := hub.Subscribe([]string{"pvz2"})
+
+	hub.Publish(entity.OutboxEvent{ID: 1, PvzID: "pvz1"})
+	hub.Publish(entity.OutboxEvent{ID: 2, PvzID: "pvz2"})
+	hub.Publish(entity.OutboxEvent{ID: 3, PvzID: "pvz3"})
+	hub.Close()
+
+	if got := drain(first); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("expected events [1 2] for first subscriber, got %v", got)
+	}
+	if got := drain(second); len(got) != 1 || got[0] != 2 {
+		t.Errorf("expected events [2] for second subscriber, got %v", got)
+	}
+	if !errors.Is(first.Err(), ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", first.Err())
+	}
+}
+
+func TestHub_SlowSubscriberIsDisconnected(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(1)
+	slow := hub.Subscribe([]string{"pvz1"})
+	fast := hub.Subscribe([]string{"pvz1"})
+
+	hub.Publish(entity.OutboxEvent{ID: 1, PvzID: "pvz1"})
+	<-fast.Events()
+	hub.Publish(entity.OutboxEvent{ID: 2, PvzID: "pvz1"})
+
+	if got := drain(slow); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected only buffered event [1], got %v", got)
+	}
+	if !errors.Is(slow.Err(), ErrLagged) {
+		t.Errorf("expected ErrLagged, got %v", slow.Err())
+	}
+	if e := <-fast.Events(); e.ID != 2 {
+		t.Errorf("expected fast subscriber to get event 2, got %d", e.ID)
+	}
+	fast.Close()
+}
+
+func TestHub_ResyncAndClose(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(10)
+	sub := hub.Subscribe([]string{"pvz1"})
+	hub.Resync()
+	drain(sub)
+	if !errors.Is(sub.Err(), ErrResync) {
+		t.Errorf("expected ErrResync, got %v", sub.Err())
+	}
+
+	// закрытие клиентом не является прерыванием
+	own := hub.Subscribe([]string{"pvz1"})
+	own.Close()
+	own.Close()
+	if own.Err() != nil {
+		t.Errorf("expected no error after client close, got %v", own.Err())
+	}
+
+	hub.Close()
+	late := hub.Subscribe([]string{"pvz1"})
+	if _, ok := <-late.Events(); ok || !errors.Is(late.Err(), ErrClosed) {
+		t.Errorf("expected closed subscription after hub close, got %v", late.Err())
+	}
+}
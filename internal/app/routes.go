@@ -5,19 +5,36 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"net"
 	controller "order-pick-up-point/internal/controller/http"
 	"order-pick-up-point/internal/controller/http/middleware"
 	"order-pick-up-point/internal/metrics"
 	"order-pick-up-point/pkg/jwt"
 )
 
-func SetupRoutes(router *gin.Engine, authCtrl controller.AuthController, pvzCtrl controller.PvzController, tokenSvc jwt.TokenService) {
+// DummyLoginOptions — доступ к /dummyLogin: без Enabled маршрут не регистрируется, Networks и Secret
+// ограничивают, кто может получить токен.
+type DummyLoginOptions struct {
+	Enabled  bool
+	Networks []*net.IPNet
+	Secret   string
+}
+
+func SetupRoutes(router *gin.Engine, authCtrl controller.AuthController, pvzCtrl controller.PvzController, webhookCtrl controller.WebhookController, auditCtrl controller.AuditController, tokenSvc jwt.TokenService, revocations jwt.RevocationChecker, dummyLogin DummyLoginOptions) {
+	// Контроллеры передают в сервисы *gin.Context, а claims, request ID и span лежат в контексте запроса
+	router.ContextWithFallback = true
+
+	router.Use(middleware.RequestIDMiddleware())
 	router.Use(metrics.GinPrometheusMiddleware())
 	router.Use(otelgin.Middleware("order-pick-up-point"))
 
-	router.POST("/dummyLogin", authCtrl.DummyLogin)
+	if dummyLogin.Enabled {
+		router.POST("/dummyLogin", middleware.DummyLoginGuard(dummyLogin.Networks, dummyLogin.Secret), authCtrl.DummyLogin)
+	}
 	router.POST("/register", authCtrl.Register)
 	router.POST("/login", authCtrl.Login)
+	router.POST("/token/refresh", authCtrl.RefreshToken)
+	router.GET("/.well-known/jwks.json", authCtrl.JWKS)
 
 	router.StaticFile("/swagger/spec/http/swagger.json", "./docs/swagger.json")
 	router.GET("/swagger/http/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/swagger/spec/http/swagger.json")))
@@ -26,11 +43,17 @@ func SetupRoutes(router *gin.Engine, authCtrl controller.AuthController, pvzCtrl
 	router.GET("/swagger/grpc/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/swagger/spec/grpc/swagger.json")))
 
 	protected := router.Group("/")
-	protected.Use(middleware.JWTAuthMiddleware(tokenSvc))
+	protected.Use(middleware.JWTAuthMiddleware(tokenSvc, revocations))
 	{
+		protected.POST("/logout", authCtrl.Logout)
+
 		protected.POST("/pvz", pvzCtrl.CreatePvz)
+		protected.PATCH("/pvz/:pvzId", pvzCtrl.UpdatePvz)
 		protected.POST("/receptions", pvzCtrl.CreateReception)
 		protected.POST("/products", pvzCtrl.AddProduct)
+		protected.POST("/products/batch", pvzCtrl.AddProductsBatch)
+		protected.POST("/products/batch/csv", pvzCtrl.AddProductsBatchCSV)
+		protected.GET("/products/barcode/:barcode", pvzCtrl.GetProductByBarcode)
 		protected.POST("/pvz/:pvzId/delete_last_product", func(c *gin.Context) {
 			c.Set("pvzId", c.Param("pvzId"))
 			pvzCtrl.DeleteLastProduct(c)
@@ -41,5 +64,47 @@ func SetupRoutes(router *gin.Engine, authCtrl controller.AuthController, pvzCtrl
 		})
 		protected.GET("/pvz", pvzCtrl.GetPvzsInfo)
 		protected.GET("/pvz/optimized", pvzCtrl.GetPvzsInfoOptimized)
+		protected.GET("/pvz/nearby", pvzCtrl.SearchNearbyPvzs)
+		protected.GET("/pvz/export", pvzCtrl.ExportPvzHistory)
+		protected.GET("/pvz/activity", pvzCtrl.StreamPvzActivity)
+
+		protected.GET("/analytics/receptions/daily", pvzCtrl.GetReceptionsPerDay)
+		protected.GET("/analytics/products/types", pvzCtrl.GetProductTypeCounts)
+		protected.GET("/analytics/receptions/stats", pvzCtrl.GetReceptionStats)
+
+		protected.POST("/pvz/:pvzId/orders", pvzCtrl.PrepareOrder)
+		protected.GET("/pvz/:pvzId/orders", pvzCtrl.GetOrdersForPickup)
+		protected.POST("/pvz/:pvzId/orders/:productId/issue", pvzCtrl.IssueOrder)
+		protected.POST("/pvz/:pvzId/orders/:productId/return", pvzCtrl.ReturnOrder)
+
+		protected.GET("/my/orders", pvzCtrl.GetMyOrders)
+		protected.GET("/my/orders/:productId", pvzCtrl.GetMyOrder)
+		protected.POST("/my/orders/:productId/pickup_code", pvzCtrl.RenewMyPickupCode)
+		protected.GET("/pvz/:pvzId/overdue", pvzCtrl.GetOverdueOrders)
+
+		protected.POST("/returns", pvzCtrl.CreateReturn)
+		protected.POST("/returns/items", pvzCtrl.AddReturnItem)
+		protected.POST("/pvz/:pvzId/delete_last_return_item", pvzCtrl.DeleteLastReturnItem)
+		protected.POST("/pvz/:pvzId/close_last_return", pvzCtrl.CloseReturn)
+
+		protected.GET("/audit", auditCtrl.GetAuditLog)
+
+		protected.GET("/users", authCtrl.ListUsers)
+		protected.GET("/users/:userId", authCtrl.GetUser)
+		protected.PATCH("/users/:userId/role", authCtrl.ChangeUserRole)
+		protected.POST("/users/:userId/deactivate", authCtrl.DeactivateUser)
+		protected.POST("/users/:userId/reactivate", authCtrl.ReactivateUser)
+		protected.POST("/users/:userId/reset_password", authCtrl.ResetUserPassword)
+		protected.POST("/users/:userId/unlock", authCtrl.UnlockUser)
+		protected.POST("/my/password", authCtrl.ChangePassword)
+
+		protected.POST("/webhooks", webhookCtrl.CreateWebhook)
+		protected.GET("/webhooks", webhookCtrl.ListWebhooks)
+		protected.GET("/webhooks/:webhookId", webhookCtrl.GetWebhook)
+		protected.PATCH("/webhooks/:webhookId", webhookCtrl.UpdateWebhook)
+		protected.DELETE("/webhooks/:webhookId", webhookCtrl.DeleteWebhook)
+		protected.GET("/webhooks/:webhookId/deliveries", webhookCtrl.GetWebhookDeliveries)
+		protected.GET("/webhooks/:webhookId/deliveries/:deliveryId", webhookCtrl.GetWebhookDelivery)
+		protected.POST("/webhooks/:webhookId/deliveries/:deliveryId/retry", webhookCtrl.RetryWebhookDelivery)
 	}
 }
@@ -21,15 +21,18 @@ import (
 	"net"
 	"net/http"
 	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/activity"
 	"order-pick-up-point/internal/config"
 	grpcController "order-pick-up-point/internal/controller/grpc"
 	"order-pick-up-point/internal/controller/grpc/middleware"
 	controller "order-pick-up-point/internal/controller/http"
 	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/outbox"
 	grpcServ "order-pick-up-point/internal/service/grpc"
 	httpServ "order-pick-up-point/internal/service/http"
 	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/internal/worker"
 	"order-pick-up-point/pkg/jwt"
 	"order-pick-up-point/pkg/logger"
 	"order-pick-up-point/pkg/password"
@@ -77,6 +80,40 @@ func initTracer(ctx context.Context) func() {
 	}
 }
 
+// dummyLoginOptions собирает доступ к /dummyLogin из конфига. Включённый /dummyLogin выдаёт токен
+// любой роли без учётных данных, поэтому об этом громко пишется при старте.
+func dummyLoginOptions(cfg *config.Config, log logger.Logger) DummyLoginOptions {
+	if !cfg.DummyLogin.EnabledFor(cfg.Env) {
+		return DummyLoginOptions{}
+	}
+
+	networks, err := cfg.DummyLogin.Networks()
+	if err != nil {
+		log.Fatalw("parse dummy login networks",
+			"error", err)
+	}
+
+	log.Warnw("!!! /dummyLogin IS ENABLED: anyone allowed below can get a token for any role without credentials !!!",
+		"env", cfg.Env,
+		"allowedNetworks", cfg.DummyLogin.AllowedNetworks,
+		"secretRequired", cfg.DummyLogin.Secret != "",
+	)
+
+	return DummyLoginOptions{Enabled: true, Networks: networks, Secret: cfg.DummyLogin.Secret}
+}
+
+func loginProtectionOptions(cfg config.LoginProtectionConfig) httpServ.LoginProtectionOptions {
+	return httpServ.LoginProtectionOptions{
+		Enabled:          cfg.Enable,
+		EmailMaxFailures: cfg.EmailMaxFailures,
+		IPMaxFailures:    cfg.IPMaxFailures,
+		Lockout:          time.Duration(cfg.Lockout) * time.Second,
+		BaseDelay:        time.Duration(cfg.BaseDelay) * time.Second,
+		MaxDelay:         time.Duration(cfg.MaxDelay) * time.Second,
+		FailureWindow:    time.Duration(cfg.FailureWindow) * time.Second,
+	}
+}
+
 func NewServer(cfg *config.Config, log logger.Logger) *Server {
 	c := NewCloser()
 
@@ -113,20 +150,118 @@ func NewServer(cfg *config.Config, log logger.Logger) *Server {
 	pvzRepo := db.NewPvzRepository(txManager, log)
 	receptionRepo := db.NewReceptionRepository(txManager, log)
 	productRepo := db.NewProductRepository(txManager, log)
+	orderRepo := db.NewOrderRepository(txManager, log)
+	returnRepo := db.NewReturnRepository(txManager, log)
+	auditRepo := db.NewAuditRepository(txManager, log)
+	outboxRepo := db.NewOutboxRepository(txManager, log)
+	webhookRepo := db.NewWebhookRepository(txManager, log)
+	tokenRepo := db.NewTokenRepository(txManager, log)
+	loginAttemptRepo := db.NewLoginAttemptRepository(txManager, log)
 
-	repo := db.NewRepository(userRepo, pvzRepo, receptionRepo, productRepo)
+	repo := db.NewRepository(userRepo, pvzRepo, receptionRepo, productRepo, orderRepo, returnRepo, auditRepo, outboxRepo, webhookRepo, tokenRepo, loginAttemptRepo)
 
-	tokenService := jwt.NewTokenService(cfg.JWT.SecretKey, cfg.JWT.TokenExpiry)
+	jwtKeys, err := cfg.JWT.KeySet()
+	if err != nil {
+		log.Fatalw("load jwt keys",
+			"error", err)
+	}
+	if len(cfg.JWT.Keys) == 0 {
+		log.Warnw("JWT keys are not configured, tokens are signed with the shared HS256 secret and JWKS is empty")
+	}
+	dummyLogin := dummyLoginOptions(cfg, log)
+	tokenService := jwt.NewTokenService(jwtKeys, cfg.JWT.Issuer, cfg.JWT.Audience, cfg.JWT.TokenExpiry, dummyLogin.Enabled)
 	passwordHasher := password.NewBCryptHasher(0)
 
-	authService := httpServ.NewAuthService(repo, txManager, tokenService, passwordHasher, log, cfg.Allowed.Roles)
-	pvzService := httpServ.NewPvzService(repo, txManager, log, cfg.Allowed.Cities, cfg.Allowed.ProductTypes)
+	authService := httpServ.NewAuthService(repo, txManager, tokenService, passwordHasher, log, cfg.Allowed.Roles,
+		time.Duration(cfg.JWT.RefreshTokenExpiry)*time.Second, loginProtectionOptions(cfg.Login))
+	activityHub := activity.NewHub(activity.DefaultBuffer)
+	activityListener := db.NewPvzActivityListener(pgPool, log)
+	activityListener.Start(context.Background(), activityHub.Publish, activityHub.Resync)
+	c.Add(func(ctx context.Context) error {
+		log.Infow("Stopping PVZ activity feed")
+		activityHub.Close()
+		return activityListener.Stop(ctx)
+	})
+
+	pvzService := httpServ.NewPvzService(repo, txManager, log, cfg.Allowed.Cities, cfg.Allowed.ProductTypes, cfg.Allowed.StoragePeriods, activityHub)
+	webhookService := httpServ.NewWebhookService(repo, txManager, log)
+	auditService := httpServ.NewAuditService(auditRepo, log)
+
+	if cfg.ExpiryWorker.Enable {
+		expiryWorker := worker.NewExpiryWorker(
+			orderRepo,
+			log,
+			time.Duration(cfg.ExpiryWorker.Interval)*time.Second,
+			cfg.ExpiryWorker.BatchSize,
+		)
+		expiryWorker.Start(context.Background())
+		c.Add(func(ctx context.Context) error {
+			log.Infow("Stopping expiry worker")
+			return expiryWorker.Stop(ctx)
+		})
+	}
+
+	if cfg.Outbox.Enable {
+		publisher, err := outbox.NewPublisher(cfg.Outbox.Publisher, cfg.Outbox.FilePath)
+		if err != nil {
+			log.Fatalw("create outbox publisher",
+				"error", err)
+		}
+		outboxRelay := worker.NewOutboxRelay(outboxRepo, txManager, publisher, log, worker.OutboxRelayOptions{
+			Interval:    time.Duration(cfg.Outbox.Interval) * time.Second,
+			BatchSize:   cfg.Outbox.BatchSize,
+			MaxAttempts: cfg.Outbox.MaxAttempts,
+			BaseBackoff: time.Duration(cfg.Outbox.BaseBackoff) * time.Second,
+			MaxBackoff:  time.Duration(cfg.Outbox.MaxBackoff) * time.Second,
+			Retention:   time.Duration(cfg.Outbox.RetentionDays) * 24 * time.Hour,
+		})
+		outboxRelay.Start(context.Background())
+		c.Add(func(ctx context.Context) error {
+			log.Infow("Stopping outbox relay")
+			return outboxRelay.Stop(ctx)
+		})
+	}
+
+	if cfg.Webhooks.Enable {
+		webhookWorker := worker.NewWebhookWorker(webhookRepo, log, worker.WebhookWorkerOptions{
+			Interval:             time.Duration(cfg.Webhooks.Interval) * time.Second,
+			BatchSize:            cfg.Webhooks.BatchSize,
+			MaxAttempts:          cfg.Webhooks.MaxAttempts,
+			BaseBackoff:          time.Duration(cfg.Webhooks.BaseBackoff) * time.Second,
+			MaxBackoff:           time.Duration(cfg.Webhooks.MaxBackoff) * time.Second,
+			Timeout:              time.Duration(cfg.Webhooks.Timeout) * time.Second,
+			AllowPrivateNetworks: cfg.Webhooks.AllowPrivateNetworks,
+		})
+		webhookWorker.Start(context.Background())
+		c.Add(func(ctx context.Context) error {
+			log.Infow("Stopping webhook worker")
+			return webhookWorker.Stop(ctx)
+		})
+	}
+
+	if cfg.TokenCleanup.Enable {
+		tokenCleanupWorker := worker.NewTokenCleanupWorker(tokenRepo, loginAttemptRepo, log,
+			time.Duration(cfg.TokenCleanup.Interval)*time.Second, time.Duration(cfg.Login.FailureWindow)*time.Second)
+		tokenCleanupWorker.Start(context.Background())
+		c.Add(func(ctx context.Context) error {
+			log.Infow("Stopping token cleanup worker")
+			return tokenCleanupWorker.Stop(ctx)
+		})
+	}
 
 	authController := controller.NewAuthController(authService)
 	pvzController := controller.NewPvzController(pvzService)
+	webhookController := controller.NewWebhookController(webhookService)
+	auditController := controller.NewAuditController(auditService)
 
 	router := gin.Default()
-	SetupRoutes(router, authController, pvzController, tokenService)
+	// Без доверенных прокси ClientIP — адрес соединения: иначе X-Forwarded-For позволил бы
+	// обходить ограничение попыток входа по IP
+	if err := router.SetTrustedProxies(cfg.PublicServer.TrustedProxies); err != nil {
+		log.Fatalw("set trusted proxies",
+			"error", err)
+	}
+	SetupRoutes(router, authController, pvzController, webhookController, auditController, tokenService, repo, dummyLogin)
 
 	httpServer := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", cfg.PublicServer.Endpoint, cfg.PublicServer.Port),
@@ -134,11 +269,13 @@ func NewServer(cfg *config.Config, log logger.Logger) *Server {
 	}
 
 	pvzGRPCService := grpcServ.NewPvzService(pvzRepo, log)
-	pvzGRPCController := grpcController.NewPvzServer(pvzGRPCService)
+	pvzGRPCController := grpcController.NewPvzServer(pvzGRPCService, pvzService)
+
+	authInterceptor := middleware.NewAuthInterceptor(tokenService, repo, middleware.MethodRoles)
 
 	grpcSrv := grpc.NewServer(
-		grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
-		grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor()),
+		grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor(), middleware.RequestIDUnary(), authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(otelgrpc.StreamServerInterceptor(), middleware.RequestIDStream(), authInterceptor.Stream()),
 	)
 	pb.RegisterPVZServiceServer(grpcSrv, pvzGRPCController)
 
@@ -245,6 +382,8 @@ func (s *Server) runGateway(ctx context.Context) error {
 				DiscardUnknown: true,
 			},
 		}),
+		runtime.WithIncomingHeaderMatcher(middleware.GatewayHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(middleware.GatewayOutgoingHeaderMatcher),
 	)
 
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
@@ -270,6 +409,16 @@ func (s *Server) runGateway(ctx context.Context) error {
 	gatewayRouter.Use(gin.Logger(), gin.Recovery())
 
 	gatewayRouter.GET("/grpc/listPvz", gin.WrapH(corsHandler))
+	gatewayRouter.POST("/grpc/pvz", gin.WrapH(corsHandler))
+	gatewayRouter.GET("/grpc/pvz", gin.WrapH(corsHandler))
+	gatewayRouter.POST("/grpc/receptions", gin.WrapH(corsHandler))
+	gatewayRouter.POST("/grpc/products", gin.WrapH(corsHandler))
+	gatewayRouter.POST("/grpc/pvz/:pvzId/delete_last_product", gin.WrapH(corsHandler))
+	gatewayRouter.POST("/grpc/pvz/:pvzId/close_last_reception", gin.WrapH(corsHandler))
+	gatewayRouter.GET("/grpc/products/barcode/:barcode", gin.WrapH(corsHandler))
+	gatewayRouter.GET("/grpc/pvz/nearby", gin.WrapH(corsHandler))
+	gatewayRouter.GET("/grpc/pvz/export", gin.WrapH(corsHandler))
+	gatewayRouter.GET("/grpc/pvz/activity", gin.WrapH(corsHandler))
 
 	gwAddr := fmt.Sprintf(":%d", s.config.Gateway.Port)
 	gwServer := &http.Server{
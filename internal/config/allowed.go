@@ -4,4 +4,7 @@ type AllowedConfig struct {
 	Cities       map[string]bool `mapstructure:"cities"`
 	ProductTypes map[string]bool `mapstructure:"product_types"`
 	Roles        map[string]bool `mapstructure:"roles"`
+
+	// StoragePeriods — срок хранения товара в ПВЗ в днях по типу товара
+	StoragePeriods map[string]int `mapstructure:"storage_periods"`
 }
@@ -7,15 +7,21 @@ import (
 )
 
 type Config struct {
-	Env          string             `mapstructure:"env"`
-	Application  ApplicationConfig  `mapstructure:"application"`
-	PublicServer PublicServerConfig `mapstructure:"public_server"`
-	GRPCServer   GRPCServerConfig   `mapstructure:"grpc_server"`
-	Gateway      GatewayConfig      `mapstructure:"gateway"`
-	Storage      StorageConfig      `mapstructure:"storage"`
-	JWT          JWTConfig          `mapstructure:"jwt"`
-	Allowed      AllowedConfig      `mapstructure:"allowed"`
-	Metrics      MetricsConfig      `mapstructure:"metrics"`
+	Env          string                `mapstructure:"env"`
+	Application  ApplicationConfig     `mapstructure:"application"`
+	PublicServer PublicServerConfig    `mapstructure:"public_server"`
+	GRPCServer   GRPCServerConfig      `mapstructure:"grpc_server"`
+	Gateway      GatewayConfig         `mapstructure:"gateway"`
+	Storage      StorageConfig         `mapstructure:"storage"`
+	JWT          JWTConfig             `mapstructure:"jwt"`
+	DummyLogin   DummyLoginConfig      `mapstructure:"dummy_login"`
+	Login        LoginProtectionConfig `mapstructure:"login_protection"`
+	Allowed      AllowedConfig         `mapstructure:"allowed"`
+	Metrics      MetricsConfig         `mapstructure:"metrics"`
+	ExpiryWorker ExpiryWorkerConfig    `mapstructure:"expiry_worker"`
+	Outbox       OutboxConfig          `mapstructure:"outbox"`
+	Webhooks     WebhookConfig         `mapstructure:"webhooks"`
+	TokenCleanup TokenCleanupConfig    `mapstructure:"token_cleanup"`
 }
 
 func LoadConfig(configPath, envPath string) (*Config, error) {
@@ -38,6 +44,8 @@ func LoadConfig(configPath, envPath string) (*Config, error) {
 	viper.BindEnv("storage.postgres.hosts", "DB_HOST")
 	viper.BindEnv("storage.postgres.password", "DB_PASSWORD")
 	viper.BindEnv("jwt.secret_key", "JWT_SECRET_KEY")
+	viper.BindEnv("jwt.active_key", "JWT_ACTIVE_KEY")
+	viper.BindEnv("dummy_login.secret", "DUMMY_LOGIN_SECRET")
 
 	var config Config
 	err = viper.Unmarshal(&config)
@@ -45,5 +53,19 @@ func LoadConfig(configPath, envPath string) (*Config, error) {
 		return nil, fmt.Errorf("unable to decode into struct: %v", err)
 	}
 
+	if err := config.validateWorkers(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	return &config, nil
 }
+
+// validateWorkers проверяет параметры фоновых воркеров при старте, до их запуска.
+func (c *Config) validateWorkers() error {
+	for _, v := range []interface{ Validate() error }{&c.ExpiryWorker, &c.Outbox, &c.Webhooks, &c.TokenCleanup} {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
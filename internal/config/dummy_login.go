@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DummyLoginConfig — доступ к /dummyLogin. Маршрут регистрируется только в окружениях EnabledEnvs.
+// AllowedNetworks (CIDR или отдельные адреса) и Secret дополнительно ограничивают доступ,
+// пустые значения не ограничивают.
+type DummyLoginConfig struct {
+	EnabledEnvs     []string `mapstructure:"enabled_envs"`
+	AllowedNetworks []string `mapstructure:"allowed_networks"`
+	Secret          string   `mapstructure:"secret"`
+}
+
+func (d *DummyLoginConfig) EnabledFor(env string) bool {
+	for _, e := range d.EnabledEnvs {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
+
+// Networks разбирает AllowedNetworks; адрес без маски означает одну машину.
+func (d *DummyLoginConfig) Networks() ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(d.AllowedNetworks))
+	for _, n := range d.AllowedNetworks {
+		if !strings.Contains(n, "/") {
+			ip := net.ParseIP(n)
+			if ip == nil {
+				return nil, fmt.Errorf("dummy_login: invalid network %q", n)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(n)
+		if err != nil {
+			return nil, fmt.Errorf("dummy_login: invalid network %q: %w", n, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
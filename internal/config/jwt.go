@@ -1,6 +1,55 @@
 package config
 
+import (
+	"fmt"
+	"order-pick-up-point/pkg/jwt"
+)
+
+// JWTConfig — TokenExpiry — срок жизни access-токена, RefreshTokenExpiry — refresh-токена, в секундах.
+// Токены подписываются ключом ActiveKey из Keys; остальные ключи из Keys только проверяют подпись
+// и публикуются в JWKS. Без Keys токены подписываются общим секретом SecretKey (HS256).
 type JWTConfig struct {
-	SecretKey   string `mapstructure:"secret_key"`
-	TokenExpiry int    `mapstructure:"token_expiry"`
+	SecretKey          string         `mapstructure:"secret_key"`
+	Issuer             string         `mapstructure:"issuer"`
+	Audience           string         `mapstructure:"audience"`
+	ActiveKey          string         `mapstructure:"active_key"`
+	Keys               []JWTKeyConfig `mapstructure:"keys"`
+	TokenExpiry        int            `mapstructure:"token_expiry"`
+	RefreshTokenExpiry int            `mapstructure:"refresh_token_expiry"`
+}
+
+// JWTKeyConfig — PEM-файлы ключа. Для активного ключа нужен закрытый ключ, для выводимого
+// из ротации достаточно открытого.
+type JWTKeyConfig struct {
+	ID             string `mapstructure:"id"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
+}
+
+const hmacKeyID = "hs256"
+
+// KeySet загружает ключи подписи токенов.
+func (c *JWTConfig) KeySet() (*jwt.KeySet, error) {
+	if len(c.Keys) == 0 {
+		key, err := jwt.NewHMACKey(hmacKeyID, c.SecretKey)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: %w", err)
+		}
+		return jwt.NewKeySet(hmacKeyID, key)
+	}
+
+	keys := make([]*jwt.Key, 0, len(c.Keys))
+	for _, kc := range c.Keys {
+		key, err := jwt.LoadKeyFiles(kc.ID, kc.PrivateKeyFile, kc.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	set, err := jwt.NewKeySet(c.ActiveKey, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: %w", err)
+	}
+	return set, nil
 }
@@ -0,0 +1,15 @@
+package config
+
+// LoginProtectionConfig — защита /login от перебора. После k-й неудачи подряд следующая попытка
+// возможна не раньше чем через BaseDelay*2^(k-1), но не больше MaxDelay; после EmailMaxFailures
+// неудач по email или IPMaxFailures с одного IP вход блокируется на Lockout. Счётчик сбрасывается,
+// если неудач не было дольше FailureWindow. Все длительности в секундах.
+type LoginProtectionConfig struct {
+	Enable           bool `mapstructure:"enabled"`
+	EmailMaxFailures int  `mapstructure:"email_max_failures"`
+	IPMaxFailures    int  `mapstructure:"ip_max_failures"`
+	Lockout          int  `mapstructure:"lockout"`
+	BaseDelay        int  `mapstructure:"base_delay"`
+	MaxDelay         int  `mapstructure:"max_delay"`
+	FailureWindow    int  `mapstructure:"failure_window"`
+}
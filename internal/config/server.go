@@ -1,10 +1,13 @@
 package config
 
+// PublicServerConfig — TrustedProxies — адреса или CIDR прокси, которым доверяется X-Forwarded-For
+// при определении IP клиента; пустой список — IP берётся из соединения.
 type PublicServerConfig struct {
-	Enable          bool   `mapstructure:"enabled"`
-	Endpoint        string `mapstructure:"endpoint"`
-	Port            int    `mapstructure:"port"`
-	ShutdownTimeout int    `mapstructure:"shutdown_timeout"`
+	Enable          bool     `mapstructure:"enabled"`
+	Endpoint        string   `mapstructure:"endpoint"`
+	Port            int      `mapstructure:"port"`
+	ShutdownTimeout int      `mapstructure:"shutdown_timeout"`
+	TrustedProxies  []string `mapstructure:"trusted_proxies"`
 }
 
 type GatewayConfig struct {
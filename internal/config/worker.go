@@ -0,0 +1,117 @@
+package config
+
+import "fmt"
+
+type ExpiryWorkerConfig struct {
+	Enable    bool `mapstructure:"enabled"`
+	Interval  int  `mapstructure:"interval"`
+	BatchSize int  `mapstructure:"batch_size"`
+}
+
+// OutboxConfig — relay доменных событий. Interval и задержки повторов в секундах;
+// Publisher: file (NDJSON-файл FilePath) или memory. Опубликованные события хранятся
+// RetentionDays дней.
+type OutboxConfig struct {
+	Enable        bool   `mapstructure:"enabled"`
+	Interval      int    `mapstructure:"interval"`
+	BatchSize     int    `mapstructure:"batch_size"`
+	MaxAttempts   int    `mapstructure:"max_attempts"`
+	BaseBackoff   int    `mapstructure:"base_backoff"`
+	MaxBackoff    int    `mapstructure:"max_backoff"`
+	Publisher     string `mapstructure:"publisher"`
+	FilePath      string `mapstructure:"file_path"`
+	RetentionDays int    `mapstructure:"retention_days"`
+}
+
+// WebhookConfig — доставка вебхуков. Interval, задержки повторов и Timeout запроса в секундах.
+// AllowPrivateNetworks разрешает доставку на loopback, частные и link-local адреса.
+type WebhookConfig struct {
+	Enable               bool `mapstructure:"enabled"`
+	Interval             int  `mapstructure:"interval"`
+	BatchSize            int  `mapstructure:"batch_size"`
+	MaxAttempts          int  `mapstructure:"max_attempts"`
+	BaseBackoff          int  `mapstructure:"base_backoff"`
+	MaxBackoff           int  `mapstructure:"max_backoff"`
+	Timeout              int  `mapstructure:"timeout"`
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+}
+
+// TokenCleanupConfig — удаление истёкших refresh-токенов и записей об отозванных токенах. Interval в секундах.
+type TokenCleanupConfig struct {
+	Enable   bool `mapstructure:"enabled"`
+	Interval int  `mapstructure:"interval"`
+}
+
+// Validate проверяет параметры включённого воркера: нулевой интервал уронил бы time.NewTicker,
+// а нулевой размер пачки или число попыток остановили бы обработку.
+func (c *ExpiryWorkerConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if err := positive("expiry_worker", "interval", c.Interval); err != nil {
+		return err
+	}
+	return positive("expiry_worker", "batch_size", c.BatchSize)
+}
+
+// Validate проверяет параметры включённого relay.
+func (c *OutboxConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if err := positive("outbox", "interval", c.Interval); err != nil {
+		return err
+	}
+	if err := positive("outbox", "batch_size", c.BatchSize); err != nil {
+		return err
+	}
+	if err := positive("outbox", "max_attempts", c.MaxAttempts); err != nil {
+		return err
+	}
+	if err := positive("outbox", "retention_days", c.RetentionDays); err != nil {
+		return err
+	}
+	return validBackoff("outbox", c.BaseBackoff, c.MaxBackoff)
+}
+
+// Validate проверяет параметры включённой доставки вебхуков.
+func (c *WebhookConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if err := positive("webhooks", "interval", c.Interval); err != nil {
+		return err
+	}
+	if err := positive("webhooks", "batch_size", c.BatchSize); err != nil {
+		return err
+	}
+	if err := positive("webhooks", "max_attempts", c.MaxAttempts); err != nil {
+		return err
+	}
+	if err := positive("webhooks", "timeout", c.Timeout); err != nil {
+		return err
+	}
+	return validBackoff("webhooks", c.BaseBackoff, c.MaxBackoff)
+}
+
+// Validate проверяет параметры включённой очистки токенов.
+func (c *TokenCleanupConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	return positive("token_cleanup", "interval", c.Interval)
+}
+
+func positive(section, key string, value int) error {
+	if value <= 0 {
+		return fmt.Errorf("%s: %s must be positive, got %d", section, key, value)
+	}
+	return nil
+}
+
+func validBackoff(section string, base, maxBackoff int) error {
+	if base < 0 || maxBackoff < base {
+		return fmt.Errorf("%s: backoff must satisfy 0 <= base_backoff <= max_backoff, got %d and %d", section, base, maxBackoff)
+	}
+	return nil
+}
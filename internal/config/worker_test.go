@@ -0,0 +1,53 @@
+package config
+
+import "testing"
+
+func TestConfig_ValidateWorkers(t *testing.T) {
+	t.Parallel()
+
+	valid := func() Config {
+		return Config{
+			ExpiryWorker: ExpiryWorkerConfig{Enable: true, Interval: 60, BatchSize: 500},
+			Outbox:       OutboxConfig{Enable: true, Interval: 2, BatchSize: 100, MaxAttempts: 10, BaseBackoff: 1, MaxBackoff: 300, RetentionDays: 7},
+			Webhooks:     WebhookConfig{Enable: true, Interval: 5, BatchSize: 20, MaxAttempts: 8, BaseBackoff: 10, MaxBackoff: 3600, Timeout: 10},
+			TokenCleanup: TokenCleanupConfig{Enable: true, Interval: 3600},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{name: "disabled workers are not checked", modify: func(c *Config) { *c = Config{} }},
+		{name: "zero expiry interval", modify: func(c *Config) { c.ExpiryWorker.Interval = 0 }, wantErr: true},
+		{name: "zero expiry batch", modify: func(c *Config) { c.ExpiryWorker.BatchSize = 0 }, wantErr: true},
+		{name: "negative outbox interval", modify: func(c *Config) { c.Outbox.Interval = -1 }, wantErr: true},
+		{name: "zero outbox batch", modify: func(c *Config) { c.Outbox.BatchSize = 0 }, wantErr: true},
+		{name: "zero outbox attempts", modify: func(c *Config) { c.Outbox.MaxAttempts = 0 }, wantErr: true},
+		{name: "zero outbox retention", modify: func(c *Config) { c.Outbox.RetentionDays = 0 }, wantErr: true},
+		{name: "outbox max backoff below base", modify: func(c *Config) { c.Outbox.MaxBackoff = 0 }, wantErr: true},
+		{name: "zero webhook interval", modify: func(c *Config) { c.Webhooks.Interval = 0 }, wantErr: true},
+		{name: "zero webhook attempts", modify: func(c *Config) { c.Webhooks.MaxAttempts = 0 }, wantErr: true},
+		{name: "zero webhook timeout", modify: func(c *Config) { c.Webhooks.Timeout = 0 }, wantErr: true},
+		{name: "zero token cleanup interval", modify: func(c *Config) { c.TokenCleanup.Interval = 0 }, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := valid()
+			tc.modify(&cfg)
+
+			err := cfg.validateWorkers()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package grpc
+
+import (
+	"order-pick-up-point/internal/errs"
+)
+
+// statusError переводит ошибку сервиса в gRPC-статус по таблице errs,
+// для ошибок без кода клиент получает Internal и сообщение fallback.
+func statusError(err error, fallback string) error {
+	return errs.FromError(err, fallback).GRPCStatus().Err()
+}
+
+// invalidArgument возвращает InvalidArgument с кодом INVALID_REQUEST в деталях статуса.
+func invalidArgument(message string) error {
+	return errs.New(errs.ErrInvalidRequestCode, message).GRPCStatus().Err()
+}
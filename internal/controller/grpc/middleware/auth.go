@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/pkg/jwt"
+	"order-pick-up-point/pkg/requestid"
+	"strings"
+)
+
+// MethodRoles — роли, которым разрешён вызов каждого метода PVZService.
+// Аналог проверок CheckRole в HTTP-контроллерах. Метод без записи здесь недоступен никому.
+var MethodRoles = map[string][]string{
+	pb.PVZService_GetPVZList_FullMethodName:          {"moderator", "employee"},
+	pb.PVZService_CreatePvz_FullMethodName:           {"moderator"},
+	pb.PVZService_GetPvzsInfo_FullMethodName:         {"moderator", "employee"},
+	pb.PVZService_SearchNearbyPvz_FullMethodName:     {"client", "employee", "moderator"},
+	pb.PVZService_CreateReception_FullMethodName:     {"employee"},
+	pb.PVZService_AddProduct_FullMethodName:          {"employee"},
+	pb.PVZService_DeleteLastProduct_FullMethodName:   {"employee"},
+	pb.PVZService_GetProductByBarcode_FullMethodName: {"employee", "moderator"},
+	pb.PVZService_CloseReception_FullMethodName:      {"employee"},
+	pb.PVZService_ExportPvzHistory_FullMethodName:    {"moderator"},
+	pb.PVZService_StreamPvzActivity_FullMethodName:   {"employee", "moderator"},
+}
+
+// publicMethodPrefixes — методы, доступные без токена (reflection для grpcurl/evans).
+var publicMethodPrefixes = []string{
+	"/grpc.reflection.",
+}
+
+type AuthInterceptor struct {
+	tokenSvc    jwt.TokenService
+	revocations jwt.RevocationChecker
+	methodRoles map[string][]string
+}
+
+func NewAuthInterceptor(tokenSvc jwt.TokenService, revocations jwt.RevocationChecker, methodRoles map[string][]string) *AuthInterceptor {
+	return &AuthInterceptor{
+		tokenSvc:    tokenSvc,
+		revocations: revocations,
+		methodRoles: methodRoles,
+	}
+}
+
+func (a *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (a *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	// Новый RPC без записи в methodRoles закрыт, пока для него явно не указаны роли
+	allowedRoles, ok := a.methodRoles[method]
+	if !ok {
+		return nil, errs.New(errs.ErrForbiddenCode, "method is not allowed").GRPCStatus().Err()
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		return nil, errs.New(errs.ErrUnauthorizedCode, "missing token").GRPCStatus().Err()
+	}
+
+	token := strings.TrimPrefix(values[0], "Bearer ")
+
+	claims, err := a.tokenSvc.ParseJWTToken(token)
+	if err != nil {
+		return nil, errs.New(errs.ErrUnauthorizedCode, "invalid token").GRPCStatus().Err()
+	}
+
+	// Тот же список отозванных токенов, что и в JWTAuthMiddleware
+	revoked, err := a.revocations.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to check token").GRPCStatus().Err()
+	}
+	if revoked {
+		return nil, errs.New(errs.ErrUnauthorizedCode, "token revoked").GRPCStatus().Err()
+	}
+
+	if err := checkRole(claims.Role, allowedRoles); err != nil {
+		return nil, err
+	}
+
+	return jwt.ContextWithClaims(ctx, claims), nil
+}
+
+func checkRole(role string, allowedRoles []string) error {
+	if role == "" {
+		return errs.New(errs.ErrUnauthorizedCode, "missing role in token").GRPCStatus().Err()
+	}
+
+	role = strings.ToLower(role)
+	for _, allowed := range allowedRoles {
+		if role == strings.ToLower(allowed) {
+			return nil
+		}
+	}
+
+	return errs.New(errs.ErrForbiddenCode, fmt.Sprintf("access denied, allowed roles: %v", allowedRoles)).GRPCStatus().Err()
+}
+
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// GatewayHeaderMatcher отбирает HTTP-заголовки, которые gateway передаёт в metadata gRPC-вызова.
+// Заголовок Authorization runtime всегда пробрасывает как "authorization" без префикса,
+// поэтому здесь он исключается, чтобы токен не дублировался под ключом "grpcgateway-authorization".
+// X-Request-ID передаётся без префикса, чтобы RequestID-перехватчик видел идентификатор клиента.
+func GatewayHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "Authorization") {
+		return "", false
+	}
+	if strings.EqualFold(key, requestid.Header) {
+		return requestid.MetadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// GatewayOutgoingHeaderMatcher возвращает клиенту gateway идентификатор запроса под его обычным заголовком.
+func GatewayOutgoingHeaderMatcher(key string) (string, bool) {
+	if key == requestid.MetadataKey {
+		return requestid.Header, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
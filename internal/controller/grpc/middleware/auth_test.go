@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/pkg/jwt"
+	mockJwt "order-pick-up-point/pkg/jwt/mock"
+	"testing"
+)
+
+func claimsFor(userID, role string) *jwt.CustomClaims {
+	return &jwt.CustomClaims{
+		UserID:           userID,
+		Role:             role,
+		RegisteredClaims: jwtlib.RegisteredClaims{ID: "jti-" + userID},
+	}
+}
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		method        string
+		authHeader    string
+		parseToken    string
+		parseClaims   *jwt.CustomClaims
+		parseErr      error
+		revoked       bool
+		revokedErr    error
+		expectedCode  codes.Code
+		expectHandler bool
+	}{
+		{
+			name:          "reflection is public",
+			method:        "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+			expectedCode:  codes.OK,
+			expectHandler: true,
+		},
+		{
+			name:         "method without roles is denied",
+			method:       "/pvz.v1.PVZService/Unlisted",
+			authHeader:   "Bearer token",
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "missing token",
+			method:       pb.PVZService_GetPVZList_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "invalid token",
+			method:       pb.PVZService_GetPVZList_FullMethodName,
+			authHeader:   "Bearer broken",
+			parseToken:   "broken",
+			parseErr:     errors.New("token is malformed"),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "revoked token",
+			method:       pb.PVZService_GetPVZList_FullMethodName,
+			authHeader:   "Bearer token",
+			parseToken:   "token",
+			parseClaims:  claimsFor("u1", "employee"),
+			revoked:      true,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "revocation check fails",
+			method:       pb.PVZService_GetPVZList_FullMethodName,
+			authHeader:   "Bearer token",
+			parseToken:   "token",
+			parseClaims:  claimsFor("u1", "employee"),
+			revokedErr:   errors.New("db down"),
+			expectedCode: codes.Internal,
+		},
+		{
+			name:         "role not allowed",
+			method:       pb.PVZService_CreatePvz_FullMethodName,
+			authHeader:   "Bearer token",
+			parseToken:   "token",
+			parseClaims:  claimsFor("u1", "employee"),
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:          "role allowed",
+			method:        pb.PVZService_CreatePvz_FullMethodName,
+			authHeader:    "Bearer token",
+			parseToken:    "token",
+			parseClaims:   claimsFor("u1", "Moderator"),
+			expectedCode:  codes.OK,
+			expectHandler: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokenSvc := mockJwt.NewTokenService(t)
+			if tc.parseToken != "" {
+				tokenSvc.
+					On("ParseJWTToken", tc.parseToken).
+					Return(tc.parseClaims, tc.parseErr).
+					Once()
+			}
+			revocations := mockJwt.NewRevocationChecker(t)
+			if tc.parseClaims != nil {
+				revocations.
+					On("IsTokenRevoked", mock.Anything, tc.parseClaims.ID).
+					Return(tc.revoked, tc.revokedErr).
+					Once()
+			}
+
+			ctx := context.Background()
+			if tc.authHeader != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tc.authHeader))
+			}
+
+			handlerCalled := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCalled = true
+				if tc.parseClaims != nil {
+					claims, ok := jwt.ClaimsFromContext(ctx)
+					if !ok || claims.UserID != tc.parseClaims.UserID {
+						t.Errorf("expected claims with user %q in context, got %+v", tc.parseClaims.UserID, claims)
+					}
+				}
+				return "ok", nil
+			}
+
+			interceptor := NewAuthInterceptor(tokenSvc, revocations, MethodRoles)
+			_, err := interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+
+			if status.Code(err) != tc.expectedCode {
+				t.Errorf("expected code %v, got %v (%v)", tc.expectedCode, status.Code(err), err)
+			}
+			if handlerCalled != tc.expectHandler {
+				t.Errorf("expected handler called = %v, got %v", tc.expectHandler, handlerCalled)
+			}
+		})
+	}
+}
+
+func TestMethodRoles_CoverAllMethods(t *testing.T) {
+	t.Parallel()
+
+	desc := pb.PVZService_ServiceDesc
+	var methods []string
+	for _, m := range desc.Methods {
+		methods = append(methods, "/"+desc.ServiceName+"/"+m.MethodName)
+	}
+	for _, s := range desc.Streams {
+		methods = append(methods, "/"+desc.ServiceName+"/"+s.StreamName)
+	}
+
+	for _, method := range methods {
+		if roles := MethodRoles[method]; len(roles) == 0 {
+			t.Errorf("method %s has no roles in MethodRoles", method)
+		}
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestAuthInterceptor_Stream(t *testing.T) {
+	t.Parallel()
+
+	tokenSvc := mockJwt.NewTokenService(t)
+	tokenSvc.
+		On("ParseJWTToken", "token").
+		Return(claimsFor("u1", "employee"), nil).
+		Once()
+
+	revocations := mockJwt.NewRevocationChecker(t)
+	revocations.On("IsTokenRevoked", mock.Anything, "jti-u1").Return(false, nil).Once()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+
+	var gotClaims *jwt.CustomClaims
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		gotClaims, _ = jwt.ClaimsFromContext(stream.Context())
+		return nil
+	}
+
+	interceptor := NewAuthInterceptor(tokenSvc, revocations, map[string][]string{"/svc/Stream": {"employee"}})
+	err := interceptor.Stream()(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/svc/Stream"}, handler)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotClaims == nil || gotClaims.UserID != "u1" {
+		t.Errorf("expected claims for user u1 in stream context, got %+v", gotClaims)
+	}
+}
+
+type metadataRecorder struct {
+	pb.UnimplementedPVZServiceServer
+	md metadata.MD
+}
+
+func (m *metadataRecorder) GetPVZList(ctx context.Context, _ *pb.GetPVZListRequest) (*pb.GetPVZListResponse, error) {
+	m.md, _ = metadata.FromIncomingContext(ctx)
+	return &pb.GetPVZListResponse{}, nil
+}
+
+func TestGatewayHeaderMatcher_ForwardsAuthorization(t *testing.T) {
+	t.Parallel()
+
+	recorder := &metadataRecorder{}
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(GatewayHeaderMatcher))
+	if err := pb.RegisterPVZServiceHandlerServer(context.Background(), mux, recorder); err != nil {
+		t.Fatalf("register handler: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/grpc/listPvz", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-ID", "req-1")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	got := recorder.md.Get("authorization")
+	if len(got) != 1 || got[0] != "Bearer token" {
+		t.Errorf("expected single authorization value %q, got %v", "Bearer token", got)
+	}
+	if got := recorder.md.Get("x-request-id"); len(got) != 1 || got[0] != "req-1" {
+		t.Errorf("expected request id %q in metadata, got %v", "req-1", got)
+	}
+}
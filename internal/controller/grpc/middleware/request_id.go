@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"order-pick-up-point/pkg/requestid"
+)
+
+// RequestIDUnary берёт идентификатор запроса из metadata x-request-id или генерирует новый,
+// кладёт его в контекст и возвращает клиенту в заголовках ответа.
+func RequestIDUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withRequestID(ctx), req)
+	}
+}
+
+// RequestIDStream — аналог RequestIDUnary для потоковых методов.
+func RequestIDStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: withRequestID(ss.Context())})
+	}
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	var fromClient string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+			fromClient = values[0]
+		}
+	}
+	id := requestid.Resolve(fromClient)
+
+	// Вне настоящего gRPC-вызова (в тестах) заголовок установить некуда, это не ошибка запроса
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+	return requestid.ContextWithID(ctx, id)
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"order-pick-up-point/pkg/requestid"
+	"testing"
+)
+
+func TestRequestIDUnary(t *testing.T) {
+	t.Parallel()
+
+	call := func(ctx context.Context) string {
+		var got string
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			got = requestid.FromContext(ctx)
+			return nil, nil
+		}
+		if _, err := RequestIDUnary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Unary"}, handler); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return got
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-7"))
+	if got := call(ctx); got != "req-7" {
+		t.Errorf("expected request id from metadata, got %q", got)
+	}
+	if got := call(context.Background()); uuid.Validate(got) != nil {
+		t.Errorf("expected generated uuid, got %q", got)
+	}
+}
+
+func TestRequestIDStream(t *testing.T) {
+	t.Parallel()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-8"))
+
+	var got string
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		got = requestid.FromContext(stream.Context())
+		return nil
+	}
+	if err := RequestIDStream()(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/svc/Stream"}, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "req-8" {
+		t.Errorf("expected request id from metadata, got %q", got)
+	}
+}
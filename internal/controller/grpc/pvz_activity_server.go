@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+)
+
+// StreamPvzActivity отправляет события приёмок и товаров ПВЗ потоком, пока клиент не отключится.
+// Заголовки ответа уходят сразу после подписки, чтобы клиент знал, что новые события не будут пропущены.
+func (s *PvzServer) StreamPvzActivity(req *pb.StreamPvzActivityRequest, stream grpc.ServerStreamingServer[pb.PvzActivityEvent]) error {
+	filter := entity.PvzActivityFilter{PvzIDs: req.GetPvzIds()}
+	if req.LastEventId != nil {
+		id := req.GetLastEventId()
+		filter.LastEventID = &id
+	}
+
+	ready := func() error {
+		return stream.SendHeader(metadata.MD{})
+	}
+	err := s.pvzSvc.StreamPvzActivity(stream.Context(), filter, ready, func(e entity.OutboxEvent) error {
+		msg, err := mapper.PvzActivityEventToProto(e)
+		if err != nil {
+			return errs.Wrap(err, errs.ErrInternalCode, "failed to encode event payload")
+		}
+		return stream.Send(msg)
+	})
+	if err != nil {
+		return statusError(err, "PVZ activity stream failed")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockHttpSvc "order-pick-up-point/internal/service/http/mock"
+	"testing"
+)
+
+// activityStream — поток StreamPvzActivity, запоминающий заголовки и отправленные события.
+type activityStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	headerSent bool
+	sent       []*pb.PvzActivityEvent
+}
+
+func (s *activityStream) Context() context.Context { return s.ctx }
+
+func (s *activityStream) SendHeader(metadata.MD) error {
+	s.headerSent = true
+	return nil
+}
+
+func (s *activityStream) Send(e *pb.PvzActivityEvent) error {
+	s.sent = append(s.sent, e)
+	return nil
+}
+
+func TestPvzServer_StreamPvzActivity(t *testing.T) {
+	t.Parallel()
+
+	events := []entity.OutboxEvent{
+		{ID: 11, Type: entity.EventReceptionOpened, PvzID: "pvz1", AggregateID: "rec1", Payload: json.RawMessage(`{"receptionId":"rec1"}`)},
+		{ID: 12, Type: entity.EventProductAdded, PvzID: "pvz1", AggregateID: "prod1", Payload: json.RawMessage(`{"productId":"prod1"}`)},
+	}
+	// streamEvents подтверждает подписку, отдаёт события и возвращает svcErr
+	streamEvents := func(svcMock *mockHttpSvc.PvzService, filter interface{}, events []entity.OutboxEvent, svcErr error) {
+		svcMock.
+			On("StreamPvzActivity", mock.Anything, filter, mock.Anything, mock.Anything).
+			Return(func(_ context.Context, _ entity.PvzActivityFilter, ready func() error, fn func(entity.OutboxEvent) error) error {
+				if err := ready(); err != nil {
+					return err
+				}
+				for _, e := range events {
+					if err := fn(e); err != nil {
+						return err
+					}
+				}
+				return svcErr
+			}).
+			Once()
+	}
+
+	t.Run("streams events after header", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		streamEvents(svcMock, mock.MatchedBy(func(f entity.PvzActivityFilter) bool {
+			return len(f.PvzIDs) == 2 && f.LastEventID != nil && *f.LastEventID == 10
+		}), events, nil)
+
+		lastID := int64(10)
+		stream := &activityStream{ctx: context.Background()}
+		err := NewPvzServer(nil, svcMock).StreamPvzActivity(&pb.StreamPvzActivityRequest{
+			PvzIds:      []string{"pvz1", "pvz2"},
+			LastEventId: &lastID,
+		}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !stream.headerSent {
+			t.Error("expected header to be sent on subscription")
+		}
+		if len(stream.sent) != 2 || stream.sent[1].GetId() != 12 || stream.sent[1].GetPayload().GetFields()["productId"].GetStringValue() != "prod1" {
+			t.Fatalf("unexpected events: %v", stream.sent)
+		}
+	})
+
+	t.Run("no last event id", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		streamEvents(svcMock, entity.PvzActivityFilter{PvzIDs: []string{"pvz1"}}, nil, nil)
+
+		stream := &activityStream{ctx: context.Background()}
+		if err := NewPvzServer(nil, svcMock).StreamPvzActivity(&pb.StreamPvzActivityRequest{PvzIds: []string{"pvz1"}}, stream); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("interrupted stream is unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		streamEvents(svcMock, mock.Anything, events[:1], errs.New(errs.ErrActivityStreamInterrupted, "activity stream interrupted"))
+
+		stream := &activityStream{ctx: context.Background()}
+		err := NewPvzServer(nil, svcMock).StreamPvzActivity(&pb.StreamPvzActivityRequest{PvzIds: []string{"pvz1"}}, stream)
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("expected Unavailable, got %v", err)
+		}
+		if len(stream.sent) != 1 {
+			t.Errorf("expected 1 event before interruption, got %d", len(stream.sent))
+		}
+	})
+
+	t.Run("unknown pvz", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.On("StreamPvzActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errs.New(errs.ErrPvzNotFound, "pvz not found")).Once()
+
+		stream := &activityStream{ctx: context.Background()}
+		err := NewPvzServer(nil, svcMock).StreamPvzActivity(&pb.StreamPvzActivityRequest{PvzIds: []string{"pvz1"}}, stream)
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected NotFound, got %v", err)
+		}
+		if stream.headerSent {
+			t.Error("header must not be sent before subscription")
+		}
+	})
+}
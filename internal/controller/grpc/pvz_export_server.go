@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+)
+
+// ExportPvzHistory отправляет историю ПВЗ потоком, по сообщению на строку выгрузки.
+func (s *PvzServer) ExportPvzHistory(req *pb.ExportPvzHistoryRequest, stream grpc.ServerStreamingServer[pb.PvzExportRow]) error {
+	var filter entity.PvzExportFilter
+	if req.GetStartDate() != nil {
+		t := req.GetStartDate().AsTime()
+		filter.StartDate = &t
+	}
+	if req.GetEndDate() != nil {
+		t := req.GetEndDate().AsTime()
+		filter.EndDate = &t
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return invalidArgument("start_date must not be after end_date")
+	}
+
+	err := s.pvzSvc.ExportPvzHistory(stream.Context(), filter, func(row entity.PvzExportRow) error {
+		return stream.Send(mapper.PvzExportRowEntityToProto(row))
+	})
+	if err != nil {
+		return statusError(err, "failed to export PVZ history")
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/models/entity"
+	mockHttpSvc "order-pick-up-point/internal/service/http/mock"
+	"testing"
+	"time"
+)
+
+// exportStream — поток ExportPvzHistory, собирающий отправленные сообщения.
+type exportStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    []*pb.PvzExportRow
+	sendErr error
+}
+
+func (s *exportStream) Context() context.Context { return s.ctx }
+
+func (s *exportStream) Send(row *pb.PvzExportRow) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent = append(s.sent, row)
+	return nil
+}
+
+func TestPvzServer_ExportPvzHistory(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	rows := []entity.PvzExportRow{
+		{PvzID: "pvz1", PvzCity: "Moscow", ReceptionID: "rec1", ReceptionStatus: "close", ProductID: "prod1"},
+		{PvzID: "pvz2", PvzCity: "Kazan"},
+	}
+	streamRows := func(svcMock *mockHttpSvc.PvzService, filter interface{}) {
+		svcMock.
+			On("ExportPvzHistory", mock.Anything, filter, mock.Anything).
+			Return(func(_ context.Context, _ entity.PvzExportFilter, fn func(entity.PvzExportRow) error) error {
+				for _, row := range rows {
+					if err := fn(row); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).
+			Once()
+	}
+
+	t.Run("streams rows", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		streamRows(svcMock, mock.MatchedBy(func(f entity.PvzExportFilter) bool {
+			return f.StartDate != nil && f.StartDate.Equal(start) && f.EndDate != nil && f.EndDate.Equal(end)
+		}))
+
+		stream := &exportStream{ctx: context.Background()}
+		err := NewPvzServer(nil, svcMock).ExportPvzHistory(&pb.ExportPvzHistoryRequest{
+			StartDate: timestamppb.New(start),
+			EndDate:   timestamppb.New(end),
+		}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stream.sent) != 2 || stream.sent[0].ProductId != "prod1" || stream.sent[1].PvzCity != "Kazan" {
+			t.Fatalf("unexpected rows: %v", stream.sent)
+		}
+		if stream.sent[0].ReceptionStatus == nil || *stream.sent[0].ReceptionStatus != pb.ReceptionStatus_RECEPTION_STATUS_CLOSED {
+			t.Errorf("expected closed reception status, got %v", stream.sent[0].ReceptionStatus)
+		}
+		if stream.sent[1].ReceptionStatus != nil {
+			t.Errorf("expected no reception status for pvz without receptions, got %v", stream.sent[1].ReceptionStatus)
+		}
+	})
+
+	t.Run("send error stops export", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		streamRows(svcMock, entity.PvzExportFilter{})
+
+		stream := &exportStream{ctx: context.Background(), sendErr: errors.New("client gone")}
+		err := NewPvzServer(nil, svcMock).ExportPvzHistory(&pb.ExportPvzHistoryRequest{}, stream)
+		if status.Code(err) != codes.Internal {
+			t.Errorf("expected Internal, got %v", err)
+		}
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		t.Parallel()
+
+		stream := &exportStream{ctx: context.Background()}
+		err := NewPvzServer(nil, mockHttpSvc.NewPvzService(t)).ExportPvzHistory(&pb.ExportPvzHistoryRequest{
+			StartDate: timestamppb.New(end),
+			EndDate:   timestamppb.New(start),
+		}, stream)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
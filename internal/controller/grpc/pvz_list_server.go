@@ -2,38 +2,68 @@ package grpc
 
 import (
 	"context"
-	"google.golang.org/protobuf/types/known/timestamppb"
 	"order-pick-up-point/api/pb"
-	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
 	grpcService "order-pick-up-point/internal/service/grpc"
+	httpServ "order-pick-up-point/internal/service/http"
 )
 
 type PvzServer struct {
 	pb.UnimplementedPVZServiceServer
-	svc grpcService.PvzService
+	svc    grpcService.PvzService
+	pvzSvc httpServ.PvzService
 }
 
-func NewPvzServer(svc grpcService.PvzService) *PvzServer {
-	return &PvzServer{svc: svc}
+func NewPvzServer(svc grpcService.PvzService, pvzSvc httpServ.PvzService) *PvzServer {
+	return &PvzServer{
+		svc:    svc,
+		pvzSvc: pvzSvc,
+	}
 }
 
 func (s *PvzServer) GetPVZList(ctx context.Context, req *pb.GetPVZListRequest) (*pb.GetPVZListResponse, error) {
-	pvzs, err := s.svc.GetPVZList(ctx)
+	if req.GetPageSize() < 0 {
+		return nil, invalidArgument("invalid page_size parameter")
+	}
+	// без page_size и page_token отдаём весь список, как раньше
+	if req.GetPageSize() == 0 && req.GetPageToken() == "" {
+		pvzs, err := s.svc.GetPVZList(ctx)
+		if err != nil {
+			return nil, statusError(err, "failed to get PVZ list")
+		}
+
+		var pbPvzs []*pb.PVZ
+		for _, pvz := range pvzs {
+			pbPvzs = append(pbPvzs, mapper.PvzEntityToProto(pvz))
+		}
+
+		return &pb.GetPVZListResponse{
+			Pvzs: pbPvzs,
+		}, nil
+	}
+	if req.GetPageSize() == 0 {
+		return nil, invalidArgument("page_size is required with page_token")
+	}
+
+	after, err := mapper.DecodePvzCursor(req.GetPageToken())
+	if err != nil {
+		return nil, statusError(err, "invalid page_token")
+	}
+
+	page, err := s.svc.GetPVZPage(ctx, entity.PvzPageRequest{Limit: int(req.GetPageSize()), After: after})
 	if err != nil {
-		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get PVZ list")
+		return nil, statusError(err, "failed to get PVZ list")
 	}
 
-	var pbPvzs []*pb.PVZ
-	for _, pvz := range pvzs {
-		ts := timestamppb.New(pvz.RegistrationDate)
-		pbPvzs = append(pbPvzs, &pb.PVZ{
-			Id:               pvz.ID,
-			RegistrationDate: ts,
-			City:             pvz.City,
-		})
+	pbPvzs := make([]*pb.PVZ, 0, len(page.Items))
+	for _, pvz := range page.Items {
+		pbPvzs = append(pbPvzs, mapper.PvzEntityToProto(pvz))
 	}
 
 	return &pb.GetPVZListResponse{
-		Pvzs: pbPvzs,
+		Pvzs:          pbPvzs,
+		NextPageToken: mapper.EncodePvzCursor(page.NextCursor),
+		HasMore:       page.HasMore,
 	}, nil
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"order-pick-up-point/api/pb"
 	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
 	mockPvzSvc "order-pick-up-point/internal/service/grpc/mock"
 	"reflect"
 	"strings"
@@ -58,7 +61,7 @@ func TestPvzServer_GetPVZList(t *testing.T) {
 				Return(tc.expectedList, tc.errToReturn).
 				Once()
 
-			server := NewPvzServer(mockSvc)
+			server := NewPvzServer(mockSvc, nil)
 
 			req := &pb.GetPVZListRequest{}
 
@@ -97,3 +100,72 @@ func TestPvzServer_GetPVZList(t *testing.T) {
 		})
 	}
 }
+
+func TestPvzServer_GetPVZList_Paged(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	regDate := time.Date(2025, 4, 9, 12, 0, 0, 0, time.UTC)
+	cursor := &entity.PvzCursor{RegistrationDate: regDate, ID: "1d7e3a52-6b0c-4f8e-9a21-3c5d7e9f0b12"}
+	page := &entity.PvzPage{
+		Items:      []entity.Pvz{{ID: "2", City: "Kazan", RegistrationDate: regDate}},
+		NextCursor: cursor,
+		HasMore:    true,
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		t.Parallel()
+
+		mockSvc := mockPvzSvc.NewPvzService(t)
+		mockSvc.
+			On("GetPVZPage", mock.Anything, entity.PvzPageRequest{Limit: 1}).
+			Return(page, nil).
+			Once()
+
+		server := NewPvzServer(mockSvc, nil)
+		resp, err := server.GetPVZList(ctx, &pb.GetPVZListRequest{PageSize: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Pvzs) != 1 || resp.Pvzs[0].Id != "2" {
+			t.Errorf("unexpected pvzs: %v", resp.Pvzs)
+		}
+		if !resp.HasMore || resp.NextPageToken != mapper.EncodePvzCursor(cursor) {
+			t.Errorf("expected next page token, got %q (has_more=%v)", resp.NextPageToken, resp.HasMore)
+		}
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		t.Parallel()
+
+		mockSvc := mockPvzSvc.NewPvzService(t)
+		mockSvc.
+			On("GetPVZPage", mock.Anything, entity.PvzPageRequest{Limit: 1, After: cursor}).
+			Return(&entity.PvzPage{}, nil).
+			Once()
+
+		server := NewPvzServer(mockSvc, nil)
+		resp, err := server.GetPVZList(ctx, &pb.GetPVZListRequest{PageSize: 1, PageToken: mapper.EncodePvzCursor(cursor)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.HasMore || resp.NextPageToken != "" {
+			t.Errorf("expected last page, got %q (has_more=%v)", resp.NextPageToken, resp.HasMore)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		t.Parallel()
+
+		server := NewPvzServer(mockPvzSvc.NewPvzService(t), nil)
+		for _, req := range []*pb.GetPVZListRequest{
+			{PageSize: -1},
+			{PageToken: mapper.EncodePvzCursor(cursor)},
+			{PageSize: 1, PageToken: "garbage"},
+		} {
+			if _, err := server.GetPVZList(ctx, req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument for %v, got %v", req, err)
+			}
+		}
+	})
+}
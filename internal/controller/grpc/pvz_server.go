@@ -0,0 +1,175 @@
+package grpc
+
+import (
+	"context"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	"order-pick-up-point/pkg/jwt"
+	"time"
+)
+
+func (s *PvzServer) CreatePvz(ctx context.Context, req *pb.CreatePvzRequest) (*pb.CreatePvzResponse, error) {
+	if req.GetCity() == "" {
+		return nil, invalidArgument("city is required")
+	}
+
+	pvzID, err := s.pvzSvc.CreatePvz(ctx, mapper.CreatePvzProtoToEntity(req))
+	if err != nil {
+		return nil, statusError(err, "failed to create PVZ")
+	}
+
+	return &pb.CreatePvzResponse{Id: pvzID}, nil
+}
+
+func (s *PvzServer) SearchNearbyPvz(ctx context.Context, req *pb.SearchNearbyPvzRequest) (*pb.SearchNearbyPvzResponse, error) {
+	filter := entity.NearbyPvzFilter{
+		Latitude:     req.GetLatitude(),
+		Longitude:    req.GetLongitude(),
+		RadiusMeters: req.GetRadiusMeters(),
+		City:         req.GetCity(),
+		Limit:        int(req.GetLimit()),
+	}
+	if req.Status != nil {
+		filter.Status = mapper.PvzStatusFromProto(req.GetStatus())
+	}
+
+	pvzs, err := s.pvzSvc.SearchNearbyPvzs(ctx, filter)
+	if err != nil {
+		return nil, statusError(err, "failed to search PVZs")
+	}
+
+	items := make([]*pb.NearbyPvz, 0, len(pvzs))
+	for _, pvz := range pvzs {
+		items = append(items, mapper.NearbyPvzEntityToProto(pvz))
+	}
+	return &pb.SearchNearbyPvzResponse{Items: items}, nil
+}
+
+func (s *PvzServer) GetPvzsInfo(ctx context.Context, req *pb.GetPvzsInfoRequest) (*pb.GetPvzsInfoResponse, error) {
+	if req.GetLimit() <= 0 {
+		return nil, invalidArgument("invalid limit parameter")
+	}
+
+	page := entity.PvzPageRequest{Limit: int(req.GetLimit())}
+	if req.GetPageToken() != "" {
+		after, err := mapper.DecodePvzCursor(req.GetPageToken())
+		if err != nil {
+			return nil, statusError(err, "invalid page_token")
+		}
+		page.After = after
+	} else {
+		if req.GetPage() <= 0 {
+			return nil, invalidArgument("invalid page parameter")
+		}
+		page.Offset = int(req.GetPage()-1) * page.Limit
+	}
+
+	var startDate, endDate *time.Time
+	if req.GetStartDate() != nil {
+		t := req.GetStartDate().AsTime()
+		startDate = &t
+	}
+	if req.GetEndDate() != nil {
+		t := req.GetEndDate().AsTime()
+		endDate = &t
+	}
+
+	pvzPage, err := s.pvzSvc.GetPvzsInfo(ctx, page, startDate, endDate)
+	if err != nil {
+		return nil, statusError(err, "failed to get PVZ info")
+	}
+
+	items := make([]*pb.PvzInfo, 0, len(pvzPage.Items))
+	for _, info := range pvzPage.Items {
+		items = append(items, mapper.PvzInfoEntityToProto(info))
+	}
+
+	return &pb.GetPvzsInfoResponse{
+		Items:         items,
+		NextPageToken: mapper.EncodePvzCursor(pvzPage.NextCursor),
+		HasMore:       pvzPage.HasMore,
+	}, nil
+}
+
+func (s *PvzServer) CreateReception(ctx context.Context, req *pb.CreateReceptionRequest) (*pb.CreateReceptionResponse, error) {
+	if req.GetPvzId() == "" {
+		return nil, invalidArgument("pvz_id is required")
+	}
+
+	receptionTime := time.Now()
+	if req.GetDateTime() != nil {
+		receptionTime = req.GetDateTime().AsTime()
+	}
+
+	receptionID, err := s.pvzSvc.CreateReception(ctx, req.GetPvzId(), receptionTime, userIDFromContext(ctx))
+	if err != nil {
+		return nil, statusError(err, "failed to create reception")
+	}
+
+	return &pb.CreateReceptionResponse{ReceptionId: receptionID}, nil
+}
+
+func (s *PvzServer) AddProduct(ctx context.Context, req *pb.AddProductRequest) (*pb.AddProductResponse, error) {
+	if req.GetPvzId() == "" || req.GetType() == "" {
+		return nil, invalidArgument("pvz_id and type are required")
+	}
+
+	productID, err := s.pvzSvc.AddProduct(ctx, req.GetPvzId(), req.GetType(), req.GetBarcode())
+	if err != nil {
+		return nil, statusError(err, "failed to add product")
+	}
+
+	return &pb.AddProductResponse{ProductId: productID}, nil
+}
+
+func (s *PvzServer) DeleteLastProduct(ctx context.Context, req *pb.DeleteLastProductRequest) (*pb.DeleteLastProductResponse, error) {
+	if req.GetPvzId() == "" {
+		return nil, invalidArgument("pvz_id is required")
+	}
+
+	product, err := s.pvzSvc.DeleteLastProduct(ctx, req.GetPvzId())
+	if err != nil {
+		return nil, statusError(err, "failed to delete last product")
+	}
+
+	return &pb.DeleteLastProductResponse{
+		Message:   "product deleted successfully",
+		ProductId: product.ID,
+		Barcode:   product.Barcode,
+	}, nil
+}
+
+func (s *PvzServer) GetProductByBarcode(ctx context.Context, req *pb.GetProductByBarcodeRequest) (*pb.GetProductByBarcodeResponse, error) {
+	if req.GetBarcode() == "" {
+		return nil, invalidArgument("barcode is required")
+	}
+
+	order, err := s.pvzSvc.FindProductByBarcode(ctx, req.GetBarcode())
+	if err != nil {
+		return nil, statusError(err, "failed to find product by barcode")
+	}
+
+	return mapper.OrderEntityToBarcodeProto(*order), nil
+}
+
+func (s *PvzServer) CloseReception(ctx context.Context, req *pb.CloseReceptionRequest) (*pb.CloseReceptionResponse, error) {
+	if req.GetPvzId() == "" {
+		return nil, invalidArgument("pvz_id is required")
+	}
+
+	receptionID, err := s.pvzSvc.CloseReception(ctx, req.GetPvzId(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, statusError(err, "failed to close reception")
+	}
+
+	return &pb.CloseReceptionResponse{ReceptionId: receptionID}, nil
+}
+
+// userIDFromContext возвращает ID пользователя из claims, которые положил AuthInterceptor.
+func userIDFromContext(ctx context.Context) string {
+	if claims, ok := jwt.ClaimsFromContext(ctx); ok {
+		return claims.UserID
+	}
+	return ""
+}
@@ -0,0 +1,377 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	mockHttpSvc "order-pick-up-point/internal/service/http/mock"
+	"order-pick-up-point/pkg/jwt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPvzServer_CreatePvz(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		city           string
+		callSvc        bool
+		svcPvzID       string
+		svcErr         error
+		expectedCode   codes.Code
+		expectedErrSub string
+		expectedID     string
+	}{
+		{
+			name:         "empty city",
+			city:         "",
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "city not allowed",
+			city:         "Tver",
+			callSvc:      true,
+			svcErr:       errs.New(errs.ErrInvalidCity, "city 'Tver' is not allowed"),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:           "service error",
+			city:           "Moscow",
+			callSvc:        true,
+			svcErr:         errors.New("db error"),
+			expectedErrSub: "failed to create PVZ",
+		},
+		{
+			name:       "success",
+			city:       "Moscow",
+			callSvc:    true,
+			svcPvzID:   "pvz1",
+			expectedID: "pvz1",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			svcMock := mockHttpSvc.NewPvzService(t)
+			if tc.callSvc {
+				svcMock.
+					On("CreatePvz", mock.Anything, entity.Pvz{City: tc.city}).
+					Return(tc.svcPvzID, tc.svcErr).
+					Once()
+			}
+
+			server := NewPvzServer(nil, svcMock)
+			resp, err := server.CreatePvz(ctx, &pb.CreatePvzRequest{City: tc.city})
+
+			switch {
+			case tc.expectedCode != codes.OK:
+				if status.Code(err) != tc.expectedCode {
+					t.Errorf("expected code %v, got %v", tc.expectedCode, status.Code(err))
+				}
+			case tc.expectedErrSub != "":
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrSub) {
+					t.Errorf("expected error containing %q, got %v", tc.expectedErrSub, err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if resp.Id != tc.expectedID {
+					t.Errorf("expected id %q, got %q", tc.expectedID, resp.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestPvzServer_SearchNearbyPvz(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("maps filter and results", func(t *testing.T) {
+		t.Parallel()
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.
+			On("SearchNearbyPvzs", mock.Anything, entity.NearbyPvzFilter{
+				Latitude: 55.75, Longitude: 37.61, RadiusMeters: 1000, Status: entity.PvzStatusSuspended, Limit: 5,
+			}).
+			Return([]entity.NearbyPvz{{Pvz: entity.Pvz{ID: "pvz1", Status: entity.PvzStatusSuspended}, DistanceMeters: 300}}, nil).
+			Once()
+
+		suspended := pb.PvzStatus_PVZ_STATUS_SUSPENDED
+		resp, err := NewPvzServer(nil, svcMock).SearchNearbyPvz(ctx, &pb.SearchNearbyPvzRequest{
+			Latitude: 55.75, Longitude: 37.61, RadiusMeters: 1000, Status: &suspended, Limit: 5,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.GetItems()) != 1 || resp.GetItems()[0].GetDistanceMeters() != 300 ||
+			resp.GetItems()[0].GetPvz().GetStatus() != pb.PvzStatus_PVZ_STATUS_SUSPENDED {
+			t.Errorf("unexpected response: %v", resp)
+		}
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		t.Parallel()
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.
+			On("SearchNearbyPvzs", mock.Anything, mock.Anything).
+			Return(nil, errs.New(errs.ErrInvalidRequestCode, "latitude must be between -90 and 90")).
+			Once()
+
+		_, err := NewPvzServer(nil, svcMock).SearchNearbyPvz(ctx, &pb.SearchNearbyPvzRequest{Latitude: 100})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected code %v, got %v", codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func TestPvzServer_GetPvzsInfo(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+
+	infos := []entity.PvzInfo{
+		{
+			Pvz: entity.Pvz{ID: "pvz1", City: "Moscow", RegistrationDate: start},
+			Receptions: []entity.ReceptionInfo{
+				{
+					Reception: entity.Reception{ID: "rec1", PvzID: "pvz1", Status: "close", DateTime: start},
+					Products:  []entity.Product{{ID: "prod1", Type: "shoes", ReceptionID: "rec1", DateTime: start}},
+				},
+			},
+		},
+	}
+
+	t.Run("invalid page", func(t *testing.T) {
+		t.Parallel()
+
+		server := NewPvzServer(nil, mockHttpSvc.NewPvzService(t))
+		_, err := server.GetPvzsInfo(ctx, &pb.GetPvzsInfoRequest{Page: 0, Limit: 10})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("success with date filter", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.
+			On("GetPvzsInfo", mock.Anything, entity.PvzPageRequest{Limit: 10},
+				mock.MatchedBy(func(d *time.Time) bool { return d != nil && d.Equal(start) }),
+				mock.MatchedBy(func(d *time.Time) bool { return d != nil && d.Equal(end) }),
+			).
+			Return(&entity.PvzInfoPage{Items: infos}, nil).
+			Once()
+
+		server := NewPvzServer(nil, svcMock)
+		resp, err := server.GetPvzsInfo(ctx, &pb.GetPvzsInfoRequest{
+			Page:      1,
+			Limit:     10,
+			StartDate: timestamppb.New(start),
+			EndDate:   timestamppb.New(end),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Items) != 1 {
+			t.Fatalf("expected 1 item, got %d", len(resp.Items))
+		}
+		rec := resp.Items[0].Receptions[0]
+		if rec.Reception.Status != pb.ReceptionStatus_RECEPTION_STATUS_CLOSED {
+			t.Errorf("expected closed reception status, got %v", rec.Reception.Status)
+		}
+		if len(rec.Products) != 1 || rec.Products[0].Id != "prod1" {
+			t.Errorf("unexpected products: %v", rec.Products)
+		}
+	})
+
+	t.Run("page token", func(t *testing.T) {
+		t.Parallel()
+
+		cursor := &entity.PvzCursor{RegistrationDate: start, ID: "1d7e3a52-6b0c-4f8e-9a21-3c5d7e9f0b11"}
+		next := &entity.PvzCursor{RegistrationDate: start.Add(-time.Hour), ID: "1d7e3a52-6b0c-4f8e-9a21-3c5d7e9f0b10"}
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.
+			On("GetPvzsInfo", mock.Anything, entity.PvzPageRequest{Limit: 1, After: cursor}, (*time.Time)(nil), (*time.Time)(nil)).
+			Return(&entity.PvzInfoPage{Items: infos, NextCursor: next, HasMore: true}, nil).
+			Once()
+
+		server := NewPvzServer(nil, svcMock)
+		resp, err := server.GetPvzsInfo(ctx, &pb.GetPvzsInfoRequest{
+			Limit:     1,
+			PageToken: mapper.EncodePvzCursor(cursor),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.HasMore || resp.NextPageToken != mapper.EncodePvzCursor(next) {
+			t.Errorf("expected next page token, got %q (has_more=%v)", resp.NextPageToken, resp.HasMore)
+		}
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		t.Parallel()
+
+		server := NewPvzServer(nil, mockHttpSvc.NewPvzService(t))
+		_, err := server.GetPvzsInfo(ctx, &pb.GetPvzsInfoRequest{Limit: 10, PageToken: "garbage"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestPvzServer_ReceptionWorkflow(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	// claims кладёт в контекст AuthInterceptor; ID сотрудника передаётся в сервис
+	employeeCtx := jwt.ContextWithClaims(ctx, &jwt.CustomClaims{UserID: "emp1", Role: "employee"})
+
+	t.Run("create reception uses current time when date_time is empty", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.
+			On("CreateReception", mock.Anything, "pvz1", mock.MatchedBy(func(tm time.Time) bool {
+				return time.Since(tm) < 5*time.Second
+			}), "emp1").
+			Return("rec1", nil).
+			Once()
+
+		server := NewPvzServer(nil, svcMock)
+		resp, err := server.CreateReception(employeeCtx, &pb.CreateReceptionRequest{PvzId: "pvz1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ReceptionId != "rec1" {
+			t.Errorf("expected reception id %q, got %q", "rec1", resp.ReceptionId)
+		}
+	})
+
+	t.Run("add product requires type", func(t *testing.T) {
+		t.Parallel()
+
+		server := NewPvzServer(nil, mockHttpSvc.NewPvzService(t))
+		_, err := server.AddProduct(ctx, &pb.AddProductRequest{PvzId: "pvz1"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("add product", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.On("AddProduct", mock.Anything, "pvz1", "shoes", "BC-1").Return("prod1", nil).Once()
+
+		server := NewPvzServer(nil, svcMock)
+		resp, err := server.AddProduct(ctx, &pb.AddProductRequest{PvzId: "pvz1", Type: "shoes", Barcode: "BC-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ProductId != "prod1" {
+			t.Errorf("expected product id %q, got %q", "prod1", resp.ProductId)
+		}
+	})
+
+	t.Run("delete last product error", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.On("DeleteLastProduct", mock.Anything, "pvz1").Return(nil, errors.New("db error")).Once()
+
+		server := NewPvzServer(nil, svcMock)
+		_, err := server.DeleteLastProduct(ctx, &pb.DeleteLastProductRequest{PvzId: "pvz1"})
+		if err == nil || !strings.Contains(err.Error(), "failed to delete last product") {
+			t.Errorf("expected wrapped error, got %v", err)
+		}
+	})
+
+	t.Run("delete last product reports barcode", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.
+			On("DeleteLastProduct", mock.Anything, "pvz1").
+			Return(&entity.Product{ID: "prod1", Barcode: "BC-1"}, nil).
+			Once()
+
+		server := NewPvzServer(nil, svcMock)
+		resp, err := server.DeleteLastProduct(ctx, &pb.DeleteLastProductRequest{PvzId: "pvz1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ProductId != "prod1" || resp.Barcode != "BC-1" {
+			t.Errorf("unexpected response %+v", resp)
+		}
+	})
+
+	t.Run("get product by barcode not found", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.
+			On("FindProductByBarcode", mock.Anything, "BC-404").
+			Return(nil, errs.New(errs.ErrProductNotFound, "product with this barcode not found")).
+			Once()
+
+		server := NewPvzServer(nil, svcMock)
+		_, err := server.GetProductByBarcode(ctx, &pb.GetProductByBarcodeRequest{Barcode: "BC-404"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected NotFound, got %v", err)
+		}
+	})
+
+	t.Run("add product without open reception", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.
+			On("AddProduct", mock.Anything, "pvz1", "shoes", "").
+			Return("", errs.New(errs.ErrNoOpenReception, "no open reception found for this PVZ")).
+			Once()
+
+		server := NewPvzServer(nil, svcMock)
+		_, err := server.AddProduct(ctx, &pb.AddProductRequest{PvzId: "pvz1", Type: "shoes"})
+		st := status.Convert(err)
+		if st.Code() != codes.FailedPrecondition {
+			t.Errorf("expected code %v, got %v", codes.FailedPrecondition, st.Code())
+		}
+		if st.Message() != "no open reception found for this PVZ" {
+			t.Errorf("unexpected message %q", st.Message())
+		}
+	})
+
+	t.Run("close reception", func(t *testing.T) {
+		t.Parallel()
+
+		svcMock := mockHttpSvc.NewPvzService(t)
+		svcMock.On("CloseReception", mock.Anything, "pvz1", "emp1").Return("rec1", nil).Once()
+
+		server := NewPvzServer(nil, svcMock)
+		resp, err := server.CloseReception(employeeCtx, &pb.CloseReceptionRequest{PvzId: "pvz1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ReceptionId != "rec1" {
+			t.Errorf("expected reception id %q, got %q", "rec1", resp.ReceptionId)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/mapper"
+	http2 "order-pick-up-point/internal/service/http"
+	"strconv"
+)
+
+type AuditController interface {
+	GetAuditLog(c *gin.Context)
+}
+
+type auditController struct {
+	auditSvc http2.AuditService
+}
+
+func NewAuditController(auditSvc http2.AuditService) AuditController {
+	return &auditController{
+		auditSvc: auditSvc,
+	}
+}
+
+// GetAuditLog godoc
+// @Summary Audit log of state-changing operations
+// @Security BearerAuth
+// @Description Returns audit log entries from newest to oldest. Every PVZ, reception, product, order, return and registration change is recorded in the same transaction as the change itself, with the actor, before/after state and request/trace IDs. Pass the X-Next-Cursor header value as cursor to get the next page. Available only for moderators.
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param actorId query string false "Filter by actor user ID"
+// @Param action query string false "Filter by action" example("reception.close")
+// @Param entityType query string false "Filter by entity type: pvz, reception, product, return, return_item, user" example("reception")
+// @Param entityId query string false "Filter by entity ID"
+// @Param pvzId query string false "Filter by PVZ ID"
+// @Param startDate query string false "Start of the period in RFC3339 format" example("2025-05-01T00:00:00Z")
+// @Param endDate query string false "End of the period in RFC3339 format" example("2025-05-31T23:59:59Z")
+// @Param limit query int false "Page size, 50 by default, at most 500" example(50)
+// @Param cursor query string false "Cursor from X-Next-Cursor of the previous page"
+// @Success 200 {array} dto.AuditEntryDTO "Audit log entries"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {boolean} X-Has-More "Whether there are older entries after this page"
+// @Failure 400 {object} dto.Error "Invalid filters or cursor"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /audit [get]
+func (a *auditController) GetAuditLog(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	var query dto.AuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid query parameters"})
+		return
+	}
+	filter := mapper.AuditQueryToFilter(query)
+
+	beforeID, err := mapper.DecodeIDCursor(query.Cursor)
+	if err != nil {
+		respondError(c, err, "invalid cursor")
+		return
+	}
+	filter.BeforeID = beforeID
+
+	page, err := a.auditSvc.GetAuditLog(c, filter)
+	if err != nil {
+		respondError(c, err, "failed to get audit log")
+		return
+	}
+
+	if page.NextCursor != nil {
+		c.Header(headerNextCursor, mapper.EncodeIDCursor(page.NextCursor))
+	}
+	c.Header(headerHasMore, strconv.FormatBool(page.HasMore))
+
+	response := make([]dto.AuditEntryDTO, 0, len(page.Items))
+	for _, e := range page.Items {
+		response = append(response, mapper.AuditEntryEntityToDTO(e))
+	}
+	c.JSON(http.StatusOK, response)
+}
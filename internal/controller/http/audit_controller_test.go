@@ -0,0 +1,132 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	mockAuditServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPvzController_GetAuditLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	lastID := int64(41)
+	before := int64(100)
+	actor := "user1"
+
+	tests := []struct {
+		name               string
+		path               string
+		role               string
+		expectedFilter     *entity.AuditFilter
+		svcResult          *entity.AuditPage
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+		expectedCursor     string
+	}{
+		{
+			name:           "filters and next page",
+			path:           "/audit?action=reception.close&pvzId=pvz1&startDate=2025-05-01T00:00:00Z&limit=1&cursor=" + mapper.EncodeIDCursor(&before),
+			role:           "moderator",
+			expectedFilter: &entity.AuditFilter{Action: "reception.close", PvzID: "pvz1", StartDate: &start, Limit: 1, BeforeID: &before},
+			svcResult: &entity.AuditPage{
+				Items:      []entity.AuditEntry{{ID: lastID, ActorID: &actor, ActorRole: "employee", Action: "reception.close", EntityType: "reception", EntityID: "rec1"}},
+				NextCursor: &lastID,
+				HasMore:    true,
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"id":41,`,
+			expectedCursor:     mapper.EncodeIDCursor(&lastID),
+		},
+		{
+			name:               "empty log",
+			path:               "/audit",
+			role:               "moderator",
+			expectedFilter:     &entity.AuditFilter{},
+			svcResult:          &entity.AuditPage{},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `[]`,
+		},
+		{
+			name:               "invalid filter",
+			path:               "/audit?actorId=dummyID",
+			role:               "moderator",
+			expectedFilter:     &entity.AuditFilter{ActorID: "dummyID"},
+			svcErr:             errs.New(errs.ErrInvalidRequestCode, "invalid id 'dummyID'"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid id",
+		},
+		{
+			name:               "service error",
+			path:               "/audit",
+			role:               "moderator",
+			expectedFilter:     &entity.AuditFilter{},
+			svcErr:             errors.New("db error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedRespSubstr: "failed to get audit log",
+		},
+		{
+			name:               "invalid cursor",
+			path:               "/audit?cursor=broken!",
+			role:               "moderator",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: `"code":"INVALID_CURSOR"`,
+		},
+		{
+			name:               "invalid limit",
+			path:               "/audit?limit=many",
+			role:               "moderator",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid query parameters",
+		},
+		{
+			name:               "employee forbidden",
+			path:               "/audit",
+			role:               "employee",
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("GET", tc.path, nil)
+			c.Set("role", tc.role)
+
+			mockSvc := mockAuditServ.NewAuditService(t)
+			if tc.expectedFilter != nil {
+				mockSvc.
+					On("GetAuditLog", mock.Anything, *tc.expectedFilter).
+					Return(tc.svcResult, tc.svcErr).
+					Once()
+			}
+
+			NewAuditController(mockSvc).GetAuditLog(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+			if got := rr.Header().Get("X-Next-Cursor"); got != tc.expectedCursor {
+				t.Errorf("expected cursor %q, got %q", tc.expectedCursor, got)
+			}
+		})
+	}
+}
@@ -3,15 +3,29 @@ package http
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/mapper"
 	http2 "order-pick-up-point/internal/service/http"
 	"order-pick-up-point/pkg/validator"
+	"time"
 )
 
 type AuthController interface {
 	DummyLogin(c *gin.Context)
 	Register(c *gin.Context)
 	Login(c *gin.Context)
+	RefreshToken(c *gin.Context)
+	Logout(c *gin.Context)
+	JWKS(c *gin.Context)
+	UnlockUser(c *gin.Context)
+	ListUsers(c *gin.Context)
+	GetUser(c *gin.Context)
+	ChangeUserRole(c *gin.Context)
+	DeactivateUser(c *gin.Context)
+	ReactivateUser(c *gin.Context)
+	ResetUserPassword(c *gin.Context)
+	ChangePassword(c *gin.Context)
 }
 
 type authController struct {
@@ -26,19 +40,22 @@ func NewAuthController(authSvc http2.AuthService) AuthController {
 
 // DummyLogin godoc
 // @Summary Dummy login for testing
-// @Description Get a JWT token by passing a desired user role (client, employee, moderator) through dummy login.
+// @Description Get a JWT token by passing a desired user role (client, employee, moderator) through dummy login. The endpoint exists only in environments listed in `dummy_login.enabled_envs` and may be restricted to allow-listed networks or a shared secret. Issued tokens carry the `dummy` claim.
 // @Tags auth
 // @Accept json
 // @Produce json
+// @Param X-Dummy-Login-Secret header string false "Shared secret, required when dummy_login.secret is set"
 // @Param request body dto.DummyLoginPostRequest true "Dummy login request with role"
 // @Success 200 {object} dto.TokenResponse "JWT token"
-// @Failure 400 {object} dto.Error "Invalid request body"
-// @Failure 401 {object} dto.Error "Unauthorized: invalid role or error during dummy login"
+// @Failure 400 {object} dto.Error "Invalid request body or role is not allowed"
+// @Failure 403 {object} dto.Error "Client network is not allowed or the secret is wrong"
+// @Failure 500 {object} dto.Error "Internal server error"
 // @Router /dummyLogin [post]
 func (a *authController) DummyLogin(c *gin.Context) {
 	var req dto.DummyLoginPostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, dto.Error{
+			Code:    errs.ErrInvalidRequestCode,
 			Message: "invalid request body",
 		})
 		return
@@ -46,9 +63,7 @@ func (a *authController) DummyLogin(c *gin.Context) {
 
 	token, err := a.authSvc.DummyLogin(c, req.Role)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.Error{
-			Message: err.Error(),
-		})
+		respondError(c, err, "dummy login failed")
 		return
 	}
 
@@ -65,37 +80,33 @@ func (a *authController) DummyLogin(c *gin.Context) {
 // @Produce json
 // @Param request body dto.RegisterPostRequest true "User registration data"
 // @Success 201 {object} dto.RegisterResponse "User registration success response with user ID"
-// @Failure 400 {object} dto.Error "Invalid request body"
+// @Failure 400 {object} dto.Error "Invalid request body, email, password or role"
+// @Failure 409 {object} dto.Error "User with this email already exists"
 // @Failure 500 {object} dto.Error "Internal server error"
 // @Router /register [post]
 func (a *authController) Register(c *gin.Context) {
 	var req dto.RegisterPostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, dto.Error{
+			Code:    errs.ErrInvalidRequestCode,
 			Message: "invalid request body",
 		})
 		return
 	}
 
 	if err := validator.ValidateEmail(req.Email); err != nil {
-		c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		respondError(c, err, "invalid email")
 		return
 	}
 
 	if err := validator.ValidatePassword(req.Password); err != nil {
-		c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		respondError(c, err, "invalid password")
 		return
 	}
 
 	userID, err := a.authSvc.Register(c, req.Email, req.Password, req.Role)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Error{
-			Message: err.Error(),
-		})
+		respondError(c, err, "failed to register user")
 		return
 	}
 
@@ -106,33 +117,122 @@ func (a *authController) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary Login a user
-// @Description Login a user using email and password. Returns a JWT token if credentials are valid.
+// @Description Login a user using email and password. Returns a short-lived JWT access token and a refresh token for /token/refresh if credentials are valid. After failed attempts the next attempt for the same email or from the same IP is delayed progressively, and after too many failures login is locked for a while; such attempts get 429 with the Retry-After header whether or not the email exists. Deactivated users get 403 after entering the correct password. After a password reset by a moderator the response carries passwordChangeRequired.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginPostRequest true "User login data"
-// @Success 200 {object} dto.TokenResponse "JWT token"
+// @Success 200 {object} dto.TokenResponse "Access and refresh tokens"
 // @Failure 400 {object} dto.Error "Invalid request body"
 // @Failure 401 {object} dto.Error "Unauthorized: invalid credentials"
+// @Failure 403 {object} dto.Error "User is deactivated"
+// @Failure 429 {object} dto.Error "Too many failed attempts, retry after the Retry-After header value"
+// @Header 429 {integer} Retry-After "Seconds until the next attempt is allowed"
+// @Failure 500 {object} dto.Error "Internal server error"
 // @Router /login [post]
 func (a *authController) Login(c *gin.Context) {
 	var req dto.LoginPostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, dto.Error{
+			Code:    errs.ErrInvalidRequestCode,
 			Message: "invalid request body",
 		})
 		return
 	}
 
-	token, err := a.authSvc.Login(c, req.Email, req.Password)
+	pair, err := a.authSvc.Login(c, req.Email, req.Password, c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.Error{
-			Message: err.Error(),
+		setRetryAfter(c, err)
+		respondError(c, err, "login failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.TokenPairToDTO(*pair, time.Now()))
+}
+
+// RefreshToken godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and a new refresh token. Each refresh token can be used only once; presenting an already used token revokes the whole session, including its access tokens.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.TokenResponse "New access and refresh tokens"
+// @Failure 400 {object} dto.Error "Invalid request body"
+// @Failure 401 {object} dto.Error "Refresh token is invalid, expired, revoked or reused"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /token/refresh [post]
+func (a *authController) RefreshToken(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{
+			Code:    errs.ErrInvalidRequestCode,
+			Message: "invalid request body",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.TokenResponse{
-		Token: token,
-	})
+	pair, err := a.authSvc.RefreshTokens(c, req.RefreshToken)
+	if err != nil {
+		respondError(c, err, "failed to refresh token")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.TokenPairToDTO(*pair, time.Now()))
+}
+
+// Logout godoc
+// @Summary Logout
+// @Security BearerAuth
+// @Description Revoke the access token of the request and the session it belongs to: its refresh tokens can no longer be exchanged.
+// @Tags auth
+// @Success 204 "Logged out"
+// @Failure 401 {object} dto.Error "Unauthorized: missing, invalid or revoked token"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /logout [post]
+func (a *authController) Logout(c *gin.Context) {
+	if err := a.authSvc.Logout(c); err != nil {
+		respondError(c, err, "logout failed")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// JWKS godoc
+// @Summary Public signing keys
+// @Description Public keys (JWK Set, RFC 7517) used to verify access tokens. Tokens carry the key id in the `kid` header. Keys being rotated out stay in the set until the tokens they signed expire. The set is empty when tokens are signed with a shared HS256 secret.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwt.JWKS "JWK Set"
+// @Router /.well-known/jwks.json [get]
+func (a *authController) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, a.authSvc.JWKS())
+}
+
+// UnlockUser godoc
+// @Summary Unlock user login
+// @Security BearerAuth
+// @Description Resets failed login attempts for the user's email and lifts its temporary lockout. Lockouts of client IPs expire on their own. Available only for moderators.
+// @Tags auth
+// @Param userId path string true "User ID"
+// @Success 204 "Login unlocked"
+// @Failure 400 {object} dto.Error "Invalid user ID"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "User not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /users/{userId}/unlock [post]
+func (a *authController) UnlockUser(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	if err := a.authSvc.UnlockUser(c, c.Param("userId")); err != nil {
+		respondError(c, err, "failed to unlock user")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
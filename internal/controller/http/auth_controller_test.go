@@ -9,9 +9,14 @@ import (
 	"github.com/stretchr/testify/mock"
 	"net/http"
 	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	http2 "order-pick-up-point/internal/service/http"
 	mockAuthServ "order-pick-up-point/internal/service/http/mock"
+	"order-pick-up-point/pkg/jwt"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAuthController_DummyLogin(t *testing.T) {
@@ -31,16 +36,24 @@ func TestAuthController_DummyLogin(t *testing.T) {
 			svcReturnToken:   "",
 			svcReturnErr:     nil,
 			expectedStatus:   http.StatusBadRequest,
-			expectedResponse: `{"message":"invalid request body"}`,
+			expectedResponse: `{"code":"INVALID_REQUEST","message":"invalid request body"}`,
+		},
+		{
+			name:             "service returns invalid role",
+			requestBody:      `{"role": "client"}`,
+			svcReturnToken:   "",
+			svcReturnErr:     errs.New(errs.ErrInvalidRoleCode, "role 'client' is not allowed"),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"code":"INVALID_ROLE","message":"role 'client' is not allowed"}`,
 		},
 		{
 			name:           "service returns error",
 			requestBody:    `{"role": "client"}`,
 			svcReturnToken: "",
-			svcReturnErr:   errors.New("dummy login failed"),
-			expectedStatus: http.StatusUnauthorized,
-			// Контроллер возвращает текст ошибки через err.Error()
-			expectedResponse: `{"message":"dummy login failed"}`,
+			svcReturnErr:   errors.New("token signing failed"),
+			expectedStatus: http.StatusInternalServerError,
+			// Текст ошибки без кода не уходит клиенту
+			expectedResponse: `{"code":"INTERNAL_ERROR","message":"dummy login failed"}`,
 		},
 		{
 			name:             "success",
@@ -120,10 +133,18 @@ func TestAuthController_Register(t *testing.T) {
 			expectedErrMsg:     "password must be at least 4 characters long",
 			expectedStatusCode: http.StatusBadRequest,
 		},
+		{
+			name:               "user already exists",
+			requestBody:        `{"email": "test@example.com", "password": "secret", "role": "client"}`,
+			expectedErrMsg:     `{"code":"USER_ALREADY_EXISTS","message":"user already exists"}`,
+			expectedStatusCode: http.StatusConflict,
+			simulateSvcError:   true,
+			svcErr:             errs.New(errs.ErrUserAlreadyExists, "user already exists"),
+		},
 		{
 			name:               "service registration error",
 			requestBody:        `{"email": "test@example.com", "password": "secret", "role": "client"}`,
-			expectedErrMsg:     "create error",
+			expectedErrMsg:     "failed to register user",
 			expectedStatusCode: http.StatusInternalServerError,
 			simulateSvcError:   true,
 			svcErr:             errors.New("create error"),
@@ -199,6 +220,8 @@ func TestAuthController_Login(t *testing.T) {
 		simulateSvcError bool
 		svcErr           error
 		expectedToken    string
+		// Ожидаемое значение заголовка Retry-After
+		expectedRetryAfter string
 	}{
 		{
 			name:               "invalid request body",
@@ -210,9 +233,27 @@ func TestAuthController_Login(t *testing.T) {
 			name:               "service login error",
 			requestBody:        `{"email": "test@example.com", "password": "secret"}`,
 			expectedStatusCode: http.StatusUnauthorized,
-			expectedErrMsg:     "invalid credentials",
+			expectedErrMsg:     `{"code":"INVALID_CREDENTIALS","message":"invalid credentials"}`,
+			simulateSvcError:   true,
+			svcErr:             errs.New(errs.ErrInvalidCredentials, "invalid credentials"),
+		},
+		{
+			name:               "service internal error",
+			requestBody:        `{"email": "test@example.com", "password": "secret"}`,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrMsg:     "login failed",
 			simulateSvcError:   true,
-			svcErr:             errors.New("invalid credentials"),
+			svcErr:             errors.New("db is down"),
+		},
+		{
+			name:               "too many attempts",
+			requestBody:        `{"email": "test@example.com", "password": "secret"}`,
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedErrMsg:     `"code":"TOO_MANY_LOGIN_ATTEMPTS"`,
+			simulateSvcError:   true,
+			svcErr: errs.Wrap(&http2.LoginBlockedError{RetryAfter: 1500 * time.Millisecond},
+				errs.ErrTooManyLoginAttempts, "too many failed login attempts, try again later"),
+			expectedRetryAfter: "2",
 		},
 		{
 			name:               "success",
@@ -240,9 +281,16 @@ func TestAuthController_Login(t *testing.T) {
 			// Если тело запроса корректное, ожидаем вызов метода Login
 			if strings.HasPrefix(tc.requestBody, "{") {
 				mockAuthSvc.
-					On("Login", mock.Anything, "test@example.com", "secret").
-					Return(func(ctx context.Context, email, password string) string {
-						return tc.expectedToken
+					On("Login", mock.Anything, "test@example.com", "secret", mock.Anything).
+					Return(func(ctx context.Context, email, password, clientIP string) *entity.TokenPair {
+						if tc.svcErr != nil {
+							return nil
+						}
+						return &entity.TokenPair{
+							AccessToken:     tc.expectedToken,
+							AccessExpiresAt: time.Now().Add(15 * time.Minute),
+							RefreshToken:    "refresh_abc",
+						}
 					}, tc.svcErr).
 					Once()
 			}
@@ -253,6 +301,9 @@ func TestAuthController_Login(t *testing.T) {
 			if rr.Code != tc.expectedStatusCode {
 				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
 			}
+			if retryAfter := rr.Header().Get("Retry-After"); retryAfter != tc.expectedRetryAfter {
+				t.Errorf("expected Retry-After %q, got %q", tc.expectedRetryAfter, retryAfter)
+			}
 
 			respBody := strings.TrimSpace(rr.Body.String())
 			if tc.expectedErrMsg != "" {
@@ -260,8 +311,8 @@ func TestAuthController_Login(t *testing.T) {
 					t.Errorf("expected response containing %q, got %q", tc.expectedErrMsg, respBody)
 				}
 			} else {
-				// Ожидаем JSON, содержащий поле "token"
-				expectedStr := fmt.Sprintf(`"token":"%s"`, tc.expectedToken)
+				// Ожидаем JSON, содержащий поля "token" и "refreshToken"
+				expectedStr := fmt.Sprintf(`"token":"%s","refreshToken":"refresh_abc"`, tc.expectedToken)
 				if !strings.Contains(respBody, expectedStr) {
 					t.Errorf("expected response containing %q, got %q", expectedStr, respBody)
 				}
@@ -271,3 +322,179 @@ func TestAuthController_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthController_RefreshToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name               string
+		requestBody        string
+		svcPair            *entity.TokenPair
+		svcErr             error
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "missing refresh token",
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "invalid request body",
+		},
+		{
+			name:               "reused token",
+			requestBody:        `{"refreshToken": "old"}`,
+			svcErr:             errs.New(errs.ErrRefreshTokenReused, "refresh token has already been used, session revoked"),
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       `"code":"REFRESH_TOKEN_REUSED"`,
+		},
+		{
+			name:               "success",
+			requestBody:        `{"refreshToken": "old"}`,
+			svcPair:            &entity.TokenPair{AccessToken: "access", AccessExpiresAt: time.Now().Add(15 * time.Minute), RefreshToken: "new"},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `"token":"access","refreshToken":"new"`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("POST", "/token/refresh", bytes.NewBufferString(tc.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			mockAuthSvc := mockAuthServ.NewAuthService(t)
+			if tc.svcPair != nil || tc.svcErr != nil {
+				mockAuthSvc.On("RefreshTokens", mock.Anything, "old").Return(tc.svcPair, tc.svcErr).Once()
+			}
+
+			NewAuthController(mockAuthSvc).RefreshToken(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if body := rr.Body.String(); !strings.Contains(body, tc.expectedBody) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestAuthController_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("POST", "/logout", nil)
+
+		mockAuthSvc := mockAuthServ.NewAuthService(t)
+		mockAuthSvc.On("Logout", mock.Anything).Return(nil).Once()
+
+		NewAuthController(mockAuthSvc).Logout(c)
+
+		if c.Writer.Status() != http.StatusNoContent {
+			t.Errorf("expected status code %d, got %d", http.StatusNoContent, c.Writer.Status())
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		t.Parallel()
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("POST", "/logout", nil)
+
+		mockAuthSvc := mockAuthServ.NewAuthService(t)
+		mockAuthSvc.On("Logout", mock.Anything).Return(errors.New("db down")).Once()
+
+		NewAuthController(mockAuthSvc).Logout(c)
+
+		if rr.Code != http.StatusInternalServerError {
+			t.Errorf("expected status code %d, got %d", http.StatusInternalServerError, rr.Code)
+		}
+	})
+}
+
+func TestAuthController_JWKS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+
+	mockAuthSvc := mockAuthServ.NewAuthService(t)
+	mockAuthSvc.On("JWKS").Return(jwt.JWKS{Keys: []jwt.JWK{{Kty: "EC", Kid: "k1", Use: "sig", Alg: "ES256", Crv: "P-256", X: "x", Y: "y"}}}).Once()
+
+	NewAuthController(mockAuthSvc).JWKS(c)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	if cc := rr.Header().Get("Cache-Control"); cc != "public, max-age=300" {
+		t.Errorf("unexpected Cache-Control %q", cc)
+	}
+	expected := `{"keys":[{"kty":"EC","kid":"k1","use":"sig","alg":"ES256","crv":"P-256","x":"x","y":"y"}]}`
+	if body := rr.Body.String(); body != expected {
+		t.Errorf("expected body %s, got %s", expected, body)
+	}
+}
+
+func TestAuthController_UnlockUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const userID = "6f1c2a4e-8a57-4d4b-9a4f-2b0e5d1c9e01"
+
+	tests := []struct {
+		name               string
+		role               string
+		svcErr             error
+		callSvc            bool
+		expectedStatusCode int
+	}{
+		{
+			name:               "not a moderator",
+			role:               "employee",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "user not found",
+			role:               "moderator",
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrUserNotFound, "user not found"),
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "success",
+			role:               "moderator",
+			callSvc:            true,
+			expectedStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("POST", "/users/"+userID+"/unlock", nil)
+			c.Params = gin.Params{{Key: "userId", Value: userID}}
+			c.Set("role", tc.role)
+
+			mockAuthSvc := mockAuthServ.NewAuthService(t)
+			if tc.callSvc {
+				mockAuthSvc.On("UnlockUser", mock.Anything, userID).Return(tc.svcErr).Once()
+			}
+
+			NewAuthController(mockAuthSvc).UnlockUser(c)
+
+			if c.Writer.Status() != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, c.Writer.Status())
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"math"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	http2 "order-pick-up-point/internal/service/http"
+	"strconv"
+)
+
+// respondError формирует ответ по ошибке сервиса: статус и код берутся из таблицы errs,
+// для ошибок без кода клиент получает 500 и сообщение fallback.
+func respondError(c *gin.Context, err error, fallback string) {
+	appErr := errs.FromError(err, fallback)
+	c.JSON(errs.HTTPStatus(appErr.Code), dto.Error{
+		Code:    appErr.Code,
+		Message: appErr.Message,
+	})
+}
+
+// setRetryAfter выставляет заголовок Retry-After, если попытка отклонена защитой входа от перебора.
+func setRetryAfter(c *gin.Context, err error) {
+	var blocked *http2.LoginBlockedError
+	if errors.As(err, &blocked) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+	}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"testing"
+)
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   dto.Error
+	}{
+		{
+			name:           "open reception exists",
+			err:            errs.New(errs.ErrOpenReceptionExists, "open reception already exists"),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   dto.Error{Code: errs.ErrOpenReceptionExists, Message: "open reception already exists"},
+		},
+		{
+			name:           "no open reception",
+			err:            errs.New(errs.ErrNoOpenReception, "no open reception found for this PVZ"),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   dto.Error{Code: errs.ErrNoOpenReception, Message: "no open reception found for this PVZ"},
+		},
+		{
+			name:           "reception not found",
+			err:            errs.New(errs.ErrReceptionNotFound, "no reception found to update"),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   dto.Error{Code: errs.ErrReceptionNotFound, Message: "no reception found to update"},
+		},
+		{
+			name:           "internal AppError hides cause",
+			err:            errs.Wrap(errors.New("pq: deadlock detected"), errs.ErrInternalCode, "failed to create product"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   dto.Error{Code: errs.ErrInternalCode, Message: "failed to create product"},
+		},
+		{
+			name:           "plain error uses fallback",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   dto.Error{Code: errs.ErrInternalCode, Message: "fallback message"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			respondError(c, tc.err, "fallback message")
+
+			if rr.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rr.Code)
+			}
+			var body dto.Error
+			if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if body != tc.expectedBody {
+				t.Errorf("expected body %+v, got %+v", tc.expectedBody, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"net"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+)
+
+const DummyLoginSecretHeader = "X-Dummy-Login-Secret"
+
+// DummyLoginGuard пропускает запрос к /dummyLogin только из сетей networks и с секретом secret
+// в заголовке X-Dummy-Login-Secret; пустые networks и secret не ограничивают доступ.
+// Адрес клиента берётся из соединения, а не из X-Forwarded-For, который клиент может подделать.
+func DummyLoginGuard(networks []*net.IPNet, secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(networks) > 0 && !containsIP(networks, net.ParseIP(c.RemoteIP())) {
+			forbidDummyLogin(c)
+			return
+		}
+
+		if secret != "" && subtle.ConstantTimeCompare([]byte(c.GetHeader(DummyLoginSecretHeader)), []byte(secret)) != 1 {
+			forbidDummyLogin(c)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func forbidDummyLogin(c *gin.Context) {
+	c.JSON(http.StatusForbidden, dto.Error{
+		Code:    errs.ErrForbiddenCode,
+		Message: "dummy login is not allowed",
+	})
+	c.Abort()
+}
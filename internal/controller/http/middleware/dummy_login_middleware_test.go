@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDummyLoginGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	_, private, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		name       string
+		networks   []*net.IPNet
+		secret     string
+		remoteAddr string
+		header     string
+		forwarded  string
+		expected   int
+	}{
+		{name: "no restrictions", remoteAddr: "203.0.113.7:5000", expected: http.StatusOK},
+		{name: "allowed network", networks: []*net.IPNet{private}, remoteAddr: "10.1.2.3:5000", expected: http.StatusOK},
+		{name: "foreign network", networks: []*net.IPNet{private}, remoteAddr: "203.0.113.7:5000", expected: http.StatusForbidden},
+		{name: "forged forwarded header", networks: []*net.IPNet{private}, remoteAddr: "203.0.113.7:5000", forwarded: "10.1.2.3", expected: http.StatusForbidden},
+		{name: "valid secret", secret: "s3cret", remoteAddr: "203.0.113.7:5000", header: "s3cret", expected: http.StatusOK},
+		{name: "wrong secret", secret: "s3cret", remoteAddr: "203.0.113.7:5000", header: "guess", expected: http.StatusForbidden},
+		{name: "missing secret", secret: "s3cret", remoteAddr: "203.0.113.7:5000", expected: http.StatusForbidden},
+		{name: "network and secret", networks: []*net.IPNet{private}, secret: "s3cret", remoteAddr: "10.1.2.3:5000", expected: http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/dummyLogin", DummyLoginGuard(tc.networks, tc.secret), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/dummyLogin", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.header != "" {
+				req.Header.Set(DummyLoginSecretHeader, tc.header)
+			}
+			if tc.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwarded)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tc.expected {
+				t.Errorf("expected status %d, got %d", tc.expected, rr.Code)
+			}
+		})
+	}
+}
@@ -3,16 +3,18 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/models/dto"
 	"order-pick-up-point/pkg/jwt"
 	"strings"
 )
 
-func JWTAuthMiddleware(tokenSvc jwt.TokenService) gin.HandlerFunc {
+func JWTAuthMiddleware(tokenSvc jwt.TokenService, revocations jwt.RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := c.GetHeader("Authorization")
 		if token == "" {
 			c.JSON(http.StatusUnauthorized, dto.Error{
+				Code:    errs.ErrUnauthorizedCode,
 				Message: "missing token",
 			})
 			c.Abort()
@@ -24,14 +26,35 @@ func JWTAuthMiddleware(tokenSvc jwt.TokenService) gin.HandlerFunc {
 		claims, err := tokenSvc.ParseJWTToken(token)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, dto.Error{
+				Code:    errs.ErrUnauthorizedCode,
 				Message: "invalid token",
 			})
 			c.Abort()
 			return
 		}
 
+		revoked, err := revocations.IsTokenRevoked(c.Request.Context(), claims.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.Error{
+				Code:    errs.ErrInternalCode,
+				Message: "failed to check token",
+			})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, dto.Error{
+				Code:    errs.ErrUnauthorizedCode,
+				Message: "token revoked",
+			})
+			c.Abort()
+			return
+		}
+
 		c.Set("userID", claims.UserID)
 		c.Set("role", claims.Role)
+		// claims в контексте запроса нужны сервисам, которые получают *gin.Context как context.Context
+		c.Request = c.Request.WithContext(jwt.ContextWithClaims(c.Request.Context(), claims))
 
 		c.Next()
 	}
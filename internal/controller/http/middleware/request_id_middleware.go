@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"order-pick-up-point/pkg/requestid"
+)
+
+// RequestIDMiddleware берёт идентификатор запроса из X-Request-ID или генерирует новый,
+// возвращает его в ответе и кладёт в контекст запроса для журналов и аудита.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Resolve(c.GetHeader(requestid.Header))
+
+		c.Header(requestid.Header, id)
+		c.Request = c.Request.WithContext(requestid.ContextWithID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
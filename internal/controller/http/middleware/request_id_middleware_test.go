@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/pkg/requestid"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "client id is kept", header: "req-42", expected: "req-42"},
+		{name: "missing id is generated"},
+		{name: "invalid id is replaced", header: "bad id"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(RequestIDMiddleware())
+
+			var fromCtx string
+			router.GET("/", func(c *gin.Context) {
+				fromCtx = requestid.FromContext(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set("X-Request-ID", tc.header)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			got := rr.Header().Get("X-Request-ID")
+			if got != fromCtx {
+				t.Errorf("response id %q differs from context id %q", got, fromCtx)
+			}
+			if tc.expected != "" && got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+			if tc.expected == "" && uuid.Validate(got) != nil {
+				t.Errorf("expected generated uuid, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/mapper"
+)
+
+// PrepareOrder godoc
+// @Summary Prepare a product for pickup
+// @Security BearerAuth
+// @Description Assign a received product from a closed reception to a recipient (client). The pickup code is not returned to the employee: the recipient requests it via POST /my/orders/{productId}/pickup_code. Only employees can prepare orders.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID" example("pvz123")
+// @Param request body dto.PrepareOrderRequest true "Product and recipient"
+// @Success 200 {object} dto.PrepareOrderResponse "Order ready for pickup"
+// @Failure 400 {object} dto.Error "Invalid request body or identifiers"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Product not found in this PVZ"
+// @Failure 409 {object} dto.Error "Product status does not allow this transition"
+// @Failure 422 {object} dto.Error "Recipient not found or is not a client"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/{pvzId}/orders [post]
+func (p *pvzController) PrepareOrder(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	var req dto.PrepareOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
+		return
+	}
+
+	order, err := p.pvzSvc.PrepareOrder(c, c.Param("pvzId"), req.ProductId, req.RecipientId)
+	if err != nil {
+		respondError(c, err, "failed to prepare order")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PrepareOrderResponse{
+		Order: mapper.OrderEntityToDTO(*order),
+	})
+}
+
+// GetOrdersForPickup godoc
+// @Summary List orders waiting for a recipient
+// @Security BearerAuth
+// @Description Look up products that are ready for pickup by the given recipient at the PVZ. Only employees can look up orders.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID" example("pvz123")
+// @Param recipientId query string true "Recipient user ID" example("user123")
+// @Success 200 {array} dto.OrderDTO "Orders ready for pickup"
+// @Failure 400 {object} dto.Error "Invalid identifiers"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/{pvzId}/orders [get]
+func (p *pvzController) GetOrdersForPickup(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	recipientID := c.Query("recipientId")
+	if recipientID == "" {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "recipientId parameter is required"})
+		return
+	}
+
+	orders, err := p.pvzSvc.GetOrdersForPickup(c, c.Param("pvzId"), recipientID)
+	if err != nil {
+		respondError(c, err, "failed to get orders")
+		return
+	}
+
+	response := make([]dto.OrderDTO, 0, len(orders))
+	for _, order := range orders {
+		response = append(response, mapper.OrderEntityToDTO(order))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// IssueOrder godoc
+// @Summary Issue an order to its recipient
+// @Security BearerAuth
+// @Description Verify the pickup code and mark the product as issued by the current employee. After 5 invalid codes the order can be issued only with a new code requested by the recipient. Only employees can issue orders.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID" example("pvz123")
+// @Param productId path string true "Product ID" example("prod123")
+// @Param request body dto.IssueOrderRequest true "Pickup code"
+// @Success 200 {object} dto.OrderDTO "Issued order"
+// @Failure 400 {object} dto.Error "Invalid request body or identifiers"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges or pickup code does not match"
+// @Failure 404 {object} dto.Error "Product not found in this PVZ"
+// @Failure 409 {object} dto.Error "Product is not ready for pickup"
+// @Failure 429 {object} dto.Error "Too many invalid pickup codes: the recipient must request a new code"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/{pvzId}/orders/{productId}/issue [post]
+func (p *pvzController) IssueOrder(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	var req dto.IssueOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
+		return
+	}
+
+	order, err := p.pvzSvc.IssueOrder(c, c.Param("pvzId"), c.Param("productId"), req.PickupCode, c.GetString("userID"))
+	if err != nil {
+		respondError(c, err, "failed to issue order")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.OrderEntityToDTO(*order))
+}
+
+// ReturnOrder godoc
+// @Summary Mark an order as returned
+// @Security BearerAuth
+// @Description Mark a product that is ready for pickup as returned (not collected by the recipient). Only employees can return orders.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID" example("pvz123")
+// @Param productId path string true "Product ID" example("prod123")
+// @Success 200 {object} dto.OrderDTO "Returned order"
+// @Failure 400 {object} dto.Error "Invalid identifiers"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Product not found in this PVZ"
+// @Failure 409 {object} dto.Error "Product is not ready for pickup"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/{pvzId}/orders/{productId}/return [post]
+func (p *pvzController) ReturnOrder(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	order, err := p.pvzSvc.ReturnOrder(c, c.Param("pvzId"), c.Param("productId"))
+	if err != nil {
+		respondError(c, err, "failed to return order")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.OrderEntityToDTO(*order))
+}
+
+// GetMyOrders godoc
+// @Summary List orders of the current client
+// @Security BearerAuth
+// @Description Get parcels assigned to the authenticated client across all PVZs with their status, PVZ city and storage deadline. Pickup codes are not returned: a new code can be requested via POST /my/orders/{productId}/pickup_code. Only clients can use this endpoint; the recipient is always taken from the token.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param status query string false "Order status filter" Enums(ready_for_pickup, issued, returned)
+// @Success 200 {array} dto.ClientOrderDTO "Orders of the current client"
+// @Failure 400 {object} dto.Error "Unknown status filter"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: not a registered client"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /my/orders [get]
+func (p *pvzController) GetMyOrders(c *gin.Context) {
+	if !CheckRole(c, "client") {
+		return
+	}
+
+	orders, err := p.pvzSvc.GetMyOrders(c, c.GetString("userID"), c.Query("status"))
+	if err != nil {
+		respondError(c, err, "failed to get orders")
+		return
+	}
+
+	response := make([]dto.ClientOrderDTO, 0, len(orders))
+	for _, order := range orders {
+		response = append(response, mapper.OrderEntityToClientDTO(order))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMyOrder godoc
+// @Summary Get an order of the current client
+// @Security BearerAuth
+// @Description Get a single parcel assigned to the authenticated client. Orders of other users are reported as not found.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID" example("prod123")
+// @Success 200 {object} dto.ClientOrderDTO "Order of the current client"
+// @Failure 400 {object} dto.Error "Invalid identifiers"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: not a registered client"
+// @Failure 404 {object} dto.Error "Order not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /my/orders/{productId} [get]
+func (p *pvzController) GetMyOrder(c *gin.Context) {
+	if !CheckRole(c, "client") {
+		return
+	}
+
+	order, err := p.pvzSvc.GetMyOrder(c, c.GetString("userID"), c.Param("productId"))
+	if err != nil {
+		respondError(c, err, "failed to get order")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.OrderEntityToClientDTO(*order))
+}
+
+// RenewMyPickupCode godoc
+// @Summary Issue a pickup code for an order of the current client
+// @Security BearerAuth
+// @Description Generate a pickup code for a parcel of the authenticated client that is ready for pickup. The recipient shows this code at the PVZ to collect the parcel. A previous code stops working and the limit of invalid codes entered at the PVZ is reset. Codes are stored hashed, so the new code is returned only in this response.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID" example("prod123")
+// @Success 200 {object} dto.ClientOrderDTO "Order of the current client with the pickup code"
+// @Failure 400 {object} dto.Error "Invalid identifiers"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: not a registered client"
+// @Failure 404 {object} dto.Error "Order not found"
+// @Failure 409 {object} dto.Error "Order is not ready for pickup"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /my/orders/{productId}/pickup_code [post]
+func (p *pvzController) RenewMyPickupCode(c *gin.Context) {
+	if !CheckRole(c, "client") {
+		return
+	}
+
+	order, err := p.pvzSvc.RenewMyPickupCode(c, c.GetString("userID"), c.Param("productId"))
+	if err != nil {
+		respondError(c, err, "failed to renew pickup code")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.OrderEntityToClientDTO(*order))
+}
+
+// GetOverdueOrders godoc
+// @Summary List overdue products at a PVZ
+// @Security BearerAuth
+// @Description Get products of the PVZ whose storage period has expired and which are still waiting (not issued and not moved to a return shipment). Available for employees and moderators.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID" example("pvz123")
+// @Success 200 {array} dto.OrderDTO "Overdue products ordered by storage deadline"
+// @Failure 400 {object} dto.Error "Invalid identifiers"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/{pvzId}/overdue [get]
+func (p *pvzController) GetOverdueOrders(c *gin.Context) {
+	if !CheckRole(c, "employee", "moderator") {
+		return
+	}
+
+	orders, err := p.pvzSvc.GetOverdueOrders(c, c.Param("pvzId"))
+	if err != nil {
+		respondError(c, err, "failed to get overdue orders")
+		return
+	}
+
+	response := make([]dto.OrderDTO, 0, len(orders))
+	for _, order := range orders {
+		response = append(response, mapper.OrderEntityToDTO(order))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
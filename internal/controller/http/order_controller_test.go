@@ -0,0 +1,260 @@
+package http
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockPvzServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+)
+
+func TestPvzController_IssueOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		role               string
+		requestBody        string
+		callSvc            bool
+		svcOrder           *entity.Order
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "client cannot issue orders",
+			role:               "client",
+			requestBody:        `{"pickupCode": "123456"}`,
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+		{
+			name:               "invalid request body",
+			role:               "employee",
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid request body",
+		},
+		{
+			name:               "wrong pickup code",
+			role:               "employee",
+			requestBody:        `{"pickupCode": "000000"}`,
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrInvalidPickupCode, "pickup code does not match"),
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: `"code":"INVALID_PICKUP_CODE"`,
+		},
+		{
+			name:               "pickup code locked",
+			role:               "employee",
+			requestBody:        `{"pickupCode": "123456"}`,
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrPickupCodeLocked, "too many invalid pickup codes, the recipient must request a new code"),
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRespSubstr: `"code":"PICKUP_CODE_LOCKED"`,
+		},
+		{
+			name:               "product not ready",
+			role:               "employee",
+			requestBody:        `{"pickupCode": "123456"}`,
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrInvalidProductStatus, "product in 'issued' status cannot be issued"),
+			expectedStatusCode: http.StatusConflict,
+			expectedRespSubstr: `"code":"INVALID_PRODUCT_STATUS"`,
+		},
+		{
+			name:               "success",
+			role:               "employee",
+			requestBody:        `{"pickupCode": "123456"}`,
+			callSvc:            true,
+			svcOrder:           &entity.Order{ProductID: "prod1", PvzID: "pvz1", Status: entity.ProductStatusIssued},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"status":"issued"`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest("POST", "/pvz/pvz1/orders/prod1/issue", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = req
+			c.Params = gin.Params{{Key: "pvzId", Value: "pvz1"}, {Key: "productId", Value: "prod1"}}
+			c.Set("role", tc.role)
+			c.Set("userID", "emp1")
+
+			mockSvc := mockPvzServ.NewPvzService(t)
+			if tc.callSvc {
+				mockSvc.
+					On("IssueOrder", mock.Anything, "pvz1", "prod1", mock.AnythingOfType("string"), "emp1").
+					Return(tc.svcOrder, tc.svcErr).
+					Once()
+			}
+
+			ctrl := NewPvzController(mockSvc)
+			ctrl.IssueOrder(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestPvzController_PrepareOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest("POST", "/pvz/pvz1/orders", bytes.NewBufferString(`{"productId": "prod1", "recipientId": "user1"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "pvzId", Value: "pvz1"}}
+	c.Set("role", "employee")
+
+	mockSvc := mockPvzServ.NewPvzService(t)
+	mockSvc.
+		On("PrepareOrder", mock.Anything, "pvz1", "prod1", "user1").
+		Return(&entity.Order{ProductID: "prod1", PvzID: "pvz1", Status: entity.ProductStatusReadyForPickup}, nil).
+		Once()
+
+	NewPvzController(mockSvc).PrepareOrder(c)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), `"status":"ready_for_pickup"`) || strings.Contains(rr.Body.String(), "pickupCode") {
+		t.Errorf("unexpected response %q", rr.Body.String())
+	}
+}
+
+func TestPvzController_GetOrdersForPickup_MissingRecipient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest("GET", "/pvz/pvz1/orders", nil)
+	c.Params = gin.Params{{Key: "pvzId", Value: "pvz1"}}
+	c.Set("role", "employee")
+
+	NewPvzController(mockPvzServ.NewPvzService(t)).GetOrdersForPickup(c)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestPvzController_GetMyOrders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("employee cannot list client orders", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("GET", "/my/orders", nil)
+		c.Set("role", "employee")
+
+		NewPvzController(mockPvzServ.NewPvzService(t)).GetMyOrders(c)
+
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+		}
+	})
+
+	t.Run("orders are scoped to token user", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("GET", "/my/orders?status=ready_for_pickup", nil)
+		c.Set("role", "client")
+		c.Set("userID", "user1")
+
+		mockSvc := mockPvzServ.NewPvzService(t)
+		mockSvc.
+			On("GetMyOrders", mock.Anything, "user1", "ready_for_pickup").
+			Return([]entity.Order{{ProductID: "prod1", PvzCity: "Kazan", Status: entity.ProductStatusReadyForPickup}}, nil).
+			Once()
+
+		NewPvzController(mockSvc).GetMyOrders(c)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		if !strings.Contains(rr.Body.String(), `"pvzCity":"Kazan"`) || strings.Contains(rr.Body.String(), "pickupCode") {
+			t.Errorf("unexpected response %q", rr.Body.String())
+		}
+	})
+}
+
+func TestPvzController_RenewMyPickupCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("employee cannot renew codes", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("POST", "/my/orders/prod1/pickup_code", nil)
+		c.Set("role", "employee")
+
+		NewPvzController(mockPvzServ.NewPvzService(t)).RenewMyPickupCode(c)
+
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+		}
+	})
+
+	t.Run("new code is returned once", func(t *testing.T) {
+		code := "042917"
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("POST", "/my/orders/prod1/pickup_code", nil)
+		c.Params = gin.Params{{Key: "productId", Value: "prod1"}}
+		c.Set("role", "client")
+		c.Set("userID", "user1")
+
+		mockSvc := mockPvzServ.NewPvzService(t)
+		mockSvc.
+			On("RenewMyPickupCode", mock.Anything, "user1", "prod1").
+			Return(&entity.Order{ProductID: "prod1", Status: entity.ProductStatusReadyForPickup, PickupCode: &code}, nil).
+			Once()
+
+		NewPvzController(mockSvc).RenewMyPickupCode(c)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		if !strings.Contains(rr.Body.String(), `"pickupCode":"042917"`) {
+			t.Errorf("expected pickup code in response, got %q", rr.Body.String())
+		}
+	})
+
+	t.Run("order not ready for pickup", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("POST", "/my/orders/prod1/pickup_code", nil)
+		c.Params = gin.Params{{Key: "productId", Value: "prod1"}}
+		c.Set("role", "client")
+		c.Set("userID", "user1")
+
+		mockSvc := mockPvzServ.NewPvzService(t)
+		mockSvc.
+			On("RenewMyPickupCode", mock.Anything, "user1", "prod1").
+			Return(nil, errs.New(errs.ErrInvalidProductStatus, "order in 'issued' status has no pickup code")).
+			Once()
+
+		NewPvzController(mockSvc).RenewMyPickupCode(c)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+	})
+}
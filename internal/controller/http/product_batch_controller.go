@@ -0,0 +1,195 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	http2 "order-pick-up-point/internal/service/http"
+	"strings"
+)
+
+const (
+	batchModeAtomic  = "atomic"
+	batchModePartial = "partial"
+
+	// maxBatchBodyBytes — предел тела запроса пакета: с запасом вмещает MaxBatchProducts позиций
+	maxBatchBodyBytes = 1 << 20
+)
+
+var errBatchTooLarge = fmt.Errorf("batch is limited to %d products", http2.MaxBatchProducts)
+
+// AddProductsBatch godoc
+// @Summary Add many products to the current reception
+// @Security BearerAuth
+// @Description Add a batch of products to the open reception of a PVZ in a single transaction. In "atomic" mode (default) any invalid item rejects the whole batch, in "partial" mode only valid items are saved. Results are returned per item in request order. Only employees can add products.
+// @Tags pvz
+// @Accept json
+// @Produce json
+// @Param request body dto.ProductsBatchRequest true "Products batch"
+// @Success 201 {object} dto.ProductsBatchResponse "All products added"
+// @Success 207 {object} dto.ProductsBatchResponse "Partial mode: some products added, see per-item errors"
+// @Failure 400 {object} dto.Error "Invalid request body, empty or too large batch, body over 1 MiB"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 422 {object} dto.ProductsBatchResponse "No products added: batch rejected or every item is invalid (no open reception is reported as dto.Error)"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /products/batch [post]
+func (p *pvzController) AddProductsBatch(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBodyBytes)
+	var req dto.ProductsBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: batchBodyErrorMessage(err, "invalid request body")})
+		return
+	}
+
+	p.addProductsBatch(c, req.PvzId, req.Mode, mapper.ProductBatchItemsDTOToEntity(req.Products))
+}
+
+// AddProductsBatchCSV godoc
+// @Summary Upload a CSV file of products to the current reception
+// @Security BearerAuth
+// @Description Same as /products/batch, but products are read from an uploaded CSV file with columns "type,barcode" (barcode is optional, a header row is allowed). Only employees can add products.
+// @Tags pvz
+// @Accept mpfd
+// @Produce json
+// @Param pvzId formData string true "PVZ ID" example("pvz789")
+// @Param mode formData string false "Batch mode" Enums(atomic, partial) default(atomic)
+// @Param file formData file true "CSV file with products"
+// @Success 201 {object} dto.ProductsBatchResponse "All products added"
+// @Success 207 {object} dto.ProductsBatchResponse "Partial mode: some products added, see per-item errors"
+// @Failure 400 {object} dto.Error "Invalid form, malformed CSV, empty or too large batch, body over 1 MiB"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 422 {object} dto.ProductsBatchResponse "No products added: batch rejected or every item is invalid (no open reception is reported as dto.Error)"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /products/batch/csv [post]
+func (p *pvzController) AddProductsBatchCSV(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBodyBytes)
+	pvzId := c.PostForm("pvzId")
+	if pvzId == "" {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "pvzId is required"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: batchBodyErrorMessage(err, "csv file is required")})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "failed to read csv file"})
+		return
+	}
+	defer file.Close()
+
+	items, err := parseProductsCSV(file, http2.MaxBatchProducts)
+	if errors.Is(err, errBatchTooLarge) {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "malformed csv: " + err.Error()})
+		return
+	}
+
+	p.addProductsBatch(c, pvzId, c.PostForm("mode"), items)
+}
+
+func (p *pvzController) addProductsBatch(c *gin.Context, pvzId, mode string, items []entity.ProductBatchItem) {
+	if mode == "" {
+		mode = batchModeAtomic
+	}
+	if mode != batchModeAtomic && mode != batchModePartial {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "mode must be 'atomic' or 'partial'"})
+		return
+	}
+
+	results, err := p.pvzSvc.AddProductsBatch(c, pvzId, items, mode == batchModeAtomic)
+	if err != nil {
+		respondError(c, err, "failed to add products")
+		return
+	}
+
+	response := dto.ProductsBatchResponse{
+		Mode:  mode,
+		Items: make([]dto.ProductsBatchItemResult, 0, len(results)),
+	}
+	for _, r := range results {
+		switch r.Status {
+		case entity.BatchItemCreated:
+			response.Created++
+		case entity.BatchItemFailed:
+			response.Failed++
+		}
+		response.Items = append(response.Items, mapper.ProductBatchResultToDTO(r))
+	}
+
+	status := http.StatusCreated
+	switch {
+	case response.Created == 0:
+		status = http.StatusUnprocessableEntity
+	case response.Created < len(results):
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, response)
+}
+
+// batchBodyErrorMessage отличает превышение maxBatchBodyBytes от прочих ошибок чтения тела.
+func batchBodyErrorMessage(err error, fallback string) string {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return fmt.Sprintf("request body is limited to %d bytes", tooLarge.Limit)
+	}
+	return fallback
+}
+
+// parseProductsCSV читает позиции пакета из CSV: тип товара и необязательный штрихкод.
+// Первая строка пропускается, если это заголовок. Чтение прекращается с errBatchTooLarge,
+// как только позиций становится больше maxItems.
+func parseProductsCSV(r io.Reader, maxItems int) ([]entity.ProductBatchItem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var items []entity.ProductBatchItem
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		productType := strings.TrimSpace(record[0])
+		if line == 0 && strings.EqualFold(productType, "type") {
+			continue
+		}
+
+		item := entity.ProductBatchItem{Type: productType}
+		if len(record) > 1 {
+			item.Barcode = strings.TrimSpace(record[1])
+		}
+		items = append(items, item)
+		if len(items) > maxItems {
+			return nil, errBatchTooLarge
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,266 @@
+package http
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	http2 "order-pick-up-point/internal/service/http"
+	mockPvzServ "order-pick-up-point/internal/service/http/mock"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPvzController_AddProductsBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	created := entity.ProductBatchResult{Index: 0, ProductID: "prod1", Type: "shoes", Status: entity.BatchItemCreated}
+	failed := entity.ProductBatchResult{Index: 1, Type: "toys", Status: entity.BatchItemFailed,
+		ErrCode: errs.ErrInvalidProductType, ErrMessage: "product type 'toys' is not allowed"}
+	skipped := entity.ProductBatchResult{Index: 0, Type: "shoes", Status: entity.BatchItemSkipped}
+
+	tests := []struct {
+		name               string
+		role               string
+		requestBody        string
+		callSvc            bool
+		atomic             bool
+		svcResults         []entity.ProductBatchResult
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "moderator cannot add products",
+			role:               "moderator",
+			requestBody:        `{"pvzId": "pvz1", "products": [{"type": "shoes"}]}`,
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+		{
+			name:               "unknown mode",
+			role:               "employee",
+			requestBody:        `{"pvzId": "pvz1", "mode": "best-effort", "products": [{"type": "shoes"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid request body",
+		},
+		{
+			name:               "all created",
+			role:               "employee",
+			requestBody:        `{"pvzId": "pvz1", "products": [{"type": "shoes"}, {"type": "toys"}]}`,
+			callSvc:            true,
+			atomic:             true,
+			svcResults:         []entity.ProductBatchResult{created},
+			expectedStatusCode: http.StatusCreated,
+			expectedRespSubstr: `"created":1`,
+		},
+		{
+			name:               "partial success",
+			role:               "employee",
+			requestBody:        `{"pvzId": "pvz1", "mode": "partial", "products": [{"type": "shoes"}, {"type": "toys"}]}`,
+			callSvc:            true,
+			svcResults:         []entity.ProductBatchResult{created, failed},
+			expectedStatusCode: http.StatusMultiStatus,
+			expectedRespSubstr: `"code":"INVALID_PRODUCT_TYPE"`,
+		},
+		{
+			name:               "atomic batch rejected",
+			role:               "employee",
+			requestBody:        `{"pvzId": "pvz1", "mode": "atomic", "products": [{"type": "shoes"}, {"type": "toys"}]}`,
+			callSvc:            true,
+			atomic:             true,
+			svcResults:         []entity.ProductBatchResult{skipped, failed},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedRespSubstr: `"status":"skipped"`,
+		},
+		{
+			name:               "no open reception",
+			role:               "employee",
+			requestBody:        `{"pvzId": "pvz1", "products": [{"type": "shoes"}, {"type": "toys"}]}`,
+			callSvc:            true,
+			atomic:             true,
+			svcErr:             errs.New(errs.ErrNoOpenReception, "no open reception found for this PVZ"),
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedRespSubstr: `"code":"NO_OPEN_RECEPTION"`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest("POST", "/products/batch", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = req
+			c.Set("role", tc.role)
+
+			mockSvc := mockPvzServ.NewPvzService(t)
+			if tc.callSvc {
+				mockSvc.
+					On("AddProductsBatch", mock.Anything, "pvz1", []entity.ProductBatchItem{{Type: "shoes"}, {Type: "toys"}}, tc.atomic).
+					Return(tc.svcResults, tc.svcErr).
+					Once()
+			}
+
+			ctrl := NewPvzController(mockSvc)
+			ctrl.AddProductsBatch(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestPvzController_AddProductsBatchCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("pvzId", "pvz1")
+	_ = writer.WriteField("mode", "partial")
+	part, err := writer.CreateFormFile("file", "products.csv")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	_, _ = part.Write([]byte("type,barcode\nshoes,BC-1\nclothes\n"))
+	_ = writer.Close()
+
+	req := httptest.NewRequest("POST", "/products/batch/csv", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rr := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = req
+	c.Set("role", "employee")
+
+	expectedItems := []entity.ProductBatchItem{{Type: "shoes", Barcode: "BC-1"}, {Type: "clothes"}}
+	mockSvc := mockPvzServ.NewPvzService(t)
+	mockSvc.
+		On("AddProductsBatch", mock.Anything, "pvz1", expectedItems, false).
+		Return([]entity.ProductBatchResult{
+			{Index: 0, ProductID: "prod1", Type: "shoes", Barcode: "BC-1", Status: entity.BatchItemCreated},
+			{Index: 1, ProductID: "prod2", Type: "clothes", Status: entity.BatchItemCreated},
+		}, nil).
+		Once()
+
+	NewPvzController(mockSvc).AddProductsBatchCSV(c)
+
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status code %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+}
+
+func TestPvzController_AddProductsBatch_BodyTooLarge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"pvzId":"pvz1","products":[` + strings.Repeat(`{"type":"shoes"},`, maxBatchBodyBytes/16) + `{"type":"shoes"}]}`
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest("POST", "/products/batch", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("role", "employee")
+
+	NewPvzController(mockPvzServ.NewPvzService(t)).AddProductsBatch(c)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), "request body is limited") {
+		t.Errorf("expected body size error, got %q", rr.Body.String())
+	}
+}
+
+func TestPvzController_AddProductsBatchCSV_TooManyRows(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("pvzId", "pvz1")
+	part, err := writer.CreateFormFile("file", "products.csv")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	_, _ = part.Write([]byte(strings.Repeat("shoes\n", http2.MaxBatchProducts+1)))
+	_ = writer.Close()
+
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest("POST", "/products/batch/csv", &body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	c.Set("role", "employee")
+
+	NewPvzController(mockPvzServ.NewPvzService(t)).AddProductsBatchCSV(c)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), "batch is limited") {
+		t.Errorf("expected batch size error, got %q", rr.Body.String())
+	}
+}
+
+func TestParseProductsCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []entity.ProductBatchItem
+		wantErr  bool
+	}{
+		{
+			name:     "with header",
+			input:    "type,barcode\nelectronics,4607001234567\nshoes,\n",
+			expected: []entity.ProductBatchItem{{Type: "electronics", Barcode: "4607001234567"}, {Type: "shoes"}},
+		},
+		{
+			name:     "without header and barcode column",
+			input:    "clothes\n shoes\n",
+			expected: []entity.ProductBatchItem{{Type: "clothes"}, {Type: "shoes"}},
+		},
+		{
+			name:    "too many products",
+			input:   "type\nshoes\nshoes\nshoes\nshoes\n",
+			wantErr: true,
+		},
+		{
+			name:    "broken quoting",
+			input:   "\"shoes,BC-1\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			items, err := parseProductsCSV(strings.NewReader(tc.input), 3)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got items %+v", items)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(items, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, items)
+			}
+		})
+	}
+}
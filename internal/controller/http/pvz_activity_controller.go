@@ -0,0 +1,129 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// activityHeartbeat — период комментариев-пингов, не дающих прокси закрыть простаивающий поток
+	activityHeartbeat = 15 * time.Second
+	// activityRetryMs — через сколько миллисекунд EventSource переподключается после обрыва
+	activityRetryMs = 3000
+)
+
+// StreamPvzActivity godoc
+// @Summary Live activity of PVZs
+// @Security BearerAuth
+// @Description Server-sent events stream of reception and product events (ReceptionOpened, ProductAdded, ProductRemoved, ReceptionClosed) of one or more PVZs as soon as they are committed, on any application replica. Each event has the SSE id, event type and JSON data. After a reconnect, pass the last received id in the Last-Event-ID header (EventSource does it automatically) or in lastEventId to get missed events first. Delivery is at least once: after a reconnect some events may repeat, including events with a smaller id committed after the last received one, so deduplicate by eventId. If the server interrupts the stream (slow client, shutdown), it sends an error event and closes the connection; reconnect with the last id. Comment lines are sent every 15 seconds as a heartbeat. Available for employees and moderators.
+// @Tags pvz
+// @Produce text/event-stream
+// @Param pvzId query []string true "PVZ IDs, from 1 to 50" collectionFormat(multi)
+// @Param lastEventId query int false "Resume after this event id"
+// @Param Last-Event-ID header int false "Resume after this event id, takes precedence over lastEventId"
+// @Success 200 {object} dto.PvzActivityEventDTO "Event stream; data of each event"
+// @Failure 400 {object} dto.Error "Invalid PVZ IDs or event id"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "PVZ not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/activity [get]
+func (p *pvzController) StreamPvzActivity(c *gin.Context) {
+	if !CheckRole(c, "employee", "moderator") {
+		return
+	}
+
+	var query dto.PvzActivityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid query parameters"})
+		return
+	}
+	filter := entity.PvzActivityFilter{PvzIDs: query.PvzIds, LastEventID: query.LastEventId}
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid Last-Event-ID header"})
+			return
+		}
+		filter.LastEventID = &id
+	}
+
+	// Пинги пишутся из отдельной горутины, поэтому запись сериализуется и прекращается с выходом из обработчика
+	var (
+		mu      sync.Mutex
+		closed  bool
+		started bool
+	)
+	write := func(frame string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return nil
+		}
+		if _, err := io.WriteString(c.Writer, frame); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+	defer func() {
+		mu.Lock()
+		closed = true
+		mu.Unlock()
+	}()
+
+	ctx := c.Request.Context()
+	ready := func() error {
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		started = true
+
+		go func() {
+			ticker := time.NewTicker(activityHeartbeat)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if write(": ping\n\n") != nil {
+						return
+					}
+				}
+			}
+		}()
+		return write(fmt.Sprintf("retry: %d\n\n", activityRetryMs))
+	}
+
+	err := p.pvzSvc.StreamPvzActivity(ctx, filter, ready, func(e entity.OutboxEvent) error {
+		data, err := json.Marshal(mapper.PvzActivityEventToDTO(e))
+		if err != nil {
+			return err
+		}
+		return write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data))
+	})
+	if err == nil {
+		return
+	}
+	if !started {
+		respondError(c, err, "failed to subscribe to PVZ activity")
+		return
+	}
+	// Поток уже начат: причина передаётся событием error, после чего соединение закрывается
+	appErr := errs.FromError(err, "PVZ activity stream failed")
+	data, _ := json.Marshal(dto.Error{Code: appErr.Code, Message: appErr.Message})
+	_ = write(fmt.Sprintf("event: error\ndata: %s\n\n", data))
+}
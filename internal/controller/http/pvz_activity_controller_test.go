@@ -0,0 +1,184 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	mockPvzServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPvzController_StreamPvzActivity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	created := time.Date(2025, 5, 4, 9, 0, 0, 0, time.UTC)
+	events := []entity.OutboxEvent{
+		{ID: 11, EventID: "ev1", Type: entity.EventReceptionOpened, AggregateID: "rec1", PvzID: "pvz1", Payload: json.RawMessage(`{"receptionId":"rec1"}`), CreatedAt: created},
+		{ID: 12, EventID: "ev2", Type: entity.EventProductAdded, AggregateID: "prod1", PvzID: "pvz1", Payload: json.RawMessage(`{"productId":"prod1"}`), CreatedAt: created},
+	}
+	lastID := int64(10)
+
+	// serve вызывает StreamPvzActivity с заголовками headers; если svc не nil, он настраивает мок сервиса
+	serve := func(t *testing.T, role, query string, headers map[string]string, svc func(*mockPvzServ.PvzService)) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("GET", "/pvz/activity?"+query, nil)
+		for k, v := range headers {
+			c.Request.Header.Set(k, v)
+		}
+		c.Set("role", role)
+
+		mockSvc := mockPvzServ.NewPvzService(t)
+		if svc != nil {
+			svc(mockSvc)
+		}
+		NewPvzController(mockSvc).StreamPvzActivity(c)
+		return rr
+	}
+	// stream подтверждает подписку, отдаёт events и возвращает svcErr
+	stream := func(filter entity.PvzActivityFilter, events []entity.OutboxEvent, svcErr error) func(*mockPvzServ.PvzService) {
+		return func(m *mockPvzServ.PvzService) {
+			m.On("StreamPvzActivity", mock.Anything, filter, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					if err := args.Get(2).(func() error)(); err != nil {
+						return
+					}
+					fn := args.Get(3).(func(entity.OutboxEvent) error)
+					for _, e := range events {
+						if err := fn(e); err != nil {
+							return
+						}
+					}
+				}).
+				Return(svcErr).
+				Once()
+		}
+	}
+
+	t.Run("events are written as sse frames", func(t *testing.T) {
+		t.Parallel()
+		filter := entity.PvzActivityFilter{PvzIDs: []string{"pvz1", "pvz2"}}
+		rr := serve(t, "employee", "pvzId=pvz1&pvzId=pvz2", nil, stream(filter, events, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		body := rr.Body.String()
+		if !strings.HasPrefix(body, "retry: 3000\n\n") {
+			t.Errorf("expected retry hint first, got %q", body)
+		}
+		expected := "id: 12\nevent: ProductAdded\ndata: " +
+			`{"id":12,"eventId":"ev2","type":"ProductAdded","pvzId":"pvz1","aggregateId":"prod1","occurredAt":"2025-05-04T09:00:00Z","payload":{"productId":"prod1"}}` + "\n\n"
+		if !strings.Contains(body, "id: 11\nevent: ReceptionOpened\n") || !strings.HasSuffix(body, expected) {
+			t.Errorf("unexpected stream body %q", body)
+		}
+	})
+
+	t.Run("Last-Event-ID header takes precedence over query", func(t *testing.T) {
+		t.Parallel()
+		filter := entity.PvzActivityFilter{PvzIDs: []string{"pvz1"}, LastEventID: &lastID}
+		rr := serve(t, "moderator", "pvzId=pvz1&lastEventId=3", map[string]string{"Last-Event-ID": "10"}, stream(filter, nil, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("lastEventId from query", func(t *testing.T) {
+		t.Parallel()
+		filter := entity.PvzActivityFilter{PvzIDs: []string{"pvz1"}, LastEventID: &lastID}
+		rr := serve(t, "employee", "pvzId=pvz1&lastEventId=10", nil, stream(filter, nil, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("interruption after start is sent as error event", func(t *testing.T) {
+		t.Parallel()
+		filter := entity.PvzActivityFilter{PvzIDs: []string{"pvz1"}}
+		svcErr := errs.New(errs.ErrActivityStreamInterrupted, "activity stream interrupted, reconnect with the last received event id")
+		rr := serve(t, "employee", "pvzId=pvz1", nil, stream(filter, events[:1], svcErr))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rr.Code)
+		}
+		if !strings.HasSuffix(rr.Body.String(), "event: error\ndata: "+
+			`{"code":"ACTIVITY_STREAM_INTERRUPTED","message":"activity stream interrupted, reconnect with the last received event id"}`+"\n\n") {
+			t.Errorf("unexpected stream body %q", rr.Body.String())
+		}
+	})
+
+	errorTests := []struct {
+		name           string
+		role           string
+		query          string
+		headers        map[string]string
+		svc            func(*mockPvzServ.PvzService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "client is forbidden",
+			role:           "client",
+			query:          "pvzId=pvz1",
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   errs.ErrForbiddenCode,
+		},
+		{
+			name:           "invalid lastEventId",
+			role:           "employee",
+			query:          "pvzId=pvz1&lastEventId=abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   errs.ErrInvalidRequestCode,
+		},
+		{
+			name:           "invalid Last-Event-ID header",
+			role:           "employee",
+			query:          "pvzId=pvz1",
+			headers:        map[string]string{"Last-Event-ID": "abc"},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   errs.ErrInvalidRequestCode,
+		},
+		{
+			name:  "error before subscription is a json response",
+			role:  "employee",
+			query: "pvzId=pvz1",
+			svc: func(m *mockPvzServ.PvzService) {
+				// ошибка проверки до подписки: ready не вызывается
+				m.On("StreamPvzActivity", mock.Anything, entity.PvzActivityFilter{PvzIDs: []string{"pvz1"}}, mock.Anything, mock.Anything).
+					Return(errs.New(errs.ErrPvzNotFound, "pvz not found")).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   errs.ErrPvzNotFound,
+		},
+	}
+	for _, tc := range errorTests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rr := serve(t, tc.role, tc.query, tc.headers, tc.svc)
+
+			if rr.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rr.Code, rr.Body.String())
+			}
+			var resp dto.Error
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Code != tc.expectedCode {
+				t.Errorf("expected code %s, got %s", tc.expectedCode, resp.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+)
+
+// GetReceptionsPerDay godoc
+// @Summary Receptions per PVZ per day
+// @Security BearerAuth
+// @Description Count receptions of each PVZ by day (Moscow time). Only days with receptions are returned. Aggregation runs in the database. Available for employees and moderators.
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param city query string false "Filter by city" example("Moscow")
+// @Param pvzId query string false "Filter by PVZ ID"
+// @Param startDate query string false "Start of the reception period in RFC3339 format" example("2025-04-01T00:00:00Z")
+// @Param endDate query string false "End of the reception period in RFC3339 format" example("2025-04-30T23:59:59Z")
+// @Success 200 {array} dto.DailyReceptionsDTO "Reception counts sorted by city, PVZ and day"
+// @Failure 400 {object} dto.Error "Invalid dates, PVZ ID or city is not allowed"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /analytics/receptions/daily [get]
+func (p *pvzController) GetReceptionsPerDay(c *gin.Context) {
+	filter, ok := bindAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := p.pvzSvc.GetReceptionsPerDay(c, filter)
+	if err != nil {
+		respondError(c, err, "failed to get receptions per day")
+		return
+	}
+
+	response := make([]dto.DailyReceptionsDTO, 0, len(result))
+	for _, d := range result {
+		response = append(response, mapper.DailyReceptionsEntityToDTO(d))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetProductTypeCounts godoc
+// @Summary Products per type per PVZ
+// @Security BearerAuth
+// @Description Count products of each type received by each PVZ in receptions of the period. Aggregation runs in the database. Available for employees and moderators.
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param city query string false "Filter by city" example("Moscow")
+// @Param pvzId query string false "Filter by PVZ ID"
+// @Param startDate query string false "Start of the reception period in RFC3339 format" example("2025-04-01T00:00:00Z")
+// @Param endDate query string false "End of the reception period in RFC3339 format" example("2025-04-30T23:59:59Z")
+// @Success 200 {array} dto.ProductTypeCountDTO "Product counts sorted by city, PVZ and type"
+// @Failure 400 {object} dto.Error "Invalid dates, PVZ ID or city is not allowed"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /analytics/products/types [get]
+func (p *pvzController) GetProductTypeCounts(c *gin.Context) {
+	filter, ok := bindAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := p.pvzSvc.GetProductTypeCounts(c, filter)
+	if err != nil {
+		respondError(c, err, "failed to get product type counts")
+		return
+	}
+
+	response := make([]dto.ProductTypeCountDTO, 0, len(result))
+	for _, count := range result {
+		response = append(response, mapper.ProductTypeCountEntityToDTO(count))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetReceptionStats godoc
+// @Summary Reception summary per PVZ
+// @Security BearerAuth
+// @Description For each PVZ: number of receptions, closed receptions and products, average reception duration from opening to closing and average number of products per reception (empty receptions included). Receptions closed before close times were recorded do not count towards the duration. Aggregation runs in the database. Available for employees and moderators.
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param city query string false "Filter by city" example("Moscow")
+// @Param pvzId query string false "Filter by PVZ ID"
+// @Param startDate query string false "Start of the reception period in RFC3339 format" example("2025-04-01T00:00:00Z")
+// @Param endDate query string false "End of the reception period in RFC3339 format" example("2025-04-30T23:59:59Z")
+// @Success 200 {array} dto.ReceptionStatsDTO "Reception summaries sorted by city and PVZ"
+// @Failure 400 {object} dto.Error "Invalid dates, PVZ ID or city is not allowed"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /analytics/receptions/stats [get]
+func (p *pvzController) GetReceptionStats(c *gin.Context) {
+	filter, ok := bindAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := p.pvzSvc.GetReceptionStats(c, filter)
+	if err != nil {
+		respondError(c, err, "failed to get reception stats")
+		return
+	}
+
+	response := make([]dto.ReceptionStatsDTO, 0, len(result))
+	for _, s := range result {
+		response = append(response, mapper.ReceptionStatsEntityToDTO(s))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// bindAnalyticsQuery проверяет роль и разбирает фильтры аналитики. При ошибке ответ уже записан.
+func bindAnalyticsQuery(c *gin.Context) (entity.AnalyticsFilter, bool) {
+	if !CheckRole(c, "employee", "moderator") {
+		return entity.AnalyticsFilter{}, false
+	}
+
+	var query dto.AnalyticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid query parameters"})
+		return entity.AnalyticsFilter{}, false
+	}
+	return mapper.AnalyticsQueryToFilter(query), true
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockPvzServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPvzController_Analytics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	avg := 90.0
+
+	tests := []struct {
+		name               string
+		path               string
+		role               string
+		method             string
+		expectedFilter     entity.AnalyticsFilter
+		svcResult          interface{}
+		svcErr             error
+		handler            func(PvzController, *gin.Context)
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "receptions per day",
+			path:               "/analytics/receptions/daily?city=Moscow&startDate=2025-04-01T00:00:00Z",
+			role:               "moderator",
+			method:             "GetReceptionsPerDay",
+			expectedFilter:     entity.AnalyticsFilter{City: "Moscow", StartDate: &start},
+			svcResult:          []entity.DailyReceptions{{PvzID: "pvz1", City: "Moscow", Day: start, Receptions: 4}},
+			handler:            PvzController.GetReceptionsPerDay,
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"day":"2025-04-01","receptions":4`,
+		},
+		{
+			name:               "product type counts",
+			path:               "/analytics/products/types?pvzId=pvz1",
+			role:               "employee",
+			method:             "GetProductTypeCounts",
+			expectedFilter:     entity.AnalyticsFilter{PvzID: "pvz1"},
+			svcResult:          []entity.ProductTypeCount{{PvzID: "pvz1", City: "Moscow", Type: "shoes", Products: 3}},
+			handler:            PvzController.GetProductTypeCounts,
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"type":"shoes","products":3`,
+		},
+		{
+			name:               "reception stats",
+			path:               "/analytics/receptions/stats",
+			role:               "moderator",
+			method:             "GetReceptionStats",
+			svcResult:          []entity.ReceptionStats{{PvzID: "pvz1", Receptions: 2, ClosedReceptions: 1, AvgDurationSeconds: &avg, AvgProductsPerReception: 1.5}},
+			handler:            PvzController.GetReceptionStats,
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"avgDurationSeconds":90,"avgProductsPerReception":1.5`,
+		},
+		{
+			name:               "empty result",
+			path:               "/analytics/receptions/stats",
+			role:               "moderator",
+			method:             "GetReceptionStats",
+			svcResult:          []entity.ReceptionStats(nil),
+			handler:            PvzController.GetReceptionStats,
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `[]`,
+		},
+		{
+			name:               "city not allowed",
+			path:               "/analytics/receptions/daily?city=London",
+			role:               "moderator",
+			method:             "GetReceptionsPerDay",
+			expectedFilter:     entity.AnalyticsFilter{City: "London"},
+			svcResult:          []entity.DailyReceptions(nil),
+			svcErr:             errs.New(errs.ErrInvalidCity, "city 'London' is not allowed"),
+			handler:            PvzController.GetReceptionsPerDay,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: `"code":"INVALID_CITY"`,
+		},
+		{
+			name:               "service error",
+			path:               "/analytics/products/types",
+			role:               "moderator",
+			method:             "GetProductTypeCounts",
+			svcResult:          []entity.ProductTypeCount(nil),
+			svcErr:             errors.New("db error"),
+			handler:            PvzController.GetProductTypeCounts,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedRespSubstr: "failed to get product type counts",
+		},
+		{
+			name:               "invalid date",
+			path:               "/analytics/receptions/daily?endDate=yesterday",
+			role:               "moderator",
+			handler:            PvzController.GetReceptionsPerDay,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid query parameters",
+		},
+		{
+			name:               "client forbidden",
+			path:               "/analytics/receptions/stats",
+			role:               "client",
+			handler:            PvzController.GetReceptionStats,
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("GET", tc.path, nil)
+			c.Set("role", tc.role)
+
+			mockSvc := mockPvzServ.NewPvzService(t)
+			if tc.method != "" {
+				mockSvc.
+					On(tc.method, mock.Anything, tc.expectedFilter).
+					Return(tc.svcResult, tc.svcErr).
+					Once()
+			}
+
+			tc.handler(NewPvzController(mockSvc), c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
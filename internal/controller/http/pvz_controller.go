@@ -7,22 +7,48 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"net/http"
+	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/models/dto"
 	"order-pick-up-point/internal/models/mapper"
 	http2 "order-pick-up-point/internal/service/http"
-	"strconv"
 	"strings"
 	"time"
 )
 
 type PvzController interface {
 	CreatePvz(c *gin.Context)
+	UpdatePvz(c *gin.Context)
+	SearchNearbyPvzs(c *gin.Context)
 	GetPvzsInfo(c *gin.Context)
 	CreateReception(c *gin.Context)
 	AddProduct(c *gin.Context)
+	AddProductsBatch(c *gin.Context)
+	AddProductsBatchCSV(c *gin.Context)
 	DeleteLastProduct(c *gin.Context)
+	GetProductByBarcode(c *gin.Context)
 	CloseReception(c *gin.Context)
 	GetPvzsInfoOptimized(c *gin.Context)
+	ExportPvzHistory(c *gin.Context)
+	StreamPvzActivity(c *gin.Context)
+
+	GetReceptionsPerDay(c *gin.Context)
+	GetProductTypeCounts(c *gin.Context)
+	GetReceptionStats(c *gin.Context)
+
+	PrepareOrder(c *gin.Context)
+	GetOrdersForPickup(c *gin.Context)
+	IssueOrder(c *gin.Context)
+	ReturnOrder(c *gin.Context)
+
+	GetMyOrders(c *gin.Context)
+	GetMyOrder(c *gin.Context)
+	RenewMyPickupCode(c *gin.Context)
+	GetOverdueOrders(c *gin.Context)
+
+	CreateReturn(c *gin.Context)
+	AddReturnItem(c *gin.Context)
+	DeleteLastReturnItem(c *gin.Context)
+	CloseReturn(c *gin.Context)
 }
 
 type pvzController struct {
@@ -38,14 +64,15 @@ func NewPvzController(pvzSvc http2.PvzService) PvzController {
 // CreatePvz godoc
 // @Summary Create a new PVZ
 // @Security BearerAuth
-// @Description Create a new PVZ. Only users with a moderator role can create a PVZ.
+// @Description Create a new PVZ with optional address, coordinates, contact phone and opening hours per weekday. New PVZs are active. Only users with a moderator role can create a PVZ.
 // @Tags pvz
 // @Accept json
 // @Produce json
 // @Param request body dto.CreatePvzPostRequest true "PVZ creation data"
 // @Success 201 {object} dto.CreatePvzResponse "Newly created PVZ information"
-// @Failure 400 {object} dto.Error "Invalid request body"
-// @Failure 401 {object} dto.Error "Unauthorized: missing token or insufficient privileges"
+// @Failure 400 {object} dto.Error "Invalid request body, city is not allowed or invalid PVZ details"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
 // @Failure 500 {object} dto.Error "Internal server error"
 // @Router /pvz [post]
 func (p *pvzController) CreatePvz(c *gin.Context) {
@@ -58,39 +85,78 @@ func (p *pvzController) CreatePvz(c *gin.Context) {
 
 	if !CheckRole(c, "moderator") {
 		span.SetStatus(codes.Error, "unauthorized access")
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
 		return
 	}
 
 	var req dto.CreatePvzPostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid request body"})
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
 		return
 	}
 
-	pvzId, err := p.pvzSvc.CreatePvz(ctx, req.City)
+	pvzId, err := p.pvzSvc.CreatePvz(ctx, mapper.CreatePvzRequestToEntity(req))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Error{Message: err.Error()})
+		respondError(c, err, "failed to create PVZ")
 		return
 	}
 
 	c.JSON(http.StatusCreated, dto.CreatePvzResponse{PvzId: pvzId})
 }
 
+// UpdatePvz godoc
+// @Summary Update PVZ details
+// @Security BearerAuth
+// @Description Partially update address, coordinates, contact phone, status (active, suspended, closed) and opening hours of a PVZ. Closed PVZs do not accept new receptions. Only moderators can update a PVZ.
+// @Tags pvz
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID" example("pvz123")
+// @Param request body dto.UpdatePvzRequest true "Fields to update"
+// @Success 200 {object} dto.PvzDTO "Updated PVZ"
+// @Failure 400 {object} dto.Error "Invalid request body or invalid PVZ details"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "PVZ not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/{pvzId} [patch]
+func (p *pvzController) UpdatePvz(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	var req dto.UpdatePvzRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
+		return
+	}
+
+	pvz, err := p.pvzSvc.UpdatePvz(c, c.Param("pvzId"), mapper.UpdatePvzRequestToEntity(req))
+	if err != nil {
+		respondError(c, err, "failed to update PVZ")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.PvzEntityToDTO(*pvz))
+}
+
 // GetPvzsInfo godoc
 // @Summary Get PVZ information
 // @Security BearerAuth
-// @Description Retrieve a paginated list of PVZ with reception and product details.
+// @Description Retrieve a paginated list of PVZ with reception and product details, newest first. Pages can be requested by number (page/limit) or by the cursor returned in the X-Next-Cursor header, which is stable when PVZs are created while scrolling.
 // @Tags pvz
 // @Accept json
 // @Produce json
-// @Param page query int true "Page number" example(1)
+// @Param page query int false "Page number, required unless cursor is set" example(1)
 // @Param limit query int true "Number of items per page" example(10)
+// @Param cursor query string false "Opaque cursor from the X-Next-Cursor header of the previous page; page is ignored when set"
 // @Param startDate query string false "Filter: start date in RFC3339 format" example("2025-04-09T00:00:00Z")
 // @Param endDate query string false "Filter: end date in RFC3339 format" example("2025-04-09T23:59:59Z")
 // @Success 200 {array} dto.PvzGet200ResponseInner "List of PVZ information"
-// @Failure 400 {object} dto.Error "Invalid query parameters"
-// @Failure 401 {object} dto.Error "Unauthorized: missing token or insufficient privileges"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {boolean} X-Has-More "Whether there are more PVZs after this page"
+// @Failure 400 {object} dto.Error "Invalid query parameters or cursor"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
 // @Failure 500 {object} dto.Error "Internal server error"
 // @Router /pvz [get]
 func (p *pvzController) GetPvzsInfo(c *gin.Context) {
@@ -98,55 +164,18 @@ func (p *pvzController) GetPvzsInfo(c *gin.Context) {
 		return
 	}
 
-	pageStr := c.Query("page")
-	limitStr := c.Query("limit")
-	if pageStr == "" || limitStr == "" {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "missing page or limit parameter"})
-		return
-	}
-	page, err := strconv.Atoi(pageStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid page parameter"})
-		return
-	}
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid limit parameter"})
+	page, startDate, endDate, ok := parsePvzListQuery(c)
+	if !ok {
 		return
 	}
 
-	var startDate, endDate *time.Time
-	startDateStr := c.Query("startDate")
-	if startDateStr != "" {
-		t, err := time.Parse(time.RFC3339, startDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid startDate format"})
-			return
-		}
-		startDate = &t
-	}
-	endDateStr := c.Query("endDate")
-	if endDateStr != "" {
-		t, err := time.Parse(time.RFC3339, endDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid endDate format"})
-			return
-		}
-		endDate = &t
-	}
-
-	pvzInfos, err := p.pvzSvc.GetPvzsInfo(c, page, limit, startDate, endDate)
+	pvzPage, err := p.pvzSvc.GetPvzsInfo(c, page, startDate, endDate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Error{Message: fmt.Sprintf("failed to get PVZ info: %v", err)})
+		respondError(c, err, "failed to get PVZ info")
 		return
 	}
 
-	response := make([]dto.PvzGet200ResponseInner, 0, len(pvzInfos))
-	for _, info := range pvzInfos {
-		response = append(response, mapper.PvzInfoEntityToResponse(info))
-	}
-
-	c.JSON(http.StatusOK, response)
+	writePvzInfoPage(c, pvzPage)
 }
 
 // CreateReception godoc
@@ -159,7 +188,10 @@ func (p *pvzController) GetPvzsInfo(c *gin.Context) {
 // @Param request body dto.CreateReceptionRequest true "Reception creation data"
 // @Success 201 {object} dto.CreateReceptionResponse "Reception created, returning its ID"
 // @Failure 400 {object} dto.Error "Invalid request body"
-// @Failure 401 {object} dto.Error "Unauthorized: missing token or insufficient privileges"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "PVZ not found"
+// @Failure 409 {object} dto.Error "Open reception already exists for this PVZ or PVZ is closed"
 // @Failure 500 {object} dto.Error "Internal server error"
 // @Router /receptions [post]
 func (p *pvzController) CreateReception(c *gin.Context) {
@@ -169,7 +201,7 @@ func (p *pvzController) CreateReception(c *gin.Context) {
 
 	var req dto.CreateReceptionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid request body"})
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
 		return
 	}
 
@@ -178,9 +210,9 @@ func (p *pvzController) CreateReception(c *gin.Context) {
 		receptionTime = time.Now()
 	}
 
-	receptionID, err := p.pvzSvc.CreateReception(c, req.PvzId, receptionTime)
+	receptionID, err := p.pvzSvc.CreateReception(c, req.PvzId, receptionTime, c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Error{Message: err.Error()})
+		respondError(c, err, "failed to create reception")
 		return
 	}
 
@@ -190,14 +222,17 @@ func (p *pvzController) CreateReception(c *gin.Context) {
 // AddProduct godoc
 // @Summary Add a product to the current reception
 // @Security BearerAuth
-// @Description Add a new product to the last open reception for a given PVZ. Only employees can add products.
+// @Description Add a new product to the last open reception for a given PVZ. An optional barcode must be unique among products currently at PVZs. Only employees can add products.
 // @Tags pvz
 // @Accept json
 // @Produce json
 // @Param request body dto.ProductsPostRequest true "Product addition data"
 // @Success 201 {object} dto.ProductsPostResponse "Product added, returning its ID"
-// @Failure 400 {object} dto.Error "Invalid request body"
-// @Failure 401 {object} dto.Error "Unauthorized: missing token or insufficient privileges"
+// @Failure 400 {object} dto.Error "Invalid request body, product type is not allowed or invalid barcode"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 409 {object} dto.Error "Product with this barcode is already at a PVZ"
+// @Failure 422 {object} dto.Error "No open reception for this PVZ"
 // @Failure 500 {object} dto.Error "Internal server error"
 // @Router /products [post]
 func (p *pvzController) AddProduct(c *gin.Context) {
@@ -207,13 +242,13 @@ func (p *pvzController) AddProduct(c *gin.Context) {
 
 	var req dto.ProductsPostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid request body"})
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
 		return
 	}
 
-	productID, err := p.pvzSvc.AddProduct(c, req.PvzId, req.Type)
+	productID, err := p.pvzSvc.AddProduct(c, req.PvzId, req.Type, req.Barcode)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Error{Message: err.Error()})
+		respondError(c, err, "failed to add product")
 		return
 	}
 
@@ -228,9 +263,11 @@ func (p *pvzController) AddProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param pvzId path string true "PVZ ID" example("pvz123")
-// @Success 200 {object} dto.DeleteProductResponse "Product deletion success message"
+// @Success 200 {object} dto.DeleteProductResponse "Deleted product ID and barcode"
 // @Failure 400 {object} dto.Error "Bad request: missing pvzId"
-// @Failure 401 {object} dto.Error "Unauthorized: missing token or insufficient privileges"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 422 {object} dto.Error "No open reception or no products to delete"
 // @Failure 500 {object} dto.Error "Internal server error"
 // @Router /pvz/{pvzId}/delete_last_product [post]
 func (p *pvzController) DeleteLastProduct(c *gin.Context) {
@@ -240,16 +277,50 @@ func (p *pvzController) DeleteLastProduct(c *gin.Context) {
 
 	pvzId := c.Param("pvzId")
 	if pvzId == "" {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "pvzId parameter is required"})
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "pvzId parameter is required"})
 		return
 	}
 
-	if err := p.pvzSvc.DeleteLastProduct(c, pvzId); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Error{Message: err.Error()})
+	product, err := p.pvzSvc.DeleteLastProduct(c, pvzId)
+	if err != nil {
+		respondError(c, err, "failed to delete last product")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.DeleteProductResponse{Message: "product deleted successfully"})
+	c.JSON(http.StatusOK, dto.DeleteProductResponse{
+		Message:   "product deleted successfully",
+		ProductId: product.ID,
+		Barcode:   product.Barcode,
+	})
+}
+
+// GetProductByBarcode godoc
+// @Summary Find a product by its barcode
+// @Security BearerAuth
+// @Description Look up a product by scanned barcode or tracking number. If the barcode was reused, the product currently at a PVZ is returned, otherwise the most recent one. Available for employees and moderators.
+// @Tags pvz
+// @Accept json
+// @Produce json
+// @Param barcode path string true "Product barcode" example("4607001234567")
+// @Success 200 {object} dto.OrderDTO "Product with its PVZ and status"
+// @Failure 400 {object} dto.Error "Invalid barcode"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Product with this barcode not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /products/barcode/{barcode} [get]
+func (p *pvzController) GetProductByBarcode(c *gin.Context) {
+	if !CheckRole(c, "employee", "moderator") {
+		return
+	}
+
+	order, err := p.pvzSvc.FindProductByBarcode(c, c.Param("barcode"))
+	if err != nil {
+		respondError(c, err, "failed to find product by barcode")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.OrderEntityToDTO(*order))
 }
 
 // CloseReception godoc
@@ -262,7 +333,10 @@ func (p *pvzController) DeleteLastProduct(c *gin.Context) {
 // @Param pvzId path string true "PVZ ID" example("pvz123")
 // @Success 200 {object} dto.CloseReceptionResponse "Closed reception details with its ID"
 // @Failure 400 {object} dto.Error "Bad request: missing pvzId"
-// @Failure 401 {object} dto.Error "Unauthorized: missing token or insufficient privileges"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Reception not found"
+// @Failure 422 {object} dto.Error "No open reception for this PVZ"
 // @Failure 500 {object} dto.Error "Internal server error"
 // @Router /pvz/{pvzId}/close_last_reception [post]
 func (p *pvzController) CloseReception(c *gin.Context) {
@@ -272,13 +346,13 @@ func (p *pvzController) CloseReception(c *gin.Context) {
 
 	pvzId := c.Param("pvzId")
 	if pvzId == "" {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "pvzId parameter is required"})
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "pvzId parameter is required"})
 		return
 	}
 
-	receptionID, err := p.pvzSvc.CloseReception(c, pvzId)
+	receptionID, err := p.pvzSvc.CloseReception(c, pvzId, c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Error{Message: err.Error()})
+		respondError(c, err, "failed to close reception")
 		return
 	}
 
@@ -288,14 +362,14 @@ func (p *pvzController) CloseReception(c *gin.Context) {
 func CheckRole(c *gin.Context, allowedRoles ...string) bool {
 	roleVal, exists := c.Get("role")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.Error{Message: "missing role in token"})
+		c.JSON(http.StatusUnauthorized, dto.Error{Code: errs.ErrUnauthorizedCode, Message: "missing role in token"})
 		c.Abort()
 		return false
 	}
 
 	roleStr, ok := roleVal.(string)
 	if !ok {
-		c.JSON(http.StatusForbidden, dto.Error{Message: "invalid role type"})
+		c.JSON(http.StatusForbidden, dto.Error{Code: errs.ErrForbiddenCode, Message: "invalid role type"})
 		c.Abort()
 		return false
 	}
@@ -307,7 +381,7 @@ func CheckRole(c *gin.Context, allowedRoles ...string) bool {
 		}
 	}
 
-	c.JSON(http.StatusForbidden, dto.Error{Message: fmt.Sprintf("access denied, allowed roles: %v", allowedRoles)})
+	c.JSON(http.StatusForbidden, dto.Error{Code: errs.ErrForbiddenCode, Message: fmt.Sprintf("access denied, allowed roles: %v", allowedRoles)})
 	c.Abort()
 	return false
 }
@@ -315,17 +389,21 @@ func CheckRole(c *gin.Context, allowedRoles ...string) bool {
 // GetPvzsInfoOptimized godoc
 // @Summary Get PVZ info with receptions and products (optimized)
 // @Security BearerAuth
-// @Description Optimized method to retrieve a paginated list of PVZ with all related receptions and products.
+// @Description Optimized method to retrieve a paginated list of PVZ with all related receptions and products. Supports the same page/limit and cursor pagination as /pvz.
 // @Tags pvz
 // @Accept json
 // @Produce json
-// @Param page query int true "Page number" example(1)
+// @Param page query int false "Page number, required unless cursor is set" example(1)
 // @Param limit query int true "Number of items per page" example(10)
+// @Param cursor query string false "Opaque cursor from the X-Next-Cursor header of the previous page; page is ignored when set"
 // @Param startDate query string false "Filter: start date in RFC3339 format" example("2025-04-09T00:00:00Z")
 // @Param endDate query string false "Filter: end date in RFC3339 format" example("2025-04-09T23:59:59Z")
 // @Success 200 {array} dto.PvzGet200ResponseInner "List of PVZ information (optimized)"
-// @Failure 400 {object} dto.Error "Invalid query parameters"
-// @Failure 401 {object} dto.Error "Unauthorized: missing token or insufficient privileges"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {boolean} X-Has-More "Whether there are more PVZs after this page"
+// @Failure 400 {object} dto.Error "Invalid query parameters or cursor"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
 // @Failure 500 {object} dto.Error "Internal server error"
 // @Router /pvz/optimized [get]
 func (p *pvzController) GetPvzsInfoOptimized(c *gin.Context) {
@@ -333,53 +411,16 @@ func (p *pvzController) GetPvzsInfoOptimized(c *gin.Context) {
 		return
 	}
 
-	pageStr := c.Query("page")
-	limitStr := c.Query("limit")
-	if pageStr == "" || limitStr == "" {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "missing page or limit parameter"})
-		return
-	}
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page <= 0 {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid page parameter"})
-		return
-	}
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
-		c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid limit parameter"})
+	page, startDate, endDate, ok := parsePvzListQuery(c)
+	if !ok {
 		return
 	}
 
-	var startDate, endDate *time.Time
-	startDateStr := c.Query("startDate")
-	if startDateStr != "" {
-		t, err := time.Parse(time.RFC3339, startDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid startDate format"})
-			return
-		}
-		startDate = &t
-	}
-	endDateStr := c.Query("endDate")
-	if endDateStr != "" {
-		t, err := time.Parse(time.RFC3339, endDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, dto.Error{Message: "invalid endDate format"})
-			return
-		}
-		endDate = &t
-	}
-
-	pvzInfos, err := p.pvzSvc.GetPvzsInfoOptimized(c, page, limit, startDate, endDate)
+	pvzPage, err := p.pvzSvc.GetPvzsInfoOptimized(c, page, startDate, endDate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Error{Message: fmt.Sprintf("failed to get optimized PVZ info: %v", err)})
+		respondError(c, err, "failed to get optimized PVZ info")
 		return
 	}
 
-	response := make([]dto.PvzGet200ResponseInner, 0, len(pvzInfos))
-	for _, info := range pvzInfos {
-		response = append(response, mapper.PvzInfoEntityToResponse(info))
-	}
-
-	c.JSON(http.StatusOK, response)
+	writePvzInfoPage(c, pvzPage)
 }
@@ -10,11 +10,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/models/dto"
 	"order-pick-up-point/internal/models/entity"
 	"order-pick-up-point/internal/models/mapper"
 	mockPvzServ "order-pick-up-point/internal/service/http/mock"
-	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -133,7 +133,7 @@ func TestPvzController_CreatePvz(t *testing.T) {
 			simulateSvcError:   true,
 			svcErr:             errors.New("create pvz error"),
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedRespSubstr: "create pvz error",
+			expectedRespSubstr: "failed to create PVZ",
 		},
 		{
 			name:               "success",
@@ -173,12 +173,12 @@ func TestPvzController_CreatePvz(t *testing.T) {
 				if strings.HasPrefix(tc.requestBody, "{") {
 					if tc.simulateSvcError {
 						mockSvc.
-							On("CreatePvz", mock.Anything, "Moscow").
+							On("CreatePvz", mock.Anything, entity.Pvz{City: "Moscow"}).
 							Return("", tc.svcErr).
 							Once()
 					} else {
 						mockSvc.
-							On("CreatePvz", mock.Anything, "Moscow").
+							On("CreatePvz", mock.Anything, entity.Pvz{City: "Moscow"}).
 							Return(tc.expectedPvzID, nil).
 							Once()
 					}
@@ -234,6 +234,95 @@ func TestPvzController_CreatePvz(t *testing.T) {
 	}
 }
 
+func TestPvzController_UpdatePvz(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	closed := entity.PvzStatusClosed
+	phone := "+7 495 123-45-67"
+
+	tests := []struct {
+		name               string
+		role               string
+		requestBody        string
+		callSvc            bool
+		expectedUpdate     entity.PvzUpdate
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "employee cannot update PVZ",
+			role:               "employee",
+			requestBody:        `{"status": "closed"}`,
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+		{
+			name:               "unknown status",
+			role:               "moderator",
+			requestBody:        `{"status": "demolished"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid request body",
+		},
+		{
+			name:               "pvz not found",
+			role:               "moderator",
+			requestBody:        `{"status": "closed"}`,
+			callSvc:            true,
+			expectedUpdate:     entity.PvzUpdate{Status: &closed},
+			svcErr:             errs.New(errs.ErrPvzNotFound, "pvz not found"),
+			expectedStatusCode: http.StatusNotFound,
+			expectedRespSubstr: `"code":"PVZ_NOT_FOUND"`,
+		},
+		{
+			name:               "success",
+			role:               "moderator",
+			requestBody:        `{"status": "closed", "phone": "+7 495 123-45-67"}`,
+			callSvc:            true,
+			expectedUpdate:     entity.PvzUpdate{Status: &closed, Phone: &phone},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"status":"closed"`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest("PATCH", "/pvz/pvz1", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = req
+			c.Params = gin.Params{{Key: "pvzId", Value: "pvz1"}}
+			c.Set("role", tc.role)
+
+			mockSvc := mockPvzServ.NewPvzService(t)
+			if tc.callSvc {
+				var pvz *entity.Pvz
+				if tc.svcErr == nil {
+					pvz = &entity.Pvz{ID: "pvz1", City: "Moscow", Status: closed, Phone: phone}
+				}
+				mockSvc.
+					On("UpdatePvz", mock.Anything, "pvz1", tc.expectedUpdate).
+					Return(pvz, tc.svcErr).
+					Once()
+			}
+
+			NewPvzController(mockSvc).UpdatePvz(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
 func TestPvzController_GetPvzsInfo(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -257,18 +346,21 @@ func TestPvzController_GetPvzsInfo(t *testing.T) {
 		},
 	}
 
-	expectedResponse := make([]dto.PvzGet200ResponseInner, 0, len(dummyPvzInfos))
-	for _, info := range dummyPvzInfos {
-		expectedResponse = append(expectedResponse, mapper.PvzInfoEntityToResponse(info))
-	}
+	cursor := &entity.PvzCursor{RegistrationDate: time.Date(2025, time.April, 9, 12, 0, 0, 0, time.UTC), ID: "1d7e3a52-6b0c-4f8e-9a21-3c5d7e9f0b12"}
+	cursorToken := mapper.EncodePvzCursor(cursor)
 
 	tests := []struct {
-		name               string
-		queryParams        map[string]string
-		simulateError      bool
-		svcErr             error
+		name          string
+		queryParams   map[string]string
+		simulateError bool
+		svcErr        error
+		// expectedPage — ожидаемый запрос страницы; nil, если до сервиса дойти не должно
+		expectedPage       *entity.PvzPageRequest
+		svcPage            *entity.PvzInfoPage
 		expectedStatusCode int
 		expectedRespSubstr string
+		expectedHasMore    string
+		expectedNextCursor string
 	}{
 		{
 			name: "missing page parameter",
@@ -326,6 +418,7 @@ func TestPvzController_GetPvzsInfo(t *testing.T) {
 			},
 			simulateError:      true,
 			svcErr:             fmt.Errorf("database failure"),
+			expectedPage:       &entity.PvzPageRequest{Limit: 10},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedRespSubstr: "failed to get PVZ info",
 		},
@@ -339,8 +432,54 @@ func TestPvzController_GetPvzsInfo(t *testing.T) {
 			},
 			simulateError:      false,
 			svcErr:             nil,
+			expectedPage:       &entity.PvzPageRequest{Limit: 10},
+			svcPage:            &entity.PvzInfoPage{Items: dummyPvzInfos},
 			expectedStatusCode: http.StatusOK,
 			expectedRespSubstr: "pvz1",
+			expectedHasMore:    "false",
+		},
+		{
+			name: "page is converted to offset",
+			queryParams: map[string]string{
+				"page":  "3",
+				"limit": "2",
+			},
+			expectedPage:       &entity.PvzPageRequest{Offset: 4, Limit: 2},
+			svcPage:            &entity.PvzInfoPage{Items: dummyPvzInfos, NextCursor: cursor, HasMore: true},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: "pvz2",
+			expectedHasMore:    "true",
+			expectedNextCursor: cursorToken,
+		},
+		{
+			name: "cursor without page",
+			queryParams: map[string]string{
+				"cursor": cursorToken,
+				"limit":  "2",
+			},
+			expectedPage:       &entity.PvzPageRequest{Limit: 2, After: cursor},
+			svcPage:            &entity.PvzInfoPage{Items: dummyPvzInfos[:1]},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: "pvz1",
+			expectedHasMore:    "false",
+		},
+		{
+			name: "invalid cursor",
+			queryParams: map[string]string{
+				"cursor": "not-a-cursor",
+				"limit":  "2",
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: errs.ErrInvalidCursor,
+		},
+		{
+			name: "non-positive limit",
+			queryParams: map[string]string{
+				"page":  "1",
+				"limit": "0",
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid limit parameter",
 		},
 	}
 
@@ -365,22 +504,17 @@ func TestPvzController_GetPvzsInfo(t *testing.T) {
 
 			mockSvc := mockPvzServ.NewPvzService(t)
 
-			// Если параметры page и limit заданы, то извлекаем их
-			if pageStr, ok1 := tc.queryParams["page"]; ok1 && pageStr != "" {
-				if limitStr, ok2 := tc.queryParams["limit"]; ok2 && limitStr != "" && tc.expectedStatusCode != http.StatusBadRequest {
-					page, _ := strconv.Atoi(pageStr)
-					limit, _ := strconv.Atoi(limitStr)
-					if tc.simulateError {
-						mockSvc.
-							On("GetPvzsInfo", mock.Anything, page, limit, mock.Anything, mock.Anything).
-							Return(nil, tc.svcErr).
-							Once()
-					} else {
-						mockSvc.
-							On("GetPvzsInfo", mock.Anything, page, limit, mock.Anything, mock.Anything).
-							Return(dummyPvzInfos, nil).
-							Once()
-					}
+			if tc.expectedPage != nil {
+				if tc.simulateError {
+					mockSvc.
+						On("GetPvzsInfo", mock.Anything, *tc.expectedPage, mock.Anything, mock.Anything).
+						Return(nil, tc.svcErr).
+						Once()
+				} else {
+					mockSvc.
+						On("GetPvzsInfo", mock.Anything, *tc.expectedPage, mock.Anything, mock.Anything).
+						Return(tc.svcPage, nil).
+						Once()
 				}
 			}
 
@@ -395,6 +529,12 @@ func TestPvzController_GetPvzsInfo(t *testing.T) {
 			if !strings.Contains(respBody, tc.expectedRespSubstr) {
 				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, respBody)
 			}
+			if got := rr.Header().Get("X-Has-More"); got != tc.expectedHasMore {
+				t.Errorf("expected X-Has-More %q, got %q", tc.expectedHasMore, got)
+			}
+			if got := rr.Header().Get("X-Next-Cursor"); got != tc.expectedNextCursor {
+				t.Errorf("expected X-Next-Cursor %q, got %q", tc.expectedNextCursor, got)
+			}
 
 			mockSvc.AssertExpectations(t)
 		})
@@ -444,7 +584,7 @@ func TestPvzController_CreateReception(t *testing.T) {
 			simulateSvcError:   true,
 			svcErr:             errors.New("create reception error"),
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedRespSubstr: "create reception error",
+			expectedRespSubstr: "failed to create reception",
 		},
 		{
 			name:                "success",
@@ -469,21 +609,22 @@ func TestPvzController_CreateReception(t *testing.T) {
 			c, _ := gin.CreateTestContext(rr)
 			c.Request = req
 
-			// Если не unauthorized, устанавливаем роль "employee"
+			// Если не unauthorized, устанавливаем роль "employee" и ID сотрудника из токена
 			if !tc.unauthorized {
 				c.Set("role", "employee")
+				c.Set("userID", "emp1")
 			}
 
 			mockSvc := mockPvzServ.NewPvzService(t)
 			if !tc.unauthorized && tc.requestBody != "invalid json" {
 				if tc.simulateSvcError {
 					mockSvc.
-						On("CreateReception", mock.Anything, "pvz123", receptionTime).
+						On("CreateReception", mock.Anything, "pvz123", receptionTime, "emp1").
 						Return("", tc.svcErr).
 						Once()
 				} else {
 					mockSvc.
-						On("CreateReception", mock.Anything, "pvz123", receptionTime).
+						On("CreateReception", mock.Anything, "pvz123", receptionTime, "emp1").
 						Return(tc.expectedReceptionID, nil).
 						Once()
 				}
@@ -542,6 +683,7 @@ func TestPvzController_AddProduct(t *testing.T) {
 	tests := []struct {
 		name               string
 		requestBody        string
+		barcode            string // штрихкод, который контроллер должен передать в сервис
 		unauthorized       bool   // если true, роль не устанавливается
 		simulateSvcError   bool   // если true, p.pvzSvc.AddProduct возвращает ошибку
 		svcErr             error  // ошибка, которую возвращает сервис
@@ -570,7 +712,17 @@ func TestPvzController_AddProduct(t *testing.T) {
 			simulateSvcError:   true,
 			svcErr:             errors.New("add product error"),
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedRespSubstr: "add product error",
+			expectedRespSubstr: "failed to add product",
+		},
+		{
+			name:               "duplicate barcode",
+			requestBody:        `{"pvzId": "pvz123", "type": "electronics", "barcode": "4607001234567"}`,
+			barcode:            "4607001234567",
+			unauthorized:       false,
+			simulateSvcError:   true,
+			svcErr:             errs.New(errs.ErrDuplicateBarcode, "product with this barcode is already at a PVZ"),
+			expectedStatusCode: http.StatusConflict,
+			expectedRespSubstr: errs.ErrDuplicateBarcode,
 		},
 		{
 			name:               "success",
@@ -606,12 +758,12 @@ func TestPvzController_AddProduct(t *testing.T) {
 			if strings.HasPrefix(tc.requestBody, "{") && !tc.unauthorized {
 				if tc.simulateSvcError {
 					mockSvc.
-						On("AddProduct", mock.Anything, "pvz123", "electronics").
+						On("AddProduct", mock.Anything, "pvz123", "electronics", tc.barcode).
 						Return("", tc.svcErr).
 						Once()
 				} else {
 					mockSvc.
-						On("AddProduct", mock.Anything, "pvz123", "electronics").
+						On("AddProduct", mock.Anything, "pvz123", "electronics", tc.barcode).
 						Return(tc.expectedProductID, nil).
 						Once()
 				}
@@ -667,7 +819,7 @@ func TestPvzController_DeleteLastProduct(t *testing.T) {
 			simulateSvcError:   true,
 			svcErr:             errors.New("delete error"),
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedRespSubstr: "delete error",
+			expectedRespSubstr: "failed to delete last product",
 		},
 		{
 			name:               "success",
@@ -675,7 +827,7 @@ func TestPvzController_DeleteLastProduct(t *testing.T) {
 			unauthorized:       false,
 			simulateSvcError:   false,
 			expectedStatusCode: http.StatusOK,
-			expectedRespSubstr: "product deleted successfully",
+			expectedRespSubstr: `"barcode":"4607001234567"`,
 		},
 	}
 
@@ -708,12 +860,12 @@ func TestPvzController_DeleteLastProduct(t *testing.T) {
 				if tc.simulateSvcError {
 					mockSvc.
 						On("DeleteLastProduct", mock.Anything, tc.pvzId).
-						Return(errors.New("delete error")).
+						Return(nil, errors.New("delete error")).
 						Once()
 				} else {
 					mockSvc.
 						On("DeleteLastProduct", mock.Anything, tc.pvzId).
-						Return(nil).
+						Return(&entity.Product{ID: "prod789", Barcode: "4607001234567"}, nil).
 						Once()
 				}
 			}
@@ -768,7 +920,7 @@ func TestPvzController_CloseReception(t *testing.T) {
 			simulateSvcError:   true,
 			svcErr:             errors.New("close reception error"),
 			expectedStatusCode: http.StatusInternalServerError,
-			expectedRespSubstr: "close reception error",
+			expectedRespSubstr: "failed to close reception",
 		},
 		{
 			name:               "success",
@@ -797,22 +949,23 @@ func TestPvzController_CloseReception(t *testing.T) {
 				{Key: "pvzId", Value: tc.pathParam},
 			}
 
-			// Если сценарий авторизованный, устанавливаем роль "employee"
+			// Если сценарий авторизованный, устанавливаем роль "employee" и ID сотрудника из токена
 			if !tc.unauthorized {
 				c.Set("role", "employee")
+				c.Set("userID", "emp1")
 			}
 
 			mockSvc := mockPvzServ.NewPvzService(t)
 			if !tc.unauthorized && tc.pathParam != "" {
 				if tc.simulateSvcError {
 					mockSvc.
-						On("CloseReception", mock.Anything, tc.pathParam).
+						On("CloseReception", mock.Anything, tc.pathParam, "emp1").
 						Return("", tc.svcErr).
 						Once()
 				} else {
 					// В успешном сценарии сервис возвращает ID закрытой приёмки
 					mockSvc.
-						On("CloseReception", mock.Anything, tc.pathParam).
+						On("CloseReception", mock.Anything, tc.pathParam, "emp1").
 						Return(tc.pathParam, nil).
 						Once()
 				}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+const (
+	defaultExportFormat = "csv"
+	// pvzExportFlushRows — через сколько строк накопленная выгрузка отправляется клиенту
+	pvzExportFlushRows = 500
+)
+
+// ExportPvzHistory godoc
+// @Summary Export PVZ history
+// @Security BearerAuth
+// @Description Stream all PVZs with their receptions in the period and the products of those receptions as CSV, newline-delimited JSON or XLSX, one row per product (PVZs without receptions and receptions without products get a row with empty fields). Rows are read through a server-side cursor in a single consistent snapshot, so memory does not grow with the export size. XLSX is sent after the whole workbook is built and is split into sheets of 1048576 rows. If the export fails after the first row was sent, the connection is dropped instead of completing the response. Only moderators can export.
+// @Tags pvz
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format" Enums(csv, ndjson, xlsx) default(csv)
+// @Param startDate query string false "Start of the reception period in RFC3339 format" example("2025-04-01T00:00:00Z")
+// @Param endDate query string false "End of the reception period in RFC3339 format" example("2025-04-30T23:59:59Z")
+// @Success 200 {array} dto.PvzExportRowDTO "Export rows; CSV and XLSX have the same columns in snake_case"
+// @Failure 400 {object} dto.Error "Invalid format or dates"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/export [get]
+func (p *pvzController) ExportPvzHistory(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	var query dto.PvzExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid query parameters"})
+		return
+	}
+	if query.Format == "" {
+		query.Format = defaultExportFormat
+	}
+	format := pvzExportFormats[query.Format]
+
+	// Ответ начинается с первой строки: до неё ошибку ещё можно вернуть обычным JSON
+	var writer pvzExportWriter
+	start := func() error {
+		c.Header("Content-Type", format.contentType)
+		c.Header("Content-Disposition", `attachment; filename="pvz_export.`+query.Format+`"`)
+		c.Status(http.StatusOK)
+
+		var err error
+		writer, err = format.newWriter(c.Writer)
+		return err
+	}
+
+	rows := 0
+	filter := entity.PvzExportFilter{StartDate: query.StartDate, EndDate: query.EndDate}
+	err := p.pvzSvc.ExportPvzHistory(c, filter, func(row entity.PvzExportRow) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%pvzExportFlushRows == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil && writer == nil {
+		err = start()
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		if writer == nil {
+			respondError(c, err, "failed to export PVZ history")
+			return
+		}
+		abortStream(c, err)
+	}
+}
+
+// abortStream обрывает соединение, когда статус 200 и часть данных уже отправлены:
+// клиент увидит незавершённый ответ, а не примет обрезанную выгрузку за полную.
+func abortStream(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+	// gin паникует, если исходный ResponseWriter не умеет Hijack (HTTP/2, httptest)
+	defer func() { _ = recover() }()
+	if conn, _, hijackErr := c.Writer.Hijack(); hijackErr == nil {
+		_ = conn.Close()
+	}
+}
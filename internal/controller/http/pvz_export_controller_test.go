@@ -0,0 +1,184 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	mockPvzServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPvzController_ExportPvzHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	regDate := time.Date(2025, 4, 9, 12, 0, 0, 0, time.UTC)
+	recDate := regDate.Add(time.Hour)
+	rows := []entity.PvzExportRow{
+		{
+			PvzID: "pvz1", PvzRegistrationDate: regDate, PvzCity: "Moscow", PvzStatus: entity.PvzStatusActive,
+			ReceptionID: "rec1", ReceptionDateTime: &recDate, ReceptionStatus: "close",
+			ProductID: "prod1", ProductDateTime: &recDate, ProductType: "electronics", ProductBarcode: "4601234567890",
+		},
+		{PvzID: "pvz2", PvzRegistrationDate: regDate, PvzCity: "Kazan", PvzStatus: entity.PvzStatusActive},
+	}
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 4, 30, 23, 59, 59, 0, time.UTC)
+
+	// serve вызывает ExportPvzHistory; если svc не nil, сервис отдаёт rows и возвращает svcErr
+	serve := func(t *testing.T, role, query string, svc func(*mockPvzServ.PvzService)) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rr)
+		c.Request = httptest.NewRequest("GET", "/pvz/export?"+query, nil)
+		c.Set("role", role)
+
+		mockSvc := mockPvzServ.NewPvzService(t)
+		if svc != nil {
+			svc(mockSvc)
+		}
+		NewPvzController(mockSvc).ExportPvzHistory(c)
+		return rr
+	}
+	streamRows := func(filter entity.PvzExportFilter, rows []entity.PvzExportRow, svcErr error) func(*mockPvzServ.PvzService) {
+		return func(m *mockPvzServ.PvzService) {
+			m.On("ExportPvzHistory", mock.Anything, filter, mock.Anything).
+				Run(func(args mock.Arguments) {
+					fn := args.Get(2).(func(entity.PvzExportRow) error)
+					for _, row := range rows {
+						if err := fn(row); err != nil {
+							return
+						}
+					}
+				}).
+				Return(svcErr).
+				Once()
+		}
+	}
+
+	t.Run("csv by default", func(t *testing.T) {
+		t.Parallel()
+		filter := entity.PvzExportFilter{StartDate: &start, EndDate: &end}
+		rr := serve(t, "moderator", "startDate=2025-04-01T00:00:00Z&endDate=2025-04-30T23:59:59Z", streamRows(filter, rows, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		if cd := rr.Header().Get("Content-Disposition"); !strings.Contains(cd, "pvz_export.csv") {
+			t.Errorf("unexpected content disposition %q", cd)
+		}
+		expected := strings.Join(pvzExportColumns, ",") + "\n" +
+			"pvz1,2025-04-09T12:00:00Z,Moscow,,active,rec1,2025-04-09T13:00:00Z,close,prod1,2025-04-09T13:00:00Z,electronics,4601234567890\n" +
+			"pvz2,2025-04-09T12:00:00Z,Kazan,,active,,,,,,,\n"
+		if rr.Body.String() != expected {
+			t.Errorf("unexpected csv:\n%s", rr.Body.String())
+		}
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		t.Parallel()
+		rr := serve(t, "moderator", "format=ndjson", streamRows(entity.PvzExportFilter{}, rows, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rr.Code)
+		}
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %d", len(lines))
+		}
+		var first dto.PvzExportRowDTO
+		if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+			t.Fatalf("invalid json line: %v", err)
+		}
+		if first.PvzId != "pvz1" || first.ProductBarcode != "4601234567890" || first.ReceptionDateTime == nil {
+			t.Errorf("unexpected row: %+v", first)
+		}
+		if strings.Contains(lines[1], "receptionId") {
+			t.Errorf("empty reception fields must be omitted: %s", lines[1])
+		}
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		t.Parallel()
+		rr := serve(t, "moderator", "format=xlsx", streamRows(entity.PvzExportFilter{}, rows, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rr.Code)
+		}
+		f, err := excelize.OpenReader(bytes.NewReader(rr.Body.Bytes()))
+		if err != nil {
+			t.Fatalf("invalid xlsx: %v", err)
+		}
+		defer f.Close()
+		got, err := f.GetRows("Sheet1")
+		if err != nil {
+			t.Fatalf("failed to read rows: %v", err)
+		}
+		if len(got) != 3 || got[0][0] != "pvz_id" || got[1][0] != "pvz1" || got[2][2] != "Kazan" {
+			t.Errorf("unexpected rows: %v", got)
+		}
+	})
+
+	t.Run("empty export has header", func(t *testing.T) {
+		t.Parallel()
+		rr := serve(t, "moderator", "format=csv", streamRows(entity.PvzExportFilter{}, nil, nil))
+
+		if rr.Code != http.StatusOK || rr.Body.String() != strings.Join(pvzExportColumns, ",")+"\n" {
+			t.Errorf("unexpected response %d: %q", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("error before first row", func(t *testing.T) {
+		t.Parallel()
+		rr := serve(t, "moderator", "format=ndjson", streamRows(entity.PvzExportFilter{}, nil, errs.New(errs.ErrInvalidRequestCode, "startDate must not be after endDate")))
+
+		if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), errs.ErrInvalidRequestCode) {
+			t.Errorf("unexpected response %d: %s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("error after first row", func(t *testing.T) {
+		t.Parallel()
+		rr := serve(t, "moderator", "format=ndjson", streamRows(entity.PvzExportFilter{}, rows[:1], errors.New("connection lost")))
+
+		// статус уже отправлен, JSON с ошибкой в тело попасть не должен
+		if rr.Code != http.StatusOK || strings.Contains(rr.Body.String(), "failed to export") {
+			t.Errorf("unexpected response %d: %s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		t.Parallel()
+		rr := serve(t, "moderator", "format=xml", nil)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		t.Parallel()
+		rr := serve(t, "moderator", "startDate=2025-04-01", nil)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("forbidden for employee", func(t *testing.T) {
+		t.Parallel()
+		rr := serve(t, "employee", "format=csv", nil)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", rr.Code)
+		}
+	})
+}
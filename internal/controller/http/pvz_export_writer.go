@@ -0,0 +1,173 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	"time"
+)
+
+// pvzExportColumns — колонки выгрузки истории ПВЗ в CSV и XLSX.
+var pvzExportColumns = []string{
+	"pvz_id", "pvz_registration_date", "pvz_city", "pvz_address", "pvz_status",
+	"reception_id", "reception_date_time", "reception_status",
+	"product_id", "product_date_time", "product_type", "product_barcode",
+}
+
+// pvzExportWriter пишет строки выгрузки в поток ответа в одном из форматов.
+type pvzExportWriter interface {
+	Write(row entity.PvzExportRow) error
+	// Flush отправляет накопленные строки клиенту, если формат это позволяет.
+	Flush() error
+	// Close дописывает выгрузку до конца; после него писать нельзя.
+	Close() error
+}
+
+type pvzExportFormat struct {
+	contentType string
+	newWriter   func(w io.Writer) (pvzExportWriter, error)
+}
+
+var pvzExportFormats = map[string]pvzExportFormat{
+	"csv":    {contentType: "text/csv; charset=utf-8", newWriter: newCSVExportWriter},
+	"ndjson": {contentType: "application/x-ndjson", newWriter: newNDJSONExportWriter},
+	"xlsx":   {contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", newWriter: newXLSXExportWriter},
+}
+
+// pvzExportRecord раскладывает строку выгрузки по колонкам pvzExportColumns.
+func pvzExportRecord(row entity.PvzExportRow) []string {
+	return []string{
+		row.PvzID, row.PvzRegistrationDate.UTC().Format(time.RFC3339), row.PvzCity, row.PvzAddress, row.PvzStatus,
+		row.ReceptionID, formatExportTime(row.ReceptionDateTime), row.ReceptionStatus,
+		row.ProductID, formatExportTime(row.ProductDateTime), row.ProductType, row.ProductBarcode,
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) (pvzExportWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(pvzExportColumns); err != nil {
+		return nil, err
+	}
+	return &csvExportWriter{w: cw}, nil
+}
+
+func (e *csvExportWriter) Write(row entity.PvzExportRow) error {
+	return e.w.Write(pvzExportRecord(row))
+}
+
+func (e *csvExportWriter) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) Close() error {
+	return e.Flush()
+}
+
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONExportWriter(w io.Writer) (pvzExportWriter, error) {
+	return &ndjsonExportWriter{enc: json.NewEncoder(w)}, nil
+}
+
+func (e *ndjsonExportWriter) Write(row entity.PvzExportRow) error {
+	return e.enc.Encode(mapper.PvzExportRowEntityToDTO(row))
+}
+
+func (e *ndjsonExportWriter) Flush() error { return nil }
+
+func (e *ndjsonExportWriter) Close() error { return nil }
+
+// xlsxExportWriter пишет строки через StreamWriter excelize: он сбрасывает их во временный
+// файл, а не держит в памяти. XLSX — zip-архив, поэтому клиенту он уходит целиком в Close.
+// Лист Excel вмещает excelize.TotalRows строк, остальные строки переносятся на следующий лист.
+type xlsxExportWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	sheets int
+	row    int
+}
+
+func newXLSXExportWriter(w io.Writer) (pvzExportWriter, error) {
+	x := &xlsxExportWriter{out: w, file: excelize.NewFile()}
+	if err := x.nextSheet(); err != nil {
+		_ = x.file.Close()
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *xlsxExportWriter) nextSheet() error {
+	if x.stream != nil {
+		if err := x.stream.Flush(); err != nil {
+			return err
+		}
+	}
+
+	x.sheets++
+	name := "Sheet1"
+	if x.sheets > 1 {
+		name = fmt.Sprintf("Sheet%d", x.sheets)
+		if _, err := x.file.NewSheet(name); err != nil {
+			return err
+		}
+	}
+
+	stream, err := x.file.NewStreamWriter(name)
+	if err != nil {
+		return err
+	}
+	x.stream = stream
+	x.row = 0
+	return x.writeRecord(pvzExportColumns)
+}
+
+func (x *xlsxExportWriter) writeRecord(record []string) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(record))
+	for i, v := range record {
+		values[i] = v
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxExportWriter) Write(row entity.PvzExportRow) error {
+	if x.row >= excelize.TotalRows {
+		if err := x.nextSheet(); err != nil {
+			return err
+		}
+	}
+	return x.writeRecord(pvzExportRecord(row))
+}
+
+func (x *xlsxExportWriter) Flush() error { return nil }
+
+func (x *xlsxExportWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
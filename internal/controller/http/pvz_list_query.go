@@ -0,0 +1,91 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	"strconv"
+	"time"
+)
+
+// Заголовки ответа списков ПВЗ с курсорной пагинацией. Тело остаётся массивом ради совместимости.
+const (
+	headerNextCursor = "X-Next-Cursor"
+	headerHasMore    = "X-Has-More"
+)
+
+// parsePvzListQuery разбирает пагинацию (page/limit или cursor/limit) и фильтр по датам
+// списков ПВЗ. При ошибке ответ уже записан и возвращается false.
+func parsePvzListQuery(c *gin.Context) (entity.PvzPageRequest, *time.Time, *time.Time, bool) {
+	var page entity.PvzPageRequest
+
+	pageStr := c.Query("page")
+	limitStr := c.Query("limit")
+	cursor := c.Query("cursor")
+	if limitStr == "" || (pageStr == "" && cursor == "") {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "missing page or limit parameter"})
+		return page, nil, nil, false
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid limit parameter"})
+		return page, nil, nil, false
+	}
+	page.Limit = limit
+
+	if cursor != "" {
+		after, err := mapper.DecodePvzCursor(cursor)
+		if err != nil {
+			respondError(c, err, "invalid cursor")
+			return page, nil, nil, false
+		}
+		page.After = after
+	} else {
+		pageNum, err := strconv.Atoi(pageStr)
+		if err != nil || pageNum <= 0 {
+			c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid page parameter"})
+			return page, nil, nil, false
+		}
+		page.Offset = (pageNum - 1) * limit
+	}
+
+	var startDate, endDate *time.Time
+	startDateStr := c.Query("startDate")
+	if startDateStr != "" {
+		t, err := time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid startDate format"})
+			return page, nil, nil, false
+		}
+		startDate = &t
+	}
+	endDateStr := c.Query("endDate")
+	if endDateStr != "" {
+		t, err := time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid endDate format"})
+			return page, nil, nil, false
+		}
+		endDate = &t
+	}
+
+	return page, startDate, endDate, true
+}
+
+// writePvzInfoPage отдаёт страницу ПВЗ массивом, а курсор и признак продолжения — заголовками.
+func writePvzInfoPage(c *gin.Context, page *entity.PvzInfoPage) {
+	if page.NextCursor != nil {
+		c.Header(headerNextCursor, mapper.EncodePvzCursor(page.NextCursor))
+	}
+	c.Header(headerHasMore, strconv.FormatBool(page.HasMore))
+
+	response := make([]dto.PvzGet200ResponseInner, 0, len(page.Items))
+	for _, info := range page.Items {
+		response = append(response, mapper.PvzInfoEntityToResponse(info))
+	}
+	c.JSON(http.StatusOK, response)
+}
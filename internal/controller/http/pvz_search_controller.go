@@ -0,0 +1,53 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/mapper"
+)
+
+// SearchNearbyPvzs godoc
+// @Summary Find PVZs near a point
+// @Security BearerAuth
+// @Description Find PVZs within a radius of the given coordinates, sorted by distance. Each result contains the distance in meters and whether the PVZ is open right now by its opening hours (Moscow time). PVZs without coordinates are never returned.
+// @Tags pvz
+// @Accept json
+// @Produce json
+// @Param lat query number true "Latitude of the point" example(55.7558)
+// @Param lon query number true "Longitude of the point" example(37.6173)
+// @Param radius query number false "Search radius in meters (default 5000, max 100000)" example(3000)
+// @Param city query string false "Filter by city" example("Moscow")
+// @Param status query string false "Filter by PVZ status" Enums(active, suspended, closed)
+// @Param limit query int false "Maximum number of results (default 20, max 100)" example(10)
+// @Success 200 {array} dto.NearbyPvzDTO "PVZs sorted by distance"
+// @Failure 400 {object} dto.Error "Invalid coordinates, radius, limit, city or status"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/nearby [get]
+func (p *pvzController) SearchNearbyPvzs(c *gin.Context) {
+	if !CheckRole(c, "client", "employee", "moderator") {
+		return
+	}
+
+	var query dto.NearbyPvzQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid query parameters"})
+		return
+	}
+
+	pvzs, err := p.pvzSvc.SearchNearbyPvzs(c, mapper.NearbyPvzQueryToFilter(query))
+	if err != nil {
+		respondError(c, err, "failed to search PVZs")
+		return
+	}
+
+	response := make([]dto.NearbyPvzDTO, 0, len(pvzs))
+	for _, pvz := range pvzs {
+		response = append(response, mapper.NearbyPvzEntityToDTO(pvz))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
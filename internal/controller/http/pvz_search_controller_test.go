@@ -0,0 +1,90 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockPvzServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+)
+
+func TestPvzController_SearchNearbyPvzs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		query              string
+		callSvc            bool
+		expectedFilter     entity.NearbyPvzFilter
+		svcResult          []entity.NearbyPvz
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "missing coordinates",
+			query:              "radius=1000",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid query parameters",
+		},
+		{
+			name:               "latitude out of range",
+			query:              "lat=95&lon=37.6",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid query parameters",
+		},
+		{
+			name:               "city not allowed",
+			query:              "lat=55.75&lon=37.61&city=London",
+			callSvc:            true,
+			expectedFilter:     entity.NearbyPvzFilter{Latitude: 55.75, Longitude: 37.61, City: "London"},
+			svcErr:             errs.New(errs.ErrInvalidCity, "city 'London' is not allowed"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: `"code":"INVALID_CITY"`,
+		},
+		{
+			name:           "success",
+			query:          "lat=0&lon=37.61&radius=2500&status=active&limit=5",
+			callSvc:        true,
+			expectedFilter: entity.NearbyPvzFilter{Longitude: 37.61, RadiusMeters: 2500, Status: "active", Limit: 5},
+			svcResult: []entity.NearbyPvz{
+				{Pvz: entity.Pvz{ID: "pvz1", City: "Moscow", Status: "active"}, DistanceMeters: 742.5, OpenNow: true},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"distanceMeters":742.5,"openNow":true`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("GET", "/pvz/nearby?"+tc.query, nil)
+			c.Set("role", "client")
+
+			mockSvc := mockPvzServ.NewPvzService(t)
+			if tc.callSvc {
+				mockSvc.
+					On("SearchNearbyPvzs", mock.Anything, tc.expectedFilter).
+					Return(tc.svcResult, tc.svcErr).
+					Once()
+			}
+
+			NewPvzController(mockSvc).SearchNearbyPvzs(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
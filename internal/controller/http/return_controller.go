@@ -0,0 +1,151 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/mapper"
+)
+
+// CreateReturn godoc
+// @Summary Open a return shipment
+// @Security BearerAuth
+// @Description Open a new return shipment to the seller for a specified PVZ. Only one return shipment can be open per PVZ. Only employees can open return shipments.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateReturnRequest true "Return shipment creation data"
+// @Success 201 {object} dto.CreateReturnResponse "Return shipment opened, returning its ID"
+// @Failure 400 {object} dto.Error "Invalid request body"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "PVZ not found"
+// @Failure 409 {object} dto.Error "Open return shipment already exists for this PVZ"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /returns [post]
+func (p *pvzController) CreateReturn(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	var req dto.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
+		return
+	}
+
+	returnID, err := p.pvzSvc.CreateReturn(c, req.PvzId)
+	if err != nil {
+		respondError(c, err, "failed to create return shipment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreateReturnResponse{ReturnId: returnID})
+}
+
+// AddReturnItem godoc
+// @Summary Add a product to the current return shipment
+// @Security BearerAuth
+// @Description Move a refused, expired or damaged product of the PVZ into its open return shipment. Issued products cannot be returned. Only employees can add return items.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param request body dto.AddReturnItemRequest true "Product and return reason"
+// @Success 201 {object} dto.ReturnItemDTO "Product added to the return shipment"
+// @Failure 400 {object} dto.Error "Invalid request body or return reason"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Product not found in this PVZ"
+// @Failure 409 {object} dto.Error "Product status does not allow returning it"
+// @Failure 422 {object} dto.Error "No open return shipment for this PVZ"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /returns/items [post]
+func (p *pvzController) AddReturnItem(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	var req dto.AddReturnItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
+		return
+	}
+
+	item, err := p.pvzSvc.AddReturnItem(c, req.PvzId, req.ProductId, req.Reason)
+	if err != nil {
+		respondError(c, err, "failed to add return item")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ReturnItemEntityToDTO(*item))
+}
+
+// DeleteLastReturnItem godoc
+// @Summary Delete the last added product from the current return shipment
+// @Security BearerAuth
+// @Description Remove the last product added to the open return shipment of a PVZ (LIFO order) and restore its previous status. Only employees can delete return items.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID" example("pvz123")
+// @Success 200 {object} dto.DeleteReturnItemResponse "Return item deletion success message"
+// @Failure 400 {object} dto.Error "Bad request: missing pvzId"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 422 {object} dto.Error "No open return shipment or no items to delete"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/{pvzId}/delete_last_return_item [post]
+func (p *pvzController) DeleteLastReturnItem(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	pvzId := c.Param("pvzId")
+	if pvzId == "" {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "pvzId parameter is required"})
+		return
+	}
+
+	if err := p.pvzSvc.DeleteLastReturnItem(c, pvzId); err != nil {
+		respondError(c, err, "failed to delete last return item")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.DeleteReturnItemResponse{Message: "return item deleted successfully"})
+}
+
+// CloseReturn godoc
+// @Summary Close the current return shipment
+// @Security BearerAuth
+// @Description Close the open return shipment of a PVZ; all its products are marked as shipped back to the seller. Only employees can close return shipments.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID" example("pvz123")
+// @Success 200 {object} dto.CloseReturnResponse "Closed return shipment with its ID"
+// @Failure 400 {object} dto.Error "Bad request: missing pvzId"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 422 {object} dto.Error "No open return shipment for this PVZ"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /pvz/{pvzId}/close_last_return [post]
+func (p *pvzController) CloseReturn(c *gin.Context) {
+	if !CheckRole(c, "employee") {
+		return
+	}
+
+	pvzId := c.Param("pvzId")
+	if pvzId == "" {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "pvzId parameter is required"})
+		return
+	}
+
+	returnID, err := p.pvzSvc.CloseReturn(c, pvzId)
+	if err != nil {
+		respondError(c, err, "failed to close return shipment")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CloseReturnResponse{ReturnId: returnID})
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockPvzServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+)
+
+func TestPvzController_AddReturnItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		role               string
+		requestBody        string
+		callSvc            bool
+		svcItem            *entity.ReturnItem
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "moderator cannot add return items",
+			role:               "moderator",
+			requestBody:        `{"pvzId": "pvz1", "productId": "prod1", "reason": "refused"}`,
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+		{
+			name:               "missing reason",
+			role:               "employee",
+			requestBody:        `{"pvzId": "pvz1", "productId": "prod1"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid request body",
+		},
+		{
+			name:               "no open return",
+			role:               "employee",
+			requestBody:        `{"pvzId": "pvz1", "productId": "prod1", "reason": "refused"}`,
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrNoOpenReturn, "open return shipment not found"),
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedRespSubstr: `"code":"NO_OPEN_RETURN"`,
+		},
+		{
+			name:               "success",
+			role:               "employee",
+			requestBody:        `{"pvzId": "pvz1", "productId": "prod1", "reason": "refused"}`,
+			callSvc:            true,
+			svcItem:            &entity.ReturnItem{ID: "item1", ReturnID: "ret1", ProductID: "prod1", Reason: entity.ReturnReasonRefused},
+			expectedStatusCode: http.StatusCreated,
+			expectedRespSubstr: `"reason":"refused"`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest("POST", "/returns/items", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = req
+			c.Set("role", tc.role)
+
+			mockSvc := mockPvzServ.NewPvzService(t)
+			if tc.callSvc {
+				mockSvc.
+					On("AddReturnItem", mock.Anything, "pvz1", "prod1", "refused").
+					Return(tc.svcItem, tc.svcErr).
+					Once()
+			}
+
+			NewPvzController(mockSvc).AddReturnItem(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestPvzController_CloseReturn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest("POST", "/pvz/pvz1/close_last_return", nil)
+	c.Params = gin.Params{{Key: "pvzId", Value: "pvz1"}}
+	c.Set("role", "employee")
+
+	mockSvc := mockPvzServ.NewPvzService(t)
+	mockSvc.On("CloseReturn", mock.Anything, "pvz1").Return("ret1", nil).Once()
+
+	NewPvzController(mockSvc).CloseReturn(c)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), `"returnId":"ret1"`) {
+		t.Errorf("expected return id in response, got %q", rr.Body.String())
+	}
+}
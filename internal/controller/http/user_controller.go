@@ -0,0 +1,252 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/mapper"
+	"order-pick-up-point/pkg/validator"
+	"strconv"
+)
+
+// ListUsers godoc
+// @Summary List users
+// @Security BearerAuth
+// @Description Returns users from newest to oldest. Pass the X-Next-Cursor header value as cursor to get the next page. Available only for moderators.
+// @Tags users
+// @Produce json
+// @Param email query string false "Case-insensitive substring of the email" example("example.com")
+// @Param role query string false "Filter by role" example("employee")
+// @Param active query bool false "Filter by account status"
+// @Param limit query int false "Page size, 50 by default, at most 500" example(50)
+// @Param cursor query string false "Cursor from X-Next-Cursor of the previous page"
+// @Success 200 {array} dto.User "Users"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {boolean} X-Has-More "Whether there are older users after this page"
+// @Failure 400 {object} dto.Error "Invalid filters or cursor"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /users [get]
+func (a *authController) ListUsers(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	var query dto.UserQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid query parameters"})
+		return
+	}
+	filter := mapper.UserQueryToFilter(query)
+
+	after, err := mapper.DecodeUserCursor(query.Cursor)
+	if err != nil {
+		respondError(c, err, "invalid cursor")
+		return
+	}
+	filter.After = after
+
+	page, err := a.authSvc.ListUsers(c, filter)
+	if err != nil {
+		respondError(c, err, "failed to list users")
+		return
+	}
+
+	if page.NextCursor != nil {
+		c.Header(headerNextCursor, mapper.EncodeUserCursor(page.NextCursor))
+	}
+	c.Header(headerHasMore, strconv.FormatBool(page.HasMore))
+
+	response := make([]dto.User, 0, len(page.Items))
+	for _, u := range page.Items {
+		response = append(response, mapper.UserEntityToDTO(u))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Security BearerAuth
+// @Description Returns a user by ID. Available only for moderators.
+// @Tags users
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} dto.User "User"
+// @Failure 400 {object} dto.Error "Invalid user ID"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "User not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /users/{userId} [get]
+func (a *authController) GetUser(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	user, err := a.authSvc.GetUser(c, c.Param("userId"))
+	if err != nil {
+		respondError(c, err, "failed to get user")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.UserEntityToDTO(*user))
+}
+
+// ChangeUserRole godoc
+// @Summary Change user role
+// @Security BearerAuth
+// @Description Changes the role of a user. All sessions of the user are revoked, so the new role applies from the next login. Moderators cannot change their own role. Available only for moderators.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dto.ChangeRoleRequest true "New role"
+// @Success 200 {object} dto.User "Updated user"
+// @Failure 400 {object} dto.Error "Invalid user ID, request body or role, or an attempt to change the own role"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "User not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /users/{userId}/role [patch]
+func (a *authController) ChangeUserRole(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	var req dto.ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{
+			Code:    errs.ErrInvalidRequestCode,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	user, err := a.authSvc.ChangeUserRole(c, c.Param("userId"), req.Role)
+	if err != nil {
+		respondError(c, err, "failed to change user role")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.UserEntityToDTO(*user))
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate user
+// @Security BearerAuth
+// @Description Deactivates a user account: all sessions of the user are revoked, and login and token refresh are rejected until the account is reactivated. Moderators cannot deactivate their own account. Available only for moderators.
+// @Tags users
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} dto.User "Updated user"
+// @Failure 400 {object} dto.Error "Invalid user ID or an attempt to deactivate the own account"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "User not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /users/{userId}/deactivate [post]
+func (a *authController) DeactivateUser(c *gin.Context) {
+	a.setUserActive(c, false)
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate user
+// @Security BearerAuth
+// @Description Reactivates a deactivated user account. Available only for moderators.
+// @Tags users
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} dto.User "Updated user"
+// @Failure 400 {object} dto.Error "Invalid user ID or an attempt to reactivate the own account"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "User not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /users/{userId}/reactivate [post]
+func (a *authController) ReactivateUser(c *gin.Context) {
+	a.setUserActive(c, true)
+}
+
+func (a *authController) setUserActive(c *gin.Context, active bool) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	user, err := a.authSvc.SetUserActive(c, c.Param("userId"), active)
+	if err != nil {
+		respondError(c, err, "failed to update user status")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.UserEntityToDTO(*user))
+}
+
+// ResetUserPassword godoc
+// @Summary Force password reset
+// @Security BearerAuth
+// @Description Replaces the user's password with a temporary one and returns it once. All sessions of the user are revoked and the login lockout of the user's email is lifted. Tokens issued for the temporary password carry passwordChangeRequired until the user changes the password via /my/password. Available only for moderators.
+// @Tags users
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} dto.PasswordResetResponse "Temporary password"
+// @Failure 400 {object} dto.Error "Invalid user ID"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "User not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /users/{userId}/reset_password [post]
+func (a *authController) ResetUserPassword(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	temporary, err := a.authSvc.ResetUserPassword(c, c.Param("userId"))
+	if err != nil {
+		respondError(c, err, "failed to reset password")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, dto.PasswordResetResponse{TemporaryPassword: temporary})
+}
+
+// ChangePassword godoc
+// @Summary Change own password
+// @Security BearerAuth
+// @Description Changes the password of the current user. The current session stays valid, all other sessions of the user are revoked. A wrong current password counts as a failed login for the user's email, so repeated failures are delayed and locked like login attempts and get 429 with the Retry-After header. Not available for dummy tokens.
+// @Tags users
+// @Accept json
+// @Param request body dto.ChangePasswordRequest true "Current and new password"
+// @Success 204 "Password changed"
+// @Failure 400 {object} dto.Error "Invalid request body, new password or wrong current password"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: dummy token"
+// @Failure 429 {object} dto.Error "Too many failed attempts, retry after the Retry-After header value"
+// @Header 429 {integer} Retry-After "Seconds until the next attempt is allowed"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /my/password [post]
+func (a *authController) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{
+			Code:    errs.ErrInvalidRequestCode,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	if err := validator.ValidatePassword(req.NewPassword); err != nil {
+		respondError(c, err, "invalid password")
+		return
+	}
+
+	if err := a.authSvc.ChangePassword(c, req.CurrentPassword, req.NewPassword); err != nil {
+		setRetryAfter(c, err)
+		respondError(c, err, "failed to change password")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,500 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	http2 "order-pick-up-point/internal/service/http"
+	mockAuthServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testUserID = "6f1c2a4e-8a57-4d4b-9a4f-2b0e5d1c9e01"
+
+func TestAuthController_ListUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	created := time.Date(2025, 5, 8, 9, 0, 0, 0, time.UTC)
+	after := &entity.UserCursor{CreatedAt: created.Add(time.Hour), ID: "6f1c2a4e-8a57-4d4b-9a4f-2b0e5d1c9e02"}
+	next := &entity.UserCursor{CreatedAt: created, ID: testUserID}
+	inactive := false
+
+	tests := []struct {
+		name               string
+		path               string
+		role               string
+		expectedFilter     *entity.UserFilter
+		svcResult          *entity.UserPage
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+		expectedCursor     string
+	}{
+		{
+			name:           "filters and next page",
+			path:           "/users?email=example&role=employee&active=false&limit=1&cursor=" + mapper.EncodeUserCursor(after),
+			role:           "moderator",
+			expectedFilter: &entity.UserFilter{Email: "example", Role: "employee", Active: &inactive, Limit: 1, After: after},
+			svcResult: &entity.UserPage{
+				Items:      []entity.User{{ID: testUserID, Email: "user@example.com", Role: "employee", CreatedAt: created}},
+				NextCursor: next,
+				HasMore:    true,
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"email":"user@example.com"`,
+			expectedCursor:     mapper.EncodeUserCursor(next),
+		},
+		{
+			name:               "no users",
+			path:               "/users",
+			role:               "moderator",
+			expectedFilter:     &entity.UserFilter{},
+			svcResult:          &entity.UserPage{},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `[]`,
+		},
+		{
+			name:               "invalid role",
+			path:               "/users?role=admin",
+			role:               "moderator",
+			expectedFilter:     &entity.UserFilter{Role: "admin"},
+			svcErr:             errs.New(errs.ErrInvalidRoleCode, "role 'admin' is not allowed"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "is not allowed",
+		},
+		{
+			name:               "service error",
+			path:               "/users",
+			role:               "moderator",
+			expectedFilter:     &entity.UserFilter{},
+			svcErr:             errors.New("db error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedRespSubstr: "failed to list users",
+		},
+		{
+			name:               "invalid cursor",
+			path:               "/users?cursor=broken!",
+			role:               "moderator",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: `"code":"INVALID_CURSOR"`,
+		},
+		{
+			name:               "invalid active flag",
+			path:               "/users?active=maybe",
+			role:               "moderator",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid query parameters",
+		},
+		{
+			name:               "employee forbidden",
+			path:               "/users",
+			role:               "employee",
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("GET", tc.path, nil)
+			c.Set("role", tc.role)
+
+			mockAuthSvc := mockAuthServ.NewAuthService(t)
+			if tc.expectedFilter != nil {
+				mockAuthSvc.
+					On("ListUsers", mock.Anything, *tc.expectedFilter).
+					Return(tc.svcResult, tc.svcErr).
+					Once()
+			}
+
+			NewAuthController(mockAuthSvc).ListUsers(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+			if got := rr.Header().Get("X-Next-Cursor"); got != tc.expectedCursor {
+				t.Errorf("expected cursor %q, got %q", tc.expectedCursor, got)
+			}
+		})
+	}
+}
+
+func TestAuthController_GetUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		role               string
+		callSvc            bool
+		svcResult          *entity.User
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "not a moderator",
+			role:               "client",
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+		{
+			name:               "user not found",
+			role:               "moderator",
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrUserNotFound, "user not found"),
+			expectedStatusCode: http.StatusNotFound,
+			expectedRespSubstr: "user not found",
+		},
+		{
+			name:               "success",
+			role:               "moderator",
+			callSvc:            true,
+			svcResult:          &entity.User{ID: testUserID, Email: "user@example.com", Role: "client", Active: true},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"active":true`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("GET", "/users/"+testUserID, nil)
+			c.Params = gin.Params{{Key: "userId", Value: testUserID}}
+			c.Set("role", tc.role)
+
+			mockAuthSvc := mockAuthServ.NewAuthService(t)
+			if tc.callSvc {
+				mockAuthSvc.On("GetUser", mock.Anything, testUserID).Return(tc.svcResult, tc.svcErr).Once()
+			}
+
+			NewAuthController(mockAuthSvc).GetUser(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthController_ChangeUserRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		role               string
+		requestBody        string
+		callSvc            bool
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "not a moderator",
+			role:               "employee",
+			requestBody:        `{"role":"moderator"}`,
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+		{
+			name:               "missing role",
+			role:               "moderator",
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid request body",
+		},
+		{
+			name:               "role is not allowed",
+			role:               "moderator",
+			requestBody:        `{"role":"admin"}`,
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrInvalidRoleCode, "role 'admin' is not allowed"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "INVALID_ROLE",
+		},
+		{
+			name:               "success",
+			role:               "moderator",
+			requestBody:        `{"role":"employee"}`,
+			callSvc:            true,
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"role":"employee"`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("PATCH", "/users/"+testUserID+"/role", strings.NewReader(tc.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "userId", Value: testUserID}}
+			c.Set("role", tc.role)
+
+			mockAuthSvc := mockAuthServ.NewAuthService(t)
+			if tc.callSvc {
+				var user *entity.User
+				if tc.svcErr == nil {
+					user = &entity.User{ID: testUserID, Email: "user@example.com", Role: "employee", Active: true}
+				}
+				mockAuthSvc.On("ChangeUserRole", mock.Anything, testUserID, mock.Anything).Return(user, tc.svcErr).Once()
+			}
+
+			NewAuthController(mockAuthSvc).ChangeUserRole(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthController_SetUserActive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		role               string
+		active             bool
+		callSvc            bool
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "not a moderator",
+			role:               "employee",
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+		{
+			name:               "own account",
+			role:               "moderator",
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrInvalidRequestCode, "moderators cannot change the role or status of their own account"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "own account",
+		},
+		{
+			name:               "deactivate",
+			role:               "moderator",
+			callSvc:            true,
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"active":false`,
+		},
+		{
+			name:               "reactivate",
+			role:               "moderator",
+			active:             true,
+			callSvc:            true,
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"active":true`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("POST", "/users/"+testUserID+"/deactivate", nil)
+			c.Params = gin.Params{{Key: "userId", Value: testUserID}}
+			c.Set("role", tc.role)
+
+			mockAuthSvc := mockAuthServ.NewAuthService(t)
+			if tc.callSvc {
+				var user *entity.User
+				if tc.svcErr == nil {
+					user = &entity.User{ID: testUserID, Email: "user@example.com", Role: "client", Active: tc.active}
+				}
+				mockAuthSvc.On("SetUserActive", mock.Anything, testUserID, tc.active).Return(user, tc.svcErr).Once()
+			}
+
+			ctrl := NewAuthController(mockAuthSvc)
+			if tc.active {
+				ctrl.ReactivateUser(c)
+			} else {
+				ctrl.DeactivateUser(c)
+			}
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthController_ResetUserPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		role               string
+		callSvc            bool
+		svcResult          string
+		svcErr             error
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:               "not a moderator",
+			role:               "client",
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+		{
+			name:               "service error",
+			role:               "moderator",
+			callSvc:            true,
+			svcErr:             errors.New("db error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedRespSubstr: "failed to reset password",
+		},
+		{
+			name:               "success",
+			role:               "moderator",
+			callSvc:            true,
+			svcResult:          "temporary-password",
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `{"temporaryPassword":"temporary-password"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("POST", "/users/"+testUserID+"/reset_password", nil)
+			c.Params = gin.Params{{Key: "userId", Value: testUserID}}
+			c.Set("role", tc.role)
+
+			mockAuthSvc := mockAuthServ.NewAuthService(t)
+			if tc.callSvc {
+				mockAuthSvc.On("ResetUserPassword", mock.Anything, testUserID).Return(tc.svcResult, tc.svcErr).Once()
+			}
+
+			NewAuthController(mockAuthSvc).ResetUserPassword(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthController_ChangePassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		requestBody        string
+		callSvc            bool
+		svcErr             error
+		expectedStatusCode int
+		expectedRetryAfter string
+	}{
+		{
+			name:               "missing fields",
+			requestBody:        `{"newPassword":"newpassword456"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "weak new password",
+			requestBody:        `{"currentPassword":"oldpassword123","newPassword":"123"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "wrong current password",
+			requestBody:        `{"currentPassword":"wrong","newPassword":"newpassword456"}`,
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrInvalidCurrentPassword, "current password is invalid"),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "too many wrong current passwords",
+			requestBody: `{"currentPassword":"wrong","newPassword":"newpassword456"}`,
+			callSvc:     true,
+			svcErr: errs.Wrap(&http2.LoginBlockedError{RetryAfter: 1500 * time.Millisecond},
+				errs.ErrTooManyLoginAttempts, "too many failed login attempts, try again later"),
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+		},
+		{
+			name:               "dummy token",
+			requestBody:        `{"currentPassword":"oldpassword123","newPassword":"newpassword456"}`,
+			callSvc:            true,
+			svcErr:             errs.New(errs.ErrForbiddenCode, "password change is not available for dummy tokens"),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "success",
+			requestBody:        `{"currentPassword":"oldpassword123","newPassword":"newpassword456"}`,
+			callSvc:            true,
+			expectedStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("POST", "/my/password", strings.NewReader(tc.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			mockAuthSvc := mockAuthServ.NewAuthService(t)
+			if tc.callSvc {
+				mockAuthSvc.On("ChangePassword", mock.Anything, mock.Anything, "newpassword456").Return(tc.svcErr).Once()
+			}
+
+			NewAuthController(mockAuthSvc).ChangePassword(c)
+
+			if c.Writer.Status() != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, c.Writer.Status())
+			}
+			if retryAfter := rr.Header().Get("Retry-After"); retryAfter != tc.expectedRetryAfter {
+				t.Errorf("expected Retry-After %q, got %q", tc.expectedRetryAfter, retryAfter)
+			}
+		})
+	}
+}
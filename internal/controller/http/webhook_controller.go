@@ -0,0 +1,325 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/models/mapper"
+	http2 "order-pick-up-point/internal/service/http"
+	"strconv"
+)
+
+type WebhookController interface {
+	CreateWebhook(c *gin.Context)
+	ListWebhooks(c *gin.Context)
+	GetWebhook(c *gin.Context)
+	UpdateWebhook(c *gin.Context)
+	DeleteWebhook(c *gin.Context)
+	GetWebhookDeliveries(c *gin.Context)
+	GetWebhookDelivery(c *gin.Context)
+	RetryWebhookDelivery(c *gin.Context)
+}
+
+type webhookController struct {
+	webhookSvc http2.WebhookService
+}
+
+func NewWebhookController(webhookSvc http2.WebhookService) WebhookController {
+	return &webhookController{
+		webhookSvc: webhookSvc,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook subscription
+// @Security BearerAuth
+// @Description Subscribes an external URL to domain events (PvzCreated, ReceptionOpened, ProductAdded, ProductRemoved, ReceptionClosed, OrderIssued, OrderReturned). Every delivery is a POST with the event JSON signed by HMAC-SHA256 in the X-Webhook-Signature header. If secret is omitted, a random one is generated; the secret is returned only in this response. Available only for moderators.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWebhookRequest true "Webhook subscription"
+// @Success 201 {object} dto.WebhookDTO "Created subscription with its secret"
+// @Failure 400 {object} dto.Error "Invalid URL, event types or secret"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /webhooks [post]
+func (w *webhookController) CreateWebhook(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
+		return
+	}
+
+	sub, err := w.webhookSvc.CreateWebhook(c, mapper.CreateWebhookRequestToEntity(req))
+	if err != nil {
+		respondError(c, err, "failed to create webhook")
+		return
+	}
+
+	response := mapper.WebhookEntityToDTO(*sub)
+	response.Secret = sub.Secret
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListWebhooks godoc
+// @Summary List webhook subscriptions
+// @Security BearerAuth
+// @Description Returns all webhook subscriptions in creation order. Secrets are not returned. Available only for moderators.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} dto.WebhookDTO "Webhook subscriptions"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /webhooks [get]
+func (w *webhookController) ListWebhooks(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	subs, err := w.webhookSvc.ListWebhooks(c)
+	if err != nil {
+		respondError(c, err, "failed to list webhooks")
+		return
+	}
+
+	response := make([]dto.WebhookDTO, 0, len(subs))
+	for _, sub := range subs {
+		response = append(response, mapper.WebhookEntityToDTO(sub))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook subscription
+// @Security BearerAuth
+// @Description Returns a webhook subscription without its secret. Available only for moderators.
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "Webhook subscription ID"
+// @Success 200 {object} dto.WebhookDTO "Webhook subscription"
+// @Failure 400 {object} dto.Error "Invalid webhook ID"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Webhook subscription not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /webhooks/{webhookId} [get]
+func (w *webhookController) GetWebhook(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	sub, err := w.webhookSvc.GetWebhook(c, c.Param("webhookId"))
+	if err != nil {
+		respondError(c, err, "failed to get webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.WebhookEntityToDTO(*sub))
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook subscription
+// @Security BearerAuth
+// @Description Partially updates URL, event types and the active flag. A paused subscription (active=false) keeps collecting deliveries, they are sent after it is reactivated. Available only for moderators.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookId path string true "Webhook subscription ID"
+// @Param request body dto.UpdateWebhookRequest true "Fields to update"
+// @Success 200 {object} dto.WebhookDTO "Updated subscription"
+// @Failure 400 {object} dto.Error "Invalid request body, URL or event types"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Webhook subscription not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /webhooks/{webhookId} [patch]
+func (w *webhookController) UpdateWebhook(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid request body"})
+		return
+	}
+
+	sub, err := w.webhookSvc.UpdateWebhook(c, c.Param("webhookId"), mapper.UpdateWebhookRequestToEntity(req))
+	if err != nil {
+		respondError(c, err, "failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.WebhookEntityToDTO(*sub))
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook subscription
+// @Security BearerAuth
+// @Description Deletes a webhook subscription together with its delivery history. Available only for moderators.
+// @Tags webhooks
+// @Param webhookId path string true "Webhook subscription ID"
+// @Success 204 "Subscription deleted"
+// @Failure 400 {object} dto.Error "Invalid webhook ID"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Webhook subscription not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /webhooks/{webhookId} [delete]
+func (w *webhookController) DeleteWebhook(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	if err := w.webhookSvc.DeleteWebhook(c, c.Param("webhookId")); err != nil {
+		respondError(c, err, "failed to delete webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Delivery history of a webhook subscription
+// @Security BearerAuth
+// @Description Returns deliveries of a subscription from newest to oldest. A delivery is pending until the receiver answers 2xx (delivered) or attempts run out (failed). Pass the X-Next-Cursor header value as cursor to get the next page. Available only for moderators.
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "Webhook subscription ID"
+// @Param status query string false "Filter by status: pending, delivered, failed" example("failed")
+// @Param limit query int false "Page size, 50 by default, at most 500" example(50)
+// @Param cursor query string false "Cursor from X-Next-Cursor of the previous page"
+// @Success 200 {array} dto.WebhookDeliveryDTO "Deliveries"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {boolean} X-Has-More "Whether there are older deliveries after this page"
+// @Failure 400 {object} dto.Error "Invalid filters or cursor"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Webhook subscription not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /webhooks/{webhookId}/deliveries [get]
+func (w *webhookController) GetWebhookDeliveries(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	var query dto.WebhookDeliveriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid query parameters"})
+		return
+	}
+
+	beforeID, err := mapper.DecodeIDCursor(query.Cursor)
+	if err != nil {
+		respondError(c, err, "invalid cursor")
+		return
+	}
+
+	page, err := w.webhookSvc.GetWebhookDeliveries(c, entity.WebhookDeliveryFilter{
+		SubscriptionID: c.Param("webhookId"),
+		Status:         query.Status,
+		Limit:          query.Limit,
+		BeforeID:       beforeID,
+	})
+	if err != nil {
+		respondError(c, err, "failed to get webhook deliveries")
+		return
+	}
+
+	if page.NextCursor != nil {
+		c.Header(headerNextCursor, mapper.EncodeIDCursor(page.NextCursor))
+	}
+	c.Header(headerHasMore, strconv.FormatBool(page.HasMore))
+
+	response := make([]dto.WebhookDeliveryDTO, 0, len(page.Items))
+	for _, d := range page.Items {
+		response = append(response, mapper.WebhookDeliveryEntityToDTO(d))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWebhookDelivery godoc
+// @Summary Get a webhook delivery with its attempts
+// @Security BearerAuth
+// @Description Returns a delivery with the history of all attempts: time, duration, response status and error. Available only for moderators.
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "Webhook subscription ID"
+// @Param deliveryId path int true "Delivery ID" example(42)
+// @Success 200 {object} dto.WebhookDeliveryDTO "Delivery with attempts history"
+// @Failure 400 {object} dto.Error "Invalid webhook or delivery ID"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Delivery not found"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /webhooks/{webhookId}/deliveries/{deliveryId} [get]
+func (w *webhookController) GetWebhookDelivery(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	deliveryID, ok := parseDeliveryID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := w.webhookSvc.GetWebhookDelivery(c, c.Param("webhookId"), deliveryID)
+	if err != nil {
+		respondError(c, err, "failed to get webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.WebhookDeliveryEntityToDTO(*delivery))
+}
+
+// RetryWebhookDelivery godoc
+// @Summary Retry a failed webhook delivery
+// @Security BearerAuth
+// @Description Returns a failed delivery to the queue with a fresh attempt budget; it is sent by the next worker pass. Only failed deliveries can be retried. Available only for moderators.
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "Webhook subscription ID"
+// @Param deliveryId path int true "Delivery ID" example(42)
+// @Success 200 {object} dto.WebhookDeliveryDTO "Delivery queued again"
+// @Failure 400 {object} dto.Error "Invalid webhook or delivery ID"
+// @Failure 401 {object} dto.Error "Unauthorized: missing or invalid token"
+// @Failure 403 {object} dto.Error "Forbidden: insufficient privileges"
+// @Failure 404 {object} dto.Error "Delivery not found"
+// @Failure 409 {object} dto.Error "Delivery is not in failed status"
+// @Failure 500 {object} dto.Error "Internal server error"
+// @Router /webhooks/{webhookId}/deliveries/{deliveryId}/retry [post]
+func (w *webhookController) RetryWebhookDelivery(c *gin.Context) {
+	if !CheckRole(c, "moderator") {
+		return
+	}
+
+	deliveryID, ok := parseDeliveryID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := w.webhookSvc.RetryWebhookDelivery(c, c.Param("webhookId"), deliveryID)
+	if err != nil {
+		respondError(c, err, "failed to retry webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.WebhookDeliveryEntityToDTO(*delivery))
+}
+
+func parseDeliveryID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, dto.Error{Code: errs.ErrInvalidRequestCode, Message: "invalid delivery id"})
+		return 0, false
+	}
+	return id, true
+}
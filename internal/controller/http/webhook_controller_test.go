@@ -0,0 +1,184 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockWebhookServ "order-pick-up-point/internal/service/http/mock"
+	"strings"
+	"testing"
+)
+
+func TestPvzController_CreateWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		body               string
+		role               string
+		mockSetup          func(m *mockWebhookServ.WebhookService)
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name: "secret is returned on create",
+			body: `{"url":"https://example.com/hook","eventTypes":["OrderIssued"]}`,
+			role: "moderator",
+			mockSetup: func(m *mockWebhookServ.WebhookService) {
+				m.On("CreateWebhook", mock.Anything, entity.WebhookSubscription{URL: "https://example.com/hook", EventTypes: []string{"OrderIssued"}}).
+					Return(&entity.WebhookSubscription{ID: "hook1", URL: "https://example.com/hook", EventTypes: []string{"OrderIssued"}, Secret: "whsec_abc", Active: true}, nil).
+					Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedRespSubstr: `"secret":"whsec_abc"`,
+		},
+		{
+			name:               "no event types",
+			body:               `{"url":"https://example.com/hook","eventTypes":[]}`,
+			role:               "moderator",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid request body",
+		},
+		{
+			name: "invalid webhook",
+			body: `{"url":"ftp://example.com/hook","eventTypes":["OrderIssued"]}`,
+			role: "moderator",
+			mockSetup: func(m *mockWebhookServ.WebhookService) {
+				m.On("CreateWebhook", mock.Anything, mock.Anything).
+					Return(nil, errs.New(errs.ErrInvalidWebhook, "url must be an absolute http(s) URL without credentials")).
+					Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: `"code":"INVALID_WEBHOOK"`,
+		},
+		{
+			name:               "employee forbidden",
+			body:               `{"url":"https://example.com/hook","eventTypes":["OrderIssued"]}`,
+			role:               "employee",
+			expectedStatusCode: http.StatusForbidden,
+			expectedRespSubstr: "access denied",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("POST", "/webhooks", strings.NewReader(tc.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("role", tc.role)
+
+			mockSvc := mockWebhookServ.NewWebhookService(t)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockSvc)
+			}
+
+			NewWebhookController(mockSvc).CreateWebhook(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestPvzController_GetWebhook_HidesSecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest("GET", "/webhooks/hook1", nil)
+	c.Params = gin.Params{{Key: "webhookId", Value: "hook1"}}
+	c.Set("role", "moderator")
+
+	mockSvc := mockWebhookServ.NewWebhookService(t)
+	mockSvc.On("GetWebhook", mock.Anything, "hook1").
+		Return(&entity.WebhookSubscription{ID: "hook1", Secret: "whsec_abc"}, nil).
+		Once()
+
+	NewWebhookController(mockSvc).GetWebhook(c)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	if strings.Contains(rr.Body.String(), "whsec_abc") {
+		t.Errorf("secret must not be returned, got %q", rr.Body.String())
+	}
+}
+
+func TestPvzController_RetryWebhookDelivery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		deliveryID         string
+		mockSetup          func(m *mockWebhookServ.WebhookService)
+		expectedStatusCode int
+		expectedRespSubstr string
+	}{
+		{
+			name:       "failed delivery queued again",
+			deliveryID: "42",
+			mockSetup: func(m *mockWebhookServ.WebhookService) {
+				m.On("RetryWebhookDelivery", mock.Anything, "hook1", int64(42)).
+					Return(&entity.WebhookDelivery{ID: 42, SubscriptionID: "hook1", Status: entity.WebhookDeliveryPending}, nil).
+					Once()
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedRespSubstr: `"status":"pending"`,
+		},
+		{
+			name:       "delivered delivery",
+			deliveryID: "42",
+			mockSetup: func(m *mockWebhookServ.WebhookService) {
+				m.On("RetryWebhookDelivery", mock.Anything, "hook1", int64(42)).
+					Return(nil, errs.New(errs.ErrDeliveryNotRetryable, "delivery in 'delivered' status cannot be retried")).
+					Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedRespSubstr: `"code":"DELIVERY_NOT_RETRYABLE"`,
+		},
+		{
+			name:               "invalid delivery id",
+			deliveryID:         "abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedRespSubstr: "invalid delivery id",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest("POST", "/webhooks/hook1/deliveries/"+tc.deliveryID+"/retry", nil)
+			c.Params = gin.Params{{Key: "webhookId", Value: "hook1"}, {Key: "deliveryId", Value: tc.deliveryID}}
+			c.Set("role", "moderator")
+
+			mockSvc := mockWebhookServ.NewWebhookService(t)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockSvc)
+			}
+
+			NewWebhookController(mockSvc).RetryWebhookDelivery(c)
+
+			if rr.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, rr.Code)
+			}
+			if !strings.Contains(rr.Body.String(), tc.expectedRespSubstr) {
+				t.Errorf("expected response containing %q, got %q", tc.expectedRespSubstr, rr.Body.String())
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ const (
 	ErrInvalidRequestCode = "INVALID_REQUEST"
 	ErrNotFoundCode       = "NOT_FOUND"
 	ErrUnauthorizedCode   = "UNAUTHORIZED"
+	ErrForbiddenCode      = "FORBIDDEN" // роль пользователя не допускает выполнение запроса
 	ErrInternalCode       = "INTERNAL_ERROR"
+	ErrInvalidCursor      = "INVALID_CURSOR" // курсор пагинации повреждён или получен не от этого API
 
 	// DummyLogin
 	ErrInvalidRoleCode = "INVALID_ROLE" // неизвестная роль в /dummyLogin
@@ -17,12 +19,25 @@ const (
 	ErrPasswordHashingFailed = "PASSWORD_HASHING_FAILED" // ошибка при хэшировании пароля
 
 	// Авторизация
-	ErrInvalidCredentials = "INVALID_CREDENTIALS" // неверный email или пароль
+	ErrInvalidCredentials   = "INVALID_CREDENTIALS"     // неверный email или пароль
+	ErrInvalidRefreshToken  = "INVALID_REFRESH_TOKEN"   // refresh-токен не найден, истёк или отозван
+	ErrRefreshTokenReused   = "REFRESH_TOKEN_REUSED"    // уже обменянный refresh-токен предъявлен повторно, сессия отозвана
+	ErrTooManyLoginAttempts = "TOO_MANY_LOGIN_ATTEMPTS" // вход по email или с IP временно ограничен после неудачных попыток
+
+	// Пользователи
+	ErrUserNotFound           = "USER_NOT_FOUND"           // пользователь с указанным идентификатором не существует
+	ErrUserDeactivated        = "USER_DEACTIVATED"         // учётная запись отключена модератором
+	ErrInvalidCurrentPassword = "INVALID_CURRENT_PASSWORD" // при смене пароля указан неверный текущий пароль
 
 	// Заведение ПВЗ
 	ErrInvalidCity     = "INVALID_CITY"      // город не входит в допустимый список
 	ErrForbiddenForPvz = "FORBIDDEN_FOR_PVZ" // пользователь без роли moderator пытается создать ПВЗ
 
+	// Данные ПВЗ
+	ErrPvzNotFound       = "PVZ_NOT_FOUND"       // ПВЗ с указанным идентификатором не существует
+	ErrInvalidPvzDetails = "INVALID_PVZ_DETAILS" // адрес, координаты, телефон, статус или часы работы заданы неверно
+	ErrPvzClosed         = "PVZ_CLOSED"          // ПВЗ закрыт и не принимает новые приёмки
+
 	// Приёмка товаров
 	ErrOpenReceptionExists = "OPEN_RECEPTION_EXISTS" // уже существует незакрытая приёмка для данного ПВЗ
 	ErrNoOpenReception     = "NO_OPEN_RECEPTION"     // отсутствует незакрытая приёмка, к которой можно привязать товары
@@ -30,8 +45,32 @@ const (
 	// Товары
 	ErrInvalidProductType = "INVALID_PRODUCT_TYPE"  // тип товара не является допустимым
 	ErrNoProductsToDelete = "NO_PRODUCTS_TO_DELETE" // нет товаров для удаления в текущей незакрытой приёмке
+	ErrInvalidBarcode     = "INVALID_BARCODE"       // штрихкод не соответствует формату
+	ErrDuplicateBarcode   = "DUPLICATE_BARCODE"     // товар с таким штрихкодом уже находится в ПВЗ
 
 	// Закрытие приёмки
 	ErrReceptionAlreadyClosed = "RECEPTION_ALREADY_CLOSED" // приёмка уже закрыта
 	ErrReceptionNotFound      = "RECEPTION_NOT_FOUND"      // приёмка не найдена для данного ПВЗ
+
+	// Выдача заказов
+	ErrProductNotFound      = "PRODUCT_NOT_FOUND"      // товар не найден в данном ПВЗ
+	ErrInvalidProductStatus = "INVALID_PRODUCT_STATUS" // переход статуса товара недопустим
+	ErrInvalidRecipient     = "INVALID_RECIPIENT"      // получатель не найден или не является клиентом
+	ErrInvalidPickupCode    = "INVALID_PICKUP_CODE"    // код выдачи не совпадает
+	ErrPickupCodeLocked     = "PICKUP_CODE_LOCKED"     // исчерпаны попытки ввода кода, нужен новый код
+
+	// Отгрузка возвратов
+	ErrOpenReturnExists      = "OPEN_RETURN_EXISTS"        // уже существует незакрытая отгрузка возвратов для данного ПВЗ
+	ErrNoOpenReturn          = "NO_OPEN_RETURN"            // отсутствует незакрытая отгрузка возвратов
+	ErrNoReturnItemsToDelete = "NO_RETURN_ITEMS_TO_DELETE" // нет товаров для удаления в текущей отгрузке возвратов
+	ErrInvalidReturnReason   = "INVALID_RETURN_REASON"     // причина возврата не является допустимой
+
+	// Вебхуки
+	ErrInvalidWebhook          = "INVALID_WEBHOOK"            // URL, типы событий или секрет подписки заданы неверно
+	ErrWebhookNotFound         = "WEBHOOK_NOT_FOUND"          // подписка с указанным идентификатором не существует
+	ErrWebhookDeliveryNotFound = "WEBHOOK_DELIVERY_NOT_FOUND" // доставка не найдена у данной подписки
+	ErrDeliveryNotRetryable    = "DELIVERY_NOT_RETRYABLE"     // повторить можно только доставку, исчерпавшую попытки
+
+	// Лента активности ПВЗ
+	ErrActivityStreamInterrupted = "ACTIVITY_STREAM_INTERRUPTED" // поток прерван сервером, клиенту нужно переподключиться с последним id
 )
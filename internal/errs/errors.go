@@ -29,3 +29,7 @@ func Wrap(err error, code, message string) *AppError {
 		Err:     err,
 	}
 }
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
@@ -26,3 +26,14 @@ func IsOpenReceptionNotFound(err error) bool {
 	}
 	return false
 }
+
+func IsOpenReturnNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code == ErrNoOpenReturn
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain передаётся в ErrorInfo gRPC-статуса вместе с кодом ошибки.
+const ErrorDomain = "order-pick-up-point"
+
+type statusMapping struct {
+	http int
+	grpc codes.Code
+}
+
+// statusMappings - единая таблица соответствия кодов ошибок HTTP-статусам и gRPC-кодам.
+// Коды, которых нет в таблице, считаются внутренними ошибками.
+var statusMappings = map[string]statusMapping{
+	ErrInvalidRequestCode: {http.StatusBadRequest, codes.InvalidArgument},
+	ErrNotFoundCode:       {http.StatusNotFound, codes.NotFound},
+	ErrUnauthorizedCode:   {http.StatusUnauthorized, codes.Unauthenticated},
+	ErrForbiddenCode:      {http.StatusForbidden, codes.PermissionDenied},
+	ErrInternalCode:       {http.StatusInternalServerError, codes.Internal},
+	ErrInvalidCursor:      {http.StatusBadRequest, codes.InvalidArgument},
+
+	ErrInvalidRoleCode: {http.StatusBadRequest, codes.InvalidArgument},
+
+	ErrUserAlreadyExists:     {http.StatusConflict, codes.AlreadyExists},
+	ErrInvalidEmail:          {http.StatusBadRequest, codes.InvalidArgument},
+	ErrWeakPassword:          {http.StatusBadRequest, codes.InvalidArgument},
+	ErrPasswordHashingFailed: {http.StatusInternalServerError, codes.Internal},
+
+	ErrInvalidCredentials:   {http.StatusUnauthorized, codes.Unauthenticated},
+	ErrInvalidRefreshToken:  {http.StatusUnauthorized, codes.Unauthenticated},
+	ErrRefreshTokenReused:   {http.StatusUnauthorized, codes.Unauthenticated},
+	ErrTooManyLoginAttempts: {http.StatusTooManyRequests, codes.ResourceExhausted},
+
+	ErrUserNotFound:           {http.StatusNotFound, codes.NotFound},
+	ErrUserDeactivated:        {http.StatusForbidden, codes.PermissionDenied},
+	ErrInvalidCurrentPassword: {http.StatusBadRequest, codes.InvalidArgument},
+
+	ErrInvalidCity:     {http.StatusBadRequest, codes.InvalidArgument},
+	ErrForbiddenForPvz: {http.StatusForbidden, codes.PermissionDenied},
+
+	ErrPvzNotFound:       {http.StatusNotFound, codes.NotFound},
+	ErrInvalidPvzDetails: {http.StatusBadRequest, codes.InvalidArgument},
+	ErrPvzClosed:         {http.StatusConflict, codes.FailedPrecondition},
+
+	ErrOpenReceptionExists: {http.StatusConflict, codes.AlreadyExists},
+	ErrNoOpenReception:     {http.StatusUnprocessableEntity, codes.FailedPrecondition},
+
+	ErrInvalidProductType: {http.StatusBadRequest, codes.InvalidArgument},
+	ErrNoProductsToDelete: {http.StatusUnprocessableEntity, codes.FailedPrecondition},
+	ErrInvalidBarcode:     {http.StatusBadRequest, codes.InvalidArgument},
+	ErrDuplicateBarcode:   {http.StatusConflict, codes.AlreadyExists},
+
+	ErrReceptionAlreadyClosed: {http.StatusConflict, codes.FailedPrecondition},
+	ErrReceptionNotFound:      {http.StatusNotFound, codes.NotFound},
+
+	ErrProductNotFound:      {http.StatusNotFound, codes.NotFound},
+	ErrInvalidProductStatus: {http.StatusConflict, codes.FailedPrecondition},
+	ErrInvalidRecipient:     {http.StatusUnprocessableEntity, codes.FailedPrecondition},
+	ErrInvalidPickupCode:    {http.StatusForbidden, codes.PermissionDenied},
+	ErrPickupCodeLocked:     {http.StatusTooManyRequests, codes.ResourceExhausted},
+
+	ErrOpenReturnExists:      {http.StatusConflict, codes.AlreadyExists},
+	ErrNoOpenReturn:          {http.StatusUnprocessableEntity, codes.FailedPrecondition},
+	ErrNoReturnItemsToDelete: {http.StatusUnprocessableEntity, codes.FailedPrecondition},
+	ErrInvalidReturnReason:   {http.StatusBadRequest, codes.InvalidArgument},
+
+	ErrInvalidWebhook:          {http.StatusBadRequest, codes.InvalidArgument},
+	ErrWebhookNotFound:         {http.StatusNotFound, codes.NotFound},
+	ErrWebhookDeliveryNotFound: {http.StatusNotFound, codes.NotFound},
+	ErrDeliveryNotRetryable:    {http.StatusConflict, codes.FailedPrecondition},
+
+	ErrActivityStreamInterrupted: {http.StatusServiceUnavailable, codes.Unavailable},
+}
+
+func lookupMapping(code string) statusMapping {
+	if m, ok := statusMappings[code]; ok {
+		return m
+	}
+	return statusMappings[ErrInternalCode]
+}
+
+// HTTPStatus возвращает HTTP-статус для кода ошибки.
+func HTTPStatus(code string) int {
+	return lookupMapping(code).http
+}
+
+// GRPCCode возвращает gRPC-код для кода ошибки.
+func GRPCCode(code string) codes.Code {
+	return lookupMapping(code).grpc
+}
+
+// FromError достаёт AppError из цепочки ошибок. Ошибка без AppError считается внутренней
+// и получает сообщение fallback, чтобы детали реализации не уходили клиенту.
+func FromError(err error, fallback string) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Wrap(err, ErrInternalCode, fallback)
+}
+
+// GRPCStatus позволяет gRPC определить код ответа по AppError.
+// Машинный код ошибки передаётся в деталях статуса как ErrorInfo.Reason.
+func (e *AppError) GRPCStatus() *status.Status {
+	st := status.New(GRPCCode(e.Code), e.Message)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: e.Code,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
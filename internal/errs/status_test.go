@@ -0,0 +1,114 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusMapping(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code         string
+		expectedHTTP int
+		expectedGRPC codes.Code
+	}{
+		{ErrInvalidCity, http.StatusBadRequest, codes.InvalidArgument},
+		{ErrInvalidProductType, http.StatusBadRequest, codes.InvalidArgument},
+		{ErrForbiddenForPvz, http.StatusForbidden, codes.PermissionDenied},
+		{ErrReceptionNotFound, http.StatusNotFound, codes.NotFound},
+		{ErrUserAlreadyExists, http.StatusConflict, codes.AlreadyExists},
+		{ErrOpenReceptionExists, http.StatusConflict, codes.AlreadyExists},
+		{ErrNoOpenReception, http.StatusUnprocessableEntity, codes.FailedPrecondition},
+		{ErrNoProductsToDelete, http.StatusUnprocessableEntity, codes.FailedPrecondition},
+		{ErrDuplicateBarcode, http.StatusConflict, codes.AlreadyExists},
+		{ErrPvzNotFound, http.StatusNotFound, codes.NotFound},
+		{ErrPvzClosed, http.StatusConflict, codes.FailedPrecondition},
+		{ErrInvalidCursor, http.StatusBadRequest, codes.InvalidArgument},
+		{ErrInvalidCredentials, http.StatusUnauthorized, codes.Unauthenticated},
+		{ErrTooManyLoginAttempts, http.StatusTooManyRequests, codes.ResourceExhausted},
+		{ErrUserNotFound, http.StatusNotFound, codes.NotFound},
+		{ErrUserDeactivated, http.StatusForbidden, codes.PermissionDenied},
+		{ErrInvalidCurrentPassword, http.StatusBadRequest, codes.InvalidArgument},
+		{ErrOpenReturnExists, http.StatusConflict, codes.AlreadyExists},
+		{ErrNoOpenReturn, http.StatusUnprocessableEntity, codes.FailedPrecondition},
+		{ErrInvalidReturnReason, http.StatusBadRequest, codes.InvalidArgument},
+		{ErrWebhookNotFound, http.StatusNotFound, codes.NotFound},
+		{ErrDeliveryNotRetryable, http.StatusConflict, codes.FailedPrecondition},
+		{ErrRefreshTokenReused, http.StatusUnauthorized, codes.Unauthenticated},
+		{ErrActivityStreamInterrupted, http.StatusServiceUnavailable, codes.Unavailable},
+		{ErrInternalCode, http.StatusInternalServerError, codes.Internal},
+		{"SOME_UNKNOWN_CODE", http.StatusInternalServerError, codes.Internal},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.code, func(t *testing.T) {
+			t.Parallel()
+			if got := HTTPStatus(tc.code); got != tc.expectedHTTP {
+				t.Errorf("HTTPStatus(%s) = %d; expected %d", tc.code, got, tc.expectedHTTP)
+			}
+			if got := GRPCCode(tc.code); got != tc.expectedGRPC {
+				t.Errorf("GRPCCode(%s) = %v; expected %v", tc.code, got, tc.expectedGRPC)
+			}
+		})
+	}
+}
+
+func TestFromError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AppError in chain", func(t *testing.T) {
+		t.Parallel()
+		original := New(ErrNoOpenReception, "no open reception")
+		got := FromError(fmt.Errorf("tx failed: %w", original), "fallback")
+		if got != original {
+			t.Errorf("expected original AppError, got %v", got)
+		}
+	})
+
+	t.Run("plain error becomes internal", func(t *testing.T) {
+		t.Parallel()
+		cause := errors.New("connection refused")
+		got := FromError(cause, "failed to create PVZ")
+		if got.Code != ErrInternalCode || got.Message != "failed to create PVZ" {
+			t.Errorf("unexpected AppError %+v", got)
+		}
+		if !errors.Is(got, cause) {
+			t.Errorf("expected cause to be kept in chain")
+		}
+	})
+}
+
+func TestAppError_GRPCStatus(t *testing.T) {
+	t.Parallel()
+
+	err := New(ErrOpenReceptionExists, "open reception already exists")
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("expected AppError to be convertible to status")
+	}
+	if st.Code() != codes.AlreadyExists {
+		t.Errorf("expected code %v, got %v", codes.AlreadyExists, st.Code())
+	}
+	if st.Message() != "open reception already exists" {
+		t.Errorf("unexpected message %q", st.Message())
+	}
+
+	var reason string
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			reason = info.GetReason()
+		}
+	}
+	if reason != ErrOpenReceptionExists {
+		t.Errorf("expected ErrorInfo reason %q, got %q", ErrOpenReceptionExists, reason)
+	}
+}
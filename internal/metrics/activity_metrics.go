@@ -0,0 +1,30 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// ActivitySubscribers — число открытых подписок на ленту активности ПВЗ в этом экземпляре
+	ActivitySubscribers = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pvz_activity_subscribers",
+			Help: "Number of open PVZ activity subscriptions on this instance.",
+		},
+	)
+
+	// ActivityInterruptedTotal — подписки, прерванные сервером, по причине
+	ActivityInterruptedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pvz_activity_interrupted_total",
+			Help: "Total number of PVZ activity subscriptions interrupted by the server by reason (lagged, resync, shutdown).",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(ActivitySubscribers, ActivityInterruptedTotal)
+}
+
+func ActivityInterrupted(reason string) {
+	ActivityInterruptedTotal.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Исходы попыток входа
+const (
+	LoginResultSuccess = "success"
+	LoginResultFailed  = "failed"
+	LoginResultBlocked = "blocked"
+)
+
+var (
+	// LoginAttemptsTotal — счетчик попыток входа по исходу
+	LoginAttemptsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "login_attempts_total",
+			Help: "Total number of login attempts by result (success, failed, blocked).",
+		},
+		[]string{"result"},
+	)
+
+	// LoginLockoutsTotal — счетчик блокировок входа по области (email, ip)
+	LoginLockoutsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "login_lockouts_total",
+			Help: "Total number of temporary login lockouts by scope (email, ip).",
+		},
+		[]string{"scope"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(LoginAttemptsTotal, LoginLockoutsTotal)
+}
+
+func LoginAttempt(result string) {
+	LoginAttemptsTotal.WithLabelValues(result).Inc()
+}
+
+func LoginLockout(scope string) {
+	LoginLockoutsTotal.WithLabelValues(scope).Inc()
+}
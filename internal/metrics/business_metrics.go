@@ -26,10 +26,69 @@ var (
 			Help: "Total number of added products.",
 		},
 	)
+
+	// OrdersIssuedTotal — счетчик количества выданных получателям заказов
+	OrdersIssuedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "orders_issued_total",
+			Help: "Total number of orders issued to recipients.",
+		},
+	)
+
+	// OrdersReturnedTotal — счетчик количества заказов, отмеченных как возвращённые
+	OrdersReturnedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "orders_returned_total",
+			Help: "Total number of orders marked as returned.",
+		},
+	)
+
+	// ReturnShipmentsCreatedTotal — счетчик количества созданных отгрузок возвратов
+	ReturnShipmentsCreatedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "return_shipments_created_total",
+			Help: "Total number of created return shipments.",
+		},
+	)
+
+	// ReturnItemsAddedTotal — счетчик товаров, добавленных в отгрузки возвратов, по причине возврата
+	ReturnItemsAddedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "return_items_added_total",
+			Help: "Total number of products added to return shipments by reason.",
+		},
+		[]string{"reason"},
+	)
+
+	// ReturnShipmentsClosedTotal — счетчик количества закрытых (отгруженных) отгрузок возвратов
+	ReturnShipmentsClosedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "return_shipments_closed_total",
+			Help: "Total number of closed return shipments.",
+		},
+	)
+
+	// ProductsExpiredTotal — счетчик товаров, у которых истёк срок хранения
+	ProductsExpiredTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "products_expired_total",
+			Help: "Total number of products flagged as expired by the expiry worker.",
+		},
+	)
+
+	// OverdueProducts — текущее количество просроченных товаров, ещё находящихся в ПВЗ
+	OverdueProducts = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "overdue_products",
+			Help: "Current number of expired products still waiting at PVZs.",
+		},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(PVZCreatedTotal, ReceptionsCreatedTotal, ProductsAddedTotal)
+	prometheus.MustRegister(PVZCreatedTotal, ReceptionsCreatedTotal, ProductsAddedTotal, OrdersIssuedTotal, OrdersReturnedTotal)
+	prometheus.MustRegister(ReturnShipmentsCreatedTotal, ReturnItemsAddedTotal, ReturnShipmentsClosedTotal)
+	prometheus.MustRegister(ProductsExpiredTotal, OverdueProducts)
 }
 
 func PVZCreated() {
@@ -43,3 +102,35 @@ func ReceptionsCreated() {
 func ProductsAdded() {
 	ProductsAddedTotal.Inc()
 }
+
+func ProductsBatchAdded(count int) {
+	ProductsAddedTotal.Add(float64(count))
+}
+
+func OrdersIssued() {
+	OrdersIssuedTotal.Inc()
+}
+
+func OrdersReturned() {
+	OrdersReturnedTotal.Inc()
+}
+
+func ReturnShipmentsCreated() {
+	ReturnShipmentsCreatedTotal.Inc()
+}
+
+func ReturnItemsAdded(reason string) {
+	ReturnItemsAddedTotal.WithLabelValues(reason).Inc()
+}
+
+func ReturnShipmentsClosed() {
+	ReturnShipmentsClosedTotal.Inc()
+}
+
+func ProductsExpired(count int64) {
+	ProductsExpiredTotal.Add(float64(count))
+}
+
+func SetOverdueProducts(count int64) {
+	OverdueProducts.Set(float64(count))
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Исходы попыток публикации событий outbox
+const (
+	OutboxResultPublished = "published"
+	OutboxResultRetry     = "retry"
+	OutboxResultDead      = "dead"
+)
+
+var (
+	// OutboxEventsTotal — счетчик попыток публикации событий outbox по исходу
+	OutboxEventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "outbox_events_total",
+			Help: "Total number of outbox publish attempts by result (published, retry, dead).",
+		},
+		[]string{"result"},
+	)
+
+	// OutboxPublishLag — задержка между записью события и его публикацией
+	OutboxPublishLag = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "outbox_publish_lag_seconds",
+			Help:    "Delay between writing an outbox event and publishing it.",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+		},
+	)
+
+	// OutboxDeadEvents — число событий в dead letter, ожидающих разбора
+	OutboxDeadEvents = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "outbox_dead_events",
+			Help: "Number of outbox events in the dead letter state awaiting manual handling.",
+		},
+	)
+
+	// OutboxDeletedEventsTotal — счетчик опубликованных событий, удалённых по сроку хранения
+	OutboxDeletedEventsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "outbox_deleted_events_total",
+			Help: "Total number of published outbox events deleted after the retention period.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(OutboxEventsTotal, OutboxPublishLag, OutboxDeadEvents, OutboxDeletedEventsTotal)
+}
+
+func OutboxEvent(result string) {
+	OutboxEventsTotal.WithLabelValues(result).Inc()
+}
+
+func ObserveOutboxPublishLag(seconds float64) {
+	OutboxPublishLag.Observe(seconds)
+}
+
+func SetOutboxDeadEvents(count int64) {
+	OutboxDeadEvents.Set(float64(count))
+}
+
+func OutboxEventsDeleted(count int64) {
+	OutboxDeletedEventsTotal.Add(float64(count))
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Исходы попыток доставки вебхуков
+const (
+	WebhookResultDelivered = "delivered"
+	WebhookResultRetry     = "retry"
+	WebhookResultFailed    = "failed"
+)
+
+var (
+	// WebhookDeliveriesTotal — счетчик попыток доставки вебхуков по типу события и исходу
+	WebhookDeliveriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_total",
+			Help: "Total number of webhook delivery attempts by event type and result (delivered, retry, failed).",
+		},
+		[]string{"event_type", "result"},
+	)
+
+	// WebhookDeliveryDuration — длительность HTTP-запроса доставки вебхука
+	WebhookDeliveryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "webhook_delivery_duration_seconds",
+			Help:    "Duration of webhook delivery HTTP requests by result.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(WebhookDeliveriesTotal, WebhookDeliveryDuration)
+}
+
+func WebhookDelivery(eventType, result string, seconds float64) {
+	WebhookDeliveriesTotal.WithLabelValues(eventType, result).Inc()
+	WebhookDeliveryDuration.WithLabelValues(result).Observe(seconds)
+}
@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// AnalyticsQuery godoc
+// @Description Filters of the reception analytics. The period applies to the reception date.
+type AnalyticsQuery struct {
+	City      string     `form:"city"`
+	PvzId     string     `form:"pvzId"`
+	StartDate *time.Time `form:"startDate" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDate   *time.Time `form:"endDate" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// DailyReceptionsDTO godoc
+// @Description Number of receptions of a PVZ during one day (Moscow time).
+type DailyReceptionsDTO struct {
+	PvzId      string `json:"pvzId" example:"pvz789"`
+	City       string `json:"city" example:"Moscow"`
+	Day        string `json:"day" example:"2025-04-09"`
+	Receptions int    `json:"receptions" example:"3"`
+}
+
+// ProductTypeCountDTO godoc
+// @Description Number of products of one type received by a PVZ.
+type ProductTypeCountDTO struct {
+	PvzId    string `json:"pvzId" example:"pvz789"`
+	City     string `json:"city" example:"Moscow"`
+	Type     string `json:"type" example:"electronics"`
+	Products int    `json:"products" example:"42"`
+}
+
+// ReceptionStatsDTO godoc
+// @Description Reception summary of a PVZ. The average duration is computed over receptions with a known close time and is absent when there are none.
+type ReceptionStatsDTO struct {
+	PvzId                   string   `json:"pvzId" example:"pvz789"`
+	City                    string   `json:"city" example:"Moscow"`
+	Receptions              int      `json:"receptions" example:"10"`
+	ClosedReceptions        int      `json:"closedReceptions" example:"9"`
+	Products                int      `json:"products" example:"120"`
+	AvgDurationSeconds      *float64 `json:"avgDurationSeconds,omitempty" example:"1830.5"`
+	AvgProductsPerReception float64  `json:"avgProductsPerReception" example:"12"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditQuery godoc
+// @Description Filters of the audit log. Empty filters do not restrict the result.
+type AuditQuery struct {
+	ActorId    string     `form:"actorId"`
+	Action     string     `form:"action"`
+	EntityType string     `form:"entityType"`
+	EntityId   string     `form:"entityId"`
+	PvzId      string     `form:"pvzId"`
+	StartDate  *time.Time `form:"startDate" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDate    *time.Time `form:"endDate" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit      int        `form:"limit"`
+	Cursor     string     `form:"cursor"`
+}
+
+// AuditEntryDTO godoc
+// @Description Audit log entry. The actor is absent for registration and dummy-login tokens, the latter are marked with actorDummy; before and after hold the entity state around the change.
+type AuditEntryDTO struct {
+	Id         int64           `json:"id" example:"1024"`
+	CreatedAt  time.Time       `json:"createdAt" example:"2025-05-01T10:00:00Z"`
+	ActorId    string          `json:"actorId,omitempty" example:"7c2b8d1e-4f3a-4b5c-9d6e-1a2b3c4d5e44"`
+	ActorRole  string          `json:"actorRole,omitempty" example:"employee"`
+	ActorDummy bool            `json:"actorDummy,omitempty" example:"false"`
+	Action     string          `json:"action" example:"reception.close"`
+	EntityType string          `json:"entityType" example:"reception"`
+	EntityId   string          `json:"entityId" example:"rec123"`
+	PvzId      string          `json:"pvzId,omitempty" example:"pvz789"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	RequestId  string          `json:"requestId,omitempty" example:"9f1c2d3e-4b5a-6c7d-8e9f-0a1b2c3d4e5f"`
+	TraceId    string          `json:"traceId,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+}
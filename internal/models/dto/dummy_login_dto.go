@@ -7,7 +7,10 @@ type DummyLoginPostRequest struct {
 }
 
 // TokenResponse godoc
-// @Description Response containing a JWT token.
+// @Description Response containing a JWT access token. Login and refresh also return a refresh token and the access token lifetime in seconds; passwordChangeRequired is set after a moderator has reset the password.
 type TokenResponse struct {
-	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Token                  string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken           string `json:"refreshToken,omitempty" example:"q3Vh0lJY9mX2w6c8n1pT4rE7sK5dA0bZgF2hU9jL3oQ"`
+	ExpiresIn              int    `json:"expiresIn,omitempty" example:"900"`
+	PasswordChangeRequired bool   `json:"passwordChangeRequired,omitempty" example:"false"`
 }
@@ -1,7 +1,8 @@
 package dto
 
 // Error godoc
-// @Description Standard error response containing an error message.
+// @Description Standard error response containing a machine-readable error code and a human-readable message.
 type Error struct {
+	Code    string `json:"code" example:"INVALID_REQUEST"`
 	Message string `json:"message" example:"invalid request body"`
 }
@@ -6,3 +6,9 @@ type LoginPostRequest struct {
 	Email    string `json:"email" binding:"required" example:"user@example.com"`
 	Password string `json:"password" binding:"required" example:"strongpassword123"`
 }
+
+// RefreshTokenRequest godoc
+// @Description Request payload for exchanging a refresh token for a new token pair.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required" example:"q3Vh0lJY9mX2w6c8n1pT4rE7sK5dA0bZgF2hU9jL3oQ"`
+}
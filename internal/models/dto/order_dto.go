@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+// OrderDTO godoc
+// @Description Represents a product assigned to a recipient for pickup at a PVZ.
+type OrderDTO struct {
+	ProductId   string     `json:"productId" example:"prod123"`
+	Type        string     `json:"type" example:"electronics"`
+	Barcode     string     `json:"barcode,omitempty" example:"4607001234567"`
+	PvzId       string     `json:"pvzId" example:"pvz789"`
+	Status      string     `json:"status" example:"ready_for_pickup"`
+	RecipientId string     `json:"recipientId,omitempty" example:"user123"`
+	ReceivedAt  time.Time  `json:"receivedAt" example:"2025-04-09T15:04:05Z"`
+	IssuedAt    *time.Time `json:"issuedAt,omitempty" example:"2025-04-12T10:00:00Z"`
+	IssuedBy    string     `json:"issuedBy,omitempty" example:"user456"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" example:"2025-04-16T15:04:05Z"`
+}
+
+// PrepareOrderRequest godoc
+// @Description Request payload for assigning a received product to a recipient (a user with the client role).
+type PrepareOrderRequest struct {
+	ProductId   string `json:"productId" binding:"required" example:"prod123"`
+	RecipientId string `json:"recipientId" binding:"required" example:"user123"`
+}
+
+// PrepareOrderResponse godoc
+// @Description Response returned after a product is ready for pickup. The pickup code is issued only to the recipient.
+type PrepareOrderResponse struct {
+	Order OrderDTO `json:"order"`
+}
+
+// IssueOrderRequest godoc
+// @Description Request payload for issuing an order to its recipient.
+type IssueOrderRequest struct {
+	PickupCode string `json:"pickupCode" binding:"required" example:"042917"`
+}
+
+// ClientOrderDTO godoc
+// @Description Represents a parcel of the current client: where it is waiting, its status and the storage deadline.
+type ClientOrderDTO struct {
+	ProductId       string     `json:"productId" example:"prod123"`
+	Type            string     `json:"type" example:"electronics"`
+	Status          string     `json:"status" example:"ready_for_pickup"`
+	PvzId           string     `json:"pvzId" example:"pvz789"`
+	PvzCity         string     `json:"pvzCity" example:"Moscow"`
+	PvzAddress      string     `json:"pvzAddress,omitempty" example:"ul. Tverskaya, 7"`
+	StorageDeadline *time.Time `json:"storageDeadline,omitempty" example:"2025-04-19T15:04:05Z"`
+	PickupCode      string     `json:"pickupCode,omitempty" example:"042917"`
+	IssuedAt        *time.Time `json:"issuedAt,omitempty" example:"2025-04-12T10:00:00Z"`
+}
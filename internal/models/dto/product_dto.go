@@ -8,14 +8,16 @@ type ProductDTO struct {
 	Id          string    `json:"id,omitempty" example:"prod123"`
 	DateTime    time.Time `json:"dateTime,omitempty" example:"2025-04-09T15:04:05Z"`
 	Type        string    `json:"type" example:"electronics"`
+	Barcode     string    `json:"barcode,omitempty" example:"4607001234567"`
 	ReceptionId string    `json:"receptionId" example:"recv456"`
 }
 
 // ProductsPostRequest godoc
 // @Description Request payload for adding a product to a reception.
 type ProductsPostRequest struct {
-	Type  string `json:"type" binding:"required" example:"clothes"`
-	PvzId string `json:"pvzId" binding:"required" example:"pvz789"`
+	Type    string `json:"type" binding:"required" example:"clothes"`
+	PvzId   string `json:"pvzId" binding:"required" example:"pvz789"`
+	Barcode string `json:"barcode,omitempty" example:"4607001234567"`
 }
 
 // ProductsPostResponse godoc
@@ -27,5 +29,42 @@ type ProductsPostResponse struct {
 // DeleteProductResponse godoc
 // @Description Response returned after successful deletion of the product.
 type DeleteProductResponse struct {
-	Message string `json:"message" example:"product deleted successfully"`
+	Message   string `json:"message" example:"product deleted successfully"`
+	ProductId string `json:"productId" example:"prod123"`
+	Barcode   string `json:"barcode,omitempty" example:"4607001234567"`
+}
+
+// ProductsBatchItem godoc
+// @Description A single product of a batch intake.
+type ProductsBatchItem struct {
+	Type    string `json:"type" example:"electronics"`
+	Barcode string `json:"barcode,omitempty" example:"4607001234567"`
+}
+
+// ProductsBatchRequest godoc
+// @Description Request payload for adding many products to the open reception at once. Mode "atomic" (default) rejects the whole batch if any item is invalid, "partial" saves only valid items.
+type ProductsBatchRequest struct {
+	PvzId    string              `json:"pvzId" binding:"required" example:"pvz789"`
+	Mode     string              `json:"mode,omitempty" binding:"omitempty,oneof=atomic partial" enums:"atomic,partial" example:"atomic"`
+	Products []ProductsBatchItem `json:"products" binding:"required"`
+}
+
+// ProductsBatchItemResult godoc
+// @Description Result of a single batch item: created, failed (with error) or skipped because the atomic batch was rejected.
+type ProductsBatchItemResult struct {
+	Index     int    `json:"index" example:"0"`
+	Status    string `json:"status" enums:"created,failed,skipped" example:"created"`
+	ProductId string `json:"productId,omitempty" example:"prod123"`
+	Type      string `json:"type" example:"electronics"`
+	Barcode   string `json:"barcode,omitempty" example:"4607001234567"`
+	Error     *Error `json:"error,omitempty"`
+}
+
+// ProductsBatchResponse godoc
+// @Description Response of a batch intake with per-item results in request order.
+type ProductsBatchResponse struct {
+	Mode    string                    `json:"mode" example:"atomic"`
+	Created int                       `json:"created" example:"398"`
+	Failed  int                       `json:"failed" example:"2"`
+	Items   []ProductsBatchItemResult `json:"items"`
 }
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PvzActivityQuery godoc
+// @Description Query parameters of the PVZ activity subscription.
+type PvzActivityQuery struct {
+	PvzIds      []string `form:"pvzId"`
+	LastEventId *int64   `form:"lastEventId"`
+}
+
+// PvzActivityEventDTO godoc
+// @Description Reception or product event of a PVZ. id is the position in the feed: pass the last received id to resume after a reconnect.
+type PvzActivityEventDTO struct {
+	Id          int64           `json:"id" example:"1042"`
+	EventId     string          `json:"eventId" example:"9f1c2d3e-4b5a-6c7d-8e9f-0a1b2c3d4e5f"`
+	Type        string          `json:"type" enums:"ReceptionOpened,ProductAdded,ProductRemoved,ReceptionClosed" example:"ProductAdded"`
+	PvzId       string          `json:"pvzId" example:"pvz789"`
+	AggregateId string          `json:"aggregateId" example:"prod123"`
+	OccurredAt  time.Time       `json:"occurredAt" example:"2025-05-04T10:00:00Z"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+}
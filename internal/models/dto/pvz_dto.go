@@ -5,9 +5,23 @@ import "time"
 // PvzDTO godoc
 // @Description Represents a PVZ (pickup point) with its information.
 type PvzDTO struct {
-	Id               string    `json:"id,omitempty" example:"pvz789"`
-	RegistrationDate time.Time `json:"registrationDate,omitempty" example:"2025-04-09T12:00:00Z"`
-	City             string    `json:"city" example:"Moscow"`
+	Id               string            `json:"id,omitempty" example:"pvz789"`
+	RegistrationDate time.Time         `json:"registrationDate,omitempty" example:"2025-04-09T12:00:00Z"`
+	City             string            `json:"city" example:"Moscow"`
+	Address          string            `json:"address,omitempty" example:"ul. Tverskaya, 7"`
+	Latitude         *float64          `json:"latitude,omitempty" example:"55.757"`
+	Longitude        *float64          `json:"longitude,omitempty" example:"37.6136"`
+	Phone            string            `json:"phone,omitempty" example:"+7 495 123-45-67"`
+	Status           string            `json:"status,omitempty" enums:"active,suspended,closed" example:"active"`
+	OpeningHours     []OpeningHoursDTO `json:"openingHours,omitempty"`
+}
+
+// OpeningHoursDTO godoc
+// @Description Opening hours of a PVZ on one weekday. Days without an entry are days off.
+type OpeningHoursDTO struct {
+	Weekday string `json:"weekday" binding:"required" enums:"mon,tue,wed,thu,fri,sat,sun" example:"mon"`
+	Opens   string `json:"opens" binding:"required" example:"09:00"`
+	Closes  string `json:"closes" binding:"required" example:"21:00"`
 }
 
 // PvzGet200ResponseInnerReceptionsInner godoc
@@ -27,7 +41,24 @@ type PvzGet200ResponseInner struct {
 // CreatePvzPostRequest godoc
 // @Description Request payload for creating a new PVZ.
 type CreatePvzPostRequest struct {
-	City string `json:"city" binding:"required" example:"Moscow"`
+	City         string            `json:"city" binding:"required" example:"Moscow"`
+	Address      string            `json:"address,omitempty" example:"ul. Tverskaya, 7"`
+	Latitude     *float64          `json:"latitude,omitempty" example:"55.757"`
+	Longitude    *float64          `json:"longitude,omitempty" example:"37.6136"`
+	Phone        string            `json:"phone,omitempty" example:"+7 495 123-45-67"`
+	OpeningHours []OpeningHoursDTO `json:"openingHours,omitempty" binding:"omitempty,dive"`
+}
+
+// UpdatePvzRequest godoc
+// @Description Partial update of PVZ details. Omitted fields are left unchanged; openingHours replaces the whole schedule. clearCoordinates removes the coordinates and cannot be combined with latitude and longitude.
+type UpdatePvzRequest struct {
+	Address          *string            `json:"address,omitempty" example:"ul. Tverskaya, 9"`
+	Latitude         *float64           `json:"latitude,omitempty" example:"55.757"`
+	Longitude        *float64           `json:"longitude,omitempty" example:"37.6136"`
+	ClearCoordinates bool               `json:"clearCoordinates,omitempty" example:"false"`
+	Phone            *string            `json:"phone,omitempty" example:"+7 495 123-45-67"`
+	Status           *string            `json:"status,omitempty" binding:"omitempty,oneof=active suspended closed" enums:"active,suspended,closed" example:"suspended"`
+	OpeningHours     *[]OpeningHoursDTO `json:"openingHours,omitempty" binding:"omitempty,dive"`
 }
 
 // CreatePvzResponse godoc
@@ -35,3 +66,22 @@ type CreatePvzPostRequest struct {
 type CreatePvzResponse struct {
 	PvzId string `json:"id" example:"pvz789"`
 }
+
+// NearbyPvzQuery godoc
+// @Description Query parameters of the nearest PVZ search.
+type NearbyPvzQuery struct {
+	Lat    *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lon    *float64 `form:"lon" binding:"required,min=-180,max=180"`
+	Radius float64  `form:"radius" binding:"omitempty,min=1,max=100000"`
+	City   string   `form:"city"`
+	Status string   `form:"status" binding:"omitempty,oneof=active suspended closed"`
+	Limit  int      `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// NearbyPvzDTO godoc
+// @Description PVZ found near the requested point with the distance to it and whether it is open right now.
+type NearbyPvzDTO struct {
+	Pvz            PvzDTO  `json:"pvz"`
+	DistanceMeters float64 `json:"distanceMeters" example:"742.5"`
+	OpenNow        bool    `json:"openNow" example:"true"`
+}
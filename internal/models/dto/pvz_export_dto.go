@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// PvzExportQuery godoc
+// @Description Query parameters of the PVZ history export.
+type PvzExportQuery struct {
+	Format    string     `form:"format" binding:"omitempty,oneof=csv ndjson xlsx"`
+	StartDate *time.Time `form:"startDate" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDate   *time.Time `form:"endDate" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// PvzExportRowDTO godoc
+// @Description One row of the PVZ history export: a PVZ, one of its receptions in the period and one product of that reception. Reception and product fields are empty when there are none.
+type PvzExportRowDTO struct {
+	PvzId               string     `json:"pvzId" example:"pvz789"`
+	PvzRegistrationDate time.Time  `json:"pvzRegistrationDate" example:"2025-04-09T12:00:00Z"`
+	PvzCity             string     `json:"pvzCity" example:"Moscow"`
+	PvzAddress          string     `json:"pvzAddress,omitempty" example:"ul. Tverskaya, 7"`
+	PvzStatus           string     `json:"pvzStatus" enums:"active,suspended,closed" example:"active"`
+	ReceptionId         string     `json:"receptionId,omitempty" example:"rec123"`
+	ReceptionDateTime   *time.Time `json:"receptionDateTime,omitempty" example:"2025-04-09T15:04:05Z"`
+	ReceptionStatus     string     `json:"receptionStatus,omitempty" example:"close"`
+	ProductId           string     `json:"productId,omitempty" example:"prod456"`
+	ProductDateTime     *time.Time `json:"productDateTime,omitempty" example:"2025-04-09T15:10:00Z"`
+	ProductType         string     `json:"productType,omitempty" example:"electronics"`
+	ProductBarcode      string     `json:"productBarcode,omitempty" example:"4601234567890"`
+}
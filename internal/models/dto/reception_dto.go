@@ -3,12 +3,15 @@ package dto
 import "time"
 
 // ReceptionDTO godoc
-// @Description Represents a reception record for goods.
+// @Description Represents a reception record for goods. openedBy and closedBy are the IDs of the employees who opened and closed the reception; they are absent for dummy-login users and for receptions recorded before these fields existed.
 type ReceptionDTO struct {
-	Id       string    `json:"id,omitempty" example:"recv456"`
-	DateTime time.Time `json:"dateTime" example:"2025-04-09T15:04:05Z"`
-	PvzId    string    `json:"pvzId" example:"pvz789"`
-	Status   string    `json:"status" example:"in_progress"`
+	Id       string     `json:"id,omitempty" example:"recv456"`
+	DateTime time.Time  `json:"dateTime" example:"2025-04-09T15:04:05Z"`
+	PvzId    string     `json:"pvzId" example:"pvz789"`
+	Status   string     `json:"status" example:"in_progress"`
+	OpenedBy string     `json:"openedBy,omitempty" example:"user123"`
+	ClosedBy string     `json:"closedBy,omitempty" example:"user456"`
+	ClosedAt *time.Time `json:"closedAt,omitempty" example:"2025-04-09T16:30:00Z"`
 }
 
 // CloseReceptionResponse godoc
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// ReturnItemDTO godoc
+// @Description Represents a product placed into a return shipment to the seller.
+type ReturnItemDTO struct {
+	Id        string    `json:"id" example:"item123"`
+	DateTime  time.Time `json:"dateTime" example:"2025-04-09T15:04:05Z"`
+	ReturnId  string    `json:"returnId" example:"ret456"`
+	ProductId string    `json:"productId" example:"prod123"`
+	Reason    string    `json:"reason" enums:"refused,expired,damaged" example:"refused"`
+}
+
+// CreateReturnRequest godoc
+// @Description Request payload for opening a return shipment at a PVZ.
+type CreateReturnRequest struct {
+	PvzId string `json:"pvzId" binding:"required" example:"pvz789"`
+}
+
+// CreateReturnResponse godoc
+// @Description Response returned after a return shipment is opened.
+type CreateReturnResponse struct {
+	ReturnId string `json:"returnId" example:"ret456"`
+}
+
+// AddReturnItemRequest godoc
+// @Description Request payload for moving a product into the open return shipment of a PVZ.
+type AddReturnItemRequest struct {
+	PvzId     string `json:"pvzId" binding:"required" example:"pvz789"`
+	ProductId string `json:"productId" binding:"required" example:"prod123"`
+	Reason    string `json:"reason" binding:"required" enums:"refused,expired,damaged" example:"refused"`
+}
+
+// DeleteReturnItemResponse godoc
+// @Description Response returned after the last product is removed from the return shipment.
+type DeleteReturnItemResponse struct {
+	Message string `json:"message" example:"return item deleted successfully"`
+}
+
+// CloseReturnResponse godoc
+// @Description Response returned after successfully closing a return shipment.
+type CloseReturnResponse struct {
+	ReturnId string `json:"returnId" example:"ret456"`
+}
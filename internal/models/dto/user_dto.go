@@ -1,9 +1,43 @@
 package dto
 
+import "time"
+
 // User godoc
-// @Description Represents a user in the system.
+// @Description Represents a user in the system. Inactive users cannot log in; passwordChangeRequired is set after a moderator has reset the password.
 type User struct {
-	Id    string `json:"id,omitempty" example:"user123"`
-	Email string `json:"email" example:"user@example.com"`
-	Role  string `json:"role" example:"moderator"`
+	Id                     string    `json:"id,omitempty" example:"user123"`
+	Email                  string    `json:"email" example:"user@example.com"`
+	Role                   string    `json:"role" example:"moderator"`
+	Active                 bool      `json:"active" example:"true"`
+	PasswordChangeRequired bool      `json:"passwordChangeRequired" example:"false"`
+	CreatedAt              time.Time `json:"createdAt" example:"2025-05-01T10:00:00Z"`
+}
+
+// UserQuery godoc
+// @Description Filters of the user list. Empty filters do not restrict the result.
+type UserQuery struct {
+	Email  string `form:"email"`
+	Role   string `form:"role"`
+	Active *bool  `form:"active"`
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+}
+
+// ChangeRoleRequest godoc
+// @Description Request payload for changing a user's role.
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required" example:"employee"`
+}
+
+// PasswordResetResponse godoc
+// @Description Temporary password set by a moderator. It is shown only once.
+type PasswordResetResponse struct {
+	TemporaryPassword string `json:"temporaryPassword" example:"k3Jd9QmX2vLp0aTz"`
+}
+
+// ChangePasswordRequest godoc
+// @Description Request payload for changing the password of the current user.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required" example:"oldpassword123"`
+	NewPassword     string `json:"newPassword" binding:"required" example:"newpassword456"`
 }
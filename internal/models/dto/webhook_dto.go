@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateWebhookRequest godoc
+// @Description Webhook subscription. If secret is omitted, a random one is generated and returned once in the response.
+type CreateWebhookRequest struct {
+	Url        string   `json:"url" binding:"required" example:"https://partner.example.com/hooks/pvz"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1" example:"ProductAdded,OrderIssued"`
+	Secret     string   `json:"secret,omitempty" example:"whsec_3f1c9a7e2b4d6f8a0c1e3b5d7f9a1c3e"`
+}
+
+// UpdateWebhookRequest godoc
+// @Description Partial update of a webhook subscription. Omitted fields are left unchanged; eventTypes replaces the whole list.
+type UpdateWebhookRequest struct {
+	Url        *string   `json:"url,omitempty" example:"https://partner.example.com/hooks/pvz-v2"`
+	EventTypes *[]string `json:"eventTypes,omitempty" example:"OrderIssued"`
+	Active     *bool     `json:"active,omitempty" example:"false"`
+}
+
+// WebhookDTO godoc
+// @Description Webhook subscription. The secret is present only in the response to creation.
+type WebhookDTO struct {
+	Id         string    `json:"id" example:"5f0c6a9e-2b7d-4c1e-8f3a-9d2b4c6e8a01"`
+	Url        string    `json:"url" example:"https://partner.example.com/hooks/pvz"`
+	EventTypes []string  `json:"eventTypes" example:"OrderIssued,ProductAdded"`
+	Active     bool      `json:"active" example:"true"`
+	Secret     string    `json:"secret,omitempty" example:"whsec_3f1c9a7e2b4d6f8a0c1e3b5d7f9a1c3e"`
+	CreatedAt  time.Time `json:"createdAt" example:"2025-05-03T10:00:00Z"`
+	UpdatedAt  time.Time `json:"updatedAt" example:"2025-05-03T10:00:00Z"`
+}
+
+// WebhookDeliveriesQuery godoc
+// @Description Filters of the webhook delivery history.
+type WebhookDeliveriesQuery struct {
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+}
+
+// WebhookDeliveryDTO godoc
+// @Description Delivery of one event to a webhook subscription. Attempts history is present only when a single delivery is requested.
+type WebhookDeliveryDTO struct {
+	Id             int64                       `json:"id" example:"42"`
+	WebhookId      string                      `json:"webhookId" example:"5f0c6a9e-2b7d-4c1e-8f3a-9d2b4c6e8a01"`
+	EventId        string                      `json:"eventId" example:"9f1c2d3e-4b5a-6c7d-8e9f-0a1b2c3d4e5f"`
+	EventType      string                      `json:"eventType" example:"OrderIssued"`
+	AggregateId    string                      `json:"aggregateId" example:"prod123"`
+	PvzId          string                      `json:"pvzId" example:"pvz789"`
+	Payload        json.RawMessage             `json:"payload" swaggertype:"object"`
+	OccurredAt     time.Time                   `json:"occurredAt" example:"2025-05-03T10:00:00Z"`
+	Status         string                      `json:"status" enums:"pending,delivered,failed" example:"failed"`
+	Attempts       int                         `json:"attempts" example:"8"`
+	NextAttemptAt  *time.Time                  `json:"nextAttemptAt,omitempty" example:"2025-05-03T10:05:00Z"`
+	LastStatusCode *int                        `json:"lastStatusCode,omitempty" example:"503"`
+	LastError      string                      `json:"lastError,omitempty" example:"unexpected status 503: maintenance"`
+	CreatedAt      time.Time                   `json:"createdAt" example:"2025-05-03T10:00:00Z"`
+	DeliveredAt    *time.Time                  `json:"deliveredAt,omitempty" example:"2025-05-03T10:00:01Z"`
+	History        []WebhookDeliveryAttemptDTO `json:"history,omitempty"`
+}
+
+// WebhookDeliveryAttemptDTO godoc
+// @Description One delivery attempt. statusCode is absent when no response was received.
+type WebhookDeliveryAttemptDTO struct {
+	AttemptedAt time.Time `json:"attemptedAt" example:"2025-05-03T10:00:00Z"`
+	DurationMs  int64     `json:"durationMs" example:"120"`
+	StatusCode  *int      `json:"statusCode,omitempty" example:"503"`
+	Error       string    `json:"error,omitempty" example:"unexpected status 503: maintenance"`
+}
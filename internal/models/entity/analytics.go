@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// AnalyticsFilter — фильтр аналитики приёмок. Пустые поля не ограничивают выборку,
+// период применяется к дате приёмки.
+type AnalyticsFilter struct {
+	City      string
+	PvzID     string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// DailyReceptions — число приёмок ПВЗ за один день (по московскому времени).
+type DailyReceptions struct {
+	PvzID      string
+	City       string
+	Day        time.Time
+	Receptions int
+}
+
+// ProductTypeCount — число товаров одного типа, принятых в ПВЗ за период.
+type ProductTypeCount struct {
+	PvzID    string
+	City     string
+	Type     string
+	Products int
+}
+
+// ReceptionStats — сводка по приёмкам ПВЗ за период. AvgDurationSeconds считается только
+// по приёмкам с известным временем закрытия и равен nil, если таких нет.
+type ReceptionStats struct {
+	PvzID                   string
+	City                    string
+	Receptions              int
+	ClosedReceptions        int
+	Products                int
+	AvgDurationSeconds      *float64
+	AvgProductsPerReception float64
+}
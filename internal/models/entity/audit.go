@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Действия, попадающие в журнал аудита
+const (
+	AuditPvzCreate          = "pvz.create"
+	AuditPvzUpdate          = "pvz.update"
+	AuditReceptionCreate    = "reception.create"
+	AuditReceptionClose     = "reception.close"
+	AuditProductCreate      = "product.create"
+	AuditProductBatchCreate = "product.batch_create"
+	AuditProductDelete      = "product.delete"
+	AuditOrderPrepare       = "order.prepare"
+	AuditOrderIssue         = "order.issue"
+	AuditOrderReturn        = "order.return"
+	AuditOrderCodeRenew     = "order.pickup_code_renew"
+	AuditReturnCreate       = "return.create"
+	AuditReturnItemAdd      = "return.item_add"
+	AuditReturnItemDelete   = "return.item_delete"
+	AuditReturnClose        = "return.close"
+	AuditUserRegister       = "user.register"
+	AuditUserUnlock         = "user.unlock"
+	AuditUserRoleChange     = "user.role_change"
+	AuditUserDeactivate     = "user.deactivate"
+	AuditUserReactivate     = "user.reactivate"
+	AuditUserPasswordReset  = "user.password_reset"
+	AuditUserPasswordChange = "user.password_change"
+	AuditWebhookCreate      = "webhook.create"
+	AuditWebhookUpdate      = "webhook.update"
+	AuditWebhookDelete      = "webhook.delete"
+	AuditWebhookRetry       = "webhook.delivery_retry"
+)
+
+// Типы сущностей журнала аудита
+const (
+	AuditEntityPvz        = "pvz"
+	AuditEntityReception  = "reception"
+	AuditEntityProduct    = "product"
+	AuditEntityReturn     = "return"
+	AuditEntityReturnItem = "return_item"
+	AuditEntityUser       = "user"
+	AuditEntityWebhook    = "webhook"
+)
+
+// AuditEntry — запись журнала аудита. ActorID равен nil для анонимных вызовов
+// (регистрация) и токенов /dummyLogin, последние отмечены ActorDummy; Before и After — состояние
+// сущности до и после изменения.
+type AuditEntry struct {
+	ID         int64
+	CreatedAt  time.Time
+	ActorID    *string
+	ActorRole  string
+	ActorDummy bool
+	Action     string
+	EntityType string
+	EntityID   string
+	PvzID      *string
+	Before     json.RawMessage
+	After      json.RawMessage
+	RequestID  string
+	TraceID    string
+}
+
+// AuditFilter — фильтр журнала аудита. Пустые поля не ограничивают выборку.
+// Записи отдаются от новых к старым; если задан BeforeID, страница начинается сразу после этой записи.
+type AuditFilter struct {
+	ActorID    string
+	Action     string
+	EntityType string
+	EntityID   string
+	PvzID      string
+	StartDate  *time.Time
+	EndDate    *time.Time
+	Limit      int
+	BeforeID   *int64
+}
+
+// AuditPage — страница журнала аудита. NextCursor равен nil, если следующей страницы нет.
+type AuditPage struct {
+	Items      []AuditEntry
+	NextCursor *int64
+	HasMore    bool
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// Области учёта неудачных попыток входа
+const (
+	LoginScopeEmail = "email"
+	LoginScopeIP    = "ip"
+)
+
+// LoginAttemptKey — email (в нижнем регистре) или IP-адрес, по которому считаются попытки входа.
+type LoginAttemptKey struct {
+	Scope string
+	Key   string
+}
+
+// LoginAttempts — неудачные попытки входа подряд. LockedUntil задан, если вход был заблокирован.
+type LoginAttempts struct {
+	Scope         string
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginAttemptPolicy — ограничения попыток входа в одной области. MaxFailures <= 0 отключает блокировку.
+type LoginAttemptPolicy struct {
+	MaxFailures   int
+	Lockout       time.Duration
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+	FailureWindow time.Duration
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// Статусы товара в жизненном цикле выдачи
+const (
+	ProductStatusReceived       = "received"
+	ProductStatusReadyForPickup = "ready_for_pickup"
+	ProductStatusIssued         = "issued"
+	ProductStatusReturned       = "returned"
+	ProductStatusInReturn       = "in_return"    // товар в незакрытой отгрузке возвратов
+	ProductStatusShippedBack    = "shipped_back" // товар отгружен продавцу
+)
+
+// Order — товар, назначенный получателю для выдачи в ПВЗ.
+type Order struct {
+	ProductID       string     `json:"product_id"`
+	ProductType     string     `json:"product_type"`
+	Barcode         string     `json:"barcode"`
+	ReceptionID     string     `json:"reception_id"`
+	ReceptionStatus string     `json:"reception_status"`
+	PvzID           string     `json:"pvz_id"`
+	PvzCity         string     `json:"pvz_city"`
+	PvzAddress      string     `json:"pvz_address"`
+	Status          string     `json:"status"`
+	RecipientID     *string    `json:"recipient_id"`
+	PickupCode      *string    `json:"-"` // открытый код, известен только сразу после выпуска
+	PickupCodeHash  *string    `json:"-"`
+	PickupFailures  int        `json:"-"` // неверные коды, введённые при выдаче
+	ReceivedAt      time.Time  `json:"received_at"`
+	IssuedAt        *time.Time `json:"issued_at"`
+	IssuedBy        *string    `json:"issued_by"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+	ExpiredAt       *time.Time `json:"expired_at"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Типы доменных событий, публикуемых через outbox
+const (
+	EventPvzCreated      = "PvzCreated"
+	EventReceptionOpened = "ReceptionOpened"
+	EventProductAdded    = "ProductAdded"
+	EventProductRemoved  = "ProductRemoved"
+	EventReceptionClosed = "ReceptionClosed"
+	EventOrderIssued     = "OrderIssued"
+	EventOrderReturned   = "OrderReturned"
+)
+
+// PvzActivityEvents — события, которые транслируются в живую ленту активности ПВЗ.
+// Список совпадает с условием триггера notify_pvz_activity.
+var PvzActivityEvents = []string{EventReceptionOpened, EventProductAdded, EventProductRemoved, EventReceptionClosed}
+
+// PvzActivityFilter — подписка на ленту активности. С LastEventID сначала отдаются
+// сохранённые события с большим id, затем новые.
+type PvzActivityFilter struct {
+	PvzIDs      []string
+	LastEventID *int64
+}
+
+// Статусы события в outbox. Dead — событие исчерпало попытки публикации и ждёт разбора вручную.
+const (
+	OutboxPending   = "pending"
+	OutboxPublished = "published"
+	OutboxDead      = "dead"
+)
+
+// OutboxEvent — доменное событие, ожидающее публикации. AggregateID — ID ПВЗ, приёмки
+// или товара, к которому относится событие; EventID потребители используют для дедупликации.
+type OutboxEvent struct {
+	ID            int64
+	EventID       string
+	Type          string
+	AggregateID   string
+	PvzID         string
+	Payload       json.RawMessage
+	CreatedAt     time.Time
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
@@ -3,8 +3,34 @@ package entity
 import "time"
 
 type Product struct {
-	ID          string    `json:"id"`
-	DateTime    time.Time `json:"date_time"`
-	Type        string    `json:"type"`
-	ReceptionID string    `json:"reception_id"`
+	ID          string     `json:"id"`
+	DateTime    time.Time  `json:"date_time"`
+	Type        string     `json:"type"`
+	Barcode     string     `json:"barcode"`
+	ReceptionID string     `json:"reception_id"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// Статусы позиций пакетной приёмки товаров
+const (
+	BatchItemCreated = "created"
+	BatchItemFailed  = "failed"
+	BatchItemSkipped = "skipped" // позиция корректна, но пакет отклонён целиком
+)
+
+// ProductBatchItem — позиция пакетного добавления товаров в приёмку.
+type ProductBatchItem struct {
+	Type    string `json:"type"`
+	Barcode string `json:"barcode"`
+}
+
+// ProductBatchResult — результат обработки позиции пакета. Index — номер позиции в запросе.
+type ProductBatchResult struct {
+	Index      int    `json:"index"`
+	ProductID  string `json:"product_id"`
+	Type       string `json:"type"`
+	Barcode    string `json:"barcode"`
+	Status     string `json:"status"`
+	ErrCode    string `json:"err_code"`
+	ErrMessage string `json:"err_message"`
 }
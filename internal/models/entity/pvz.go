@@ -2,8 +2,80 @@ package entity
 
 import "time"
 
+// Статусы ПВЗ
+const (
+	PvzStatusActive    = "active"
+	PvzStatusSuspended = "suspended" // временно не работает
+	PvzStatusClosed    = "closed"    // закрыт, новые приёмки запрещены
+)
+
 type Pvz struct {
-	ID               string    `json:"id"`
-	RegistrationDate time.Time `json:"registration_date"`
-	City             string    `json:"city"`
+	ID               string         `json:"id"`
+	RegistrationDate time.Time      `json:"registration_date"`
+	City             string         `json:"city"`
+	Address          string         `json:"address"`
+	Latitude         *float64       `json:"latitude"`
+	Longitude        *float64       `json:"longitude"`
+	Phone            string         `json:"phone"`
+	Status           string         `json:"status"`
+	OpeningHours     []OpeningHours `json:"opening_hours"`
+}
+
+// OpeningHours — часы работы ПВЗ в один день недели. Дни без записи — выходные.
+// Weekday: mon, tue, wed, thu, fri, sat, sun; время в формате HH:MM.
+type OpeningHours struct {
+	Weekday string `json:"weekday"`
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"`
+}
+
+// PvzUpdate — частичное обновление ПВЗ, nil-поля не меняются.
+// ClearCoordinates удаляет координаты ПВЗ и не сочетается с Latitude и Longitude.
+type PvzUpdate struct {
+	Address          *string
+	Latitude         *float64
+	Longitude        *float64
+	ClearCoordinates bool
+	Phone            *string
+	Status           *string
+	OpeningHours     *[]OpeningHours
+}
+
+// NearbyPvzFilter — параметры поиска ПВЗ рядом с точкой.
+// City и Status необязательны; пустое значение отключает фильтр.
+type NearbyPvzFilter struct {
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64
+	City         string
+	Status       string
+	Limit        int
+}
+
+// NearbyPvz — ПВЗ из результатов поиска с расстоянием до точки в метрах.
+type NearbyPvz struct {
+	Pvz            Pvz     `json:"pvz"`
+	DistanceMeters float64 `json:"distance_meters"`
+	OpenNow        bool    `json:"open_now"`
+}
+
+// PvzCursor — ключ keyset-пагинации списка ПВЗ: (registration_date, id) последнего ПВЗ страницы.
+type PvzCursor struct {
+	RegistrationDate time.Time
+	ID               string
+}
+
+// PvzPageRequest — запрос страницы списка ПВЗ. Если задан After, страница начинается
+// сразу после этого ключа, а Offset игнорируется.
+type PvzPageRequest struct {
+	Offset int
+	Limit  int
+	After  *PvzCursor
+}
+
+// PvzPage — страница списка ПВЗ. NextCursor равен nil, если следующей страницы нет.
+type PvzPage struct {
+	Items      []Pvz
+	NextCursor *PvzCursor
+	HasMore    bool
 }
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// PvzExportFilter — период выгрузки истории ПВЗ по дате приёмки. Nil-граница период не ограничивает.
+type PvzExportFilter struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// PvzExportRow — строка выгрузки истории: ПВЗ, его приёмка и товар приёмки.
+// У ПВЗ без приёмок за период пусты поля приёмки, у приёмки без товаров — поля товара.
+type PvzExportRow struct {
+	PvzID               string
+	PvzRegistrationDate time.Time
+	PvzCity             string
+	PvzAddress          string
+	PvzStatus           string
+	ReceptionID         string
+	ReceptionDateTime   *time.Time
+	ReceptionStatus     string
+	ProductID           string
+	ProductDateTime     *time.Time
+	ProductType         string
+	ProductBarcode      string
+}
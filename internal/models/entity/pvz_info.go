@@ -4,3 +4,10 @@ type PvzInfo struct {
 	Pvz        Pvz
 	Receptions []ReceptionInfo
 }
+
+// PvzInfoPage — страница списка ПВЗ с приёмками и ключом следующей страницы.
+type PvzInfoPage struct {
+	Items      []PvzInfo
+	NextCursor *PvzCursor // nil, если следующей страницы нет
+	HasMore    bool
+}
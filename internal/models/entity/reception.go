@@ -2,9 +2,14 @@ package entity
 
 import "time"
 
+// Reception — приёмка товаров в ПВЗ. OpenedBy и ClosedBy — ID сотрудников из JWT;
+// nil, если приёмку вёл пользователь /dummyLogin или она создана до появления этих полей.
 type Reception struct {
-	ID       string    `json:"id"`
-	DateTime time.Time `json:"date_time"`
-	PvzID    string    `json:"pvz_id"`
-	Status   string    `json:"status"`
+	ID       string     `json:"id"`
+	DateTime time.Time  `json:"date_time"`
+	PvzID    string     `json:"pvz_id"`
+	Status   string     `json:"status"`
+	OpenedBy *string    `json:"opened_by"`
+	ClosedBy *string    `json:"closed_by"`
+	ClosedAt *time.Time `json:"closed_at"`
 }
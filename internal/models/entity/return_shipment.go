@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Причины возврата товара продавцу
+const (
+	ReturnReasonRefused = "refused" // получатель отказался от заказа
+	ReturnReasonExpired = "expired" // истёк срок хранения
+	ReturnReasonDamaged = "damaged" // товар повреждён
+)
+
+// ReturnShipment — отгрузка возвратов из ПВЗ продавцу, симметричная приёмке.
+type ReturnShipment struct {
+	ID       string    `json:"id"`
+	DateTime time.Time `json:"date_time"`
+	PvzID    string    `json:"pvz_id"`
+	Status   string    `json:"status"`
+}
+
+// ReturnItem — товар, добавленный в отгрузку возвратов.
+type ReturnItem struct {
+	ID             string    `json:"id"`
+	DateTime       time.Time `json:"date_time"`
+	ReturnID       string    `json:"return_id"`
+	ProductID      string    `json:"product_id"`
+	Reason         string    `json:"reason"`
+	PreviousStatus string    `json:"previous_status"`
+}
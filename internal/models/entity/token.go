@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// TokenPair — выданные при входе или обновлении access- и refresh-токены. PasswordChangeRequired
+// сообщает клиенту, что пароль сброшен модератором и его нужно сменить.
+type TokenPair struct {
+	AccessToken            string
+	AccessExpiresAt        time.Time
+	RefreshToken           string
+	RefreshExpiresAt       time.Time
+	PasswordChangeRequired bool
+}
+
+// RefreshToken — сохранённый refresh-токен. Хранится только SHA-256 хеш самого токена.
+// Токены одной сессии образуют семейство FamilyID: каждое обновление помечает текущий
+// токен использованным и выдаёт следующий в том же семействе. AccessJTI — access-токен,
+// выданный вместе с этим refresh-токеном, он отзывается вместе с семейством.
+type RefreshToken struct {
+	ID              string
+	UserID          string
+	FamilyID        string
+	TokenHash       string
+	AccessJTI       string
+	AccessExpiresAt time.Time
+	ExpiresAt       time.Time
+	CreatedAt       time.Time
+	UsedAt          *time.Time
+	RevokedAt       *time.Time
+}
@@ -2,10 +2,37 @@ package entity
 
 import "time"
 
+// User — учётная запись. Неактивному пользователю (Active = false) вход запрещён;
+// PasswordChangeRequired выставляется, когда модератор сбросил пароль на временный.
 type User struct {
-	ID           string    `son:"id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	Role         string    `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID                     string    `json:"id"`
+	Email                  string    `json:"email"`
+	PasswordHash           string    `json:"-"`
+	Role                   string    `json:"role"`
+	CreatedAt              time.Time `json:"created_at"`
+	Active                 bool      `json:"active"`
+	PasswordChangeRequired bool      `json:"password_change_required"`
+}
+
+// UserCursor — ключ keyset-пагинации списка пользователей: (created_at, id) последнего пользователя страницы.
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// UserFilter — фильтр списка пользователей. Email ищется по подстроке без учёта регистра,
+// пустые поля не ограничивают выборку. Пользователи отдаются от новых к старым.
+type UserFilter struct {
+	Email  string
+	Role   string
+	Active *bool
+	Limit  int
+	After  *UserCursor
+}
+
+// UserPage — страница списка пользователей. NextCursor равен nil, если следующей страницы нет.
+type UserPage struct {
+	Items      []User
+	NextCursor *UserCursor
+	HasMore    bool
 }
@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Статусы доставки вебхука. Failed — попытки исчерпаны, доставку можно повторить через API.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookEventTypes — события, на которые можно подписать вебхук
+var WebhookEventTypes = map[string]bool{
+	EventPvzCreated:      true,
+	EventReceptionOpened: true,
+	EventProductAdded:    true,
+	EventProductRemoved:  true,
+	EventReceptionClosed: true,
+	EventOrderIssued:     true,
+	EventOrderReturned:   true,
+}
+
+// WebhookSubscription — подписка партнёра на события. Secret не сериализуется,
+// чтобы не попасть в журнал аудита; клиенту он отдаётся только при создании.
+type WebhookSubscription struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"-"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookSubscriptionUpdate — изменение подписки. Nil-поля не меняются.
+type WebhookSubscriptionUpdate struct {
+	URL        *string
+	EventTypes []string
+	Active     *bool
+}
+
+// WebhookDelivery — доставка одного события одной подписке. History заполняется
+// только при запросе одной доставки.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	AggregateID    string
+	PvzID          string
+	Payload        json.RawMessage
+	OccurredAt     time.Time
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+	History        []WebhookDeliveryAttempt
+}
+
+// Event восстанавливает доменное событие, которое доставляется подписке.
+func (d WebhookDelivery) Event() OutboxEvent {
+	return OutboxEvent{
+		EventID:     d.EventID,
+		Type:        d.EventType,
+		AggregateID: d.AggregateID,
+		PvzID:       d.PvzID,
+		Payload:     d.Payload,
+		CreatedAt:   d.OccurredAt,
+	}
+}
+
+// WebhookDeliveryTask — доставка, взятая воркером в работу, вместе с адресом и секретом подписки.
+type WebhookDeliveryTask struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
+
+// WebhookDeliveryAttempt — одна попытка доставки. StatusCode равен nil, если ответ не получен.
+type WebhookDeliveryAttempt struct {
+	ID          int64
+	DeliveryID  int64
+	AttemptedAt time.Time
+	Duration    time.Duration
+	StatusCode  *int
+	Error       string
+}
+
+type WebhookDeliveryFilter struct {
+	SubscriptionID string
+	Status         string
+	Limit          int
+	BeforeID       *int64
+}
+
+type WebhookDeliveryPage struct {
+	Items      []WebhookDelivery
+	NextCursor *int64
+	HasMore    bool
+}
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+// analyticsDayLayout — формат дня в ответах аналитики
+const analyticsDayLayout = "2006-01-02"
+
+func AnalyticsQueryToFilter(q dto.AnalyticsQuery) entity.AnalyticsFilter {
+	return entity.AnalyticsFilter{
+		City:      q.City,
+		PvzID:     q.PvzId,
+		StartDate: q.StartDate,
+		EndDate:   q.EndDate,
+	}
+}
+
+func DailyReceptionsEntityToDTO(d entity.DailyReceptions) dto.DailyReceptionsDTO {
+	return dto.DailyReceptionsDTO{
+		PvzId:      d.PvzID,
+		City:       d.City,
+		Day:        d.Day.Format(analyticsDayLayout),
+		Receptions: d.Receptions,
+	}
+}
+
+func ProductTypeCountEntityToDTO(c entity.ProductTypeCount) dto.ProductTypeCountDTO {
+	return dto.ProductTypeCountDTO{
+		PvzId:    c.PvzID,
+		City:     c.City,
+		Type:     c.Type,
+		Products: c.Products,
+	}
+}
+
+func ReceptionStatsEntityToDTO(s entity.ReceptionStats) dto.ReceptionStatsDTO {
+	return dto.ReceptionStatsDTO{
+		PvzId:                   s.PvzID,
+		City:                    s.City,
+		Receptions:              s.Receptions,
+		ClosedReceptions:        s.ClosedReceptions,
+		Products:                s.Products,
+		AvgDurationSeconds:      s.AvgDurationSeconds,
+		AvgProductsPerReception: s.AvgProductsPerReception,
+	}
+}
@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDailyReceptionsEntityToDTO(t *testing.T) {
+	t.Parallel()
+
+	got := DailyReceptionsEntityToDTO(entity.DailyReceptions{
+		PvzID:      "pvz1",
+		City:       "Moscow",
+		Day:        time.Date(2025, 4, 9, 0, 0, 0, 0, time.UTC),
+		Receptions: 3,
+	})
+	expected := dto.DailyReceptionsDTO{PvzId: "pvz1", City: "Moscow", Day: "2025-04-09", Receptions: 3}
+	if got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestReceptionStatsEntityToDTO(t *testing.T) {
+	t.Parallel()
+
+	avg := 1830.5
+	tests := []struct {
+		name     string
+		stats    entity.ReceptionStats
+		expected dto.ReceptionStatsDTO
+	}{
+		{
+			name: "with closed receptions",
+			stats: entity.ReceptionStats{
+				PvzID: "pvz1", City: "Moscow", Receptions: 2, ClosedReceptions: 1, Products: 5,
+				AvgDurationSeconds: &avg, AvgProductsPerReception: 2.5,
+			},
+			expected: dto.ReceptionStatsDTO{
+				PvzId: "pvz1", City: "Moscow", Receptions: 2, ClosedReceptions: 1, Products: 5,
+				AvgDurationSeconds: &avg, AvgProductsPerReception: 2.5,
+			},
+		},
+		{
+			name:     "no closed receptions",
+			stats:    entity.ReceptionStats{PvzID: "pvz2", City: "Kazan", Receptions: 1},
+			expected: dto.ReceptionStatsDTO{PvzId: "pvz2", City: "Kazan", Receptions: 1},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ReceptionStatsEntityToDTO(tc.stats); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
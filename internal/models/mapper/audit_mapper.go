@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+// AuditQueryToFilter переносит фильтры запроса в entity.AuditFilter. Курсор разбирается отдельно.
+func AuditQueryToFilter(q dto.AuditQuery) entity.AuditFilter {
+	return entity.AuditFilter{
+		ActorID:    q.ActorId,
+		Action:     q.Action,
+		EntityType: q.EntityType,
+		EntityID:   q.EntityId,
+		PvzID:      q.PvzId,
+		StartDate:  q.StartDate,
+		EndDate:    q.EndDate,
+		Limit:      q.Limit,
+	}
+}
+
+func AuditEntryEntityToDTO(e entity.AuditEntry) dto.AuditEntryDTO {
+	result := dto.AuditEntryDTO{
+		Id:         e.ID,
+		CreatedAt:  e.CreatedAt,
+		ActorRole:  e.ActorRole,
+		ActorDummy: e.ActorDummy,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityId:   e.EntityID,
+		Before:     e.Before,
+		After:      e.After,
+		RequestId:  e.RequestID,
+		TraceId:    e.TraceID,
+	}
+	if e.ActorID != nil {
+		result.ActorId = *e.ActorID
+	}
+	if e.PvzID != nil {
+		result.PvzId = *e.PvzID
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package mapper
+
+import (
+	"encoding/json"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuditEntryEntityToDTO(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	actor := "user1"
+	pvz := "pvz1"
+
+	tests := []struct {
+		name     string
+		entry    entity.AuditEntry
+		expected dto.AuditEntryDTO
+	}{
+		{
+			name: "full entry",
+			entry: entity.AuditEntry{
+				ID: 7, CreatedAt: created, ActorID: &actor, ActorRole: "employee",
+				Action: entity.AuditReceptionClose, EntityType: entity.AuditEntityReception, EntityID: "rec1", PvzID: &pvz,
+				Before: json.RawMessage(`{"status":"in_progress"}`), After: json.RawMessage(`{"status":"close"}`),
+				RequestID: "req1", TraceID: "trace1",
+			},
+			expected: dto.AuditEntryDTO{
+				Id: 7, CreatedAt: created, ActorId: "user1", ActorRole: "employee",
+				Action: "reception.close", EntityType: "reception", EntityId: "rec1", PvzId: "pvz1",
+				Before: json.RawMessage(`{"status":"in_progress"}`), After: json.RawMessage(`{"status":"close"}`),
+				RequestId: "req1", TraceId: "trace1",
+			},
+		},
+		{
+			name: "anonymous registration",
+			entry: entity.AuditEntry{
+				ID: 8, CreatedAt: created, Action: entity.AuditUserRegister, EntityType: entity.AuditEntityUser, EntityID: "user2",
+				After: json.RawMessage(`{"role":"client"}`),
+			},
+			expected: dto.AuditEntryDTO{
+				Id: 8, CreatedAt: created, Action: "user.register", EntityType: "user", EntityId: "user2",
+				After: json.RawMessage(`{"role":"client"}`),
+			},
+		},
+		{
+			name: "dummy login token",
+			entry: entity.AuditEntry{
+				ID: 9, CreatedAt: created, ActorRole: "moderator", ActorDummy: true,
+				Action: entity.AuditPvzCreate, EntityType: entity.AuditEntityPvz, EntityID: "pvz1", PvzID: &pvz,
+			},
+			expected: dto.AuditEntryDTO{
+				Id: 9, CreatedAt: created, ActorRole: "moderator", ActorDummy: true,
+				Action: "pvz.create", EntityType: "pvz", EntityId: "pvz1", PvzId: "pvz1",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := AuditEntryEntityToDTO(tc.entry); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package mapper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"time"
+)
+
+// timeIDCursorToken — содержимое непрозрачного курсора по ключу (время, id) до кодирования в base64:
+// ПВЗ по дате регистрации, пользователи по дате создания
+type timeIDCursorToken struct {
+	Time time.Time `json:"d"`
+	ID   string    `json:"id"`
+}
+
+func encodeTimeIDCursor(t time.Time, id string) string {
+	raw, _ := json.Marshal(timeIDCursorToken{Time: t, ID: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeTimeIDCursor(token string) (*timeIDCursorToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errs.New(errs.ErrInvalidCursor, "invalid cursor")
+	}
+	var t timeIDCursorToken
+	if err := json.Unmarshal(raw, &t); err != nil || t.Time.IsZero() {
+		return nil, errs.New(errs.ErrInvalidCursor, "invalid cursor")
+	}
+	// id сравнивается с колонкой uuid: иначе подделанный курсор дошёл бы до БД и вернул 500
+	if _, err := uuid.Parse(t.ID); err != nil {
+		return nil, errs.New(errs.ErrInvalidCursor, "invalid cursor")
+	}
+	return &t, nil
+}
+
+// EncodePvzCursor превращает ключ страницы в непрозрачный токен для клиента. Для nil возвращает "".
+func EncodePvzCursor(cursor *entity.PvzCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return encodeTimeIDCursor(cursor.RegistrationDate, cursor.ID)
+}
+
+// DecodePvzCursor разбирает токен, выданный EncodePvzCursor. Для пустой строки возвращает nil.
+func DecodePvzCursor(token string) (*entity.PvzCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	t, err := decodeTimeIDCursor(token)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.PvzCursor{RegistrationDate: t.Time, ID: t.ID}, nil
+}
+
+// EncodeUserCursor превращает ключ страницы списка пользователей в непрозрачный токен. Для nil возвращает "".
+func EncodeUserCursor(cursor *entity.UserCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return encodeTimeIDCursor(cursor.CreatedAt, cursor.ID)
+}
+
+// DecodeUserCursor разбирает токен, выданный EncodeUserCursor. Для пустой строки возвращает nil.
+func DecodeUserCursor(token string) (*entity.UserCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	t, err := decodeTimeIDCursor(token)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.UserCursor{CreatedAt: t.Time, ID: t.ID}, nil
+}
+
+// idCursorToken — содержимое курсора по возрастающему ID (журнал аудита, доставки вебхуков): ID последней записи страницы
+type idCursorToken struct {
+	ID int64 `json:"id"`
+}
+
+// EncodeIDCursor превращает ID последней записи страницы в непрозрачный токен. Для nil возвращает "".
+func EncodeIDCursor(lastID *int64) string {
+	if lastID == nil {
+		return ""
+	}
+	raw, _ := json.Marshal(idCursorToken{ID: *lastID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeIDCursor разбирает токен, выданный EncodeIDCursor. Для пустой строки возвращает nil.
+func DecodeIDCursor(token string) (*int64, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errs.New(errs.ErrInvalidCursor, "invalid cursor")
+	}
+	var t idCursorToken
+	if err := json.Unmarshal(raw, &t); err != nil || t.ID <= 0 {
+		return nil, errs.New(errs.ErrInvalidCursor, "invalid cursor")
+	}
+	return &t.ID, nil
+}
@@ -0,0 +1,103 @@
+package mapper
+
+import (
+	"errors"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"testing"
+	"time"
+)
+
+func TestPvzCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cursor := &entity.PvzCursor{
+		RegistrationDate: time.Date(2025, 4, 9, 12, 0, 0, 123456000, time.UTC),
+		ID:               "0b7f1f6e-3c1a-4a43-9f57-0c0f5f3b2a11",
+	}
+
+	token := EncodePvzCursor(cursor)
+	decoded, err := DecodePvzCursor(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decoded.RegistrationDate.Equal(cursor.RegistrationDate) || decoded.ID != cursor.ID {
+		t.Errorf("expected %+v, got %+v", cursor, decoded)
+	}
+
+	if EncodePvzCursor(nil) != "" {
+		t.Error("expected empty token for nil cursor")
+	}
+	if c, err := DecodePvzCursor(""); c != nil || err != nil {
+		t.Errorf("expected nil cursor for empty token, got %+v, %v", c, err)
+	}
+}
+
+func TestDecodePvzCursor_Invalid(t *testing.T) {
+	t.Parallel()
+
+	notUUID := encodeTimeIDCursor(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), "pvz1")
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "e30", notUUID} {
+		_, err := DecodePvzCursor(token)
+		var appErr *errs.AppError
+		if !errors.As(err, &appErr) || appErr.Code != errs.ErrInvalidCursor {
+			t.Errorf("token %q: expected %s error, got %v", token, errs.ErrInvalidCursor, err)
+		}
+	}
+}
+
+func TestUserCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cursor := &entity.UserCursor{
+		CreatedAt: time.Date(2025, 5, 8, 9, 30, 0, 500000000, time.UTC),
+		ID:        "6f1c2a4e-8a57-4d4b-9a4f-2b0e5d1c9e01",
+	}
+
+	decoded, err := DecodeUserCursor(EncodeUserCursor(cursor))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) || decoded.ID != cursor.ID {
+		t.Errorf("expected %+v, got %+v", cursor, decoded)
+	}
+
+	if EncodeUserCursor(nil) != "" {
+		t.Error("expected empty token for nil cursor")
+	}
+	if c, err := DecodeUserCursor(""); c != nil || err != nil {
+		t.Errorf("expected nil cursor for empty token, got %+v, %v", c, err)
+	}
+	if _, err := DecodeUserCursor("e30"); err == nil {
+		t.Error("expected error for cursor without key")
+	}
+	// Подделанный id не должен доходить до сравнения с колонкой uuid в ListUsers
+	notUUID := EncodeUserCursor(&entity.UserCursor{CreatedAt: cursor.CreatedAt, ID: "1 OR 1=1"})
+	_, err = DecodeUserCursor(notUUID)
+	var appErr *errs.AppError
+	if !errors.As(err, &appErr) || appErr.Code != errs.ErrInvalidCursor {
+		t.Errorf("expected %s error for non-UUID id, got %v", errs.ErrInvalidCursor, err)
+	}
+}
+
+func TestIDCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	id := int64(42)
+	decoded, err := DecodeIDCursor(EncodeIDCursor(&id))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded == nil || *decoded != id {
+		t.Errorf("expected %d, got %v", id, decoded)
+	}
+
+	if EncodeIDCursor(nil) != "" {
+		t.Error("expected empty token for nil cursor")
+	}
+	for _, token := range []string{"not base64!", "e30", EncodePvzCursor(&entity.PvzCursor{RegistrationDate: time.Now(), ID: "x"})} {
+		if _, err := DecodeIDCursor(token); err == nil {
+			t.Errorf("token %q: expected error", token)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+// OrderEntityToDTO преобразует сущность Order в DTO. Код выдачи в DTO не попадает.
+func OrderEntityToDTO(o entity.Order) dto.OrderDTO {
+	result := dto.OrderDTO{
+		ProductId:  o.ProductID,
+		Type:       o.ProductType,
+		Barcode:    o.Barcode,
+		PvzId:      o.PvzID,
+		Status:     o.Status,
+		ReceivedAt: o.ReceivedAt,
+		IssuedAt:   o.IssuedAt,
+		ExpiresAt:  o.ExpiresAt,
+	}
+	if o.RecipientID != nil {
+		result.RecipientId = *o.RecipientID
+	}
+	if o.IssuedBy != nil {
+		result.IssuedBy = *o.IssuedBy
+	}
+	return result
+}
+
+// OrderEntityToClientDTO преобразует сущность Order в DTO для получателя.
+// Код выдачи известен только сразу после выпуска и показывается, пока заказ ожидает выдачи.
+func OrderEntityToClientDTO(o entity.Order) dto.ClientOrderDTO {
+	result := dto.ClientOrderDTO{
+		ProductId:       o.ProductID,
+		Type:            o.ProductType,
+		Status:          o.Status,
+		PvzId:           o.PvzID,
+		PvzCity:         o.PvzCity,
+		PvzAddress:      o.PvzAddress,
+		StorageDeadline: o.ExpiresAt,
+		IssuedAt:        o.IssuedAt,
+	}
+	if o.Status == entity.ProductStatusReadyForPickup && o.PickupCode != nil {
+		result.PickupCode = *o.PickupCode
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_OrderEntityToDTO(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	recipient := "user1"
+	employee := "user2"
+	code := "123456"
+
+	tests := []struct {
+		name     string
+		input    entity.Order
+		expected dto.OrderDTO
+	}{
+		{
+			name: "issued order",
+			input: entity.Order{
+				ProductID:   "prod1",
+				ProductType: "shoes",
+				ReceptionID: "rec1",
+				PvzID:       "pvz1",
+				Status:      entity.ProductStatusIssued,
+				RecipientID: &recipient,
+				PickupCode:  &code,
+				ReceivedAt:  now,
+				IssuedAt:    &now,
+				IssuedBy:    &employee,
+			},
+			expected: dto.OrderDTO{
+				ProductId:   "prod1",
+				Type:        "shoes",
+				PvzId:       "pvz1",
+				Status:      entity.ProductStatusIssued,
+				RecipientId: "user1",
+				ReceivedAt:  now,
+				IssuedAt:    &now,
+				IssuedBy:    "user2",
+			},
+		},
+		{
+			name: "received product without recipient",
+			input: entity.Order{
+				ProductID:   "prod1",
+				ProductType: "shoes",
+				PvzID:       "pvz1",
+				Status:      entity.ProductStatusReceived,
+				ReceivedAt:  now,
+			},
+			expected: dto.OrderDTO{
+				ProductId:  "prod1",
+				Type:       "shoes",
+				PvzId:      "pvz1",
+				Status:     entity.ProductStatusReceived,
+				ReceivedAt: now,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dtoResult := OrderEntityToDTO(tc.input)
+			if !reflect.DeepEqual(dtoResult, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, dtoResult)
+			}
+		})
+	}
+}
+
+func Test_OrderEntityToClientDTO(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	deadline := now.Add(7 * 24 * time.Hour)
+	code := "123456"
+
+	tests := []struct {
+		name     string
+		input    entity.Order
+		expected dto.ClientOrderDTO
+	}{
+		{
+			name: "ready order exposes pickup code",
+			input: entity.Order{
+				ProductID:   "prod1",
+				ProductType: "shoes",
+				PvzID:       "pvz1",
+				PvzCity:     "Kazan",
+				Status:      entity.ProductStatusReadyForPickup,
+				PickupCode:  &code,
+				ExpiresAt:   &deadline,
+			},
+			expected: dto.ClientOrderDTO{
+				ProductId:       "prod1",
+				Type:            "shoes",
+				Status:          entity.ProductStatusReadyForPickup,
+				PvzId:           "pvz1",
+				PvzCity:         "Kazan",
+				StorageDeadline: &deadline,
+				PickupCode:      "123456",
+			},
+		},
+		{
+			name: "issued order hides pickup code",
+			input: entity.Order{
+				ProductID:   "prod1",
+				ProductType: "shoes",
+				PvzID:       "pvz1",
+				PvzCity:     "Kazan",
+				Status:      entity.ProductStatusIssued,
+				PickupCode:  &code,
+				IssuedAt:    &now,
+			},
+			expected: dto.ClientOrderDTO{
+				ProductId: "prod1",
+				Type:      "shoes",
+				Status:    entity.ProductStatusIssued,
+				PvzId:     "pvz1",
+				PvzCity:   "Kazan",
+				IssuedAt:  &now,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dtoResult := OrderEntityToClientDTO(tc.input)
+			if !reflect.DeepEqual(dtoResult, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, dtoResult)
+			}
+		})
+	}
+}
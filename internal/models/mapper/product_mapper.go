@@ -11,6 +11,7 @@ func ProductEntityToDTO(p entity.Product) dto.ProductDTO {
 		Id:          p.ID,
 		DateTime:    p.DateTime,
 		Type:        p.Type,
+		Barcode:     p.Barcode,
 		ReceptionId: p.ReceptionID,
 	}
 }
@@ -21,6 +22,31 @@ func ProductDTOToEntity(p dto.ProductDTO) entity.Product {
 		ID:          p.Id,
 		DateTime:    p.DateTime,
 		Type:        p.Type,
+		Barcode:     p.Barcode,
 		ReceptionID: p.ReceptionId,
 	}
 }
+
+// ProductBatchResultToDTO преобразует результат позиции пакета в DTO
+func ProductBatchResultToDTO(r entity.ProductBatchResult) dto.ProductsBatchItemResult {
+	result := dto.ProductsBatchItemResult{
+		Index:     r.Index,
+		Status:    r.Status,
+		ProductId: r.ProductID,
+		Type:      r.Type,
+		Barcode:   r.Barcode,
+	}
+	if r.ErrCode != "" {
+		result.Error = &dto.Error{Code: r.ErrCode, Message: r.ErrMessage}
+	}
+	return result
+}
+
+// ProductBatchItemsDTOToEntity преобразует позиции пакета из DTO в сущности
+func ProductBatchItemsDTOToEntity(items []dto.ProductsBatchItem) []entity.ProductBatchItem {
+	result := make([]entity.ProductBatchItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, entity.ProductBatchItem{Type: item.Type, Barcode: item.Barcode})
+	}
+	return result
+}
@@ -115,3 +115,58 @@ func Test_ProductDTOToEntity(t *testing.T) {
 		})
 	}
 }
+
+func Test_ProductBatchResultToDTO(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    entity.ProductBatchResult
+		expected dto.ProductsBatchItemResult
+	}{
+		{
+			name: "created item",
+			input: entity.ProductBatchResult{
+				Index:     0,
+				ProductID: "prod1",
+				Type:      "electronics",
+				Barcode:   "4607001234567",
+				Status:    entity.BatchItemCreated,
+			},
+			expected: dto.ProductsBatchItemResult{
+				Index:     0,
+				Status:    entity.BatchItemCreated,
+				ProductId: "prod1",
+				Type:      "electronics",
+				Barcode:   "4607001234567",
+			},
+		},
+		{
+			name: "failed item carries error",
+			input: entity.ProductBatchResult{
+				Index:      3,
+				Type:       "toys",
+				Status:     entity.BatchItemFailed,
+				ErrCode:    "INVALID_PRODUCT_TYPE",
+				ErrMessage: "product type 'toys' is not allowed",
+			},
+			expected: dto.ProductsBatchItemResult{
+				Index:  3,
+				Status: entity.BatchItemFailed,
+				Type:   "toys",
+				Error:  &dto.Error{Code: "INVALID_PRODUCT_TYPE", Message: "product type 'toys' is not allowed"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := ProductBatchResultToDTO(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, result)
+			}
+		})
+	}
+}
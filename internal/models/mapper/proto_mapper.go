@@ -0,0 +1,157 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/models/entity"
+)
+
+// PvzEntityToProto преобразует сущность Pvz в protobuf-сообщение.
+func PvzEntityToProto(p entity.Pvz) *pb.PVZ {
+	return &pb.PVZ{
+		Id:               p.ID,
+		RegistrationDate: timestamppb.New(p.RegistrationDate),
+		City:             p.City,
+		Address:          p.Address,
+		Latitude:         p.Latitude,
+		Longitude:        p.Longitude,
+		Phone:            p.Phone,
+		Status:           PvzStatusToProto(p.Status),
+		OpeningHours:     OpeningHoursEntityToProto(p.OpeningHours),
+	}
+}
+
+// PvzStatusToProto преобразует строковый статус ПВЗ в enum PvzStatus.
+func PvzStatusToProto(status string) pb.PvzStatus {
+	switch status {
+	case entity.PvzStatusSuspended:
+		return pb.PvzStatus_PVZ_STATUS_SUSPENDED
+	case entity.PvzStatusClosed:
+		return pb.PvzStatus_PVZ_STATUS_CLOSED
+	}
+	return pb.PvzStatus_PVZ_STATUS_ACTIVE
+}
+
+// PvzStatusFromProto преобразует enum PvzStatus в строковый статус ПВЗ.
+func PvzStatusFromProto(status pb.PvzStatus) string {
+	switch status {
+	case pb.PvzStatus_PVZ_STATUS_SUSPENDED:
+		return entity.PvzStatusSuspended
+	case pb.PvzStatus_PVZ_STATUS_CLOSED:
+		return entity.PvzStatusClosed
+	}
+	return entity.PvzStatusActive
+}
+
+// NearbyPvzEntityToProto преобразует результат поиска ПВЗ в protobuf-сообщение.
+func NearbyPvzEntityToProto(n entity.NearbyPvz) *pb.NearbyPvz {
+	return &pb.NearbyPvz{
+		Pvz:            PvzEntityToProto(n.Pvz),
+		DistanceMeters: n.DistanceMeters,
+		OpenNow:        n.OpenNow,
+	}
+}
+
+// OpeningHoursEntityToProto преобразует расписание ПВЗ в protobuf-сообщения.
+func OpeningHoursEntityToProto(hours []entity.OpeningHours) []*pb.OpeningHours {
+	result := make([]*pb.OpeningHours, 0, len(hours))
+	for _, h := range hours {
+		result = append(result, &pb.OpeningHours{Weekday: h.Weekday, Opens: h.Opens, Closes: h.Closes})
+	}
+	return result
+}
+
+// CreatePvzProtoToEntity преобразует запрос на создание ПВЗ в сущность.
+func CreatePvzProtoToEntity(req *pb.CreatePvzRequest) entity.Pvz {
+	var hours []entity.OpeningHours
+	for _, h := range req.GetOpeningHours() {
+		hours = append(hours, entity.OpeningHours{Weekday: h.GetWeekday(), Opens: h.GetOpens(), Closes: h.GetCloses()})
+	}
+	return entity.Pvz{
+		City:         req.GetCity(),
+		Address:      req.GetAddress(),
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		Phone:        req.GetPhone(),
+		OpeningHours: hours,
+	}
+}
+
+// ReceptionStatusToProto преобразует строковый статус приёмки в enum ReceptionStatus.
+func ReceptionStatusToProto(status string) pb.ReceptionStatus {
+	if status == "close" {
+		return pb.ReceptionStatus_RECEPTION_STATUS_CLOSED
+	}
+	return pb.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS
+}
+
+// ReceptionEntityToProto преобразует сущность Reception в protobuf-сообщение.
+func ReceptionEntityToProto(r entity.Reception) *pb.Reception {
+	msg := &pb.Reception{
+		Id:       r.ID,
+		DateTime: timestamppb.New(r.DateTime),
+		PvzId:    r.PvzID,
+		Status:   ReceptionStatusToProto(r.Status),
+	}
+	if r.OpenedBy != nil {
+		msg.OpenedBy = *r.OpenedBy
+	}
+	if r.ClosedBy != nil {
+		msg.ClosedBy = *r.ClosedBy
+	}
+	if r.ClosedAt != nil {
+		msg.ClosedAt = timestamppb.New(*r.ClosedAt)
+	}
+	return msg
+}
+
+// ProductEntityToProto преобразует сущность Product в protobuf-сообщение.
+func ProductEntityToProto(p entity.Product) *pb.Product {
+	return &pb.Product{
+		Id:          p.ID,
+		DateTime:    timestamppb.New(p.DateTime),
+		Type:        p.Type,
+		ReceptionId: p.ReceptionID,
+		Barcode:     p.Barcode,
+	}
+}
+
+// PvzInfoEntityToProto преобразует PvzInfo (ПВЗ с приёмками и товарами) в protobuf-сообщение.
+func PvzInfoEntityToProto(info entity.PvzInfo) *pb.PvzInfo {
+	receptions := make([]*pb.ReceptionInfo, 0, len(info.Receptions))
+	for _, recInfo := range info.Receptions {
+		products := make([]*pb.Product, 0, len(recInfo.Products))
+		for _, prod := range recInfo.Products {
+			products = append(products, ProductEntityToProto(prod))
+		}
+
+		receptions = append(receptions, &pb.ReceptionInfo{
+			Reception: ReceptionEntityToProto(recInfo.Reception),
+			Products:  products,
+		})
+	}
+
+	return &pb.PvzInfo{
+		Pvz:        PvzEntityToProto(info.Pvz),
+		Receptions: receptions,
+	}
+}
+
+// OrderEntityToBarcodeProto преобразует найденный по штрихкоду товар в ответ gRPC.
+func OrderEntityToBarcodeProto(o entity.Order) *pb.GetProductByBarcodeResponse {
+	resp := &pb.GetProductByBarcodeResponse{
+		Product: &pb.Product{
+			Id:          o.ProductID,
+			DateTime:    timestamppb.New(o.ReceivedAt),
+			Type:        o.ProductType,
+			ReceptionId: o.ReceptionID,
+			Barcode:     o.Barcode,
+		},
+		PvzId:  o.PvzID,
+		Status: o.Status,
+	}
+	if o.ExpiresAt != nil {
+		resp.ExpiresAt = timestamppb.New(*o.ExpiresAt)
+	}
+	return resp
+}
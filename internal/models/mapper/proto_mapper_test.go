@@ -0,0 +1,145 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/models/entity"
+	"testing"
+	"time"
+)
+
+func TestReceptionStatusToProto(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   string
+		expected pb.ReceptionStatus
+	}{
+		{
+			name:     "in progress",
+			status:   "in_progress",
+			expected: pb.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS,
+		},
+		{
+			name:     "closed",
+			status:   "close",
+			expected: pb.ReceptionStatus_RECEPTION_STATUS_CLOSED,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ReceptionStatusToProto(tc.status); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPvzStatusToProto(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status   string
+		expected pb.PvzStatus
+	}{
+		{status: entity.PvzStatusActive, expected: pb.PvzStatus_PVZ_STATUS_ACTIVE},
+		{status: entity.PvzStatusSuspended, expected: pb.PvzStatus_PVZ_STATUS_SUSPENDED},
+		{status: entity.PvzStatusClosed, expected: pb.PvzStatus_PVZ_STATUS_CLOSED},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.status, func(t *testing.T) {
+			t.Parallel()
+			if got := PvzStatusToProto(tc.status); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPvzInfoEntityToProto(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 4, 9, 12, 0, 0, 0, time.UTC)
+	closedAt := now.Add(time.Hour)
+	closedBy := "emp2"
+
+	info := entity.PvzInfo{
+		Pvz: entity.Pvz{ID: "1", RegistrationDate: now, City: "Moscow"},
+		Receptions: []entity.ReceptionInfo{
+			{
+				// приёмку открыл пользователь /dummyLogin, поэтому opened_by пуст
+				Reception: entity.Reception{ID: "r1", DateTime: now, PvzID: "1", Status: "close", ClosedBy: &closedBy, ClosedAt: &closedAt},
+				Products: []entity.Product{
+					{ID: "p1", DateTime: now, Type: "electronics", ReceptionID: "r1"},
+				},
+			},
+		},
+	}
+
+	expected := &pb.PvzInfo{
+		Pvz: &pb.PVZ{Id: "1", RegistrationDate: timestamppb.New(now), City: "Moscow"},
+		Receptions: []*pb.ReceptionInfo{
+			{
+				Reception: &pb.Reception{
+					Id:       "r1",
+					DateTime: timestamppb.New(now),
+					PvzId:    "1",
+					Status:   pb.ReceptionStatus_RECEPTION_STATUS_CLOSED,
+					ClosedBy: "emp2",
+					ClosedAt: timestamppb.New(closedAt),
+				},
+				Products: []*pb.Product{
+					{Id: "p1", DateTime: timestamppb.New(now), Type: "electronics", ReceptionId: "r1"},
+				},
+			},
+		},
+	}
+
+	result := PvzInfoEntityToProto(info)
+	if !proto.Equal(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestOrderEntityToBarcodeProto(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 4, 9, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(7 * 24 * time.Hour)
+
+	order := entity.Order{
+		ProductID:   "p1",
+		ProductType: "shoes",
+		Barcode:     "4607001234567",
+		ReceptionID: "r1",
+		PvzID:       "1",
+		Status:      entity.ProductStatusReceived,
+		ReceivedAt:  now,
+		ExpiresAt:   &expiresAt,
+	}
+
+	expected := &pb.GetProductByBarcodeResponse{
+		Product: &pb.Product{
+			Id:          "p1",
+			DateTime:    timestamppb.New(now),
+			Type:        "shoes",
+			ReceptionId: "r1",
+			Barcode:     "4607001234567",
+		},
+		PvzId:     "1",
+		Status:    entity.ProductStatusReceived,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}
+
+	result := OrderEntityToBarcodeProto(order)
+	if !proto.Equal(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
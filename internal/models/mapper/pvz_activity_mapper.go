@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+func PvzActivityEventToDTO(e entity.OutboxEvent) dto.PvzActivityEventDTO {
+	return dto.PvzActivityEventDTO{
+		Id:          e.ID,
+		EventId:     e.EventID,
+		Type:        e.Type,
+		PvzId:       e.PvzID,
+		AggregateId: e.AggregateID,
+		OccurredAt:  e.CreatedAt,
+		Payload:     e.Payload,
+	}
+}
+
+// PvzActivityEventToProto преобразует событие ленты активности в protobuf-сообщение.
+// Полезная нагрузка события — JSON-объект, он передаётся как google.protobuf.Struct.
+func PvzActivityEventToProto(e entity.OutboxEvent) (*pb.PvzActivityEvent, error) {
+	msg := &pb.PvzActivityEvent{
+		Id:          e.ID,
+		EventId:     e.EventID,
+		Type:        e.Type,
+		PvzId:       e.PvzID,
+		AggregateId: e.AggregateID,
+		OccurredAt:  timestamppb.New(e.CreatedAt),
+	}
+	if len(e.Payload) > 0 {
+		msg.Payload = &structpb.Struct{}
+		if err := msg.Payload.UnmarshalJSON(e.Payload); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"encoding/json"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPvzActivityEventToDTO(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 5, 4, 9, 0, 0, 0, time.UTC)
+	event := entity.OutboxEvent{
+		ID: 7, EventID: "ev1", Type: entity.EventProductAdded, AggregateID: "prod1", PvzID: "pvz1",
+		Payload: json.RawMessage(`{"productId":"prod1"}`), CreatedAt: created,
+		Status: entity.OutboxPublished, Attempts: 1,
+	}
+	expected := dto.PvzActivityEventDTO{
+		Id: 7, EventId: "ev1", Type: "ProductAdded", PvzId: "pvz1", AggregateId: "prod1",
+		OccurredAt: created, Payload: json.RawMessage(`{"productId":"prod1"}`),
+	}
+
+	if got := PvzActivityEventToDTO(event); !reflect.DeepEqual(got, expected) {
+		t.Errorf("PvzActivityEventToDTO() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestPvzActivityEventToProto(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 5, 4, 9, 0, 0, 0, time.UTC)
+
+	t.Run("payload becomes struct", func(t *testing.T) {
+		t.Parallel()
+		msg, err := PvzActivityEventToProto(entity.OutboxEvent{
+			ID: 7, EventID: "ev1", Type: entity.EventReceptionClosed, AggregateID: "rec1", PvzID: "pvz1",
+			Payload: json.RawMessage(`{"receptionId":"rec1","products":3}`), CreatedAt: created,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.GetId() != 7 || msg.GetType() != "ReceptionClosed" || msg.GetAggregateId() != "rec1" || !msg.GetOccurredAt().AsTime().Equal(created) {
+			t.Errorf("unexpected message %v", msg)
+		}
+		fields := msg.GetPayload().GetFields()
+		if fields["receptionId"].GetStringValue() != "rec1" || fields["products"].GetNumberValue() != 3 {
+			t.Errorf("unexpected payload %v", msg.GetPayload())
+		}
+	})
+
+	t.Run("empty payload", func(t *testing.T) {
+		t.Parallel()
+		msg, err := PvzActivityEventToProto(entity.OutboxEvent{ID: 8, CreatedAt: created})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.GetPayload() != nil {
+			t.Errorf("expected nil payload, got %v", msg.GetPayload())
+		}
+	})
+
+	t.Run("payload is not an object", func(t *testing.T) {
+		t.Parallel()
+		if _, err := PvzActivityEventToProto(entity.OutboxEvent{Payload: json.RawMessage(`[1]`)}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+// PvzExportRowEntityToDTO преобразует строку выгрузки истории ПВЗ в DTO.
+func PvzExportRowEntityToDTO(row entity.PvzExportRow) dto.PvzExportRowDTO {
+	return dto.PvzExportRowDTO{
+		PvzId:               row.PvzID,
+		PvzRegistrationDate: row.PvzRegistrationDate,
+		PvzCity:             row.PvzCity,
+		PvzAddress:          row.PvzAddress,
+		PvzStatus:           row.PvzStatus,
+		ReceptionId:         row.ReceptionID,
+		ReceptionDateTime:   row.ReceptionDateTime,
+		ReceptionStatus:     row.ReceptionStatus,
+		ProductId:           row.ProductID,
+		ProductDateTime:     row.ProductDateTime,
+		ProductType:         row.ProductType,
+		ProductBarcode:      row.ProductBarcode,
+	}
+}
+
+// PvzExportRowEntityToProto преобразует строку выгрузки истории ПВЗ в protobuf-сообщение.
+func PvzExportRowEntityToProto(row entity.PvzExportRow) *pb.PvzExportRow {
+	msg := &pb.PvzExportRow{
+		PvzId:               row.PvzID,
+		PvzRegistrationDate: timestamppb.New(row.PvzRegistrationDate),
+		PvzCity:             row.PvzCity,
+		PvzAddress:          row.PvzAddress,
+		PvzStatus:           PvzStatusToProto(row.PvzStatus),
+		ReceptionId:         row.ReceptionID,
+		ProductId:           row.ProductID,
+		ProductType:         row.ProductType,
+		ProductBarcode:      row.ProductBarcode,
+	}
+	if row.ReceptionDateTime != nil {
+		msg.ReceptionDateTime = timestamppb.New(*row.ReceptionDateTime)
+	}
+	if row.ReceptionID != "" {
+		status := ReceptionStatusToProto(row.ReceptionStatus)
+		msg.ReceptionStatus = &status
+	}
+	if row.ProductDateTime != nil {
+		msg.ProductDateTime = timestamppb.New(*row.ProductDateTime)
+	}
+	return msg
+}
@@ -0,0 +1,82 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"order-pick-up-point/api/pb"
+	"order-pick-up-point/internal/models/entity"
+	"testing"
+	"time"
+)
+
+func TestPvzExportRowEntityToProto(t *testing.T) {
+	t.Parallel()
+
+	regDate := time.Date(2025, 4, 9, 12, 0, 0, 0, time.UTC)
+	recDate := regDate.Add(time.Hour)
+	prodDate := recDate.Add(time.Minute)
+	closed := pb.ReceptionStatus_RECEPTION_STATUS_CLOSED
+
+	tests := []struct {
+		name     string
+		row      entity.PvzExportRow
+		expected *pb.PvzExportRow
+	}{
+		{
+			name: "pvz without receptions",
+			row: entity.PvzExportRow{
+				PvzID:               "pvz1",
+				PvzRegistrationDate: regDate,
+				PvzCity:             "Moscow",
+				PvzStatus:           entity.PvzStatusSuspended,
+			},
+			expected: &pb.PvzExportRow{
+				PvzId:               "pvz1",
+				PvzRegistrationDate: timestamppb.New(regDate),
+				PvzCity:             "Moscow",
+				PvzStatus:           pb.PvzStatus_PVZ_STATUS_SUSPENDED,
+			},
+		},
+		{
+			name: "product row",
+			row: entity.PvzExportRow{
+				PvzID:               "pvz1",
+				PvzRegistrationDate: regDate,
+				PvzCity:             "Moscow",
+				PvzAddress:          "ul. Tverskaya, 7",
+				PvzStatus:           entity.PvzStatusActive,
+				ReceptionID:         "rec1",
+				ReceptionDateTime:   &recDate,
+				ReceptionStatus:     "close",
+				ProductID:           "prod1",
+				ProductDateTime:     &prodDate,
+				ProductType:         "electronics",
+				ProductBarcode:      "4601234567890",
+			},
+			expected: &pb.PvzExportRow{
+				PvzId:               "pvz1",
+				PvzRegistrationDate: timestamppb.New(regDate),
+				PvzCity:             "Moscow",
+				PvzAddress:          "ul. Tverskaya, 7",
+				PvzStatus:           pb.PvzStatus_PVZ_STATUS_ACTIVE,
+				ReceptionId:         "rec1",
+				ReceptionDateTime:   timestamppb.New(recDate),
+				ReceptionStatus:     &closed,
+				ProductId:           "prod1",
+				ProductDateTime:     timestamppb.New(prodDate),
+				ProductType:         "electronics",
+				ProductBarcode:      "4601234567890",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := PvzExportRowEntityToProto(tc.row); !proto.Equal(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -11,6 +11,12 @@ func PvzEntityToDTO(p entity.Pvz) dto.PvzDTO {
 		Id:               p.ID,
 		RegistrationDate: p.RegistrationDate,
 		City:             p.City,
+		Address:          p.Address,
+		Latitude:         p.Latitude,
+		Longitude:        p.Longitude,
+		Phone:            p.Phone,
+		Status:           p.Status,
+		OpeningHours:     OpeningHoursEntityToDTO(p.OpeningHours),
 	}
 }
 
@@ -20,9 +26,71 @@ func PvzDTOToEntity(p dto.PvzDTO) entity.Pvz {
 		ID:               p.Id,
 		RegistrationDate: p.RegistrationDate,
 		City:             p.City,
+		Address:          p.Address,
+		Latitude:         p.Latitude,
+		Longitude:        p.Longitude,
+		Phone:            p.Phone,
+		Status:           p.Status,
+		OpeningHours:     OpeningHoursDTOToEntity(p.OpeningHours),
 	}
 }
 
+// CreatePvzRequestToEntity преобразует запрос на создание ПВЗ в сущность Pvz.
+func CreatePvzRequestToEntity(req dto.CreatePvzPostRequest) entity.Pvz {
+	return entity.Pvz{
+		City:         req.City,
+		Address:      req.Address,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		Phone:        req.Phone,
+		OpeningHours: OpeningHoursDTOToEntity(req.OpeningHours),
+	}
+}
+
+// UpdatePvzRequestToEntity преобразует запрос на изменение ПВЗ в частичное обновление.
+func UpdatePvzRequestToEntity(req dto.UpdatePvzRequest) entity.PvzUpdate {
+	update := entity.PvzUpdate{
+		Address:          req.Address,
+		Latitude:         req.Latitude,
+		Longitude:        req.Longitude,
+		ClearCoordinates: req.ClearCoordinates,
+		Phone:            req.Phone,
+		Status:           req.Status,
+	}
+	if req.OpeningHours != nil {
+		hours := OpeningHoursDTOToEntity(*req.OpeningHours)
+		if hours == nil {
+			hours = []entity.OpeningHours{}
+		}
+		update.OpeningHours = &hours
+	}
+	return update
+}
+
+// OpeningHoursEntityToDTO преобразует расписание ПВЗ в DTO.
+func OpeningHoursEntityToDTO(hours []entity.OpeningHours) []dto.OpeningHoursDTO {
+	if len(hours) == 0 {
+		return nil
+	}
+	result := make([]dto.OpeningHoursDTO, 0, len(hours))
+	for _, h := range hours {
+		result = append(result, dto.OpeningHoursDTO{Weekday: h.Weekday, Opens: h.Opens, Closes: h.Closes})
+	}
+	return result
+}
+
+// OpeningHoursDTOToEntity преобразует расписание ПВЗ из DTO.
+func OpeningHoursDTOToEntity(hours []dto.OpeningHoursDTO) []entity.OpeningHours {
+	if len(hours) == 0 {
+		return nil
+	}
+	result := make([]entity.OpeningHours, 0, len(hours))
+	for _, h := range hours {
+		result = append(result, entity.OpeningHours{Weekday: h.Weekday, Opens: h.Opens, Closes: h.Closes})
+	}
+	return result
+}
+
 func PvzInfoEntityToResponse(info entity.PvzInfo) dto.PvzGet200ResponseInner {
 	pvzDTO := PvzEntityToDTO(info.Pvz)
 
@@ -45,3 +113,29 @@ func PvzInfoEntityToResponse(info entity.PvzInfo) dto.PvzGet200ResponseInner {
 		Receptions: receptions,
 	}
 }
+
+// NearbyPvzQueryToFilter преобразует параметры запроса поиска ПВЗ в фильтр.
+func NearbyPvzQueryToFilter(q dto.NearbyPvzQuery) entity.NearbyPvzFilter {
+	filter := entity.NearbyPvzFilter{
+		RadiusMeters: q.Radius,
+		City:         q.City,
+		Status:       q.Status,
+		Limit:        q.Limit,
+	}
+	if q.Lat != nil {
+		filter.Latitude = *q.Lat
+	}
+	if q.Lon != nil {
+		filter.Longitude = *q.Lon
+	}
+	return filter
+}
+
+// NearbyPvzEntityToDTO преобразует результат поиска ПВЗ в DTO.
+func NearbyPvzEntityToDTO(n entity.NearbyPvz) dto.NearbyPvzDTO {
+	return dto.NearbyPvzDTO{
+		Pvz:            PvzEntityToDTO(n.Pvz),
+		DistanceMeters: n.DistanceMeters,
+		OpenNow:        n.OpenNow,
+	}
+}
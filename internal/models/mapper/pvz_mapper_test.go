@@ -12,6 +12,7 @@ func TestPvzEntityToDTO(t *testing.T) {
 	t.Parallel()
 
 	now := time.Now()
+	lat, lon := 55.79, 49.12
 	tests := []struct {
 		name     string
 		input    entity.Pvz
@@ -30,6 +31,31 @@ func TestPvzEntityToDTO(t *testing.T) {
 				City:             "Moscow",
 			},
 		},
+		{
+			name: "with details",
+			input: entity.Pvz{
+				ID:               "2",
+				RegistrationDate: now,
+				City:             "Kazan",
+				Address:          "ul. Baumana, 1",
+				Latitude:         &lat,
+				Longitude:        &lon,
+				Phone:            "+7 843 000-00-00",
+				Status:           entity.PvzStatusSuspended,
+				OpeningHours:     []entity.OpeningHours{{Weekday: "mon", Opens: "09:00", Closes: "21:00"}},
+			},
+			expected: dto.PvzDTO{
+				Id:               "2",
+				RegistrationDate: now,
+				City:             "Kazan",
+				Address:          "ul. Baumana, 1",
+				Latitude:         &lat,
+				Longitude:        &lon,
+				Phone:            "+7 843 000-00-00",
+				Status:           entity.PvzStatusSuspended,
+				OpeningHours:     []dto.OpeningHoursDTO{{Weekday: "mon", Opens: "09:00", Closes: "21:00"}},
+			},
+		},
 		{
 			name: "empty PVZ",
 			input: entity.Pvz{
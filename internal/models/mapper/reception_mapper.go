@@ -7,20 +7,36 @@ import (
 
 // ReceptionEntityToDTO преобразует сущность Reception в DTO.
 func ReceptionEntityToDTO(r entity.Reception) dto.ReceptionDTO {
-	return dto.ReceptionDTO{
+	result := dto.ReceptionDTO{
 		Id:       r.ID,
 		DateTime: r.DateTime,
 		PvzId:    r.PvzID,
 		Status:   r.Status,
+		ClosedAt: r.ClosedAt,
 	}
+	if r.OpenedBy != nil {
+		result.OpenedBy = *r.OpenedBy
+	}
+	if r.ClosedBy != nil {
+		result.ClosedBy = *r.ClosedBy
+	}
+	return result
 }
 
 // ReceptionDTOToEntity преобразует DTO ReceptionDTO в сущность Reception.
 func ReceptionDTOToEntity(r dto.ReceptionDTO) entity.Reception {
-	return entity.Reception{
+	result := entity.Reception{
 		ID:       r.Id,
 		DateTime: r.DateTime,
 		PvzID:    r.PvzId,
 		Status:   r.Status,
+		ClosedAt: r.ClosedAt,
+	}
+	if r.OpenedBy != "" {
+		result.OpenedBy = &r.OpenedBy
+	}
+	if r.ClosedBy != "" {
+		result.ClosedBy = &r.ClosedBy
 	}
+	return result
 }
@@ -12,6 +12,8 @@ func Test_ReceptionEntityToDTO(t *testing.T) {
 	t.Parallel()
 
 	now := time.Now()
+	closedAt := now.Add(time.Hour)
+	openedBy, closedBy := "user1", "user2"
 
 	tests := []struct {
 		name     string
@@ -33,6 +35,27 @@ func Test_ReceptionEntityToDTO(t *testing.T) {
 				Status:   "in_progress",
 			},
 		},
+		{
+			name: "closed reception",
+			input: entity.Reception{
+				ID:       "r2",
+				DateTime: now,
+				PvzID:    "pvz1",
+				Status:   "close",
+				OpenedBy: &openedBy,
+				ClosedBy: &closedBy,
+				ClosedAt: &closedAt,
+			},
+			expected: dto.ReceptionDTO{
+				Id:       "r2",
+				DateTime: now,
+				PvzId:    "pvz1",
+				Status:   "close",
+				OpenedBy: openedBy,
+				ClosedBy: closedBy,
+				ClosedAt: &closedAt,
+			},
+		},
 		{
 			name: "empty fields",
 			input: entity.Reception{
@@ -61,6 +84,8 @@ func Test_ReceptionDTOToEntity(t *testing.T) {
 	t.Parallel()
 
 	now := time.Now()
+	closedAt := now.Add(time.Hour)
+	openedBy, closedBy := "user1", "user2"
 
 	tests := []struct {
 		name     string
@@ -82,6 +107,27 @@ func Test_ReceptionDTOToEntity(t *testing.T) {
 				Status:   "in_progress",
 			},
 		},
+		{
+			name: "closed reception",
+			input: dto.ReceptionDTO{
+				Id:       "r2",
+				DateTime: now,
+				PvzId:    "pvz1",
+				Status:   "close",
+				OpenedBy: openedBy,
+				ClosedBy: closedBy,
+				ClosedAt: &closedAt,
+			},
+			expected: entity.Reception{
+				ID:       "r2",
+				DateTime: now,
+				PvzID:    "pvz1",
+				Status:   "close",
+				OpenedBy: &openedBy,
+				ClosedBy: &closedBy,
+				ClosedAt: &closedAt,
+			},
+		},
 		{
 			name: "empty fields",
 			input: dto.ReceptionDTO{
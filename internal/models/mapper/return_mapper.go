@@ -0,0 +1,17 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+// ReturnItemEntityToDTO преобразует сущность ReturnItem в DTO. Предыдущий статус товара наружу не отдаётся
+func ReturnItemEntityToDTO(i entity.ReturnItem) dto.ReturnItemDTO {
+	return dto.ReturnItemDTO{
+		Id:        i.ID,
+		DateTime:  i.DateTime,
+		ReturnId:  i.ReturnID,
+		ProductId: i.ProductID,
+		Reason:    i.Reason,
+	}
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_ReturnItemEntityToDTO(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	input := entity.ReturnItem{
+		ID:             "item1",
+		DateTime:       now,
+		ReturnID:       "ret1",
+		ProductID:      "prod1",
+		Reason:         entity.ReturnReasonExpired,
+		PreviousStatus: entity.ProductStatusReadyForPickup,
+	}
+	expected := dto.ReturnItemDTO{
+		Id:        "item1",
+		DateTime:  now,
+		ReturnId:  "ret1",
+		ProductId: "prod1",
+		Reason:    entity.ReturnReasonExpired,
+	}
+
+	if got := ReturnItemEntityToDTO(input); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
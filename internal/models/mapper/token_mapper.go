@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"time"
+)
+
+// TokenPairToDTO преобразует выданную пару токенов в ответ. ExpiresIn — оставшийся на момент now
+// срок жизни access-токена в секундах.
+func TokenPairToDTO(pair entity.TokenPair, now time.Time) dto.TokenResponse {
+	return dto.TokenResponse{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    int(pair.AccessExpiresAt.Sub(now).Round(time.Second).Seconds()),
+
+		PasswordChangeRequired: pair.PasswordChangeRequired,
+	}
+}
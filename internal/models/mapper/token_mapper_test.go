@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"testing"
+	"time"
+)
+
+func TestTokenPairToDTO(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 5, 5, 10, 0, 0, 0, time.UTC)
+	pair := entity.TokenPair{
+		AccessToken:      "access",
+		AccessExpiresAt:  now.Add(15*time.Minute + 200*time.Millisecond),
+		RefreshToken:     "refresh",
+		RefreshExpiresAt: now.Add(30 * 24 * time.Hour),
+	}
+
+	got := TokenPairToDTO(pair, now)
+	expected := dto.TokenResponse{Token: "access", RefreshToken: "refresh", ExpiresIn: 900}
+	if got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	pair.PasswordChangeRequired = true
+	if got := TokenPairToDTO(pair, now); !got.PasswordChangeRequired {
+		t.Error("expected passwordChangeRequired to be set")
+	}
+}
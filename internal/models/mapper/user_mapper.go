@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+// UserQueryToFilter переносит фильтры запроса в entity.UserFilter. Курсор разбирается отдельно.
+func UserQueryToFilter(q dto.UserQuery) entity.UserFilter {
+	return entity.UserFilter{
+		Email:  q.Email,
+		Role:   q.Role,
+		Active: q.Active,
+		Limit:  q.Limit,
+	}
+}
+
+func UserEntityToDTO(u entity.User) dto.User {
+	return dto.User{
+		Id:                     u.ID,
+		Email:                  u.Email,
+		Role:                   u.Role,
+		Active:                 u.Active,
+		PasswordChangeRequired: u.PasswordChangeRequired,
+		CreatedAt:              u.CreatedAt,
+	}
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"testing"
+	"time"
+)
+
+func TestUserEntityToDTO(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 5, 8, 9, 0, 0, 0, time.UTC)
+	user := entity.User{
+		ID:                     "user1",
+		Email:                  "user@example.com",
+		PasswordHash:           "hash",
+		Role:                   "employee",
+		CreatedAt:              created,
+		Active:                 true,
+		PasswordChangeRequired: true,
+	}
+
+	expected := dto.User{
+		Id:                     "user1",
+		Email:                  "user@example.com",
+		Role:                   "employee",
+		Active:                 true,
+		PasswordChangeRequired: true,
+		CreatedAt:              created,
+	}
+	if got := UserEntityToDTO(user); got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestUserQueryToFilter(t *testing.T) {
+	t.Parallel()
+
+	active := false
+	got := UserQueryToFilter(dto.UserQuery{Email: "example", Role: "client", Active: &active, Limit: 10, Cursor: "ignored"})
+	if got.Email != "example" || got.Role != "client" || got.Active == nil || *got.Active || got.Limit != 10 || got.After != nil {
+		t.Errorf("unexpected filter %+v", got)
+	}
+}
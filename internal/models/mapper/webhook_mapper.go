@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+)
+
+func CreateWebhookRequestToEntity(req dto.CreateWebhookRequest) entity.WebhookSubscription {
+	return entity.WebhookSubscription{
+		URL:        req.Url,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	}
+}
+
+func UpdateWebhookRequestToEntity(req dto.UpdateWebhookRequest) entity.WebhookSubscriptionUpdate {
+	update := entity.WebhookSubscriptionUpdate{
+		URL:    req.Url,
+		Active: req.Active,
+	}
+	if req.EventTypes != nil {
+		update.EventTypes = append([]string{}, *req.EventTypes...)
+	}
+	return update
+}
+
+// WebhookEntityToDTO преобразует подписку в DTO. Секрет добавляет только ответ на создание.
+func WebhookEntityToDTO(sub entity.WebhookSubscription) dto.WebhookDTO {
+	return dto.WebhookDTO{
+		Id:         sub.ID,
+		Url:        sub.URL,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
+
+// WebhookDeliveryEntityToDTO преобразует доставку в DTO. Время следующей попытки
+// показывается только для доставок, ожидающих отправки.
+func WebhookDeliveryEntityToDTO(d entity.WebhookDelivery) dto.WebhookDeliveryDTO {
+	result := dto.WebhookDeliveryDTO{
+		Id:             d.ID,
+		WebhookId:      d.SubscriptionID,
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		AggregateId:    d.AggregateID,
+		PvzId:          d.PvzID,
+		Payload:        d.Payload,
+		OccurredAt:     d.OccurredAt,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.Status == entity.WebhookDeliveryPending {
+		next := d.NextAttemptAt
+		result.NextAttemptAt = &next
+	}
+	for _, a := range d.History {
+		result.History = append(result.History, dto.WebhookDeliveryAttemptDTO{
+			AttemptedAt: a.AttemptedAt,
+			DurationMs:  a.Duration.Milliseconds(),
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+		})
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package mapper
+
+import (
+	"encoding/json"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWebhookDeliveryEntityToDTO(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 5, 3, 10, 0, 0, 0, time.UTC)
+	next := created.Add(time.Minute)
+	code := 503
+
+	tests := []struct {
+		name     string
+		delivery entity.WebhookDelivery
+		expected dto.WebhookDeliveryDTO
+	}{
+		{
+			name: "pending delivery with attempts",
+			delivery: entity.WebhookDelivery{
+				ID: 42, SubscriptionID: "hook1", EventID: "ev1", EventType: entity.EventOrderIssued,
+				AggregateID: "prod1", PvzID: "pvz1", Payload: json.RawMessage(`{"productId":"prod1"}`), OccurredAt: created,
+				Status: entity.WebhookDeliveryPending, Attempts: 1, NextAttemptAt: next, LastStatusCode: &code,
+				LastError: "unexpected status 503", CreatedAt: created,
+				History: []entity.WebhookDeliveryAttempt{{ID: 1, DeliveryID: 42, AttemptedAt: created, Duration: 120 * time.Millisecond, StatusCode: &code, Error: "unexpected status 503"}},
+			},
+			expected: dto.WebhookDeliveryDTO{
+				Id: 42, WebhookId: "hook1", EventId: "ev1", EventType: "OrderIssued",
+				AggregateId: "prod1", PvzId: "pvz1", Payload: json.RawMessage(`{"productId":"prod1"}`), OccurredAt: created,
+				Status: "pending", Attempts: 1, NextAttemptAt: &next, LastStatusCode: &code,
+				LastError: "unexpected status 503", CreatedAt: created,
+				History: []dto.WebhookDeliveryAttemptDTO{{AttemptedAt: created, DurationMs: 120, StatusCode: &code, Error: "unexpected status 503"}},
+			},
+		},
+		{
+			name: "delivered delivery has no next attempt",
+			delivery: entity.WebhookDelivery{
+				ID: 43, SubscriptionID: "hook1", Status: entity.WebhookDeliveryDelivered, Attempts: 1,
+				NextAttemptAt: next, CreatedAt: created, DeliveredAt: &next,
+			},
+			expected: dto.WebhookDeliveryDTO{
+				Id: 43, WebhookId: "hook1", Status: "delivered", Attempts: 1, CreatedAt: created, DeliveredAt: &next,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := WebhookDeliveryEntityToDTO(tc.delivery); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestUpdateWebhookRequestToEntity(t *testing.T) {
+	t.Parallel()
+
+	if got := UpdateWebhookRequestToEntity(dto.UpdateWebhookRequest{}); got.EventTypes != nil {
+		t.Errorf("omitted event types must stay nil, got %v", got.EventTypes)
+	}
+
+	// пустой список — явная попытка снять все типы, сервис её отклонит
+	empty := []string{}
+	if got := UpdateWebhookRequestToEntity(dto.UpdateWebhookRequest{EventTypes: &empty}); got.EventTypes == nil {
+		t.Error("explicit empty event types must not become nil")
+	}
+}
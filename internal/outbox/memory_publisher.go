@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher хранит события в памяти процесса. События теряются при перезапуске,
+// поэтому он предназначен для тестов и встраивания в тот же процесс.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+// Messages возвращает копию опубликованных событий в порядке публикации.
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.messages...)
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"order-pick-up-point/internal/models/entity"
+	"time"
+)
+
+// Message — доменное событие в том виде, в котором его получают подписчики.
+// Доставка at-least-once: одно событие может прийти повторно, дедуплицировать нужно по ID.
+type Message struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregateId"`
+	PvzID       string          `json:"pvzId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// Publisher доставляет события во внешнюю систему. Ошибка Publish означает,
+// что событие не доставлено и relay повторит попытку позже.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Поддерживаемые реализации Publisher
+const (
+	PublisherFile   = "file"
+	PublisherMemory = "memory"
+)
+
+// NewPublisher создаёт publisher по его типу из конфигурации.
+func NewPublisher(kind, filePath string) (Publisher, error) {
+	switch kind {
+	case PublisherFile:
+		return NewFilePublisher(filePath)
+	case PublisherMemory:
+		return NewMemoryPublisher(), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", kind)
+	}
+}
+
+func MessageFromEvent(event entity.OutboxEvent) Message {
+	return Message{
+		ID:          event.EventID,
+		Type:        event.Type,
+		AggregateID: event.AggregateID,
+		PvzID:       event.PvzID,
+		OccurredAt:  event.CreatedAt,
+		Payload:     event.Payload,
+	}
+}
@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFilePublisher(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	p, err := NewFilePublisher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msgs := []Message{
+		{ID: "e1", Type: "ReceptionOpened", AggregateID: "rec1", PvzID: "pvz1", OccurredAt: time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC), Payload: json.RawMessage(`{"id":"rec1"}`)},
+		{ID: "e2", Type: "ReceptionClosed", AggregateID: "rec1", PvzID: "pvz1", Payload: json.RawMessage(`{}`)},
+	}
+	for _, m := range msgs {
+		if err := p.Publish(context.Background(), m); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("unexpected error on close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	var got []Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var m Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, m)
+	}
+	if len(got) != 2 || got[0].ID != "e1" || got[1].Type != "ReceptionClosed" || string(got[0].Payload) != `{"id":"rec1"}` {
+		t.Errorf("unexpected file contents: %+v", got)
+	}
+}
+
+func TestNewPublisher(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewPublisher(PublisherMemory, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mem, ok := p.(*MemoryPublisher)
+	if !ok {
+		t.Fatalf("expected memory publisher, got %T", p)
+	}
+	_ = mem.Publish(context.Background(), Message{ID: "e1"})
+	if msgs := mem.Messages(); len(msgs) != 1 || msgs[0].ID != "e1" {
+		t.Errorf("unexpected messages: %+v", msgs)
+	}
+
+	if _, err := NewPublisher("kafka", ""); err == nil {
+		t.Error("expected error for unknown publisher")
+	}
+}
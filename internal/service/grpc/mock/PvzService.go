@@ -4,6 +4,7 @@ package mock
 
 import (
 	context "context"
+
 	entity "order-pick-up-point/internal/models/entity"
 
 	mock "github.com/stretchr/testify/mock"
@@ -44,6 +45,36 @@ func (_m *PvzService) GetPVZList(ctx context.Context) ([]entity.Pvz, error) {
 	return r0, r1
 }
 
+// GetPVZPage provides a mock function with given fields: ctx, page
+func (_m *PvzService) GetPVZPage(ctx context.Context, page entity.PvzPageRequest) (*entity.PvzPage, error) {
+	ret := _m.Called(ctx, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZPage")
+	}
+
+	var r0 *entity.PvzPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest) (*entity.PvzPage, error)); ok {
+		return rf(ctx, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest) *entity.PvzPage); ok {
+		r0 = rf(ctx, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PvzPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PvzPageRequest) error); ok {
+		r1 = rf(ctx, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPvzService creates a new instance of PvzService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPvzService(t interface {
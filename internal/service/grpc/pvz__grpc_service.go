@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/models/entity"
 	"order-pick-up-point/internal/storage/db"
 	"order-pick-up-point/pkg/logger"
@@ -9,6 +10,7 @@ import (
 
 type PvzService interface {
 	GetPVZList(ctx context.Context) ([]entity.Pvz, error)
+	GetPVZPage(ctx context.Context, page entity.PvzPageRequest) (*entity.PvzPage, error)
 }
 
 type pvzServiceImp struct {
@@ -33,3 +35,30 @@ func (s *pvzServiceImp) GetPVZList(ctx context.Context) ([]entity.Pvz, error) {
 	}
 	return pvzs, nil
 }
+
+// GetPVZPage возвращает страницу списка ПВЗ, читая на один ПВЗ больше, чтобы узнать, есть ли следующая.
+func (s *pvzServiceImp) GetPVZPage(ctx context.Context, page entity.PvzPageRequest) (*entity.PvzPage, error) {
+	if page.Limit <= 0 || page.Offset < 0 {
+		return nil, errs.New(errs.ErrInvalidRequestCode, "limit must be positive and offset must not be negative")
+	}
+
+	lookahead := page
+	lookahead.Limit++
+	pvzs, err := s.repo.GetPvzs(ctx, lookahead)
+	if err != nil {
+		s.logger.Errorw("get PVZ page",
+			"error", err,
+			"limit", page.Limit,
+		)
+		return nil, err
+	}
+
+	result := &entity.PvzPage{Items: pvzs}
+	if len(pvzs) > page.Limit {
+		last := pvzs[page.Limit-1]
+		result.Items = pvzs[:page.Limit]
+		result.HasMore = true
+		result.NextCursor = &entity.PvzCursor{RegistrationDate: last.RegistrationDate, ID: last.ID}
+	}
+	return result, nil
+}
@@ -93,3 +93,89 @@ func TestPvzService_GetPVZList(t *testing.T) {
 		})
 	}
 }
+
+func TestPvzService_GetPVZPage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	now := time.Now()
+	dummyList := []entity.Pvz{
+		{ID: "3", City: "Moscow", RegistrationDate: now},
+		{ID: "2", City: "Kazan", RegistrationDate: now.Add(-time.Hour)},
+		{ID: "1", City: "Moscow", RegistrationDate: now.Add(-2 * time.Hour)},
+	}
+
+	t.Run("has more", func(t *testing.T) {
+		t.Parallel()
+
+		repoMock := mockPvzRepo.NewPvzRepository(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t)}
+
+		// читаем на один ПВЗ больше размера страницы
+		repoMock.
+			On("GetPvzs", mock.Anything, entity.PvzPageRequest{Limit: 3}).
+			Return(dummyList, nil).
+			Once()
+
+		page, err := svc.GetPVZPage(ctx, entity.PvzPageRequest{Limit: 2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(page.Items) != 2 || !page.HasMore {
+			t.Fatalf("expected 2 pvzs and more pages, got %d (hasMore=%v)", len(page.Items), page.HasMore)
+		}
+		if page.NextCursor == nil || page.NextCursor.ID != "2" {
+			t.Errorf("expected cursor of pvz 2, got %+v", page.NextCursor)
+		}
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		t.Parallel()
+
+		repoMock := mockPvzRepo.NewPvzRepository(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t)}
+
+		repoMock.
+			On("GetPvzs", mock.Anything, entity.PvzPageRequest{Limit: 4}).
+			Return(dummyList, nil).
+			Once()
+
+		page, err := svc.GetPVZPage(ctx, entity.PvzPageRequest{Limit: 3})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(page.Items) != 3 || page.HasMore || page.NextCursor != nil {
+			t.Errorf("expected last page of 3 pvzs, got %d (hasMore=%v, cursor=%+v)", len(page.Items), page.HasMore, page.NextCursor)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+
+		repoMock := mockPvzRepo.NewPvzRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock}
+
+		repoMock.
+			On("GetPvzs", mock.Anything, entity.PvzPageRequest{Limit: 3}).
+			Return(nil, errors.New("db error")).
+			Once()
+		loggerMock.
+			On("Errorw", "get PVZ page", "error", mock.Anything, "limit", 2).
+			Return().
+			Once()
+
+		if _, err := svc.GetPVZPage(ctx, entity.PvzPageRequest{Limit: 2}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+
+		svc := &pvzServiceImp{}
+		if _, err := svc.GetPVZPage(ctx, entity.PvzPageRequest{}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
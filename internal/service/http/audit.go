@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.opentelemetry.io/otel/trace"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/pkg/jwt"
+	"order-pick-up-point/pkg/logger"
+	"order-pick-up-point/pkg/requestid"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// auditChange — изменение одной сущности для журнала аудита. Before и After сериализуются в JSON,
+// nil означает, что сущности до или после изменения нет.
+type auditChange struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	PvzID      string
+	Before     any
+	After      any
+}
+
+// recordAudit пишет изменение в журнал аудита. Вызывается внутри транзакции изменения,
+// поэтому ошибка записи откатывает и само изменение. Автор, request ID и trace ID берутся из контекста.
+func recordAudit(ctx context.Context, repo db.AuditRepository, change auditChange) error {
+	entry := entity.AuditEntry{
+		Action:     change.Action,
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+		RequestID:  requestid.FromContext(ctx),
+	}
+	if claims, ok := jwt.ClaimsFromContext(ctx); ok {
+		entry.ActorID = actorID(claims.UserID)
+		entry.ActorRole = claims.Role
+		entry.ActorDummy = claims.Dummy
+	}
+	if change.PvzID != "" {
+		entry.PvzID = &change.PvzID
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		entry.TraceID = sc.TraceID().String()
+	}
+
+	var err error
+	if entry.Before, err = auditPayload(change.Before); err != nil {
+		return err
+	}
+	if entry.After, err = auditPayload(change.After); err != nil {
+		return err
+	}
+	return repo.InsertAuditEntry(ctx, entry)
+}
+
+func auditPayload(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to encode audit payload")
+	}
+	return raw, nil
+}
+
+// AuditService — чтение журнала аудита. Записи добавляют сами изменяющие операции через recordAudit.
+type AuditService interface {
+	GetAuditLog(ctx context.Context, filter entity.AuditFilter) (*entity.AuditPage, error)
+}
+
+type auditServiceImp struct {
+	repo   db.AuditRepository
+	logger logger.Logger
+}
+
+func NewAuditService(repo db.AuditRepository, logger logger.Logger) AuditService {
+	return &auditServiceImp{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetAuditLog возвращает страницу журнала аудита от новых записей к старым.
+func (s *auditServiceImp) GetAuditLog(ctx context.Context, filter entity.AuditFilter) (*entity.AuditPage, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if err := validateAuditFilter(filter); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	filter.Limit++ // лишняя запись показывает, есть ли следующая страница
+	entries, err := s.repo.ListAuditEntries(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("GetAuditLog",
+			"error", err,
+			"action", filter.Action,
+			"entityID", filter.EntityID,
+		)
+		return nil, err
+	}
+
+	page := &entity.AuditPage{Items: entries}
+	if len(entries) > limit {
+		page.Items = entries[:limit]
+		page.HasMore = true
+		page.NextCursor = &page.Items[limit-1].ID
+	}
+	return page, nil
+}
+
+func validateAuditFilter(filter entity.AuditFilter) error {
+	if filter.Limit < 0 || filter.Limit > maxAuditLimit {
+		return errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("limit must be between 1 and %d", maxAuditLimit))
+	}
+	for _, id := range []string{filter.ActorID, filter.EntityID, filter.PvzID} {
+		if id == "" {
+			continue
+		}
+		if err := validateIDs(id); err != nil {
+			return err
+		}
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return errs.New(errs.ErrInvalidRequestCode, "startDate must not be after endDate")
+	}
+	return nil
+}
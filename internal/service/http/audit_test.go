@@ -0,0 +1,160 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/trace"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	"order-pick-up-point/pkg/jwt"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"order-pick-up-point/pkg/requestid"
+	"testing"
+	"time"
+)
+
+func TestRecordAudit(t *testing.T) {
+	t.Parallel()
+
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{1}})
+
+	t.Run("actor, request and trace are taken from context", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+
+		ctx := jwt.ContextWithClaims(context.Background(), &jwt.CustomClaims{UserID: testEmployee, Role: "employee"})
+		ctx = requestid.ContextWithID(ctx, "req-1")
+		ctx = trace.ContextWithSpanContext(ctx, spanCtx)
+
+		repoMock.On("InsertAuditEntry", mock.Anything, mock.MatchedBy(func(e entity.AuditEntry) bool {
+			return e.ActorID != nil && *e.ActorID == testEmployee && e.ActorRole == "employee" &&
+				e.RequestID == "req-1" && e.TraceID == traceID.String() &&
+				e.PvzID != nil && *e.PvzID == testPvzID &&
+				e.Before == nil && string(e.After) == `{"id":"rec1","date_time":"0001-01-01T00:00:00Z","pvz_id":"","status":"in_progress","opened_by":null,"closed_by":null,"closed_at":null}`
+		})).Return(nil).Once()
+
+		err := recordAudit(ctx, repoMock, auditChange{
+			Action:     entity.AuditReceptionCreate,
+			EntityType: entity.AuditEntityReception,
+			EntityID:   "rec1",
+			PvzID:      testPvzID,
+			After:      entity.Reception{ID: "rec1", Status: "in_progress"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("dummy token keeps role without actor", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+
+		ctx := jwt.ContextWithClaims(context.Background(), &jwt.CustomClaims{UserID: jwt.DummyUserID, Role: "moderator", Dummy: true})
+		repoMock.On("InsertAuditEntry", mock.Anything, mock.MatchedBy(func(e entity.AuditEntry) bool {
+			return e.ActorID == nil && e.ActorRole == "moderator" && e.ActorDummy && e.PvzID == nil && e.TraceID == ""
+		})).Return(nil).Once()
+
+		err := recordAudit(ctx, repoMock, auditChange{Action: entity.AuditUserRegister, EntityType: entity.AuditEntityUser, EntityID: testClientID})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("write error is returned to roll back the change", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+
+		repoMock.On("InsertAuditEntry", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+
+		err := recordAudit(context.Background(), repoMock, auditChange{Action: entity.AuditPvzCreate, EntityID: testPvzID})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestPvzService_GetAuditLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("default limit and next cursor", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		svc := &auditServiceImp{repo: repoMock, logger: mockLog.NewLogger(t)}
+
+		entries := make([]entity.AuditEntry, defaultAuditLimit+1)
+		for i := range entries {
+			entries[i].ID = int64(100 - i)
+		}
+		repoMock.On("ListAuditEntries", mock.Anything, entity.AuditFilter{Action: entity.AuditPvzCreate, Limit: defaultAuditLimit + 1}).
+			Return(entries, nil).Once()
+
+		page, err := svc.GetAuditLog(ctx, entity.AuditFilter{Action: entity.AuditPvzCreate})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(page.Items) != defaultAuditLimit || !page.HasMore {
+			t.Fatalf("expected full page with more entries, got %d items (hasMore=%v)", len(page.Items), page.HasMore)
+		}
+		if page.NextCursor == nil || *page.NextCursor != 51 {
+			t.Errorf("expected next cursor 51, got %v", page.NextCursor)
+		}
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		svc := &auditServiceImp{repo: repoMock, logger: mockLog.NewLogger(t)}
+
+		repoMock.On("ListAuditEntries", mock.Anything, entity.AuditFilter{PvzID: testPvzID, Limit: 3}).
+			Return([]entity.AuditEntry{{ID: 2}, {ID: 1}}, nil).Once()
+
+		page, err := svc.GetAuditLog(ctx, entity.AuditFilter{PvzID: testPvzID, Limit: 2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(page.Items) != 2 || page.HasMore || page.NextCursor != nil {
+			t.Errorf("unexpected page: %+v", page)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		svc := &auditServiceImp{repo: repoMock, logger: loggerMock}
+		expectErrorLog(loggerMock, "GetAuditLog", 6)
+
+		repoMock.On("ListAuditEntries", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+
+		if _, err := svc.GetAuditLog(ctx, entity.AuditFilter{}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	start := time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, -1)
+	invalid := []struct {
+		name   string
+		filter entity.AuditFilter
+	}{
+		{name: "limit too large", filter: entity.AuditFilter{Limit: maxAuditLimit + 1}},
+		{name: "negative limit", filter: entity.AuditFilter{Limit: -1}},
+		{name: "actor is not uuid", filter: entity.AuditFilter{ActorID: "dummyID"}},
+		{name: "start after end", filter: entity.AuditFilter{StartDate: &start, EndDate: &end}},
+	}
+	for _, tc := range invalid {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			svc := &auditServiceImp{repo: mockRepo.NewRepository(t), logger: mockLog.NewLogger(t)}
+
+			_, err := svc.GetAuditLog(ctx, tc.filter)
+			assertErrCode(t, err, errs.ErrInvalidRequestCode)
+		})
+	}
+}
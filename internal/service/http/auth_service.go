@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/models/entity"
@@ -15,8 +16,18 @@ import (
 
 type AuthService interface {
 	Register(ctx context.Context, email string, password string, role string) (string, error)
-	Login(ctx context.Context, email string, password string) (string, error)
+	Login(ctx context.Context, email string, password string, clientIP string) (*entity.TokenPair, error)
 	DummyLogin(ctx context.Context, role string) (string, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*entity.TokenPair, error)
+	Logout(ctx context.Context) error
+	JWKS() jwt.JWKS
+	UnlockUser(ctx context.Context, userID string) error
+	ListUsers(ctx context.Context, filter entity.UserFilter) (*entity.UserPage, error)
+	GetUser(ctx context.Context, userID string) (*entity.User, error)
+	ChangeUserRole(ctx context.Context, userID string, role string) (*entity.User, error)
+	SetUserActive(ctx context.Context, userID string, active bool) (*entity.User, error)
+	ResetUserPassword(ctx context.Context, userID string) (string, error)
+	ChangePassword(ctx context.Context, currentPassword string, newPassword string) error
 }
 
 type authServiceImp struct {
@@ -26,6 +37,8 @@ type authServiceImp struct {
 	hasher       password.PasswordHasher
 	logger       logger.Logger
 	allowedRoles map[string]bool
+	refreshTTL   time.Duration
+	protection   LoginProtectionOptions
 }
 
 func NewAuthService(
@@ -35,6 +48,8 @@ func NewAuthService(
 	hasher password.PasswordHasher,
 	logger logger.Logger,
 	roles map[string]bool,
+	refreshTTL time.Duration,
+	protection LoginProtectionOptions,
 ) AuthService {
 	return &authServiceImp{
 		repo:         repo,
@@ -43,6 +58,8 @@ func NewAuthService(
 		hasher:       hasher,
 		logger:       logger,
 		allowedRoles: roles,
+		refreshTTL:   refreshTTL,
+		protection:   protection,
 	}
 }
 
@@ -83,6 +100,7 @@ func (s *authServiceImp) Register(ctx context.Context, email string, passwordStr
 			PasswordHash: hashedPassword,
 			Role:         role,
 			CreatedAt:    time.Now(),
+			Active:       true,
 		}
 
 		uid, err := s.repo.CreateUser(txCtx, user)
@@ -90,7 +108,14 @@ func (s *authServiceImp) Register(ctx context.Context, email string, passwordStr
 			return err
 		}
 		userID = uid
-		return nil
+		user.ID = uid
+		// Хеш пароля в JSON не сериализуется
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditUserRegister,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   uid,
+			After:      user,
+		})
 	})
 	if err != nil {
 		s.logger.Errorw("Register",
@@ -103,35 +128,57 @@ func (s *authServiceImp) Register(ctx context.Context, email string, passwordStr
 	return userID, nil
 }
 
-func (s *authServiceImp) Login(ctx context.Context, email string, passwordStr string) (string, error) {
-	user, err := s.repo.FindByEmail(ctx, email)
+func (s *authServiceImp) Login(ctx context.Context, email string, passwordStr string, clientIP string) (*entity.TokenPair, error) {
+	keys := loginAttemptKeys(email, clientIP)
+	reserved, err := s.reserveLoginAttempt(ctx, email, clientIP, keys)
 	if err != nil {
+		return nil, err
+	}
+
+	// Сбой БД не считается неудачной попыткой и не выдаётся за неверный пароль
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil && !errs.IsNotFound(err) {
 		s.logger.Errorw("Login",
 			"email", email,
 			"error", err,
 		)
-		return "", errs.New(errs.ErrInvalidCredentials, "invalid credentials")
+		s.releaseLoginAttempts(ctx, keys)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find user")
 	}
 
-	if !s.hasher.Check(user.PasswordHash, passwordStr) {
-		errCheckPass := errs.New(errs.ErrInvalidCredentials, "invalid credentials")
-		s.logger.Errorw("Login",
-			"email", email,
-			"error", errCheckPass,
+	// Для неизвестного email пароль всё равно проверяется, чтобы ответ не отличался по времени
+	passwordHash := unknownUserPasswordHash
+	if user != nil {
+		passwordHash = user.PasswordHash
+	}
+	if !s.hasher.Check(passwordHash, passwordStr) || user == nil {
+		s.recordLoginFailure(email, clientIP, reserved)
+		return nil, errs.New(errs.ErrInvalidCredentials, "invalid credentials")
+	}
+
+	// Отключённый пользователь узнаёт об этом только после верного пароля
+	if !user.Active {
+		s.logger.Warnw("login rejected: user is deactivated",
+			"userID", user.ID,
+			"ip", clientIP,
 		)
-		return "", errCheckPass
+		s.releaseLoginAttempts(ctx, keys)
+		return nil, errs.New(errs.ErrUserDeactivated, "user is deactivated")
 	}
 
-	tokenStr, err := s.tokenSvc.GenerateToken(user.ID, user.Role)
+	// Вход открывает новую сессию — новое семейство refresh-токенов
+	pair, err := s.issueTokens(ctx, user, uuid.NewString())
 	if err != nil {
 		s.logger.Errorw("Login",
 			"error", err,
 			"userID", user.ID,
 		)
-		return "", errs.Wrap(err, errs.ErrInternalCode, "failed to generate token")
+		s.releaseLoginAttempts(ctx, keys)
+		return nil, err
 	}
 
-	return tokenStr, nil
+	s.resetLoginFailures(ctx, keys)
+	return pair, nil
 }
 
 func (s *authServiceImp) DummyLogin(ctx context.Context, role string) (string, error) {
@@ -143,7 +190,7 @@ func (s *authServiceImp) DummyLogin(ctx context.Context, role string) (string, e
 		return "", err
 	}
 
-	tokenStr, err := s.tokenSvc.GenerateToken("dummyID", role)
+	token, err := s.tokenSvc.GenerateDummyToken(role)
 	if err != nil {
 		s.logger.Errorw("DummyLogin",
 			"role", role,
@@ -151,7 +198,7 @@ func (s *authServiceImp) DummyLogin(ctx context.Context, role string) (string, e
 		)
 		return "", errs.Wrap(err, errs.ErrInternalCode, "dummy login failed")
 	}
-	return tokenStr, nil
+	return token.Value, nil
 }
 
 func (s *authServiceImp) validateRole(role string) error {
@@ -8,11 +8,13 @@ import (
 	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/models/entity"
 	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	"order-pick-up-point/pkg/jwt"
 	mockToken "order-pick-up-point/pkg/jwt/mock"
 	mockLog "order-pick-up-point/pkg/logger/mock"
 	mockPass "order-pick-up-point/pkg/password/mock"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAuthService_Register(t *testing.T) {
@@ -217,6 +219,14 @@ func TestAuthService_Register(t *testing.T) {
 						})).
 						Return(fakeUserID, nil).
 						Once()
+					// Хеш пароля не должен попасть в журнал аудита
+					repoMock.
+						On("InsertAuditEntry", mock.Anything, mock.MatchedBy(func(e entity.AuditEntry) bool {
+							return e.Action == entity.AuditUserRegister && e.EntityID == fakeUserID &&
+								!strings.Contains(string(e.After), "hashed_secret")
+						})).
+						Return(nil).
+						Once()
 				}
 
 				if tc.expectedErrMsg != "" && tc.simulateError != "tx" {
@@ -250,15 +260,19 @@ func TestAuthService_Login(t *testing.T) {
 	ctx := context.Background()
 	email := "test@example.com"
 	passwordStr := "secret"
+	clientIP := "10.0.0.1"
 
 	user := &entity.User{
 		ID:           "user123",
 		Email:        email,
 		PasswordHash: "hashed_secret",
 		Role:         "client",
+		Active:       true,
 	}
+	deactivated := *user
+	deactivated.Active = false
 
-	fakeToken := "jwt_token_abc"
+	fakeToken := &jwt.Token{Value: "jwt_token_abc", ID: "jti1", ExpiresAt: time.Now().Add(15 * time.Minute)}
 
 	tests := []struct {
 		name           string
@@ -268,6 +282,11 @@ func TestAuthService_Login(t *testing.T) {
 		{
 			name:           "error in find user",
 			simulateError:  "find",
+			expectedErrMsg: "failed to find user",
+		},
+		{
+			name:           "unknown email",
+			simulateError:  "unknown",
 			expectedErrMsg: "invalid credentials",
 		},
 		{
@@ -275,11 +294,21 @@ func TestAuthService_Login(t *testing.T) {
 			simulateError:  "wrong_pass",
 			expectedErrMsg: "invalid credentials",
 		},
+		{
+			name:           "deactivated user",
+			simulateError:  "deactivated",
+			expectedErrMsg: "user is deactivated",
+		},
 		{
 			name:           "error in token generate",
 			simulateError:  "token",
 			expectedErrMsg: "failed to generate token",
 		},
+		{
+			name:           "error in refresh token save",
+			simulateError:  "refresh",
+			expectedErrMsg: "failed to create refresh token",
+		},
 		{
 			name:           "success",
 			simulateError:  "",
@@ -304,6 +333,7 @@ func TestAuthService_Login(t *testing.T) {
 				hasher:       hasherMock,
 				logger:       loggerMock,
 				allowedRoles: map[string]bool{"client": true, "moderator": true},
+				refreshTTL:   time.Hour,
 			}
 
 			switch tc.simulateError {
@@ -319,6 +349,17 @@ func TestAuthService_Login(t *testing.T) {
 					})).
 					Return().
 					Once()
+			case "unknown":
+				// Пароль сверяется с заглушкой, в лог пишется то же, что и при неверном пароле
+				repoMock.
+					On("FindByEmail", mock.Anything, email).
+					Return(nil, errs.New(errs.ErrNotFoundCode, "user not found")).
+					Once()
+				hasherMock.
+					On("Check", unknownUserPasswordHash, passwordStr).
+					Return(false).
+					Once()
+				loggerMock.On("Warnw", "login failed", "email", email, "ip", clientIP).Return().Once()
 			case "wrong_pass":
 				// Пользователь найден, но hasher.Check возвращает false
 				repoMock.
@@ -329,12 +370,18 @@ func TestAuthService_Login(t *testing.T) {
 					On("Check", user.PasswordHash, passwordStr).
 					Return(false).
 					Once()
-				loggerMock.
-					On("Errorw", "Login", "email", email, "error", mock.MatchedBy(func(err error) bool {
-						return strings.Contains(err.Error(), "invalid credentials")
-					})).
-					Return().
+				loggerMock.On("Warnw", "login failed", "email", email, "ip", clientIP).Return().Once()
+			case "deactivated":
+				// Пароль верный, но учётная запись отключена: токены не выдаются
+				repoMock.
+					On("FindByEmail", mock.Anything, email).
+					Return(&deactivated, nil).
+					Once()
+				hasherMock.
+					On("Check", user.PasswordHash, passwordStr).
+					Return(true).
 					Once()
+				loggerMock.On("Warnw", "login rejected: user is deactivated", "userID", user.ID, "ip", clientIP).Return().Once()
 			case "token":
 				// Пользователь найден, пароль верный
 				repoMock.
@@ -348,7 +395,7 @@ func TestAuthService_Login(t *testing.T) {
 				// tokenSvc.GenerateToken возвращает ошибку "token service error"
 				tokenSvcMock.
 					On("GenerateToken", user.ID, user.Role).
-					Return("", errors.New("token service error")).
+					Return(nil, errors.New("token service error")).
 					Once()
 				// Логгер должен залогировать оригинальную ошибку от GenerateToken
 				loggerMock.
@@ -357,6 +404,27 @@ func TestAuthService_Login(t *testing.T) {
 					}), "userID", user.ID).
 					Return().
 					Once()
+			case "refresh":
+				repoMock.
+					On("FindByEmail", mock.Anything, email).
+					Return(user, nil).
+					Once()
+				hasherMock.
+					On("Check", user.PasswordHash, passwordStr).
+					Return(true).
+					Once()
+				tokenSvcMock.
+					On("GenerateToken", user.ID, user.Role).
+					Return(fakeToken, nil).
+					Once()
+				repoMock.
+					On("CreateRefreshToken", mock.Anything, mock.Anything).
+					Return(errs.New(errs.ErrInternalCode, "failed to create refresh token")).
+					Once()
+				loggerMock.
+					On("Errorw", "Login", "error", mock.Anything, "userID", user.ID).
+					Return().
+					Once()
 			case "":
 				// Успешный сценарий
 				repoMock.
@@ -371,18 +439,28 @@ func TestAuthService_Login(t *testing.T) {
 					On("GenerateToken", user.ID, user.Role).
 					Return(fakeToken, nil).
 					Once()
+				// Сохраняется только хеш refresh-токена, access-токен привязан к той же сессии
+				repoMock.
+					On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt entity.RefreshToken) bool {
+						return rt.UserID == user.ID && rt.FamilyID != "" && len(rt.TokenHash) == 64 &&
+							rt.AccessJTI == fakeToken.ID && rt.AccessExpiresAt.Equal(fakeToken.ExpiresAt)
+					})).
+					Return(nil).
+					Once()
 			}
 
-			tokenStr, err := svc.Login(ctx, email, passwordStr)
+			pair, err := svc.Login(ctx, email, passwordStr, clientIP)
 			if tc.expectedErrMsg != "" {
 				if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
 					t.Errorf("expected error containing %q, got %v", tc.expectedErrMsg, err)
 				}
-			} else if err != nil {
-				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
 			}
-			if tc.expectedErrMsg == "" && tokenStr != fakeToken {
-				t.Errorf("expected token %q, got %q", fakeToken, tokenStr)
+			if pair.AccessToken != fakeToken.Value || pair.RefreshToken == "" {
+				t.Errorf("expected access token %q and a refresh token, got %+v", fakeToken.Value, pair)
 			}
 		})
 	}
@@ -456,12 +534,12 @@ func TestAuthService_DummyLogin(t *testing.T) {
 				return
 			}
 
-			// Для остальных сценариев вызываем tokenSvc.GenerateToken
+			// Для остальных сценариев вызываем tokenSvc.GenerateDummyToken
 			switch tc.simulateError {
 			case "token":
 				tokenSvcMock.
-					On("GenerateToken", "dummyID", tc.role).
-					Return("", errors.New("token service error")).
+					On("GenerateDummyToken", tc.role).
+					Return(nil, errors.New("token service error")).
 					Once()
 				loggerMock.
 					On("Errorw", "DummyLogin", "role", tc.role, "error", mock.MatchedBy(func(err error) bool {
@@ -471,8 +549,8 @@ func TestAuthService_DummyLogin(t *testing.T) {
 					Once()
 			case "":
 				tokenSvcMock.
-					On("GenerateToken", "dummyID", tc.role).
-					Return(fakeToken, nil).
+					On("GenerateDummyToken", tc.role).
+					Return(&jwt.Token{Value: fakeToken, ID: "jti1", ExpiresAt: time.Now().Add(time.Minute)}, nil).
 					Once()
 			}
 
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/jwt"
+	"time"
+)
+
+const refreshTokenBytes = 32
+
+// RefreshTokens обменивает refresh-токен на новую пару токенов той же сессии, предъявленный
+// токен становится использованным. Повторное предъявление использованного токена значит, что
+// его копия есть у кого-то ещё: отзывается вся сессия вместе с выданными в ней access-токенами.
+func (s *authServiceImp) RefreshTokens(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	if refreshToken == "" {
+		return nil, errs.New(errs.ErrInvalidRefreshToken, "invalid refresh token")
+	}
+
+	var (
+		pair   *entity.TokenPair
+		reused *entity.RefreshToken
+	)
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		stored, err := s.repo.GetRefreshTokenForUpdate(txCtx, hashRefreshToken(refreshToken))
+		if err != nil {
+			return err
+		}
+
+		if stored.UsedAt != nil {
+			// Отзыв сессии должен зафиксироваться, поэтому ошибка возвращается уже после транзакции
+			reused = stored
+			return s.repo.RevokeTokenFamily(txCtx, stored.FamilyID)
+		}
+		if stored.RevokedAt != nil || !time.Now().Before(stored.ExpiresAt) {
+			return errs.New(errs.ErrInvalidRefreshToken, "invalid refresh token")
+		}
+
+		// Роль берётся из БД, а не из старого токена
+		user, err := s.repo.FindByID(txCtx, stored.UserID)
+		if err != nil {
+			if errs.IsNotFound(err) {
+				return errs.New(errs.ErrInvalidRefreshToken, "invalid refresh token")
+			}
+			return err
+		}
+		if !user.Active {
+			return errs.New(errs.ErrInvalidRefreshToken, "invalid refresh token")
+		}
+
+		if err := s.repo.MarkRefreshTokenUsed(txCtx, stored.ID); err != nil {
+			return err
+		}
+		pair, err = s.issueTokens(txCtx, user, stored.FamilyID)
+		return err
+	})
+	if err != nil {
+		s.logger.Errorw("RefreshTokens",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	if reused != nil {
+		s.logger.Warnw("refresh token reuse detected, session revoked",
+			"userID", reused.UserID,
+			"familyID", reused.FamilyID,
+		)
+		return nil, errs.New(errs.ErrRefreshTokenReused, "refresh token has already been used, session revoked")
+	}
+
+	return pair, nil
+}
+
+// Logout отзывает access-токен запроса и сессию, в которой он выдан: её refresh-токены
+// больше не обмениваются. Токены /dummyLogin выдаются без сессии, у них отзывается только сам токен.
+func (s *authServiceImp) Logout(ctx context.Context) error {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return errs.New(errs.ErrUnauthorizedCode, "missing token claims")
+	}
+
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		if err := s.repo.RevokeAccessToken(txCtx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			return err
+		}
+
+		familyID, err := s.repo.GetTokenFamilyByAccessJTI(txCtx, claims.ID)
+		if err != nil {
+			if errs.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		return s.repo.RevokeTokenFamily(txCtx, familyID)
+	})
+	if err != nil {
+		s.logger.Errorw("Logout",
+			"error", err,
+			"userID", claims.UserID,
+		)
+		return err
+	}
+
+	return nil
+}
+
+// JWKS возвращает открытые ключи, которыми другие сервисы проверяют наши токены.
+func (s *authServiceImp) JWKS() jwt.JWKS {
+	return s.tokenSvc.JWKS()
+}
+
+// issueTokens выдаёт access-токен и сохраняет новый refresh-токен сессии familyID.
+func (s *authServiceImp) issueTokens(ctx context.Context, user *entity.User, familyID string) (*entity.TokenPair, error) {
+	access, err := s.tokenSvc.GenerateToken(user.ID, user.Role)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to generate token")
+	}
+
+	refresh, err := newRefreshToken()
+	if err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to generate refresh token")
+	}
+	expiresAt := time.Now().Add(s.refreshTTL)
+
+	err = s.repo.CreateRefreshToken(ctx, entity.RefreshToken{
+		UserID:          user.ID,
+		FamilyID:        familyID,
+		TokenHash:       hashRefreshToken(refresh),
+		AccessJTI:       access.ID,
+		AccessExpiresAt: access.ExpiresAt,
+		ExpiresAt:       expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TokenPair{
+		AccessToken:            access.Value,
+		AccessExpiresAt:        access.ExpiresAt,
+		RefreshToken:           refresh,
+		RefreshExpiresAt:       expiresAt,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+	}, nil
+}
+
+// newRefreshToken возвращает непрозрачный случайный токен. В отличие от JWT он ничего не
+// значит без записи в БД, поэтому отзывается удалением или пометкой записи.
+func newRefreshToken() (string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashRefreshToken — в БД хранится только хеш, утечка таблицы не даёт действующих токенов.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
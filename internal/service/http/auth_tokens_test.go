@@ -0,0 +1,205 @@
+package http
+
+import (
+	"context"
+	"errors"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	"order-pick-up-point/pkg/jwt"
+	mockToken "order-pick-up-point/pkg/jwt/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+	"time"
+)
+
+func newTokenTestService(t *testing.T) (*authServiceImp, *mockRepo.Repository, *mockRepo.TxManager, *mockToken.TokenService, *mockLog.Logger) {
+	repoMock := mockRepo.NewRepository(t)
+	txManager := mockRepo.NewTxManager(t)
+	tokenSvcMock := mockToken.NewTokenService(t)
+	loggerMock := mockLog.NewLogger(t)
+	svc := &authServiceImp{
+		repo:       repoMock,
+		txManager:  txManager,
+		tokenSvc:   tokenSvcMock,
+		logger:     loggerMock,
+		refreshTTL: time.Hour,
+	}
+	return svc, repoMock, txManager, tokenSvcMock, loggerMock
+}
+
+func TestAuthService_RefreshTokens(t *testing.T) {
+	t.Parallel()
+
+	const refresh = "refresh-token"
+	hash := hashRefreshToken(refresh)
+	now := time.Now()
+	user := &entity.User{ID: testClientID, Role: "client", Active: true}
+	stored := func() *entity.RefreshToken {
+		return &entity.RefreshToken{ID: "rt1", UserID: user.ID, FamilyID: "family1", TokenHash: hash, ExpiresAt: now.Add(time.Hour)}
+	}
+
+	t.Run("rotates token within the same family", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, tokenSvcMock, _ := newTokenTestService(t)
+		passThroughTx(txManager)
+
+		access := &jwt.Token{Value: "access2", ID: "jti2", ExpiresAt: now.Add(15 * time.Minute)}
+		repoMock.On("GetRefreshTokenForUpdate", mock.Anything, hash).Return(stored(), nil).Once()
+		repoMock.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+		repoMock.On("MarkRefreshTokenUsed", mock.Anything, "rt1").Return(nil).Once()
+		tokenSvcMock.On("GenerateToken", user.ID, user.Role).Return(access, nil).Once()
+		repoMock.
+			On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt entity.RefreshToken) bool {
+				return rt.FamilyID == "family1" && rt.AccessJTI == "jti2" && rt.TokenHash != hash
+			})).
+			Return(nil).
+			Once()
+
+		pair, err := svc.RefreshTokens(context.Background(), refresh)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pair.AccessToken != "access2" || pair.RefreshToken == "" || pair.RefreshToken == refresh {
+			t.Errorf("expected new token pair, got %+v", pair)
+		}
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, loggerMock := newTokenTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("GetRefreshTokenForUpdate", mock.Anything, hash).Return(stored(), nil).Once()
+		repoMock.On("FindByID", mock.Anything, user.ID).Return(&entity.User{ID: user.ID, Role: "client"}, nil).Once()
+		expectErrorLog(loggerMock, "RefreshTokens", 2)
+
+		_, err := svc.RefreshTokens(context.Background(), refresh)
+		assertErrCode(t, err, errs.ErrInvalidRefreshToken)
+	})
+
+	t.Run("reused token revokes the family", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, loggerMock := newTokenTestService(t)
+		passThroughTx(txManager)
+
+		used := stored()
+		usedAt := now.Add(-time.Minute)
+		used.UsedAt = &usedAt
+		repoMock.On("GetRefreshTokenForUpdate", mock.Anything, hash).Return(used, nil).Once()
+		repoMock.On("RevokeTokenFamily", mock.Anything, "family1").Return(nil).Once()
+		loggerMock.On("Warnw", "refresh token reuse detected, session revoked", "userID", user.ID, "familyID", "family1").Return().Once()
+
+		_, err := svc.RefreshTokens(context.Background(), refresh)
+		assertErrCode(t, err, errs.ErrRefreshTokenReused)
+	})
+
+	t.Run("revoked and expired tokens are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		revoked := stored()
+		revokedAt := now.Add(-time.Minute)
+		revoked.RevokedAt = &revokedAt
+		expired := stored()
+		expired.ExpiresAt = now.Add(-time.Second)
+
+		for _, rt := range []*entity.RefreshToken{revoked, expired} {
+			svc, repoMock, txManager, _, loggerMock := newTokenTestService(t)
+			passThroughTx(txManager)
+			repoMock.On("GetRefreshTokenForUpdate", mock.Anything, hash).Return(rt, nil).Once()
+			expectErrorLog(loggerMock, "RefreshTokens", 2)
+
+			_, err := svc.RefreshTokens(context.Background(), refresh)
+			assertErrCode(t, err, errs.ErrInvalidRefreshToken)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, loggerMock := newTokenTestService(t)
+		passThroughTx(txManager)
+		repoMock.
+			On("GetRefreshTokenForUpdate", mock.Anything, hash).
+			Return(nil, errs.New(errs.ErrInvalidRefreshToken, "invalid refresh token")).
+			Once()
+		expectErrorLog(loggerMock, "RefreshTokens", 2)
+
+		_, err := svc.RefreshTokens(context.Background(), refresh)
+		assertErrCode(t, err, errs.ErrInvalidRefreshToken)
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _, _ := newTokenTestService(t)
+
+		_, err := svc.RefreshTokens(context.Background(), "")
+		assertErrCode(t, err, errs.ErrInvalidRefreshToken)
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	claims := &jwt.CustomClaims{
+		UserID: testClientID,
+		Role:   "client",
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ID:        "jti1",
+			ExpiresAt: jwtlib.NewNumericDate(expiresAt),
+		},
+	}
+	ctx := jwt.ContextWithClaims(context.Background(), claims)
+
+	t.Run("revokes token and its session", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, _ := newTokenTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("RevokeAccessToken", mock.Anything, "jti1", expiresAt).Return(nil).Once()
+		repoMock.On("GetTokenFamilyByAccessJTI", mock.Anything, "jti1").Return("family1", nil).Once()
+		repoMock.On("RevokeTokenFamily", mock.Anything, "family1").Return(nil).Once()
+
+		if err := svc.Logout(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("token without session", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, _ := newTokenTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("RevokeAccessToken", mock.Anything, "jti1", expiresAt).Return(nil).Once()
+		repoMock.
+			On("GetTokenFamilyByAccessJTI", mock.Anything, "jti1").
+			Return("", errs.New(errs.ErrNotFoundCode, "token family not found")).
+			Once()
+
+		if err := svc.Logout(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, loggerMock := newTokenTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("RevokeAccessToken", mock.Anything, "jti1", expiresAt).Return(errors.New("db down")).Once()
+		expectErrorLog(loggerMock, "Logout", 4)
+
+		if err := svc.Logout(ctx); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("missing claims", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _, _ := newTokenTestService(t)
+
+		assertErrCode(t, svc.Logout(context.Background()), errs.ErrUnauthorizedCode)
+	})
+}
@@ -0,0 +1,241 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"strings"
+	"time"
+)
+
+// unknownUserPasswordHash — bcrypt-хеш случайной строки. С ним сверяется пароль, если email не
+// найден: время ответа не должно выдавать, зарегистрирован ли адрес.
+const unknownUserPasswordHash = "$2a$10$IsX9AS2DWJ8JXt7sLDSPzOyW3iNMyWJpmoveO8vg.qgsk6H8pAPQy"
+
+// LoginProtectionOptions — параметры защиты входа от перебора, см. config.LoginProtectionConfig.
+type LoginProtectionOptions struct {
+	Enabled          bool
+	EmailMaxFailures int
+	IPMaxFailures    int
+	Lockout          time.Duration
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	FailureWindow    time.Duration
+}
+
+// LoginBlockedError — вход временно ограничен. RetryAfter — через сколько можно повторить попытку.
+type LoginBlockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("login blocked, retry after %s", e.RetryAfter)
+}
+
+func loginAttemptKeys(email, clientIP string) []entity.LoginAttemptKey {
+	keys := []entity.LoginAttemptKey{{Scope: entity.LoginScopeEmail, Key: strings.ToLower(strings.TrimSpace(email))}}
+	if clientIP != "" {
+		keys = append(keys, entity.LoginAttemptKey{Scope: entity.LoginScopeIP, Key: clientIP})
+	}
+	return keys
+}
+
+// reserveLoginAttempt до проверки пароля учитывает попытку по email и IP одной транзакцией и
+// отклоняет её, если email или IP заблокирован или с последней попытки не прошла задержка.
+// Параллельные попытки не проходят проверку одновременно: каждая видит резерв предыдущей.
+// Возвращает счётчики после резерва. Ответ не зависит от того, существует ли пользователь.
+func (s *authServiceImp) reserveLoginAttempt(ctx context.Context, email, clientIP string, keys []entity.LoginAttemptKey) ([]entity.LoginAttempts, error) {
+	if !s.protection.Enabled {
+		return nil, nil
+	}
+
+	var reserved []entity.LoginAttempts
+	var wait time.Duration
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		reserved = reserved[:0]
+		for _, key := range keys {
+			attempts, err := s.repo.ReserveLoginAttempt(txCtx, key, s.loginPolicy(key.Scope))
+			if err != nil {
+				return err
+			}
+			if attempts == nil {
+				wait, err = s.loginRetryAfter(txCtx, keys)
+				if err != nil {
+					return err
+				}
+				return errs.Wrap(&LoginBlockedError{RetryAfter: wait}, errs.ErrTooManyLoginAttempts, "too many failed login attempts, try again later")
+			}
+			reserved = append(reserved, *attempts)
+		}
+		return nil
+	})
+	if err == nil {
+		return reserved, nil
+	}
+
+	var blocked *LoginBlockedError
+	if !errors.As(err, &blocked) {
+		s.logger.Errorw("Login",
+			"error", err,
+			"ip", clientIP,
+		)
+		return nil, err
+	}
+	metrics.LoginAttempt(metrics.LoginResultBlocked)
+	s.logger.Warnw("login blocked",
+		"email", email,
+		"ip", clientIP,
+		"retryAfter", wait,
+	)
+	return nil, err
+}
+
+// loginRetryAfter — через сколько можно повторить отклонённую попытку
+func (s *authServiceImp) loginRetryAfter(ctx context.Context, keys []entity.LoginAttemptKey) (time.Duration, error) {
+	attempts, err := s.repo.GetLoginAttempts(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	// Резерв был отклонён по состоянию на момент запроса: без задержки ответ не обходится
+	wait := time.Second
+	for _, a := range attempts {
+		wait = max(wait, s.loginWait(a, now))
+	}
+	return wait, nil
+}
+
+// loginPolicy — ограничения попыток входа для области scope
+func (s *authServiceImp) loginPolicy(scope string) entity.LoginAttemptPolicy {
+	limit := s.protection.EmailMaxFailures
+	if scope == entity.LoginScopeIP {
+		limit = s.protection.IPMaxFailures
+	}
+	return entity.LoginAttemptPolicy{
+		MaxFailures:   limit,
+		Lockout:       s.protection.Lockout,
+		BaseDelay:     s.protection.BaseDelay,
+		MaxDelay:      s.protection.MaxDelay,
+		FailureWindow: s.protection.FailureWindow,
+	}
+}
+
+// loginWait — сколько осталось ждать до следующей попытки
+func (s *authServiceImp) loginWait(a entity.LoginAttempts, now time.Time) time.Duration {
+	if a.LockedUntil != nil && now.Before(*a.LockedUntil) {
+		return a.LockedUntil.Sub(now)
+	}
+	if a.Failures == 0 || now.Sub(a.LastFailureAt) > s.protection.FailureWindow {
+		return 0
+	}
+	return a.LastFailureAt.Add(s.loginDelay(a.Failures)).Sub(now)
+}
+
+// loginDelay — задержка после failures неудач подряд: BaseDelay, удваиваемая с каждой неудачей, не больше MaxDelay
+func (s *authServiceImp) loginDelay(failures int) time.Duration {
+	delay := s.protection.BaseDelay
+	for i := 1; i < failures && delay < s.protection.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, s.protection.MaxDelay)
+}
+
+// recordLoginFailure логирует неудачную попытку, уже учтённую reserveLoginAttempt, и блокировку,
+// если эта попытка исчерпала лимит. Неизвестный email и неверный пароль логируются одинаково.
+func (s *authServiceImp) recordLoginFailure(email, clientIP string, reserved []entity.LoginAttempts) {
+	metrics.LoginAttempt(metrics.LoginResultFailed)
+	s.logger.Warnw("login failed",
+		"email", email,
+		"ip", clientIP,
+	)
+
+	for _, attempts := range reserved {
+		limit := s.loginPolicy(attempts.Scope).MaxFailures
+		if limit <= 0 || attempts.Failures < limit || attempts.LockedUntil == nil {
+			continue
+		}
+		metrics.LoginLockout(attempts.Scope)
+		s.logger.Warnw("login locked",
+			"scope", attempts.Scope,
+			"key", attempts.Key,
+			"failures", attempts.Failures,
+			"lockedUntil", *attempts.LockedUntil,
+		)
+	}
+}
+
+// releaseLoginAttempts отменяет резерв попытки, которая не была неудачной: пароль верный,
+// но вход не состоялся.
+func (s *authServiceImp) releaseLoginAttempts(ctx context.Context, keys []entity.LoginAttemptKey) {
+	if !s.protection.Enabled {
+		return
+	}
+	for _, key := range keys {
+		s.releaseLoginAttempt(ctx, key)
+	}
+}
+
+func (s *authServiceImp) releaseLoginAttempt(ctx context.Context, key entity.LoginAttemptKey) {
+	if err := s.repo.ReleaseLoginAttempt(ctx, key, s.loginPolicy(key.Scope).MaxFailures); err != nil {
+		s.logger.Errorw("Login",
+			"error", err,
+			"scope", key.Scope,
+		)
+	}
+}
+
+// resetLoginFailures сбрасывает счётчик email после успешного входа. По IP отменяется только
+// резерв этой попытки: иначе перебор чужих паролей можно было бы перемежать входом в свой аккаунт.
+func (s *authServiceImp) resetLoginFailures(ctx context.Context, keys []entity.LoginAttemptKey) {
+	metrics.LoginAttempt(metrics.LoginResultSuccess)
+	if !s.protection.Enabled {
+		return
+	}
+	if err := s.repo.ResetLoginAttempts(ctx, keys[0]); err != nil {
+		s.logger.Errorw("Login",
+			"error", err,
+			"scope", keys[0].Scope,
+		)
+	}
+	for _, key := range keys[1:] {
+		s.releaseLoginAttempt(ctx, key)
+	}
+}
+
+// UnlockUser снимает блокировку входа по email пользователя. Блокировки IP истекают сами.
+func (s *authServiceImp) UnlockUser(ctx context.Context, userID string) error {
+	if err := validateIDs(userID); err != nil {
+		return err
+	}
+
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		user, err := s.findUser(txCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.ResetLoginAttempts(txCtx, loginAttemptKeys(user.Email, "")[0]); err != nil {
+			return err
+		}
+
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditUserUnlock,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   user.ID,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("UnlockUser",
+			"error", err,
+			"userID", userID,
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	"order-pick-up-point/pkg/jwt"
+	mockToken "order-pick-up-point/pkg/jwt/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	mockPass "order-pick-up-point/pkg/password/mock"
+	"testing"
+	"time"
+)
+
+var testProtection = LoginProtectionOptions{
+	Enabled:          true,
+	EmailMaxFailures: 5,
+	IPMaxFailures:    20,
+	Lockout:          15 * time.Minute,
+	BaseDelay:        time.Second,
+	MaxDelay:         30 * time.Second,
+	FailureWindow:    time.Hour,
+}
+
+func newProtectedTestService(t *testing.T) (*authServiceImp, *mockRepo.Repository, *mockPass.PasswordHasher, *mockLog.Logger) {
+	repoMock := mockRepo.NewRepository(t)
+	txManager := mockRepo.NewTxManager(t)
+	hasherMock := mockPass.NewPasswordHasher(t)
+	loggerMock := mockLog.NewLogger(t)
+	passThroughTx(txManager)
+	svc := &authServiceImp{
+		repo:       repoMock,
+		txManager:  txManager,
+		hasher:     hasherMock,
+		logger:     loggerMock,
+		refreshTTL: time.Hour,
+		protection: testProtection,
+	}
+	return svc, repoMock, hasherMock, loggerMock
+}
+
+func TestAuthService_Login_Protection(t *testing.T) {
+	t.Parallel()
+
+	const (
+		email    = "Test@Example.com"
+		password = "secret"
+		clientIP = "10.0.0.1"
+	)
+	emailKey := entity.LoginAttemptKey{Scope: entity.LoginScopeEmail, Key: "test@example.com"}
+	ipKey := entity.LoginAttemptKey{Scope: entity.LoginScopeIP, Key: clientIP}
+	keys := []entity.LoginAttemptKey{emailKey, ipKey}
+	emailPolicy := entity.LoginAttemptPolicy{MaxFailures: 5, Lockout: 15 * time.Minute, BaseDelay: time.Second, MaxDelay: 30 * time.Second, FailureWindow: time.Hour}
+	ipPolicy := emailPolicy
+	ipPolicy.MaxFailures = 20
+	user := &entity.User{ID: testClientID, Email: "test@example.com", PasswordHash: "hash", Role: "client", Active: true}
+
+	t.Run("locked email is rejected without checking the password", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _, loggerMock := newProtectedTestService(t)
+
+		lockedUntil := time.Now().Add(10 * time.Minute)
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(nil, nil).Once()
+		repoMock.
+			On("GetLoginAttempts", mock.Anything, keys).
+			Return([]entity.LoginAttempts{{Scope: emailKey.Scope, Key: emailKey.Key, Failures: 5, LastFailureAt: time.Now(), LockedUntil: &lockedUntil}}, nil).
+			Once()
+		loggerMock.On("Warnw", "login blocked", "email", email, "ip", clientIP, "retryAfter", mock.Anything).Return().Once()
+
+		_, err := svc.Login(context.Background(), email, password, clientIP)
+		assertErrCode(t, err, errs.ErrTooManyLoginAttempts)
+
+		var blocked *LoginBlockedError
+		if !errors.As(err, &blocked) {
+			t.Fatalf("expected LoginBlockedError, got %v", err)
+		}
+		if blocked.RetryAfter <= 9*time.Minute || blocked.RetryAfter > 10*time.Minute {
+			t.Errorf("unexpected retry after %s", blocked.RetryAfter)
+		}
+	})
+
+	t.Run("attempt within progressive delay is rejected", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _, loggerMock := newProtectedTestService(t)
+
+		// После трёх неудач следующая попытка возможна через 4 секунды
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(&entity.LoginAttempts{Failures: 1}, nil).Once()
+		repoMock.On("ReserveLoginAttempt", mock.Anything, ipKey, ipPolicy).Return(nil, nil).Once()
+		repoMock.
+			On("GetLoginAttempts", mock.Anything, keys).
+			Return([]entity.LoginAttempts{{Scope: ipKey.Scope, Key: ipKey.Key, Failures: 3, LastFailureAt: time.Now().Add(-time.Second)}}, nil).
+			Once()
+		loggerMock.On("Warnw", "login blocked", "email", email, "ip", clientIP, "retryAfter", mock.Anything).Return().Once()
+
+		_, err := svc.Login(context.Background(), email, password, clientIP)
+		assertErrCode(t, err, errs.ErrTooManyLoginAttempts)
+
+		var blocked *LoginBlockedError
+		if !errors.As(err, &blocked) || blocked.RetryAfter <= 2*time.Second {
+			t.Errorf("unexpected block %v", err)
+		}
+	})
+
+	t.Run("concurrent attempt rejected after the state changed waits at least a second", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _, loggerMock := newProtectedTestService(t)
+
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(nil, nil).Once()
+		repoMock.On("GetLoginAttempts", mock.Anything, keys).Return(nil, nil).Once()
+		loggerMock.On("Warnw", "login blocked", "email", email, "ip", clientIP, "retryAfter", time.Second).Return().Once()
+
+		_, err := svc.Login(context.Background(), email, password, clientIP)
+		assertErrCode(t, err, errs.ErrTooManyLoginAttempts)
+	})
+
+	t.Run("failed attempt stays counted", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, hasherMock, loggerMock := newProtectedTestService(t)
+
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(&entity.LoginAttempts{Scope: emailKey.Scope, Failures: 2}, nil).Once()
+		repoMock.On("ReserveLoginAttempt", mock.Anything, ipKey, ipPolicy).Return(&entity.LoginAttempts{Scope: ipKey.Scope, Failures: 1}, nil).Once()
+		repoMock.On("FindByEmail", mock.Anything, email).Return(user, nil).Once()
+		hasherMock.On("Check", user.PasswordHash, password).Return(false).Once()
+		loggerMock.On("Warnw", "login failed", "email", email, "ip", clientIP).Return().Once()
+
+		_, err := svc.Login(context.Background(), email, password, clientIP)
+		assertErrCode(t, err, errs.ErrInvalidCredentials)
+	})
+
+	t.Run("unknown email reaching the limit is locked", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, hasherMock, loggerMock := newProtectedTestService(t)
+
+		lockedUntil := time.Now().Add(15 * time.Minute)
+		repoMock.
+			On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).
+			Return(&entity.LoginAttempts{Scope: emailKey.Scope, Key: emailKey.Key, Failures: 5, LockedUntil: &lockedUntil}, nil).
+			Once()
+		repoMock.On("ReserveLoginAttempt", mock.Anything, ipKey, ipPolicy).Return(&entity.LoginAttempts{Scope: ipKey.Scope, Key: ipKey.Key, Failures: 5}, nil).Once()
+		repoMock.On("FindByEmail", mock.Anything, email).Return(nil, errs.New(errs.ErrNotFoundCode, "user not found")).Once()
+		hasherMock.On("Check", unknownUserPasswordHash, password).Return(false).Once()
+		loggerMock.On("Warnw", "login failed", "email", email, "ip", clientIP).Return().Once()
+		loggerMock.
+			On("Warnw", "login locked", "scope", entity.LoginScopeEmail, "key", emailKey.Key, "failures", 5, "lockedUntil", lockedUntil).
+			Return().
+			Once()
+
+		_, err := svc.Login(context.Background(), email, password, clientIP)
+		assertErrCode(t, err, errs.ErrInvalidCredentials)
+	})
+
+	t.Run("success resets email and releases ip", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, hasherMock, _ := newProtectedTestService(t)
+		tokenSvcMock := mockToken.NewTokenService(t)
+		svc.tokenSvc = tokenSvcMock
+
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(&entity.LoginAttempts{Failures: 1}, nil).Once()
+		repoMock.On("ReserveLoginAttempt", mock.Anything, ipKey, ipPolicy).Return(&entity.LoginAttempts{Failures: 4}, nil).Once()
+		repoMock.On("FindByEmail", mock.Anything, email).Return(user, nil).Once()
+		hasherMock.On("Check", user.PasswordHash, password).Return(true).Once()
+		tokenSvcMock.On("GenerateToken", user.ID, user.Role).Return(&jwt.Token{Value: "access", ID: "jti", ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+		repoMock.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil).Once()
+		repoMock.On("ResetLoginAttempts", mock.Anything, emailKey).Return(nil).Once()
+		repoMock.On("ReleaseLoginAttempt", mock.Anything, ipKey, 20).Return(nil).Once()
+
+		if _, err := svc.Login(context.Background(), email, password, clientIP); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("deactivated user releases the reservation", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, hasherMock, loggerMock := newProtectedTestService(t)
+		inactive := *user
+		inactive.Active = false
+
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(&entity.LoginAttempts{Failures: 1}, nil).Once()
+		repoMock.On("ReserveLoginAttempt", mock.Anything, ipKey, ipPolicy).Return(&entity.LoginAttempts{Failures: 1}, nil).Once()
+		repoMock.On("FindByEmail", mock.Anything, email).Return(&inactive, nil).Once()
+		hasherMock.On("Check", user.PasswordHash, password).Return(true).Once()
+		loggerMock.On("Warnw", "login rejected: user is deactivated", "userID", user.ID, "ip", clientIP).Return().Once()
+		repoMock.On("ReleaseLoginAttempt", mock.Anything, emailKey, 5).Return(nil).Once()
+		repoMock.On("ReleaseLoginAttempt", mock.Anything, ipKey, 20).Return(nil).Once()
+
+		_, err := svc.Login(context.Background(), email, password, clientIP)
+		assertErrCode(t, err, errs.ErrUserDeactivated)
+	})
+
+	t.Run("user lookup error releases the reservation", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _, loggerMock := newProtectedTestService(t)
+
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(&entity.LoginAttempts{Failures: 1}, nil).Once()
+		repoMock.On("ReserveLoginAttempt", mock.Anything, ipKey, ipPolicy).Return(&entity.LoginAttempts{Failures: 1}, nil).Once()
+		repoMock.On("FindByEmail", mock.Anything, email).Return(nil, errs.New(errs.ErrInternalCode, "failed to find user")).Once()
+		expectErrorLog(loggerMock, "Login", 4)
+		repoMock.On("ReleaseLoginAttempt", mock.Anything, emailKey, 5).Return(nil).Once()
+		repoMock.On("ReleaseLoginAttempt", mock.Anything, ipKey, 20).Return(nil).Once()
+
+		_, err := svc.Login(context.Background(), email, password, clientIP)
+		assertErrCode(t, err, errs.ErrInternalCode)
+	})
+
+	t.Run("attempts repository error", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _, loggerMock := newProtectedTestService(t)
+
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(nil, errors.New("db down")).Once()
+		expectErrorLog(loggerMock, "Login", 4)
+
+		if _, err := svc.Login(context.Background(), email, password, clientIP); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestAuthService_ChangePassword_Protection(t *testing.T) {
+	t.Parallel()
+
+	ctx := jwt.ContextWithClaims(context.Background(), &jwt.CustomClaims{UserID: testClientID, Role: "client"})
+	emailKey := entity.LoginAttemptKey{Scope: entity.LoginScopeEmail, Key: "test@example.com"}
+	emailPolicy := entity.LoginAttemptPolicy{MaxFailures: 5, Lockout: 15 * time.Minute, BaseDelay: time.Second, MaxDelay: 30 * time.Second, FailureWindow: time.Hour}
+	user := &entity.User{ID: testClientID, Email: "Test@Example.com", PasswordHash: "hash", Role: "client", Active: true}
+
+	t.Run("locked email is rejected without checking the password", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _, loggerMock := newProtectedTestService(t)
+
+		lockedUntil := time.Now().Add(10 * time.Minute)
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user, nil).Once()
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(nil, nil).Once()
+		repoMock.
+			On("GetLoginAttempts", mock.Anything, []entity.LoginAttemptKey{emailKey}).
+			Return([]entity.LoginAttempts{{Scope: emailKey.Scope, Key: emailKey.Key, Failures: 5, LastFailureAt: time.Now(), LockedUntil: &lockedUntil}}, nil).
+			Once()
+		loggerMock.On("Warnw", "login blocked", "email", user.Email, "ip", "", "retryAfter", mock.Anything).Return().Once()
+
+		err := svc.ChangePassword(ctx, "secret", "new-secret")
+		assertErrCode(t, err, errs.ErrTooManyLoginAttempts)
+		var blocked *LoginBlockedError
+		if !errors.As(err, &blocked) || blocked.RetryAfter <= 9*time.Minute {
+			t.Errorf("expected retry after the lockout, got %v", err)
+		}
+	})
+
+	t.Run("wrong current password counts as a failed login", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, hasherMock, loggerMock := newProtectedTestService(t)
+
+		lockedUntil := time.Now().Add(15 * time.Minute)
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user, nil).Once()
+		repoMock.
+			On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).
+			Return(&entity.LoginAttempts{Scope: emailKey.Scope, Key: emailKey.Key, Failures: 5, LockedUntil: &lockedUntil}, nil).
+			Once()
+		hasherMock.On("Check", user.PasswordHash, "wrong").Return(false).Once()
+		loggerMock.On("Warnw", "login failed", "email", user.Email, "ip", "").Return().Once()
+		loggerMock.
+			On("Warnw", "login locked", "scope", entity.LoginScopeEmail, "key", emailKey.Key, "failures", 5, "lockedUntil", lockedUntil).
+			Return().
+			Once()
+
+		assertErrCode(t, svc.ChangePassword(ctx, "wrong", "new-secret"), errs.ErrInvalidCurrentPassword)
+	})
+
+	t.Run("correct current password releases the reservation", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, hasherMock, _ := newProtectedTestService(t)
+		// вторая транзакция — сама смена пароля
+		passThroughTx(svc.txManager.(*mockRepo.TxManager))
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user, nil).Twice()
+		repoMock.On("ReserveLoginAttempt", mock.Anything, emailKey, emailPolicy).Return(&entity.LoginAttempts{Failures: 1}, nil).Once()
+		hasherMock.On("Check", user.PasswordHash, "secret").Return(true).Once()
+		repoMock.On("ReleaseLoginAttempt", mock.Anything, emailKey, 5).Return(nil).Once()
+		hasherMock.On("Hash", "new-secret").Return("new-hash", nil).Once()
+		repoMock.On("UpdateUserPassword", mock.Anything, testClientID, "new-hash", false).Return(nil).Once()
+		repoMock.On("GetTokenFamilyByAccessJTI", mock.Anything, "").Return("", errs.New(errs.ErrNotFoundCode, "token family not found")).Once()
+		repoMock.On("RevokeUserTokens", mock.Anything, testClientID, "").Return(nil).Once()
+		expectAudit(repoMock, entity.AuditUserPasswordChange)
+
+		if err := svc.ChangePassword(ctx, "secret", "new-secret"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestAuthService_LoginWait(t *testing.T) {
+	t.Parallel()
+
+	svc := &authServiceImp{protection: testProtection}
+	now := time.Now()
+	lockedUntil := now.Add(time.Minute)
+	expiredLock := now.Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		attempts entity.LoginAttempts
+		expected time.Duration
+	}{
+		{name: "no failures", attempts: entity.LoginAttempts{}, expected: 0},
+		{name: "first failure", attempts: entity.LoginAttempts{Failures: 1, LastFailureAt: now}, expected: time.Second},
+		{name: "delay doubles", attempts: entity.LoginAttempts{Failures: 4, LastFailureAt: now}, expected: 8 * time.Second},
+		{name: "delay is capped", attempts: entity.LoginAttempts{Failures: 10, LastFailureAt: now}, expected: 30 * time.Second},
+		{name: "delay elapsed", attempts: entity.LoginAttempts{Failures: 2, LastFailureAt: now.Add(-time.Minute)}, expected: -58 * time.Second},
+		{name: "outside window", attempts: entity.LoginAttempts{Failures: 3, LastFailureAt: now.Add(-2 * time.Hour)}, expected: 0},
+		{name: "locked", attempts: entity.LoginAttempts{Failures: 5, LastFailureAt: now, LockedUntil: &lockedUntil}, expected: time.Minute},
+		{name: "lock expired", attempts: entity.LoginAttempts{Failures: 5, LastFailureAt: expiredLock, LockedUntil: &expiredLock}, expected: -44 * time.Second},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := svc.loginWait(tc.attempts, now); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestAuthService_UnlockUser(t *testing.T) {
+	t.Parallel()
+
+	user := &entity.User{ID: testClientID, Email: "Client@Example.com"}
+
+	t.Run("resets email attempts and records audit", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, _ := newTokenTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+		repoMock.
+			On("ResetLoginAttempts", mock.Anything, entity.LoginAttemptKey{Scope: entity.LoginScopeEmail, Key: "client@example.com"}).
+			Return(nil).
+			Once()
+		repoMock.
+			On("InsertAuditEntry", mock.Anything, mock.MatchedBy(func(e entity.AuditEntry) bool {
+				return e.Action == entity.AuditUserUnlock && e.EntityType == entity.AuditEntityUser && e.EntityID == user.ID
+			})).
+			Return(nil).
+			Once()
+
+		if err := svc.UnlockUser(context.Background(), user.ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, loggerMock := newTokenTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, user.ID).Return(nil, errs.New(errs.ErrNotFoundCode, "user not found")).Once()
+		expectErrorLog(loggerMock, "UnlockUser", 4)
+
+		assertErrCode(t, svc.UnlockUser(context.Background(), user.ID), errs.ErrUserNotFound)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _, _ := newTokenTestService(t)
+
+		assertErrCode(t, svc.UnlockUser(context.Background(), "not-a-uuid"), errs.ErrInvalidRequestCode)
+	})
+}
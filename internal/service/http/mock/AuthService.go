@@ -4,6 +4,9 @@ package mock
 
 import (
 	context "context"
+	entity "order-pick-up-point/internal/models/entity"
+
+	jwt "order-pick-up-point/pkg/jwt"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,6 +16,54 @@ type AuthService struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: ctx, currentPassword, newPassword
+func (_m *AuthService) ChangePassword(ctx context.Context, currentPassword string, newPassword string) error {
+	ret := _m.Called(ctx, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangeUserRole provides a mock function with given fields: ctx, userID, role
+func (_m *AuthService) ChangeUserRole(ctx context.Context, userID string, role string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeUserRole")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.User, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.User); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DummyLogin provides a mock function with given fields: ctx, role
 func (_m *AuthService) DummyLogin(ctx context.Context, role string) (string, error) {
 	ret := _m.Called(ctx, role)
@@ -41,27 +92,155 @@ func (_m *AuthService) DummyLogin(ctx context.Context, role string) (string, err
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, email, password
-func (_m *AuthService) Login(ctx context.Context, email string, password string) (string, error) {
-	ret := _m.Called(ctx, email, password)
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *AuthService) GetUser(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWKS provides a mock function with no fields
+func (_m *AuthService) JWKS() jwt.JWKS {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 jwt.JWKS
+	if rf, ok := ret.Get(0).(func() jwt.JWKS); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(jwt.JWKS)
+	}
+
+	return r0
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter
+func (_m *AuthService) ListUsers(ctx context.Context, filter entity.UserFilter) (*entity.UserPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 *entity.UserPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserFilter) (*entity.UserPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserFilter) *entity.UserPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, email, password, clientIP
+func (_m *AuthService) Login(ctx context.Context, email string, password string, clientIP string) (*entity.TokenPair, error) {
+	ret := _m.Called(ctx, email, password, clientIP)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
+	var r0 *entity.TokenPair
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*entity.TokenPair, error)); ok {
+		return rf(ctx, email, password, clientIP)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *entity.TokenPair); ok {
+		r0 = rf(ctx, email, password, clientIP)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TokenPair)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, email, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, email, password, clientIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx
+func (_m *AuthService) Logout(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokens provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthService) RefreshTokens(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshTokens")
+	}
+
+	var r0 *entity.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.TokenPair, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.TokenPair); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -97,6 +276,82 @@ func (_m *AuthService) Register(ctx context.Context, email string, password stri
 	return r0, r1
 }
 
+// ResetUserPassword provides a mock function with given fields: ctx, userID
+func (_m *AuthService) ResetUserPassword(ctx context.Context, userID string) (string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetUserPassword")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetUserActive provides a mock function with given fields: ctx, userID, active
+func (_m *AuthService) SetUserActive(ctx context.Context, userID string, active bool) (*entity.User, error) {
+	ret := _m.Called(ctx, userID, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserActive")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*entity.User, error)); ok {
+		return rf(ctx, userID, active)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *entity.User); ok {
+		r0 = rf(ctx, userID, active)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, userID, active)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlockUser provides a mock function with given fields: ctx, userID
+func (_m *AuthService) UnlockUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthService(t interface {
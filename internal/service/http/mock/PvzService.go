@@ -16,27 +16,115 @@ type PvzService struct {
 	mock.Mock
 }
 
-// AddProduct provides a mock function with given fields: ctx, pvzID, productType
-func (_m *PvzService) AddProduct(ctx context.Context, pvzID string, productType string) (string, error) {
-	ret := _m.Called(ctx, pvzID, productType)
+// AddProduct provides a mock function with given fields: ctx, pvzID, productType, barcode
+func (_m *PvzService) AddProduct(ctx context.Context, pvzID string, productType string, barcode string) (string, error) {
+	ret := _m.Called(ctx, pvzID, productType, barcode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddProduct")
 	}
 
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, pvzID, productType, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, pvzID, productType, barcode)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, pvzID, productType, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddProductsBatch provides a mock function with given fields: ctx, pvzID, items, atomic
+func (_m *PvzService) AddProductsBatch(ctx context.Context, pvzID string, items []entity.ProductBatchItem, atomic bool) ([]entity.ProductBatchResult, error) {
+	ret := _m.Called(ctx, pvzID, items, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProductsBatch")
+	}
+
+	var r0 []entity.ProductBatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []entity.ProductBatchItem, bool) ([]entity.ProductBatchResult, error)); ok {
+		return rf(ctx, pvzID, items, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []entity.ProductBatchItem, bool) []entity.ProductBatchResult); ok {
+		r0 = rf(ctx, pvzID, items, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProductBatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []entity.ProductBatchItem, bool) error); ok {
+		r1 = rf(ctx, pvzID, items, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddReturnItem provides a mock function with given fields: ctx, pvzID, productID, reason
+func (_m *PvzService) AddReturnItem(ctx context.Context, pvzID string, productID string, reason string) (*entity.ReturnItem, error) {
+	ret := _m.Called(ctx, pvzID, productID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReturnItem")
+	}
+
+	var r0 *entity.ReturnItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*entity.ReturnItem, error)); ok {
+		return rf(ctx, pvzID, productID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *entity.ReturnItem); ok {
+		r0 = rf(ctx, pvzID, productID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ReturnItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, pvzID, productID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseReception provides a mock function with given fields: ctx, pvzID, employeeID
+func (_m *PvzService) CloseReception(ctx context.Context, pvzID string, employeeID string) (string, error) {
+	ret := _m.Called(ctx, pvzID, employeeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseReception")
+	}
+
 	var r0 string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, pvzID, productType)
+		return rf(ctx, pvzID, employeeID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, pvzID, productType)
+		r0 = rf(ctx, pvzID, employeeID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, pvzID, productType)
+		r1 = rf(ctx, pvzID, employeeID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,12 +132,12 @@ func (_m *PvzService) AddProduct(ctx context.Context, pvzID string, productType
 	return r0, r1
 }
 
-// CloseReception provides a mock function with given fields: ctx, pvzID
-func (_m *PvzService) CloseReception(ctx context.Context, pvzID string) (string, error) {
+// CloseReturn provides a mock function with given fields: ctx, pvzID
+func (_m *PvzService) CloseReturn(ctx context.Context, pvzID string) (string, error) {
 	ret := _m.Called(ctx, pvzID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CloseReception")
+		panic("no return value specified for CloseReturn")
 	}
 
 	var r0 string
@@ -72,9 +160,9 @@ func (_m *PvzService) CloseReception(ctx context.Context, pvzID string) (string,
 	return r0, r1
 }
 
-// CreatePvz provides a mock function with given fields: ctx, city
-func (_m *PvzService) CreatePvz(ctx context.Context, city string) (string, error) {
-	ret := _m.Called(ctx, city)
+// CreatePvz provides a mock function with given fields: ctx, pvz
+func (_m *PvzService) CreatePvz(ctx context.Context, pvz entity.Pvz) (string, error) {
+	ret := _m.Called(ctx, pvz)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreatePvz")
@@ -82,17 +170,17 @@ func (_m *PvzService) CreatePvz(ctx context.Context, city string) (string, error
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, city)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pvz) (string, error)); ok {
+		return rf(ctx, pvz)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, city)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pvz) string); ok {
+		r0 = rf(ctx, pvz)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, city)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pvz) error); ok {
+		r1 = rf(ctx, pvz)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,9 +188,9 @@ func (_m *PvzService) CreatePvz(ctx context.Context, city string) (string, error
 	return r0, r1
 }
 
-// CreateReception provides a mock function with given fields: ctx, pvzID, dateTime
-func (_m *PvzService) CreateReception(ctx context.Context, pvzID string, dateTime time.Time) (string, error) {
-	ret := _m.Called(ctx, pvzID, dateTime)
+// CreateReception provides a mock function with given fields: ctx, pvzID, dateTime, employeeID
+func (_m *PvzService) CreateReception(ctx context.Context, pvzID string, dateTime time.Time, employeeID string) (string, error) {
+	ret := _m.Called(ctx, pvzID, dateTime, employeeID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateReception")
@@ -110,17 +198,45 @@ func (_m *PvzService) CreateReception(ctx context.Context, pvzID string, dateTim
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (string, error)); ok {
-		return rf(ctx, pvzID, dateTime)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string) (string, error)); ok {
+		return rf(ctx, pvzID, dateTime, employeeID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) string); ok {
-		r0 = rf(ctx, pvzID, dateTime)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string) string); ok {
+		r0 = rf(ctx, pvzID, dateTime, employeeID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
-		r1 = rf(ctx, pvzID, dateTime)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, string) error); ok {
+		r1 = rf(ctx, pvzID, dateTime, employeeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReturn provides a mock function with given fields: ctx, pvzID
+func (_m *PvzService) CreateReturn(ctx context.Context, pvzID string) (string, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReturn")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,13 +245,43 @@ func (_m *PvzService) CreateReception(ctx context.Context, pvzID string, dateTim
 }
 
 // DeleteLastProduct provides a mock function with given fields: ctx, pvzID
-func (_m *PvzService) DeleteLastProduct(ctx context.Context, pvzID string) error {
+func (_m *PvzService) DeleteLastProduct(ctx context.Context, pvzID string) (*entity.Product, error) {
 	ret := _m.Called(ctx, pvzID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteLastProduct")
 	}
 
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Product, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Product); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLastReturnItem provides a mock function with given fields: ctx, pvzID
+func (_m *PvzService) DeleteLastReturnItem(ctx context.Context, pvzID string) error {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLastReturnItem")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
 		r0 = rf(ctx, pvzID)
@@ -146,29 +292,515 @@ func (_m *PvzService) DeleteLastProduct(ctx context.Context, pvzID string) error
 	return r0
 }
 
-// GetPvzsInfo provides a mock function with given fields: ctx, page, limit, startDate, endDate
-func (_m *PvzService) GetPvzsInfo(ctx context.Context, page int, limit int, startDate *time.Time, endDate *time.Time) ([]entity.PvzInfo, error) {
-	ret := _m.Called(ctx, page, limit, startDate, endDate)
+// ExportPvzHistory provides a mock function with given fields: ctx, filter, fn
+func (_m *PvzService) ExportPvzHistory(ctx context.Context, filter entity.PvzExportFilter, fn func(entity.PvzExportRow) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportPvzHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzExportFilter, func(entity.PvzExportRow) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindProductByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *PvzService) FindProductByBarcode(ctx context.Context, barcode string) (*entity.Order, error) {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProductByBarcode")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Order, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Order); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMyOrder provides a mock function with given fields: ctx, userID, productID
+func (_m *PvzService) GetMyOrder(ctx context.Context, userID string, productID string) (*entity.Order, error) {
+	ret := _m.Called(ctx, userID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMyOrder")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Order, error)); ok {
+		return rf(ctx, userID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Order); ok {
+		r0 = rf(ctx, userID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMyOrders provides a mock function with given fields: ctx, userID, status
+func (_m *PvzService) GetMyOrders(ctx context.Context, userID string, status string) ([]entity.Order, error) {
+	ret := _m.Called(ctx, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMyOrders")
+	}
+
+	var r0 []entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]entity.Order, error)); ok {
+		return rf(ctx, userID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []entity.Order); ok {
+		r0 = rf(ctx, userID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrdersForPickup provides a mock function with given fields: ctx, pvzID, recipientID
+func (_m *PvzService) GetOrdersForPickup(ctx context.Context, pvzID string, recipientID string) ([]entity.Order, error) {
+	ret := _m.Called(ctx, pvzID, recipientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrdersForPickup")
+	}
+
+	var r0 []entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]entity.Order, error)); ok {
+		return rf(ctx, pvzID, recipientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []entity.Order); ok {
+		r0 = rf(ctx, pvzID, recipientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, recipientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverdueOrders provides a mock function with given fields: ctx, pvzID
+func (_m *PvzService) GetOverdueOrders(ctx context.Context, pvzID string) ([]entity.Order, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdueOrders")
+	}
+
+	var r0 []entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Order, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Order); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProductTypeCounts provides a mock function with given fields: ctx, filter
+func (_m *PvzService) GetProductTypeCounts(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductTypeCount, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductTypeCounts")
+	}
+
+	var r0 []entity.ProductTypeCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) ([]entity.ProductTypeCount, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) []entity.ProductTypeCount); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProductTypeCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AnalyticsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzsInfo provides a mock function with given fields: ctx, page, startDate, endDate
+func (_m *PvzService) GetPvzsInfo(ctx context.Context, page entity.PvzPageRequest, startDate *time.Time, endDate *time.Time) (*entity.PvzInfoPage, error) {
+	ret := _m.Called(ctx, page, startDate, endDate)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPvzsInfo")
 	}
 
-	var r0 []entity.PvzInfo
+	var r0 *entity.PvzInfoPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) (*entity.PvzInfoPage, error)); ok {
+		return rf(ctx, page, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) *entity.PvzInfoPage); ok {
+		r0 = rf(ctx, page, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PvzInfoPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, page, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzsInfoOptimized provides a mock function with given fields: ctx, page, startDate, endDate
+func (_m *PvzService) GetPvzsInfoOptimized(ctx context.Context, page entity.PvzPageRequest, startDate *time.Time, endDate *time.Time) (*entity.PvzInfoPage, error) {
+	ret := _m.Called(ctx, page, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPvzsInfoOptimized")
+	}
+
+	var r0 *entity.PvzInfoPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) (*entity.PvzInfoPage, error)); ok {
+		return rf(ctx, page, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) *entity.PvzInfoPage); ok {
+		r0 = rf(ctx, page, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PvzInfoPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, page, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceptionStats provides a mock function with given fields: ctx, filter
+func (_m *PvzService) GetReceptionStats(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionStats, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptionStats")
+	}
+
+	var r0 []entity.ReceptionStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) ([]entity.ReceptionStats, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) []entity.ReceptionStats); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReceptionStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AnalyticsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceptionsPerDay provides a mock function with given fields: ctx, filter
+func (_m *PvzService) GetReceptionsPerDay(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.DailyReceptions, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptionsPerDay")
+	}
+
+	var r0 []entity.DailyReceptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) ([]entity.DailyReceptions, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) []entity.DailyReceptions); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DailyReceptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AnalyticsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueOrder provides a mock function with given fields: ctx, pvzID, productID, pickupCode, employeeID
+func (_m *PvzService) IssueOrder(ctx context.Context, pvzID string, productID string, pickupCode string, employeeID string) (*entity.Order, error) {
+	ret := _m.Called(ctx, pvzID, productID, pickupCode, employeeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueOrder")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*entity.Order, error)); ok {
+		return rf(ctx, pvzID, productID, pickupCode, employeeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *entity.Order); ok {
+		r0 = rf(ctx, pvzID, productID, pickupCode, employeeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, pvzID, productID, pickupCode, employeeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PrepareOrder provides a mock function with given fields: ctx, pvzID, productID, recipientID
+func (_m *PvzService) PrepareOrder(ctx context.Context, pvzID string, productID string, recipientID string) (*entity.Order, error) {
+	ret := _m.Called(ctx, pvzID, productID, recipientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrepareOrder")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*entity.Order, error)); ok {
+		return rf(ctx, pvzID, productID, recipientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *entity.Order); ok {
+		r0 = rf(ctx, pvzID, productID, recipientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, pvzID, productID, recipientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenewMyPickupCode provides a mock function with given fields: ctx, userID, productID
+func (_m *PvzService) RenewMyPickupCode(ctx context.Context, userID string, productID string) (*entity.Order, error) {
+	ret := _m.Called(ctx, userID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewMyPickupCode")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Order, error)); ok {
+		return rf(ctx, userID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Order); ok {
+		r0 = rf(ctx, userID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReturnOrder provides a mock function with given fields: ctx, pvzID, productID
+func (_m *PvzService) ReturnOrder(ctx context.Context, pvzID string, productID string) (*entity.Order, error) {
+	ret := _m.Called(ctx, pvzID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnOrder")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Order, error)); ok {
+		return rf(ctx, pvzID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Order); ok {
+		r0 = rf(ctx, pvzID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchNearbyPvzs provides a mock function with given fields: ctx, filter
+func (_m *PvzService) SearchNearbyPvzs(ctx context.Context, filter entity.NearbyPvzFilter) ([]entity.NearbyPvz, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchNearbyPvzs")
+	}
+
+	var r0 []entity.NearbyPvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NearbyPvzFilter) ([]entity.NearbyPvz, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NearbyPvzFilter) []entity.NearbyPvz); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.NearbyPvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.NearbyPvzFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamPvzActivity provides a mock function with given fields: ctx, filter, ready, fn
+func (_m *PvzService) StreamPvzActivity(ctx context.Context, filter entity.PvzActivityFilter, ready func() error, fn func(entity.OutboxEvent) error) error {
+	ret := _m.Called(ctx, filter, ready, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamPvzActivity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzActivityFilter, func() error, func(entity.OutboxEvent) error) error); ok {
+		r0 = rf(ctx, filter, ready, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePvz provides a mock function with given fields: ctx, pvzID, update
+func (_m *PvzService) UpdatePvz(ctx context.Context, pvzID string, update entity.PvzUpdate) (*entity.Pvz, error) {
+	ret := _m.Called(ctx, pvzID, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePvz")
+	}
+
+	var r0 *entity.Pvz
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *time.Time, *time.Time) ([]entity.PvzInfo, error)); ok {
-		return rf(ctx, page, limit, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.PvzUpdate) (*entity.Pvz, error)); ok {
+		return rf(ctx, pvzID, update)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *time.Time, *time.Time) []entity.PvzInfo); ok {
-		r0 = rf(ctx, page, limit, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.PvzUpdate) *entity.Pvz); ok {
+		r0 = rf(ctx, pvzID, update)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.PvzInfo)
+			r0 = ret.Get(0).(*entity.Pvz)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, *time.Time, *time.Time) error); ok {
-		r1 = rf(ctx, page, limit, startDate, endDate)
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.PvzUpdate) error); ok {
+		r1 = rf(ctx, pvzID, update)
 	} else {
 		r1 = ret.Error(1)
 	}
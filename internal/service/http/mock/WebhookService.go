@@ -0,0 +1,257 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+	entity "order-pick-up-point/internal/models/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, sub
+func (_m *WebhookService) CreateWebhook(ctx context.Context, sub entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	ret := _m.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *entity.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookSubscription) (*entity.WebhookSubscription, error)); ok {
+		return rf(ctx, sub)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookSubscription) *entity.WebhookSubscription); ok {
+		r0 = rf(ctx, sub)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.WebhookSubscription) error); ok {
+		r1 = rf(ctx, sub)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetWebhook provides a mock function with given fields: ctx, id
+func (_m *WebhookService) GetWebhook(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhook")
+	}
+
+	var r0 *entity.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.WebhookSubscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.WebhookSubscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDeliveries provides a mock function with given fields: ctx, filter
+func (_m *WebhookService) GetWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) (*entity.WebhookDeliveryPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDeliveries")
+	}
+
+	var r0 *entity.WebhookDeliveryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookDeliveryFilter) (*entity.WebhookDeliveryPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookDeliveryFilter) *entity.WebhookDeliveryPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookDeliveryPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.WebhookDeliveryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDelivery provides a mock function with given fields: ctx, subscriptionID, deliveryID
+func (_m *WebhookService) GetWebhookDelivery(ctx context.Context, subscriptionID string, deliveryID int64) (*entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 *entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*entity.WebhookDelivery, error)); ok {
+		return rf(ctx, subscriptionID, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *entity.WebhookDelivery); ok {
+		r0 = rf(ctx, subscriptionID, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, subscriptionID, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhooks provides a mock function with given fields: ctx
+func (_m *WebhookService) ListWebhooks(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []entity.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryWebhookDelivery provides a mock function with given fields: ctx, subscriptionID, deliveryID
+func (_m *WebhookService) RetryWebhookDelivery(ctx context.Context, subscriptionID string, deliveryID int64) (*entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryWebhookDelivery")
+	}
+
+	var r0 *entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*entity.WebhookDelivery, error)); ok {
+		return rf(ctx, subscriptionID, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *entity.WebhookDelivery); ok {
+		r0 = rf(ctx, subscriptionID, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, subscriptionID, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, id, update
+func (_m *WebhookService) UpdateWebhook(ctx context.Context, id string, update entity.WebhookSubscriptionUpdate) (*entity.WebhookSubscription, error) {
+	ret := _m.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhook")
+	}
+
+	var r0 *entity.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.WebhookSubscriptionUpdate) (*entity.WebhookSubscription, error)); ok {
+		return rf(ctx, id, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.WebhookSubscriptionUpdate) *entity.WebhookSubscription); ok {
+		r0 = rf(ctx, id, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.WebhookSubscriptionUpdate) error); ok {
+		r1 = rf(ctx, id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWebhookService creates a new instance of WebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookService {
+	mock := &WebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,421 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"math/big"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/password"
+	"strings"
+	"time"
+)
+
+const (
+	pickupCodeDigits = 6
+
+	// maxPickupCodeFailures — число неверных кодов, после которого выдача по коду блокируется
+	// до выпуска нового кода получателем. Шесть цифр — миллион вариантов, без ограничения код
+	// перебирается через API.
+	maxPickupCodeFailures = 5
+
+	// defaultStorageDays — срок хранения для типов товаров, которых нет в allowed.storage_periods
+	defaultStorageDays = 7
+)
+
+// pickupCodeHasher хеширует коды выдачи: в БД хранится только bcrypt-хеш
+var pickupCodeHasher = password.NewBCryptHasher(0)
+
+// clientOrderStatuses — статусы, в которых заказ виден получателю
+var clientOrderStatuses = map[string]bool{
+	entity.ProductStatusReadyForPickup: true,
+	entity.ProductStatusIssued:         true,
+	entity.ProductStatusReturned:       true,
+}
+
+// PrepareOrder назначает товару получателя и переводит товар в ready_for_pickup. Код выдачи
+// подтверждает, что за заказом пришёл получатель, поэтому сотрудник его не получает: код
+// выпускает сам клиент через RenewMyPickupCode.
+func (s *pvzServiceImp) PrepareOrder(ctx context.Context, pvzID, productID, recipientID string) (*entity.Order, error) {
+	if err := validateIDs(pvzID, productID, recipientID); err != nil {
+		return nil, err
+	}
+
+	var order *entity.Order
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		recipient, err := s.repo.FindByID(txCtx, recipientID)
+		if err != nil {
+			if errs.IsNotFound(err) {
+				return errs.New(errs.ErrInvalidRecipient, "recipient not found")
+			}
+			return err
+		}
+		if recipient.Role != "client" {
+			return errs.New(errs.ErrInvalidRecipient, "recipient must have the client role")
+		}
+
+		found, err := s.repo.FindOrderForUpdate(txCtx, pvzID, productID)
+		if err != nil {
+			return err
+		}
+		if found.ReceptionStatus != "close" {
+			return errs.New(errs.ErrInvalidProductStatus, "reception of the product is not closed yet")
+		}
+		if found.Status != entity.ProductStatusReceived {
+			return errs.New(errs.ErrInvalidProductStatus, fmt.Sprintf("product is already in '%s' status", found.Status))
+		}
+
+		if err := s.repo.SetOrderRecipient(txCtx, productID, recipientID); err != nil {
+			return err
+		}
+
+		before := *found
+		found.Status = entity.ProductStatusReadyForPickup
+		found.RecipientID = &recipientID
+		found.PickupCodeHash = nil
+		found.PickupFailures = 0
+		order = found
+		return s.auditOrder(txCtx, entity.AuditOrderPrepare, before, *found)
+	})
+	if err != nil {
+		s.logger.Errorw("PrepareOrder",
+			"error", err,
+			"pvzID", pvzID,
+			"productID", productID,
+			"recipientID", recipientID,
+		)
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetOrdersForPickup возвращает заказы получателя, ожидающие выдачи в ПВЗ.
+func (s *pvzServiceImp) GetOrdersForPickup(ctx context.Context, pvzID, recipientID string) ([]entity.Order, error) {
+	if err := validateIDs(pvzID, recipientID); err != nil {
+		return nil, err
+	}
+
+	orders, err := s.repo.GetOrdersByRecipient(ctx, pvzID, recipientID, entity.ProductStatusReadyForPickup)
+	if err != nil {
+		s.logger.Errorw("GetOrdersForPickup",
+			"error", err,
+			"pvzID", pvzID,
+			"recipientID", recipientID,
+		)
+		return nil, err
+	}
+	return orders, nil
+}
+
+// IssueOrder проверяет код выдачи и отмечает товар выданным сотрудником employeeID.
+// Неверный код учитывается в транзакции, удерживающей блокировку товара, поэтому параллельные
+// попытки не обходят лимит; после maxPickupCodeFailures неверных кодов выдача блокируется.
+func (s *pvzServiceImp) IssueOrder(ctx context.Context, pvzID, productID, pickupCode, employeeID string) (*entity.Order, error) {
+	if err := validateIDs(pvzID, productID); err != nil {
+		return nil, err
+	}
+	if pickupCode == "" {
+		return nil, errs.New(errs.ErrInvalidRequestCode, "pickup code is required")
+	}
+
+	var order *entity.Order
+	var codeErr error
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		found, err := s.repo.FindOrderForUpdate(txCtx, pvzID, productID)
+		if err != nil {
+			return err
+		}
+		if found.Status != entity.ProductStatusReadyForPickup {
+			return errs.New(errs.ErrInvalidProductStatus, fmt.Sprintf("product in '%s' status cannot be issued", found.Status))
+		}
+		if found.PickupFailures >= maxPickupCodeFailures {
+			return errs.New(errs.ErrPickupCodeLocked, "too many invalid pickup codes, the recipient must request a new code")
+		}
+		if found.PickupCodeHash == nil {
+			return errs.New(errs.ErrInvalidPickupCode, "the recipient has not requested a pickup code yet")
+		}
+		if !pickupCodeHasher.Check(*found.PickupCodeHash, pickupCode) {
+			// Счётчик должен зафиксироваться, поэтому транзакция завершается без ошибки
+			codeErr = errs.New(errs.ErrInvalidPickupCode, "pickup code does not match")
+			return s.repo.RecordPickupCodeFailure(txCtx, productID)
+		}
+
+		issuedAt := time.Now()
+		issuedBy := actorID(employeeID)
+		if err := s.repo.MarkOrderIssued(txCtx, productID, issuedBy, issuedAt); err != nil {
+			return err
+		}
+
+		before := *found
+		found.Status = entity.ProductStatusIssued
+		found.IssuedAt = &issuedAt
+		found.IssuedBy = issuedBy
+		order = found
+		if err := s.auditOrder(txCtx, entity.AuditOrderIssue, before, *found); err != nil {
+			return err
+		}
+		return emitEvent(txCtx, s.repo, entity.EventOrderIssued, productID, pvzID, *found)
+	})
+	if err == nil {
+		err = codeErr
+	}
+	if err != nil {
+		s.logger.Errorw("IssueOrder",
+			"error", err,
+			"pvzID", pvzID,
+			"productID", productID,
+			"employeeID", employeeID,
+		)
+		return nil, err
+	}
+
+	metrics.OrdersIssued()
+	return order, nil
+}
+
+// ReturnOrder отмечает невостребованный товар как возвращённый.
+func (s *pvzServiceImp) ReturnOrder(ctx context.Context, pvzID, productID string) (*entity.Order, error) {
+	if err := validateIDs(pvzID, productID); err != nil {
+		return nil, err
+	}
+
+	var order *entity.Order
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		found, err := s.repo.FindOrderForUpdate(txCtx, pvzID, productID)
+		if err != nil {
+			return err
+		}
+		if found.Status != entity.ProductStatusReadyForPickup {
+			return errs.New(errs.ErrInvalidProductStatus, fmt.Sprintf("product in '%s' status cannot be returned", found.Status))
+		}
+		if err := s.repo.MarkOrderReturned(txCtx, productID); err != nil {
+			return err
+		}
+
+		before := *found
+		found.Status = entity.ProductStatusReturned
+		order = found
+		if err := s.auditOrder(txCtx, entity.AuditOrderReturn, before, *found); err != nil {
+			return err
+		}
+		return emitEvent(txCtx, s.repo, entity.EventOrderReturned, productID, pvzID, *found)
+	})
+	if err != nil {
+		s.logger.Errorw("ReturnOrder",
+			"error", err,
+			"pvzID", pvzID,
+			"productID", productID,
+		)
+		return nil, err
+	}
+
+	metrics.OrdersReturned()
+	return order, nil
+}
+
+// GetMyOrders возвращает заказы клиента userID во всех ПВЗ, опционально отфильтрованные по статусу.
+func (s *pvzServiceImp) GetMyOrders(ctx context.Context, userID, status string) ([]entity.Order, error) {
+	if err := validateClient(userID); err != nil {
+		return nil, err
+	}
+
+	var statusFilter *string
+	if status != "" {
+		if !clientOrderStatuses[status] {
+			return nil, errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("unknown order status '%s'", status))
+		}
+		statusFilter = &status
+	}
+
+	orders, err := s.repo.GetRecipientOrders(ctx, userID, statusFilter)
+	if err != nil {
+		s.logger.Errorw("GetMyOrders",
+			"error", err,
+			"userID", userID,
+			"status", status,
+		)
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetMyOrder возвращает один заказ клиента. Чужой заказ неотличим от несуществующего.
+func (s *pvzServiceImp) GetMyOrder(ctx context.Context, userID, productID string) (*entity.Order, error) {
+	if err := validateClient(userID); err != nil {
+		return nil, err
+	}
+	if err := validateIDs(productID); err != nil {
+		return nil, err
+	}
+
+	order, err := s.repo.FindRecipientOrder(ctx, userID, productID)
+	if err != nil {
+		s.logger.Errorw("GetMyOrder",
+			"error", err,
+			"userID", userID,
+			"productID", productID,
+		)
+		return nil, err
+	}
+	return order, nil
+}
+
+// RenewMyPickupCode выпускает код выдачи для заказа клиента userID, ожидающего выдачи: первый
+// после PrepareOrder или новый взамен прежнего, снимая блокировку после неверных кодов. Открытый код возвращается только в ответе:
+// в БД хранится его хеш, поэтому прочитать код повторно нельзя.
+func (s *pvzServiceImp) RenewMyPickupCode(ctx context.Context, userID, productID string) (*entity.Order, error) {
+	if err := validateClient(userID); err != nil {
+		return nil, err
+	}
+	if err := validateIDs(productID); err != nil {
+		return nil, err
+	}
+
+	var order *entity.Order
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		found, err := s.repo.FindRecipientOrder(txCtx, userID, productID)
+		if err != nil {
+			return err
+		}
+		if found.Status != entity.ProductStatusReadyForPickup {
+			return errs.New(errs.ErrInvalidProductStatus, fmt.Sprintf("order in '%s' status has no pickup code", found.Status))
+		}
+
+		code, hash, err := newPickupCode()
+		if err != nil {
+			return err
+		}
+		if err := s.repo.ReplacePickupCode(txCtx, productID, hash); err != nil {
+			return err
+		}
+
+		before := *found
+		found.PickupCode = &code
+		found.PickupCodeHash = &hash
+		found.PickupFailures = 0
+		order = found
+		return s.auditOrder(txCtx, entity.AuditOrderCodeRenew, before, *found)
+	})
+	if err != nil {
+		s.logger.Errorw("RenewMyPickupCode",
+			"error", err,
+			"userID", userID,
+			"productID", productID,
+		)
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetOverdueOrders возвращает товары ПВЗ, у которых истёк срок хранения и которые ещё не выданы и не переданы в возврат.
+func (s *pvzServiceImp) GetOverdueOrders(ctx context.Context, pvzID string) ([]entity.Order, error) {
+	if err := validateIDs(pvzID); err != nil {
+		return nil, err
+	}
+
+	orders, err := s.repo.GetOverdueOrders(ctx, pvzID)
+	if err != nil {
+		s.logger.Errorw("GetOverdueOrders",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return nil, err
+	}
+	return orders, nil
+}
+
+// FindProductByBarcode ищет товар по штрихкоду: сначала среди находящихся в ПВЗ, затем среди выданных и отгруженных.
+func (s *pvzServiceImp) FindProductByBarcode(ctx context.Context, barcode string) (*entity.Order, error) {
+	if !barcodePattern.MatchString(barcode) {
+		return nil, errs.New(errs.ErrInvalidBarcode, "barcode must be 1-64 latin letters, digits or dashes")
+	}
+
+	order, err := s.repo.FindOrderByBarcode(ctx, barcode)
+	if err != nil {
+		s.logger.Errorw("FindProductByBarcode",
+			"error", err,
+			"barcode", barcode,
+		)
+		return nil, err
+	}
+	return order, nil
+}
+
+// auditOrder пишет в журнал аудита смену статуса заказа. Код выдачи в JSON не попадает.
+func (s *pvzServiceImp) auditOrder(ctx context.Context, action string, before, after entity.Order) error {
+	return recordAudit(ctx, s.repo, auditChange{
+		Action:     action,
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   after.ProductID,
+		PvzID:      after.PvzID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// storagePeriod возвращает срок хранения товара заданного типа.
+func (s *pvzServiceImp) storagePeriod(productType string) time.Duration {
+	days, ok := s.storagePeriods[strings.ToLower(productType)]
+	if !ok || days <= 0 {
+		days = defaultStorageDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// validateClient проверяет, что токен принадлежит зарегистрированному пользователю.
+// У токенов /dummyLogin нет записи в users, поэтому заказов у них быть не может.
+func validateClient(userID string) error {
+	if actorID(userID) == nil {
+		return errs.New(errs.ErrForbiddenCode, "orders are available only to registered clients")
+	}
+	return nil
+}
+
+// validateIDs проверяет, что идентификаторы являются UUID, до обращения к БД.
+func validateIDs(ids ...string) error {
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("invalid id '%s'", id))
+		}
+	}
+	return nil
+}
+
+// actorID возвращает ID пользователя из токена для записи в БД.
+// Токены /dummyLogin не содержат реального пользователя, для них возвращается nil.
+func actorID(userID string) *string {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil
+	}
+	return &userID
+}
+
+// newPickupCode генерирует код выдачи и его хеш для хранения в БД.
+func newPickupCode() (string, string, error) {
+	code, err := generatePickupCode()
+	if err != nil {
+		return "", "", errs.Wrap(err, errs.ErrInternalCode, "failed to generate pickup code")
+	}
+	hash, err := pickupCodeHasher.Hash(code)
+	if err != nil {
+		return "", "", errs.Wrap(err, errs.ErrInternalCode, "failed to hash pickup code")
+	}
+	return code, hash, nil
+}
+
+func generatePickupCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < pickupCodeDigits; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", pickupCodeDigits, n), nil
+}
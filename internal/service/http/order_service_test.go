@@ -0,0 +1,529 @@
+package http
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"regexp"
+	"testing"
+	"time"
+)
+
+const (
+	testPvzID     = "0b0f3c52-6c1f-4a52-9a9c-4f4a2b0a3d11"
+	testProductID = "5d7e1f7a-8a0b-4a39-9c51-1f9e3f3b2c22"
+	testClientID  = "9a1c2e4b-3d5f-4a6b-8c7d-0e1f2a3b4c33"
+	testEmployee  = "7c2b8d1e-4f3a-4b5c-9d6e-1a2b3c4d5e44"
+)
+
+// passThroughTx выполняет колбэк транзакции сразу и возвращает его ошибку
+func passThroughTx(txManager *mockRepo.TxManager) {
+	var callbackErr error
+	txManager.
+		On("WithTx", mock.Anything, pgx.ReadCommitted, pgx.ReadWrite, mock.Anything).
+		Run(func(args mock.Arguments) {
+			f := args.Get(3).(func(context.Context) error)
+			callbackErr = f(context.Background())
+		}).
+		Return(func(_ context.Context, _ pgx.TxIsoLevel, _ pgx.TxAccessMode, _ func(context.Context) error) error {
+			return callbackErr
+		}).
+		Once()
+}
+
+// expectAudit ожидает одну запись в журнал аудита с указанным действием
+func expectAudit(repoMock *mockRepo.Repository, action string) {
+	repoMock.
+		On("InsertAuditEntry", mock.Anything, mock.MatchedBy(func(e entity.AuditEntry) bool {
+			return e.Action == action
+		})).
+		Return(nil).
+		Once()
+}
+
+// expectEvent ожидает одно событие outbox указанного типа для агрегата aggregateID и постановку его доставок вебхукам
+func expectEvent(repoMock *mockRepo.Repository, eventType, aggregateID string) {
+	repoMock.
+		On("InsertOutboxEvent", mock.Anything, mock.MatchedBy(func(e entity.OutboxEvent) bool {
+			return e.Type == eventType && e.AggregateID == aggregateID && e.EventID != ""
+		})).
+		Return(nil).
+		Once()
+	repoMock.
+		On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(e entity.OutboxEvent) bool {
+			return e.Type == eventType && e.AggregateID == aggregateID
+		})).
+		Return(nil).
+		Once()
+}
+
+func expectErrorLog(loggerMock *mockLog.Logger, msg string, argsCount int) {
+	args := []interface{}{msg}
+	for i := 0; i < argsCount; i++ {
+		args = append(args, mock.Anything)
+	}
+	loggerMock.On("Errorw", args...).Return().Once()
+}
+
+func assertErrCode(t *testing.T, err error, code string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected error with code %s, got nil", code)
+	}
+	if got := errs.FromError(err, "").Code; got != code {
+		t.Errorf("expected error code %s, got %s (%v)", code, got, err)
+	}
+}
+
+func TestPvzService_PrepareOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := &entity.User{ID: testClientID, Role: "client"}
+
+	t.Run("invalid product id", func(t *testing.T) {
+		t.Parallel()
+		svc := &pvzServiceImp{repo: mockRepo.NewRepository(t), logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		_, err := svc.PrepareOrder(ctx, testPvzID, "not-a-uuid", testClientID)
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+	})
+
+	t.Run("recipient is not a client", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindByID", mock.Anything, testEmployee).
+			Return(&entity.User{ID: testEmployee, Role: "employee"}, nil).Once()
+		expectErrorLog(loggerMock, "PrepareOrder", 8)
+
+		_, err := svc.PrepareOrder(ctx, testPvzID, testProductID, testEmployee)
+		assertErrCode(t, err, errs.ErrInvalidRecipient)
+	})
+
+	t.Run("reception is still open", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(client, nil).Once()
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).
+			Return(&entity.Order{ProductID: testProductID, ReceptionStatus: "in_progress", Status: entity.ProductStatusReceived}, nil).
+			Once()
+		expectErrorLog(loggerMock, "PrepareOrder", 8)
+
+		_, err := svc.PrepareOrder(ctx, testPvzID, testProductID, testClientID)
+		assertErrCode(t, err, errs.ErrInvalidProductStatus)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(client, nil).Once()
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).
+			Return(&entity.Order{ProductID: testProductID, PvzID: testPvzID, ReceptionStatus: "close", Status: entity.ProductStatusReceived}, nil).
+			Once()
+		repoMock.On("SetOrderRecipient", mock.Anything, testProductID, testClientID).Return(nil).Once()
+		expectAudit(repoMock, entity.AuditOrderPrepare)
+
+		order, err := svc.PrepareOrder(ctx, testPvzID, testProductID, testClientID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if order.Status != entity.ProductStatusReadyForPickup {
+			t.Errorf("expected status %s, got %s", entity.ProductStatusReadyForPickup, order.Status)
+		}
+		// код выдачи получает только клиент через RenewMyPickupCode
+		if order.PickupCode != nil || order.PickupCodeHash != nil {
+			t.Errorf("employee must not receive a pickup code, got %v", order.PickupCode)
+		}
+	})
+}
+
+func TestPvzService_IssueOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	code := "123456"
+	hash, err := pickupCodeHasher.Hash(code)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	readyOrder := func() *entity.Order {
+		return &entity.Order{
+			ProductID:      testProductID,
+			PvzID:          testPvzID,
+			Status:         entity.ProductStatusReadyForPickup,
+			PickupCodeHash: &hash,
+		}
+	}
+
+	t.Run("wrong pickup code is counted", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).Return(readyOrder(), nil).Once()
+		repoMock.On("RecordPickupCodeFailure", mock.Anything, testProductID).Return(nil).Once()
+		expectErrorLog(loggerMock, "IssueOrder", 8)
+
+		_, err := svc.IssueOrder(ctx, testPvzID, testProductID, "000000", testEmployee)
+		assertErrCode(t, err, errs.ErrInvalidPickupCode)
+	})
+
+	t.Run("too many wrong codes lock the order", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		locked := readyOrder()
+		locked.PickupFailures = maxPickupCodeFailures
+		passThroughTx(txManager)
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).Return(locked, nil).Once()
+		expectErrorLog(loggerMock, "IssueOrder", 8)
+
+		// даже верный код не принимается, пока получатель не выпустит новый
+		_, err := svc.IssueOrder(ctx, testPvzID, testProductID, code, testEmployee)
+		assertErrCode(t, err, errs.ErrPickupCodeLocked)
+	})
+
+	t.Run("code not requested by recipient", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		prepared := readyOrder()
+		prepared.PickupCodeHash = nil
+		passThroughTx(txManager)
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).Return(prepared, nil).Once()
+		expectErrorLog(loggerMock, "IssueOrder", 8)
+
+		_, err := svc.IssueOrder(ctx, testPvzID, testProductID, code, testEmployee)
+		assertErrCode(t, err, errs.ErrInvalidPickupCode)
+	})
+
+	t.Run("already issued", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		issued := readyOrder()
+		issued.Status = entity.ProductStatusIssued
+		passThroughTx(txManager)
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).Return(issued, nil).Once()
+		expectErrorLog(loggerMock, "IssueOrder", 8)
+
+		_, err := svc.IssueOrder(ctx, testPvzID, testProductID, code, testEmployee)
+		assertErrCode(t, err, errs.ErrInvalidProductStatus)
+	})
+
+	t.Run("success records employee", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).Return(readyOrder(), nil).Once()
+		repoMock.On("MarkOrderIssued", mock.Anything, testProductID, mock.MatchedBy(func(by *string) bool {
+			return by != nil && *by == testEmployee
+		}), mock.AnythingOfType("time.Time")).Return(nil).Once()
+		expectAudit(repoMock, entity.AuditOrderIssue)
+		expectEvent(repoMock, entity.EventOrderIssued, testProductID)
+
+		order, err := svc.IssueOrder(ctx, testPvzID, testProductID, code, testEmployee)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if order.Status != entity.ProductStatusIssued || order.IssuedAt == nil {
+			t.Errorf("expected issued order with timestamp, got %+v", order)
+		}
+	})
+
+	t.Run("dummy token is stored without employee", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).Return(readyOrder(), nil).Once()
+		repoMock.On("MarkOrderIssued", mock.Anything, testProductID, (*string)(nil), mock.AnythingOfType("time.Time")).
+			Return(nil).Once()
+		expectAudit(repoMock, entity.AuditOrderIssue)
+		expectEvent(repoMock, entity.EventOrderIssued, testProductID)
+
+		order, err := svc.IssueOrder(ctx, testPvzID, testProductID, code, "dummyID")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if order.IssuedBy != nil {
+			t.Errorf("expected no issuing employee, got %v", *order.IssuedBy)
+		}
+	})
+}
+
+func TestPvzService_RenewMyPickupCode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("issued order has no pickup code", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindRecipientOrder", mock.Anything, testClientID, testProductID).
+			Return(&entity.Order{ProductID: testProductID, Status: entity.ProductStatusIssued}, nil).Once()
+		expectErrorLog(loggerMock, "RenewMyPickupCode", 6)
+
+		_, err := svc.RenewMyPickupCode(ctx, testClientID, testProductID)
+		assertErrCode(t, err, errs.ErrInvalidProductStatus)
+	})
+
+	t.Run("dummy token has no orders", func(t *testing.T) {
+		t.Parallel()
+		svc := &pvzServiceImp{}
+
+		_, err := svc.RenewMyPickupCode(ctx, "dummyID", testProductID)
+		assertErrCode(t, err, errs.ErrForbiddenCode)
+	})
+
+	t.Run("success replaces the hash", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindRecipientOrder", mock.Anything, testClientID, testProductID).
+			Return(&entity.Order{ProductID: testProductID, PvzID: testPvzID, Status: entity.ProductStatusReadyForPickup, PickupFailures: 5}, nil).Once()
+		var storedHash string
+		repoMock.On("ReplacePickupCode", mock.Anything, testProductID, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { storedHash = args.String(2) }).
+			Return(nil).Once()
+		expectAudit(repoMock, entity.AuditOrderCodeRenew)
+
+		order, err := svc.RenewMyPickupCode(ctx, testClientID, testProductID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if order.PickupCode == nil || !pickupCodeHasher.Check(storedHash, *order.PickupCode) {
+			t.Errorf("expected the stored hash to match the new code %v", order.PickupCode)
+		}
+		if order.PickupFailures != 0 {
+			t.Errorf("expected failures to be reset, got %d", order.PickupFailures)
+		}
+	})
+}
+
+func TestPvzService_ReturnOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("received product cannot be returned", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).
+			Return(&entity.Order{ProductID: testProductID, Status: entity.ProductStatusReceived}, nil).Once()
+		expectErrorLog(loggerMock, "ReturnOrder", 6)
+
+		_, err := svc.ReturnOrder(ctx, testPvzID, testProductID)
+		assertErrCode(t, err, errs.ErrInvalidProductStatus)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).
+			Return(&entity.Order{ProductID: testProductID, Status: entity.ProductStatusReadyForPickup}, nil).Once()
+		repoMock.On("MarkOrderReturned", mock.Anything, testProductID).Return(nil).Once()
+		expectAudit(repoMock, entity.AuditOrderReturn)
+		expectEvent(repoMock, entity.EventOrderReturned, testProductID)
+
+		order, err := svc.ReturnOrder(ctx, testPvzID, testProductID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if order.Status != entity.ProductStatusReturned {
+			t.Errorf("expected status %s, got %s", entity.ProductStatusReturned, order.Status)
+		}
+	})
+}
+
+func TestGeneratePickupCode(t *testing.T) {
+	t.Parallel()
+
+	re := regexp.MustCompile(`^\d{6}$`)
+	for i := 0; i < 100; i++ {
+		code, err := generatePickupCode()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !re.MatchString(code) {
+			t.Fatalf("unexpected pickup code %q", code)
+		}
+	}
+}
+
+func TestPvzService_GetMyOrders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("dummy token has no orders", func(t *testing.T) {
+		t.Parallel()
+		svc := &pvzServiceImp{repo: mockRepo.NewRepository(t), logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		_, err := svc.GetMyOrders(ctx, "dummyID", "")
+		assertErrCode(t, err, errs.ErrForbiddenCode)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		t.Parallel()
+		svc := &pvzServiceImp{repo: mockRepo.NewRepository(t), logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		_, err := svc.GetMyOrders(ctx, testClientID, entity.ProductStatusReceived)
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+	})
+
+	t.Run("all statuses", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		repoMock.On("GetRecipientOrders", mock.Anything, testClientID, (*string)(nil)).
+			Return([]entity.Order{{ProductID: testProductID}}, nil).Once()
+
+		orders, err := svc.GetMyOrders(ctx, testClientID, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(orders) != 1 {
+			t.Errorf("expected 1 order, got %d", len(orders))
+		}
+	})
+
+	t.Run("filtered by status", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		repoMock.On("GetRecipientOrders", mock.Anything, testClientID, mock.MatchedBy(func(status *string) bool {
+			return status != nil && *status == entity.ProductStatusIssued
+		})).Return([]entity.Order{}, nil).Once()
+
+		if _, err := svc.GetMyOrders(ctx, testClientID, entity.ProductStatusIssued); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestPvzService_GetMyOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("foreign order is not found", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: mockRepo.NewTxManager(t)}
+
+		repoMock.On("FindRecipientOrder", mock.Anything, testClientID, testProductID).
+			Return(nil, errs.New(errs.ErrProductNotFound, "order not found")).Once()
+		expectErrorLog(loggerMock, "GetMyOrder", 6)
+
+		_, err := svc.GetMyOrder(ctx, testClientID, testProductID)
+		assertErrCode(t, err, errs.ErrProductNotFound)
+	})
+
+	t.Run("invalid product id", func(t *testing.T) {
+		t.Parallel()
+		svc := &pvzServiceImp{repo: mockRepo.NewRepository(t), logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		_, err := svc.GetMyOrder(ctx, testClientID, "not-a-uuid")
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+	})
+}
+
+func TestPvzService_FindProductByBarcode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		repoMock.On("FindOrderByBarcode", mock.Anything, "4607001234567").
+			Return(&entity.Order{ProductID: testProductID, Barcode: "4607001234567"}, nil).Once()
+
+		order, err := svc.FindProductByBarcode(ctx, "4607001234567")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if order.ProductID != testProductID {
+			t.Errorf("expected product %q, got %q", testProductID, order.ProductID)
+		}
+	})
+
+	t.Run("invalid barcode", func(t *testing.T) {
+		t.Parallel()
+		svc := &pvzServiceImp{repo: mockRepo.NewRepository(t), logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		_, err := svc.FindProductByBarcode(ctx, "not a barcode")
+		assertErrCode(t, err, errs.ErrInvalidBarcode)
+	})
+}
+
+func TestPvzService_StoragePeriod(t *testing.T) {
+	t.Parallel()
+
+	svc := &pvzServiceImp{storagePeriods: map[string]int{"electronics": 14}}
+
+	if got := svc.storagePeriod("Electronics"); got != 14*24*time.Hour {
+		t.Errorf("expected 14 days for electronics, got %v", got)
+	}
+	if got := svc.storagePeriod("shoes"); got != defaultStorageDays*24*time.Hour {
+		t.Errorf("expected default period for unconfigured type, got %v", got)
+	}
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/storage/db"
+	"time"
+)
+
+// eventRepository — хранилища, куда попадает доменное событие: outbox и очередь вебхуков.
+type eventRepository interface {
+	db.OutboxRepository
+	db.WebhookRepository
+}
+
+// emitEvent кладёт доменное событие в outbox и создаёт доставки подписанным вебхукам.
+// Как и recordAudit, вызывается внутри транзакции изменения: событие публикуется
+// тогда и только тогда, когда изменение зафиксировано.
+func emitEvent(ctx context.Context, repo eventRepository, eventType, aggregateID, pvzID string, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to encode event payload")
+	}
+	event := entity.OutboxEvent{
+		EventID:     uuid.NewString(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		PvzID:       pvzID,
+		Payload:     raw,
+		CreatedAt:   time.Now(),
+	}
+	if err := repo.InsertOutboxEvent(ctx, event); err != nil {
+		return err
+	}
+	return repo.EnqueueWebhookDeliveries(ctx, event)
+}
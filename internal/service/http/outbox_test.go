@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	"testing"
+)
+
+func TestEmitEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("payload is stored as json", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+
+		var inserted entity.OutboxEvent
+		repoMock.On("InsertOutboxEvent", mock.Anything, mock.MatchedBy(func(e entity.OutboxEvent) bool {
+			return e.Type == entity.EventProductRemoved && e.AggregateID == testProductID && e.PvzID == testPvzID &&
+				e.EventID != "" && !e.CreatedAt.IsZero() &&
+				string(e.Payload) == `{"id":"`+testProductID+`","date_time":"0001-01-01T00:00:00Z","type":"shoes","barcode":"BC-1","reception_id":"rec1","expires_at":null}`
+		})).Run(func(args mock.Arguments) {
+			inserted = args.Get(1).(entity.OutboxEvent)
+		}).Return(nil).Once()
+		// вебхуки получают то же событие с тем же ID
+		repoMock.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(e entity.OutboxEvent) bool {
+			return e.EventID == inserted.EventID
+		})).Return(nil).Once()
+
+		product := entity.Product{ID: testProductID, Type: "shoes", Barcode: "BC-1", ReceptionID: "rec1"}
+		if err := emitEvent(context.Background(), repoMock, entity.EventProductRemoved, testProductID, testPvzID, product); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("write error is returned to roll back the change", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+
+		repoMock.On("InsertOutboxEvent", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+
+		if err := emitEvent(context.Background(), repoMock, entity.EventPvzCreated, testPvzID, testPvzID, entity.Pvz{}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("webhook enqueue error is returned", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+
+		repoMock.On("InsertOutboxEvent", mock.Anything, mock.Anything).Return(nil).Once()
+		repoMock.On("EnqueueWebhookDeliveries", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+
+		if err := emitEvent(context.Background(), repoMock, entity.EventPvzCreated, testPvzID, testPvzID, entity.Pvz{}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"strings"
+	"time"
+)
+
+// MaxBatchProducts — ограничение на размер одного пакета, чтобы не держать транзакцию слишком долго
+const MaxBatchProducts = 1000
+
+// AddProductsBatch добавляет пакет товаров в открытую приёмку ПВЗ в одной транзакции.
+// В режиме atomic любая некорректная позиция отклоняет весь пакет, иначе сохраняются только корректные позиции.
+// Ошибки отдельных позиций возвращаются в результатах, ошибка функции означает сбой пакета целиком.
+func (s *pvzServiceImp) AddProductsBatch(ctx context.Context, pvzID string, items []entity.ProductBatchItem, atomic bool) ([]entity.ProductBatchResult, error) {
+	if err := validateIDs(pvzID); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errs.New(errs.ErrInvalidRequestCode, "products list is empty")
+	}
+	if len(items) > MaxBatchProducts {
+		return nil, errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("batch is limited to %d products", MaxBatchProducts))
+	}
+
+	results := s.validateBatchItems(items)
+
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		reception, err := s.repo.FindOpenReceptionByPvzID(txCtx, pvzID)
+		if err != nil {
+			return err
+		}
+		if reception == nil {
+			return errs.New(errs.ErrNoOpenReception, "no open reception found for this PVZ")
+		}
+
+		if err := s.markTakenBarcodes(txCtx, results); err != nil {
+			return err
+		}
+
+		valid := make([]int, 0, len(results))
+		for i := range results {
+			if results[i].Status != entity.BatchItemFailed {
+				valid = append(valid, i)
+			}
+		}
+		if len(valid) == 0 {
+			return nil
+		}
+		if atomic && len(valid) < len(results) {
+			for _, i := range valid {
+				results[i].Status = entity.BatchItemSkipped
+			}
+			return nil
+		}
+
+		// DeleteLastProduct выбирает последний товар по date_time, поэтому время товаров пакета
+		// строго возрастает в порядке позиций: шаг в микросекунду — точность timestamptz
+		now := time.Now()
+		products := make([]entity.Product, 0, len(valid))
+		for k, i := range valid {
+			addedAt := now.Add(time.Duration(k) * time.Microsecond)
+			expiresAt := addedAt.Add(s.storagePeriod(results[i].Type))
+			products = append(products, entity.Product{
+				ReceptionID: reception.ID,
+				Type:        results[i].Type,
+				Barcode:     results[i].Barcode,
+				DateTime:    addedAt,
+				ExpiresAt:   &expiresAt,
+			})
+		}
+
+		// Штрихкод мог занять параллельный запрос уже после markTakenBarcodes: в режиме partial
+		// такая позиция отмечается ошибкой, в atomic конфликт отклоняет весь пакет
+		ids, err := s.repo.CreateProducts(txCtx, products, !atomic)
+		if err != nil {
+			return err
+		}
+		created := products[:0]
+		for k, i := range valid {
+			if ids[k] == "" {
+				failBatchItem(&results[i], errs.ErrDuplicateBarcode, "product with this barcode is already at a PVZ")
+				continue
+			}
+			results[i].ProductID = ids[k]
+			results[i].Status = entity.BatchItemCreated
+			products[k].ID = ids[k]
+			created = append(created, products[k])
+		}
+		products = created
+		if len(products) == 0 {
+			return nil
+		}
+		// Пакет пишется одной записью на приёмку, товары — в After
+		if err := recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditProductBatchCreate,
+			EntityType: entity.AuditEntityReception,
+			EntityID:   reception.ID,
+			PvzID:      pvzID,
+			After:      products,
+		}); err != nil {
+			return err
+		}
+		// Подписчикам пакет виден как отдельные ProductAdded, как и при добавлении по одному
+		for _, product := range products {
+			if err := emitEvent(txCtx, s.repo, entity.EventProductAdded, product.ID, pvzID, product); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Errorw("AddProductsBatch",
+			"error", err,
+			"pvzID", pvzID,
+			"count", len(items),
+			"atomic", atomic,
+		)
+		return nil, err
+	}
+
+	created := 0
+	for _, r := range results {
+		if r.Status == entity.BatchItemCreated {
+			created++
+		}
+	}
+	metrics.ProductsBatchAdded(created)
+	return results, nil
+}
+
+// validateBatchItems проверяет тип и штрихкод каждой позиции без обращения к БД.
+// Корректные позиции пока не имеют статуса — он выставляется после вставки.
+func (s *pvzServiceImp) validateBatchItems(items []entity.ProductBatchItem) []entity.ProductBatchResult {
+	results := make([]entity.ProductBatchResult, len(items))
+	seen := make(map[string]int, len(items))
+
+	for i, item := range items {
+		results[i] = entity.ProductBatchResult{Index: i, Type: item.Type, Barcode: item.Barcode}
+
+		switch {
+		case !s.allowedProductTypes[strings.ToLower(item.Type)]:
+			failBatchItem(&results[i], errs.ErrInvalidProductType, fmt.Sprintf("product type '%s' is not allowed", item.Type))
+		case item.Barcode != "" && !barcodePattern.MatchString(item.Barcode):
+			failBatchItem(&results[i], errs.ErrInvalidBarcode, "barcode must be 1-64 latin letters, digits or dashes")
+		case item.Barcode != "":
+			if first, ok := seen[item.Barcode]; ok {
+				failBatchItem(&results[i], errs.ErrDuplicateBarcode, fmt.Sprintf("barcode repeats item %d of the batch", first))
+				continue
+			}
+			seen[item.Barcode] = i
+		}
+	}
+	return results
+}
+
+// markTakenBarcodes отмечает позиции, штрихкоды которых уже заняты товарами в ПВЗ.
+func (s *pvzServiceImp) markTakenBarcodes(ctx context.Context, results []entity.ProductBatchResult) error {
+	barcodes := make([]string, 0, len(results))
+	for _, r := range results {
+		if r.Status != entity.BatchItemFailed && r.Barcode != "" {
+			barcodes = append(barcodes, r.Barcode)
+		}
+	}
+	if len(barcodes) == 0 {
+		return nil
+	}
+
+	taken, err := s.repo.FindActiveBarcodes(ctx, barcodes)
+	if err != nil {
+		return err
+	}
+	if len(taken) == 0 {
+		return nil
+	}
+
+	takenSet := make(map[string]bool, len(taken))
+	for _, b := range taken {
+		takenSet[b] = true
+	}
+	for i := range results {
+		if results[i].Status != entity.BatchItemFailed && takenSet[results[i].Barcode] {
+			failBatchItem(&results[i], errs.ErrDuplicateBarcode, "product with this barcode is already at a PVZ")
+		}
+	}
+	return nil
+}
+
+func failBatchItem(r *entity.ProductBatchResult, code, message string) {
+	r.Status = entity.BatchItemFailed
+	r.ErrCode = code
+	r.ErrMessage = message
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+)
+
+func newBatchTestService(t *testing.T) (*pvzServiceImp, *mockRepo.Repository, *mockRepo.TxManager) {
+	repoMock := mockRepo.NewRepository(t)
+	txManager := mockRepo.NewTxManager(t)
+	svc := &pvzServiceImp{
+		repo:                repoMock,
+		txManager:           txManager,
+		logger:              mockLog.NewLogger(t),
+		allowedProductTypes: map[string]bool{"shoes": true, "clothes": true},
+	}
+	return svc, repoMock, txManager
+}
+
+func TestPvzService_AddProductsBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	reception := &entity.Reception{ID: "rec1", PvzID: testPvzID, Status: "in_progress"}
+	items := []entity.ProductBatchItem{
+		{Type: "shoes", Barcode: "BC-1"},
+		{Type: "toys"},
+		{Type: "clothes", Barcode: "BC-1"},
+		{Type: "clothes", Barcode: "BC-2"},
+	}
+
+	t.Run("partial mode saves valid items", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager := newBatchTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindOpenReceptionByPvzID", mock.Anything, testPvzID).Return(reception, nil).Once()
+		repoMock.On("FindActiveBarcodes", mock.Anything, []string{"BC-1", "BC-2"}).Return([]string{"BC-2"}, nil).Once()
+		repoMock.On("CreateProducts", mock.Anything, mock.MatchedBy(func(products []entity.Product) bool {
+			return len(products) == 1 && products[0].Barcode == "BC-1" && products[0].ReceptionID == reception.ID &&
+				products[0].ExpiresAt != nil
+		}), true).Return([]string{"prod1"}, nil).Once()
+		expectAudit(repoMock, entity.AuditProductBatchCreate)
+		expectEvent(repoMock, entity.EventProductAdded, "prod1")
+
+		results, err := svc.AddProductsBatch(ctx, testPvzID, items, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []struct{ status, code string }{
+			{entity.BatchItemCreated, ""},
+			{entity.BatchItemFailed, errs.ErrInvalidProductType},
+			{entity.BatchItemFailed, errs.ErrDuplicateBarcode},
+			{entity.BatchItemFailed, errs.ErrDuplicateBarcode},
+		}
+		for i, e := range expected {
+			if results[i].Status != e.status || results[i].ErrCode != e.code {
+				t.Errorf("item %d: expected %s/%s, got %s/%s", i, e.status, e.code, results[i].Status, results[i].ErrCode)
+			}
+		}
+		if results[0].ProductID != "prod1" {
+			t.Errorf("expected product id prod1, got %q", results[0].ProductID)
+		}
+	})
+
+	t.Run("batch items get increasing timestamps", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager := newBatchTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindOpenReceptionByPvzID", mock.Anything, testPvzID).Return(reception, nil).Once()
+		var created []entity.Product
+		repoMock.On("CreateProducts", mock.Anything, mock.Anything, false).
+			Run(func(args mock.Arguments) { created = args.Get(1).([]entity.Product) }).
+			Return([]string{"prod1", "prod2", "prod3"}, nil).Once()
+		expectAudit(repoMock, entity.AuditProductBatchCreate)
+		for _, id := range []string{"prod1", "prod2", "prod3"} {
+			expectEvent(repoMock, entity.EventProductAdded, id)
+		}
+
+		batch := []entity.ProductBatchItem{{Type: "shoes"}, {Type: "clothes"}, {Type: "shoes"}}
+		if _, err := svc.AddProductsBatch(ctx, testPvzID, batch, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// последняя позиция пакета должна удаляться первой
+		for k := 1; k < len(created); k++ {
+			if !created[k].DateTime.After(created[k-1].DateTime) {
+				t.Errorf("item %d: date_time %v is not after %v", k, created[k].DateTime, created[k-1].DateTime)
+			}
+		}
+	})
+
+	t.Run("partial mode reports barcode taken concurrently", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager := newBatchTestService(t)
+		passThroughTx(txManager)
+
+		batch := []entity.ProductBatchItem{{Type: "shoes", Barcode: "BC-1"}, {Type: "clothes", Barcode: "BC-2"}}
+		repoMock.On("FindOpenReceptionByPvzID", mock.Anything, testPvzID).Return(reception, nil).Once()
+		repoMock.On("FindActiveBarcodes", mock.Anything, []string{"BC-1", "BC-2"}).Return(nil, nil).Once()
+		// BC-2 занят параллельной вставкой между проверкой и вставкой
+		repoMock.On("CreateProducts", mock.Anything, mock.Anything, true).Return([]string{"prod1", ""}, nil).Once()
+		expectAudit(repoMock, entity.AuditProductBatchCreate)
+		expectEvent(repoMock, entity.EventProductAdded, "prod1")
+
+		results, err := svc.AddProductsBatch(ctx, testPvzID, batch, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if results[0].Status != entity.BatchItemCreated || results[0].ProductID != "prod1" {
+			t.Errorf("expected first item to be created, got %+v", results[0])
+		}
+		if results[1].Status != entity.BatchItemFailed || results[1].ErrCode != errs.ErrDuplicateBarcode {
+			t.Errorf("expected second item to fail with duplicate barcode, got %+v", results[1])
+		}
+	})
+
+	t.Run("partial mode with every barcode taken concurrently", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager := newBatchTestService(t)
+		passThroughTx(txManager)
+
+		batch := []entity.ProductBatchItem{{Type: "shoes", Barcode: "BC-1"}}
+		repoMock.On("FindOpenReceptionByPvzID", mock.Anything, testPvzID).Return(reception, nil).Once()
+		repoMock.On("FindActiveBarcodes", mock.Anything, []string{"BC-1"}).Return(nil, nil).Once()
+		repoMock.On("CreateProducts", mock.Anything, mock.Anything, true).Return([]string{""}, nil).Once()
+
+		results, err := svc.AddProductsBatch(ctx, testPvzID, batch, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if results[0].Status != entity.BatchItemFailed || results[0].ErrCode != errs.ErrDuplicateBarcode {
+			t.Errorf("expected item to fail with duplicate barcode, got %+v", results[0])
+		}
+	})
+
+	t.Run("atomic mode rejects whole batch", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager := newBatchTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindOpenReceptionByPvzID", mock.Anything, testPvzID).Return(reception, nil).Once()
+		repoMock.On("FindActiveBarcodes", mock.Anything, []string{"BC-1", "BC-2"}).Return(nil, nil).Once()
+
+		results, err := svc.AddProductsBatch(ctx, testPvzID, items, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if results[0].Status != entity.BatchItemSkipped || results[3].Status != entity.BatchItemSkipped {
+			t.Errorf("expected valid items to be skipped, got %+v", results)
+		}
+		repoMock.AssertNotCalled(t, "CreateProducts", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("no open reception", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager := newBatchTestService(t)
+		passThroughTx(txManager)
+		expectErrorLog(svc.logger.(*mockLog.Logger), "AddProductsBatch", 8)
+
+		repoMock.On("FindOpenReceptionByPvzID", mock.Anything, testPvzID).Return(nil, nil).Once()
+
+		_, err := svc.AddProductsBatch(ctx, testPvzID, items[:1], true)
+		assertErrCode(t, err, errs.ErrNoOpenReception)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _ := newBatchTestService(t)
+
+		_, err := svc.AddProductsBatch(ctx, testPvzID, nil, true)
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+	})
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+)
+
+const (
+	maxActivityPvzs = 50
+	// activityBacklogBatch — сколько сохранённых событий дочитывается за один запрос
+	activityBacklogBatch = 500
+)
+
+// StreamPvzActivity передаёт в fn события приёмок и товаров указанных ПВЗ по мере их фиксации.
+// ready вызывается один раз после проверки фильтра и подписки, до первого события: после него
+// ответ клиенту уже начат. С LastEventID сначала дочитываются сохранённые события после него
+// и события с меньшим id, зафиксированные позже него; последние клиент мог уже получить,
+// поэтому он дедуплицирует по EventID. Метод возвращает nil, когда клиент отключился, и
+// ErrActivityStreamInterrupted, когда поток прервал сервер: клиент переподключается
+// с последним полученным id и ничего не теряет.
+func (s *pvzServiceImp) StreamPvzActivity(
+	ctx context.Context,
+	filter entity.PvzActivityFilter,
+	ready func() error,
+	fn func(entity.OutboxEvent) error,
+) error {
+	pvzIDs, err := s.validateActivityFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	// Подписка оформляется до чтения истории, чтобы не пропустить события между ними
+	sub := s.activity.Subscribe(pvzIDs)
+	defer sub.Close()
+
+	if err := ready(); err != nil {
+		return err
+	}
+
+	sent := make(map[int64]bool)
+	send := func(events []entity.OutboxEvent) error {
+		for _, e := range events {
+			if sent[e.ID] {
+				continue
+			}
+			if err := fn(e); err != nil {
+				return err
+			}
+			sent[e.ID] = true
+		}
+		return nil
+	}
+	if filter.LastEventID != nil {
+		lastID := *filter.LastEventID
+		// id выдаётся при вставке, а не при коммите: события с меньшим id из транзакций,
+		// ещё не зафиксированных при записи lastID, клиент мог не получить
+		var afterID int64
+		for {
+			events, err := s.repo.ListConcurrentPvzActivity(ctx, pvzIDs, lastID, afterID, activityBacklogBatch)
+			if err != nil {
+				s.logger.Errorw("StreamPvzActivity",
+					"error", err,
+					"lastEventID", lastID,
+				)
+				return err
+			}
+			if err := send(events); err != nil {
+				return err
+			}
+			if len(events) < activityBacklogBatch {
+				break
+			}
+			afterID = events[len(events)-1].ID
+		}
+
+		afterID = lastID
+		for {
+			events, err := s.repo.ListPvzActivity(ctx, pvzIDs, afterID, activityBacklogBatch)
+			if err != nil {
+				s.logger.Errorw("StreamPvzActivity",
+					"error", err,
+					"afterID", afterID,
+				)
+				return err
+			}
+			if err := send(events); err != nil {
+				return err
+			}
+			if len(events) < activityBacklogBatch {
+				break
+			}
+			afterID = events[len(events)-1].ID
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-sub.Events():
+			if !ok {
+				return errs.Wrap(sub.Err(), errs.ErrActivityStreamInterrupted,
+					"activity stream interrupted, reconnect with the last received event id")
+			}
+			// событие могло попасть и в историю, и в живой поток
+			if sent[e.ID] {
+				delete(sent, e.ID)
+				continue
+			}
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// validateActivityFilter проверяет ПВЗ подписки и возвращает их без повторов.
+func (s *pvzServiceImp) validateActivityFilter(ctx context.Context, filter entity.PvzActivityFilter) ([]string, error) {
+	pvzIDs := make([]string, 0, len(filter.PvzIDs))
+	seen := make(map[string]bool, len(filter.PvzIDs))
+	for _, id := range filter.PvzIDs {
+		if !seen[id] {
+			seen[id] = true
+			pvzIDs = append(pvzIDs, id)
+		}
+	}
+	if len(pvzIDs) == 0 || len(pvzIDs) > maxActivityPvzs {
+		return nil, errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("from 1 to %d PVZ ids are required", maxActivityPvzs))
+	}
+	if err := validateIDs(pvzIDs...); err != nil {
+		return nil, err
+	}
+	if filter.LastEventID != nil && *filter.LastEventID < 0 {
+		return nil, errs.New(errs.ErrInvalidRequestCode, "last event id must not be negative")
+	}
+	for _, id := range pvzIDs {
+		if _, err := s.repo.GetPvzByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return pvzIDs, nil
+}
@@ -0,0 +1,194 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/activity"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+	"time"
+)
+
+func TestPvzService_StreamPvzActivity(t *testing.T) {
+	t.Parallel()
+
+	const otherPvzID = "3e4f5a6b-7c8d-4e9f-8a0b-1c2d3e4f5a66"
+
+	// newSvc возвращает сервис с реальным Hub, ПВЗ testPvzID существует
+	newSvc := func(t *testing.T) (*pvzServiceImp, *mockRepo.Repository, *activity.Hub) {
+		repoMock := mockRepo.NewRepository(t)
+		hub := activity.NewHub(8)
+		t.Cleanup(hub.Close)
+		repoMock.On("GetPvzByID", mock.Anything, testPvzID).Return(&entity.Pvz{ID: testPvzID}, nil).Maybe()
+		return &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), activity: hub}, repoMock, hub
+	}
+	// collect запускает поток в отдельной горутине и отдаёт полученные события в канал
+	collect := func(ctx context.Context, svc *pvzServiceImp, filter entity.PvzActivityFilter) (<-chan entity.OutboxEvent, <-chan error, <-chan struct{}) {
+		received := make(chan entity.OutboxEvent, 16)
+		done := make(chan error, 1)
+		subscribed := make(chan struct{})
+		go func() {
+			done <- svc.StreamPvzActivity(ctx, filter, func() error {
+				close(subscribed)
+				return nil
+			}, func(e entity.OutboxEvent) error {
+				received <- e
+				return nil
+			})
+		}()
+		return received, done, subscribed
+	}
+	next := func(t *testing.T, received <-chan entity.OutboxEvent) entity.OutboxEvent {
+		t.Helper()
+		select {
+		case e := <-received:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("event was not delivered")
+			return entity.OutboxEvent{}
+		}
+	}
+
+	t.Run("backlog then live events without duplicates", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, hub := newSvc(t)
+		lastID := int64(10)
+
+		repoMock.On("ListConcurrentPvzActivity", mock.Anything, []string{testPvzID}, int64(10), int64(0), activityBacklogBatch).
+			Return(nil, nil).Once()
+		// событие 12 попадает и в историю, и в живой поток
+		repoMock.On("ListPvzActivity", mock.Anything, []string{testPvzID}, int64(10), activityBacklogBatch).
+			Return([]entity.OutboxEvent{{ID: 11, PvzID: testPvzID}, {ID: 12, PvzID: testPvzID}}, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		received, done, subscribed := collect(ctx, svc, entity.PvzActivityFilter{PvzIDs: []string{testPvzID, testPvzID}, LastEventID: &lastID})
+		<-subscribed
+
+		if e := next(t, received); e.ID != 11 {
+			t.Fatalf("expected event 11, got %d", e.ID)
+		}
+		if e := next(t, received); e.ID != 12 {
+			t.Fatalf("expected event 12, got %d", e.ID)
+		}
+		hub.Publish(entity.OutboxEvent{ID: 12, PvzID: testPvzID})
+		hub.Publish(entity.OutboxEvent{ID: 13, PvzID: otherPvzID})
+		hub.Publish(entity.OutboxEvent{ID: 14, PvzID: testPvzID})
+		if e := next(t, received); e.ID != 14 {
+			t.Fatalf("expected event 14, got %d", e.ID)
+		}
+
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("expected nil after client disconnect, got %v", err)
+		}
+	})
+
+	t.Run("events committed out of id order are replayed", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, hub := newSvc(t)
+		lastID := int64(10)
+
+		// событие 9 зафиксировано после 10, событие 8 клиент мог уже получить
+		repoMock.On("ListConcurrentPvzActivity", mock.Anything, []string{testPvzID}, int64(10), int64(0), activityBacklogBatch).
+			Return([]entity.OutboxEvent{{ID: 8, PvzID: testPvzID}, {ID: 9, PvzID: testPvzID}}, nil).Once()
+		repoMock.On("ListPvzActivity", mock.Anything, []string{testPvzID}, int64(10), activityBacklogBatch).
+			Return([]entity.OutboxEvent{{ID: 11, PvzID: testPvzID}}, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		received, _, subscribed := collect(ctx, svc, entity.PvzActivityFilter{PvzIDs: []string{testPvzID}, LastEventID: &lastID})
+		<-subscribed
+
+		for _, want := range []int64{8, 9, 11} {
+			if e := next(t, received); e.ID != want {
+				t.Fatalf("expected event %d, got %d", want, e.ID)
+			}
+		}
+		hub.Publish(entity.OutboxEvent{ID: 9, PvzID: testPvzID})
+		hub.Publish(entity.OutboxEvent{ID: 12, PvzID: testPvzID})
+		if e := next(t, received); e.ID != 12 {
+			t.Fatalf("expected event 12, got %d", e.ID)
+		}
+	})
+
+	t.Run("server interruption", func(t *testing.T) {
+		t.Parallel()
+		svc, _, hub := newSvc(t)
+
+		received, done, subscribed := collect(context.Background(), svc, entity.PvzActivityFilter{PvzIDs: []string{testPvzID}})
+		<-subscribed
+		hub.Publish(entity.OutboxEvent{ID: 1, PvzID: testPvzID})
+		next(t, received)
+		hub.Resync()
+
+		err := <-done
+		assertErrCode(t, err, errs.ErrActivityStreamInterrupted)
+		if !errors.Is(err, activity.ErrResync) {
+			t.Errorf("expected resync cause, got %v", err)
+		}
+	})
+
+	t.Run("backlog error", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _ := newSvc(t)
+		loggerMock := mockLog.NewLogger(t)
+		svc.logger = loggerMock
+		expectErrorLog(loggerMock, "StreamPvzActivity", 4)
+		lastID := int64(0)
+
+		repoMock.On("ListConcurrentPvzActivity", mock.Anything, mock.Anything, int64(0), int64(0), mock.Anything).
+			Return(nil, nil).Once()
+		repoMock.On("ListPvzActivity", mock.Anything, mock.Anything, int64(0), mock.Anything).
+			Return(nil, errors.New("db error")).Once()
+
+		err := svc.StreamPvzActivity(context.Background(), entity.PvzActivityFilter{PvzIDs: []string{testPvzID}, LastEventID: &lastID},
+			func() error { return nil }, func(entity.OutboxEvent) error { return nil })
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("unknown pvz is rejected before subscription", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _ := newSvc(t)
+		repoMock.On("GetPvzByID", mock.Anything, otherPvzID).
+			Return(nil, errs.New(errs.ErrPvzNotFound, "pvz not found")).Once()
+
+		err := svc.StreamPvzActivity(context.Background(), entity.PvzActivityFilter{PvzIDs: []string{testPvzID, otherPvzID}},
+			func() error {
+				t.Error("ready must not be called")
+				return nil
+			}, func(entity.OutboxEvent) error { return nil })
+		assertErrCode(t, err, errs.ErrPvzNotFound)
+	})
+
+	negative := int64(-1)
+	tooMany := make([]string, maxActivityPvzs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("00000000-0000-4000-8000-%012d", i)
+	}
+	invalid := []struct {
+		name   string
+		filter entity.PvzActivityFilter
+	}{
+		{name: "no pvz ids", filter: entity.PvzActivityFilter{}},
+		{name: "too many pvz ids", filter: entity.PvzActivityFilter{PvzIDs: tooMany}},
+		{name: "pvz id is not uuid", filter: entity.PvzActivityFilter{PvzIDs: []string{"pvz1"}}},
+		{name: "negative last event id", filter: entity.PvzActivityFilter{PvzIDs: []string{testPvzID}, LastEventID: &negative}},
+	}
+	for _, tc := range invalid {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			svc := &pvzServiceImp{repo: mockRepo.NewRepository(t), logger: mockLog.NewLogger(t), activity: activity.NewHub(1)}
+
+			err := svc.StreamPvzActivity(context.Background(), tc.filter, func() error { return nil }, func(entity.OutboxEvent) error { return nil })
+			assertErrCode(t, err, errs.ErrInvalidRequestCode)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"strings"
+)
+
+// GetReceptionsPerDay возвращает число приёмок каждого ПВЗ по дням.
+func (s *pvzServiceImp) GetReceptionsPerDay(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.DailyReceptions, error) {
+	if err := s.validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.CountReceptionsPerDay(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("GetReceptionsPerDay",
+			"error", err,
+			"city", filter.City,
+			"pvzID", filter.PvzID,
+		)
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetProductTypeCounts возвращает число принятых товаров каждого типа по ПВЗ.
+func (s *pvzServiceImp) GetProductTypeCounts(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductTypeCount, error) {
+	if err := s.validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.CountProductsByType(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("GetProductTypeCounts",
+			"error", err,
+			"city", filter.City,
+			"pvzID", filter.PvzID,
+		)
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetReceptionStats возвращает по каждому ПВЗ число приёмок, среднюю длительность приёмки
+// и среднее число товаров в приёмке.
+func (s *pvzServiceImp) GetReceptionStats(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionStats, error) {
+	if err := s.validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.GetReceptionStats(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("GetReceptionStats",
+			"error", err,
+			"city", filter.City,
+			"pvzID", filter.PvzID,
+		)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *pvzServiceImp) validateAnalyticsFilter(filter entity.AnalyticsFilter) error {
+	if filter.City != "" && !s.allowedCities[strings.ToLower(filter.City)] {
+		return errs.New(errs.ErrInvalidCity, fmt.Sprintf("city '%s' is not allowed", filter.City))
+	}
+	if filter.PvzID != "" {
+		if err := validateIDs(filter.PvzID); err != nil {
+			return err
+		}
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return errs.New(errs.ErrInvalidRequestCode, "startDate must not be after endDate")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+	"time"
+)
+
+func TestPvzService_Analytics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newService := func(t *testing.T) (*pvzServiceImp, *mockRepo.Repository, *mockLog.Logger) {
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		return &pvzServiceImp{
+			repo:          repoMock,
+			logger:        loggerMock,
+			allowedCities: map[string]bool{"moscow": true},
+		}, repoMock, loggerMock
+	}
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	filter := entity.AnalyticsFilter{City: "Moscow", StartDate: &start, EndDate: &end}
+
+	t.Run("receptions per day", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _ := newService(t)
+
+		expected := []entity.DailyReceptions{{PvzID: testPvzID, City: "Moscow", Day: start, Receptions: 2}}
+		repoMock.On("CountReceptionsPerDay", mock.Anything, filter).Return(expected, nil).Once()
+
+		result, err := svc.GetReceptionsPerDay(ctx, filter)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result) != 1 || result[0].Receptions != 2 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("product type counts", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _ := newService(t)
+
+		expected := []entity.ProductTypeCount{{PvzID: testPvzID, City: "Moscow", Type: "shoes", Products: 7}}
+		repoMock.On("CountProductsByType", mock.Anything, entity.AnalyticsFilter{}).Return(expected, nil).Once()
+
+		result, err := svc.GetProductTypeCounts(ctx, entity.AnalyticsFilter{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result) != 1 || result[0].Products != 7 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("reception stats repository error", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, loggerMock := newService(t)
+		expectErrorLog(loggerMock, "GetReceptionStats", 6)
+
+		repoMock.On("GetReceptionStats", mock.Anything, filter).Return(nil, errors.New("db error")).Once()
+
+		if _, err := svc.GetReceptionStats(ctx, filter); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		filter entity.AnalyticsFilter
+		code   string
+	}{
+		{name: "city not allowed", filter: entity.AnalyticsFilter{City: "London"}, code: errs.ErrInvalidCity},
+		{name: "start after end", filter: entity.AnalyticsFilter{StartDate: &end, EndDate: &start}, code: errs.ErrInvalidRequestCode},
+		{name: "pvz id is not a uuid", filter: entity.AnalyticsFilter{PvzID: "pvz1"}, code: errs.ErrInvalidRequestCode},
+	}
+	for _, tc := range invalid {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			svc, _, _ := newService(t)
+
+			_, err := svc.GetReceptionsPerDay(ctx, tc.filter)
+			assertErrCode(t, err, tc.code)
+			_, err = svc.GetProductTypeCounts(ctx, tc.filter)
+			assertErrCode(t, err, tc.code)
+			_, err = svc.GetReceptionStats(ctx, tc.filter)
+			assertErrCode(t, err, tc.code)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"regexp"
+	"time"
+)
+
+// pvzStatuses — допустимые статусы ПВЗ
+var pvzStatuses = map[string]bool{
+	entity.PvzStatusActive:    true,
+	entity.PvzStatusSuspended: true,
+	entity.PvzStatusClosed:    true,
+}
+
+// pvzWeekdays — коды дней недели в расписании ПВЗ
+var pvzWeekdays = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+// openingTimeLayout — формат времени открытия и закрытия ПВЗ
+const openingTimeLayout = "15:04"
+
+const maxPvzAddressLength = 255
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9()\- ]{5,20}$`)
+
+// UpdatePvz частично обновляет данные ПВЗ: адрес, координаты, телефон, статус и часы работы.
+// Строка ПВЗ блокируется до конца транзакции, поэтому параллельные обновления разных полей
+// не теряют изменения друг друга.
+func (s *pvzServiceImp) UpdatePvz(ctx context.Context, pvzID string, update entity.PvzUpdate) (*entity.Pvz, error) {
+	if err := validateIDs(pvzID); err != nil {
+		return nil, err
+	}
+	if update.ClearCoordinates && (update.Latitude != nil || update.Longitude != nil) {
+		return nil, errs.New(errs.ErrInvalidPvzDetails, "coordinates cannot be set and cleared at the same time")
+	}
+
+	var updated *entity.Pvz
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		pvz, err := s.repo.GetPvzByIDForUpdate(txCtx, pvzID)
+		if err != nil {
+			return err
+		}
+
+		before := *pvz
+		applyPvzUpdate(pvz, update)
+		if err := validatePvzDetails(*pvz); err != nil {
+			return err
+		}
+		if err := s.repo.UpdatePvz(txCtx, *pvz); err != nil {
+			return err
+		}
+		updated = pvz
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditPvzUpdate,
+			EntityType: entity.AuditEntityPvz,
+			EntityID:   pvzID,
+			PvzID:      pvzID,
+			Before:     before,
+			After:      *pvz,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("UpdatePvz",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return nil, err
+	}
+	return updated, nil
+}
+
+func applyPvzUpdate(pvz *entity.Pvz, update entity.PvzUpdate) {
+	if update.Address != nil {
+		pvz.Address = *update.Address
+	}
+	if update.Latitude != nil {
+		pvz.Latitude = update.Latitude
+	}
+	if update.Longitude != nil {
+		pvz.Longitude = update.Longitude
+	}
+	if update.ClearCoordinates {
+		pvz.Latitude = nil
+		pvz.Longitude = nil
+	}
+	if update.Phone != nil {
+		pvz.Phone = *update.Phone
+	}
+	if update.Status != nil {
+		pvz.Status = *update.Status
+	}
+	if update.OpeningHours != nil {
+		pvz.OpeningHours = *update.OpeningHours
+	}
+}
+
+// validatePvzDetails проверяет адрес, координаты, телефон, статус и часы работы ПВЗ.
+func validatePvzDetails(pvz entity.Pvz) error {
+	if !pvzStatuses[pvz.Status] {
+		return errs.New(errs.ErrInvalidPvzDetails, fmt.Sprintf("pvz status '%s' is not allowed", pvz.Status))
+	}
+	if len(pvz.Address) > maxPvzAddressLength {
+		return errs.New(errs.ErrInvalidPvzDetails, fmt.Sprintf("address must not exceed %d characters", maxPvzAddressLength))
+	}
+	if (pvz.Latitude == nil) != (pvz.Longitude == nil) {
+		return errs.New(errs.ErrInvalidPvzDetails, "latitude and longitude must be set together")
+	}
+	if pvz.Latitude != nil && (*pvz.Latitude < -90 || *pvz.Latitude > 90) {
+		return errs.New(errs.ErrInvalidPvzDetails, "latitude must be between -90 and 90")
+	}
+	if pvz.Longitude != nil && (*pvz.Longitude < -180 || *pvz.Longitude > 180) {
+		return errs.New(errs.ErrInvalidPvzDetails, "longitude must be between -180 and 180")
+	}
+	if pvz.Phone != "" && !phonePattern.MatchString(pvz.Phone) {
+		return errs.New(errs.ErrInvalidPvzDetails, "phone must contain 5-20 digits, spaces, dashes or parentheses")
+	}
+
+	seen := make(map[string]bool, len(pvz.OpeningHours))
+	for _, h := range pvz.OpeningHours {
+		if _, ok := pvzWeekdays[h.Weekday]; !ok {
+			return errs.New(errs.ErrInvalidPvzDetails, fmt.Sprintf("unknown weekday '%s'", h.Weekday))
+		}
+		if seen[h.Weekday] {
+			return errs.New(errs.ErrInvalidPvzDetails, fmt.Sprintf("opening hours for '%s' are set twice", h.Weekday))
+		}
+		seen[h.Weekday] = true
+
+		opens, err := time.Parse(openingTimeLayout, h.Opens)
+		if err != nil {
+			return errs.New(errs.ErrInvalidPvzDetails, fmt.Sprintf("invalid opening time '%s', expected HH:MM", h.Opens))
+		}
+		closes, err := time.Parse(openingTimeLayout, h.Closes)
+		if err != nil {
+			return errs.New(errs.ErrInvalidPvzDetails, fmt.Sprintf("invalid closing time '%s', expected HH:MM", h.Closes))
+		}
+		if !opens.Before(closes) {
+			return errs.New(errs.ErrInvalidPvzDetails, fmt.Sprintf("opening time must be before closing time on '%s'", h.Weekday))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"strings"
+	"testing"
+)
+
+func TestValidatePvzDetails(t *testing.T) {
+	t.Parallel()
+
+	lat, lon, badLat := 55.75, 37.61, 91.0
+
+	tests := []struct {
+		name    string
+		pvz     entity.Pvz
+		wantErr bool
+	}{
+		{
+			name: "full details",
+			pvz: entity.Pvz{
+				Status:    entity.PvzStatusActive,
+				Address:   "ul. Tverskaya, 7",
+				Latitude:  &lat,
+				Longitude: &lon,
+				Phone:     "+7 (495) 123-45-67",
+				OpeningHours: []entity.OpeningHours{
+					{Weekday: "mon", Opens: "09:00", Closes: "21:00"},
+					{Weekday: "sun", Opens: "10:00", Closes: "18:00"},
+				},
+			},
+		},
+		{
+			name: "only status",
+			pvz:  entity.Pvz{Status: entity.PvzStatusClosed},
+		},
+		{
+			name:    "unknown status",
+			pvz:     entity.Pvz{Status: "demolished"},
+			wantErr: true,
+		},
+		{
+			name:    "latitude without longitude",
+			pvz:     entity.Pvz{Status: entity.PvzStatusActive, Latitude: &lat},
+			wantErr: true,
+		},
+		{
+			name:    "latitude out of range",
+			pvz:     entity.Pvz{Status: entity.PvzStatusActive, Latitude: &badLat, Longitude: &lon},
+			wantErr: true,
+		},
+		{
+			name:    "invalid phone",
+			pvz:     entity.Pvz{Status: entity.PvzStatusActive, Phone: "call me"},
+			wantErr: true,
+		},
+		{
+			name: "unknown weekday",
+			pvz: entity.Pvz{Status: entity.PvzStatusActive,
+				OpeningHours: []entity.OpeningHours{{Weekday: "monday", Opens: "09:00", Closes: "21:00"}}},
+			wantErr: true,
+		},
+		{
+			name: "weekday set twice",
+			pvz: entity.Pvz{Status: entity.PvzStatusActive, OpeningHours: []entity.OpeningHours{
+				{Weekday: "mon", Opens: "09:00", Closes: "13:00"},
+				{Weekday: "mon", Opens: "14:00", Closes: "21:00"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "closes before opens",
+			pvz: entity.Pvz{Status: entity.PvzStatusActive,
+				OpeningHours: []entity.OpeningHours{{Weekday: "fri", Opens: "21:00", Closes: "09:00"}}},
+			wantErr: true,
+		},
+		{
+			name: "malformed time",
+			pvz: entity.Pvz{Status: entity.PvzStatusActive,
+				OpeningHours: []entity.OpeningHours{{Weekday: "fri", Opens: "9am", Closes: "21:00"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validatePvzDetails(tc.pvz)
+			if tc.wantErr {
+				assertErrCode(t, err, errs.ErrInvalidPvzDetails)
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPvzService_UpdatePvz(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("updates only given fields", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, txManager: txManager, logger: mockLog.NewLogger(t)}
+		passThroughTx(txManager)
+
+		existing := &entity.Pvz{ID: testPvzID, City: "Moscow", Address: "old", Phone: "+7 495 000-00-00", Status: entity.PvzStatusActive}
+		repoMock.On("GetPvzByIDForUpdate", mock.Anything, testPvzID).Return(existing, nil).Once()
+		repoMock.On("UpdatePvz", mock.Anything, mock.MatchedBy(func(p entity.Pvz) bool {
+			return p.Status == entity.PvzStatusClosed && p.Address == "old" && p.Phone == "+7 495 000-00-00"
+		})).Return(nil).Once()
+		// В журнал попадают оба состояния ПВЗ
+		repoMock.On("InsertAuditEntry", mock.Anything, mock.MatchedBy(func(e entity.AuditEntry) bool {
+			return e.Action == entity.AuditPvzUpdate &&
+				strings.Contains(string(e.Before), `"status":"active"`) &&
+				strings.Contains(string(e.After), `"status":"closed"`)
+		})).Return(nil).Once()
+
+		status := entity.PvzStatusClosed
+		pvz, err := svc.UpdatePvz(ctx, testPvzID, entity.PvzUpdate{Status: &status})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pvz.Status != entity.PvzStatusClosed || pvz.City != "Moscow" {
+			t.Errorf("unexpected pvz: %+v", pvz)
+		}
+	})
+
+	t.Run("invalid details", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		loggerMock := mockLog.NewLogger(t)
+		svc := &pvzServiceImp{repo: repoMock, txManager: txManager, logger: loggerMock}
+		passThroughTx(txManager)
+		expectErrorLog(loggerMock, "UpdatePvz", 4)
+
+		repoMock.On("GetPvzByIDForUpdate", mock.Anything, testPvzID).
+			Return(&entity.Pvz{ID: testPvzID, Status: entity.PvzStatusActive}, nil).Once()
+
+		status := "demolished"
+		_, err := svc.UpdatePvz(ctx, testPvzID, entity.PvzUpdate{Status: &status})
+		assertErrCode(t, err, errs.ErrInvalidPvzDetails)
+		repoMock.AssertNotCalled(t, "UpdatePvz", mock.Anything, mock.Anything)
+	})
+
+	t.Run("clear coordinates", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, txManager: txManager, logger: mockLog.NewLogger(t)}
+		passThroughTx(txManager)
+
+		lat, lon := 55.757, 37.6136
+		repoMock.On("GetPvzByIDForUpdate", mock.Anything, testPvzID).
+			Return(&entity.Pvz{ID: testPvzID, Status: entity.PvzStatusActive, Latitude: &lat, Longitude: &lon}, nil).Once()
+		repoMock.On("UpdatePvz", mock.Anything, mock.MatchedBy(func(p entity.Pvz) bool {
+			return p.Latitude == nil && p.Longitude == nil
+		})).Return(nil).Once()
+		expectAudit(repoMock, entity.AuditPvzUpdate)
+
+		pvz, err := svc.UpdatePvz(ctx, testPvzID, entity.PvzUpdate{ClearCoordinates: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pvz.Latitude != nil || pvz.Longitude != nil {
+			t.Errorf("expected coordinates to be cleared, got %v, %v", pvz.Latitude, pvz.Longitude)
+		}
+	})
+
+	t.Run("clear and set coordinates together", func(t *testing.T) {
+		t.Parallel()
+		svc := &pvzServiceImp{repo: mockRepo.NewRepository(t), txManager: mockRepo.NewTxManager(t), logger: mockLog.NewLogger(t)}
+
+		lat := 55.757
+		_, err := svc.UpdatePvz(ctx, testPvzID, entity.PvzUpdate{Latitude: &lat, ClearCoordinates: true})
+		assertErrCode(t, err, errs.ErrInvalidPvzDetails)
+	})
+
+	t.Run("pvz not found", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		loggerMock := mockLog.NewLogger(t)
+		svc := &pvzServiceImp{repo: repoMock, txManager: txManager, logger: loggerMock}
+		passThroughTx(txManager)
+		expectErrorLog(loggerMock, "UpdatePvz", 4)
+
+		repoMock.On("GetPvzByIDForUpdate", mock.Anything, testPvzID).
+			Return(nil, errs.New(errs.ErrPvzNotFound, "pvz not found")).Once()
+
+		_, err := svc.UpdatePvz(ctx, testPvzID, entity.PvzUpdate{})
+		assertErrCode(t, err, errs.ErrPvzNotFound)
+	})
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+)
+
+// ExportPvzHistory передаёт в fn историю ПВЗ с приёмками за период и их товарами.
+// Выгрузка идёт в одной read-only транзакции REPEATABLE READ, поэтому видит согласованный
+// снимок данных, даже если длится долго. Ошибка fn (например, клиент отключился) прерывает выгрузку.
+func (s *pvzServiceImp) ExportPvzHistory(ctx context.Context, filter entity.PvzExportFilter, fn func(entity.PvzExportRow) error) error {
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return errs.New(errs.ErrInvalidRequestCode, "startDate must not be after endDate")
+	}
+
+	err := s.txManager.WithTx(ctx, pgx.RepeatableRead, pgx.ReadOnly, func(txCtx context.Context) error {
+		return s.repo.ExportPvzHistory(txCtx, filter, fn)
+	})
+	if err != nil {
+		s.logger.Errorw("ExportPvzHistory failed",
+			"error", err,
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+	"time"
+)
+
+func TestPvzService_ExportPvzHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	newService := func(t *testing.T) (*pvzServiceImp, *mockRepo.Repository, *mockRepo.TxManager, *mockLog.Logger) {
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		loggerMock := mockLog.NewLogger(t)
+		return &pvzServiceImp{
+			repo:      repoMock,
+			txManager: txManager,
+			logger:    loggerMock,
+		}, repoMock, txManager, loggerMock
+	}
+	// выгрузка должна идти в одном read-only снимке
+	snapshotTx := func(txManager *mockRepo.TxManager) {
+		var callbackErr error
+		txManager.
+			On("WithTx", mock.Anything, pgx.RepeatableRead, pgx.ReadOnly, mock.Anything).
+			Run(func(args mock.Arguments) {
+				f := args.Get(3).(func(context.Context) error)
+				callbackErr = f(context.Background())
+			}).
+			Return(func(_ context.Context, _ pgx.TxIsoLevel, _ pgx.TxAccessMode, _ func(context.Context) error) error {
+				return callbackErr
+			}).
+			Once()
+	}
+
+	t.Run("rows are passed to callback", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _ := newService(t)
+		snapshotTx(txManager)
+
+		filter := entity.PvzExportFilter{StartDate: &start, EndDate: &end}
+		rows := []entity.PvzExportRow{{PvzID: "pvz1"}, {PvzID: "pvz2"}}
+		repoMock.On("ExportPvzHistory", mock.Anything, filter, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(2).(func(entity.PvzExportRow) error)
+				for _, row := range rows {
+					if err := fn(row); err != nil {
+						t.Fatalf("unexpected callback error: %v", err)
+					}
+				}
+			}).
+			Return(nil).
+			Once()
+
+		var got []string
+		err := svc.ExportPvzHistory(ctx, filter, func(row entity.PvzExportRow) error {
+			got = append(got, row.PvzID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 2 || got[0] != "pvz1" || got[1] != "pvz2" {
+			t.Errorf("unexpected rows: %v", got)
+		}
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _ := newService(t)
+
+		err := svc.ExportPvzHistory(ctx, entity.PvzExportFilter{StartDate: &end, EndDate: &start}, func(entity.PvzExportRow) error {
+			return nil
+		})
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, loggerMock := newService(t)
+		snapshotTx(txManager)
+
+		repoMock.On("ExportPvzHistory", mock.Anything, entity.PvzExportFilter{}, mock.Anything).
+			Return(errs.Wrap(errors.New("db error"), errs.ErrInternalCode, "failed to fetch pvz export rows")).
+			Once()
+		expectErrorLog(loggerMock, "ExportPvzHistory failed", 2)
+
+		err := svc.ExportPvzHistory(ctx, entity.PvzExportFilter{}, func(entity.PvzExportRow) error {
+			return nil
+		})
+		assertErrCode(t, err, errs.ErrInternalCode)
+	})
+}
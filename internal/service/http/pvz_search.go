@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSearchRadiusMeters = 5000
+	maxSearchRadiusMeters     = 100000
+	defaultNearbyLimit        = 20
+	maxNearbyLimit            = 100
+)
+
+// pvzLocation — часовой пояс расписания ПВЗ. Все допустимые города живут по московскому
+// времени без перехода на летнее, поэтому достаточно фиксированной зоны UTC+3.
+var pvzLocation = time.FixedZone("MSK", 3*60*60)
+
+// SearchNearbyPvzs ищет ПВЗ в радиусе от точки и сортирует их по расстоянию.
+func (s *pvzServiceImp) SearchNearbyPvzs(ctx context.Context, filter entity.NearbyPvzFilter) ([]entity.NearbyPvz, error) {
+	if filter.RadiusMeters == 0 {
+		filter.RadiusMeters = defaultSearchRadiusMeters
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultNearbyLimit
+	}
+	if err := s.validateNearbyFilter(filter); err != nil {
+		return nil, err
+	}
+
+	pvzs, err := s.repo.FindNearbyPvzs(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("SearchNearbyPvzs",
+			"error", err,
+			"latitude", filter.Latitude,
+			"longitude", filter.Longitude,
+		)
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range pvzs {
+		pvzs[i].OpenNow = isPvzOpenAt(pvzs[i].Pvz, now)
+	}
+	return pvzs, nil
+}
+
+func (s *pvzServiceImp) validateNearbyFilter(filter entity.NearbyPvzFilter) error {
+	if filter.Latitude < -90 || filter.Latitude > 90 {
+		return errs.New(errs.ErrInvalidRequestCode, "latitude must be between -90 and 90")
+	}
+	if filter.Longitude < -180 || filter.Longitude > 180 {
+		return errs.New(errs.ErrInvalidRequestCode, "longitude must be between -180 and 180")
+	}
+	if filter.RadiusMeters < 0 || filter.RadiusMeters > maxSearchRadiusMeters {
+		return errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("radius must be between 1 and %d meters", maxSearchRadiusMeters))
+	}
+	if filter.Limit < 0 || filter.Limit > maxNearbyLimit {
+		return errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("limit must be between 1 and %d", maxNearbyLimit))
+	}
+	if filter.City != "" && !s.allowedCities[strings.ToLower(filter.City)] {
+		return errs.New(errs.ErrInvalidCity, fmt.Sprintf("city '%s' is not allowed", filter.City))
+	}
+	if filter.Status != "" && !pvzStatuses[filter.Status] {
+		return errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("pvz status '%s' is not allowed", filter.Status))
+	}
+	return nil
+}
+
+// isPvzOpenAt сообщает, работает ли ПВЗ в момент t по своему расписанию.
+// Приостановленные и закрытые ПВЗ, а также дни без записи в расписании считаются нерабочими.
+func isPvzOpenAt(pvz entity.Pvz, t time.Time) bool {
+	if pvz.Status != entity.PvzStatusActive {
+		return false
+	}
+
+	local := t.In(pvzLocation)
+	// time.Parse с layout HH:MM даёт дату 0000-01-01 UTC, к ней приводится и текущее время
+	current := time.Date(0, time.January, 1, local.Hour(), local.Minute(), 0, 0, time.UTC)
+	for _, h := range pvz.OpeningHours {
+		if day, ok := pvzWeekdays[h.Weekday]; !ok || day != local.Weekday() {
+			continue
+		}
+		opens, err := time.Parse(openingTimeLayout, h.Opens)
+		if err != nil {
+			continue
+		}
+		closes, err := time.Parse(openingTimeLayout, h.Closes)
+		if err != nil {
+			continue
+		}
+		if !current.Before(opens) && current.Before(closes) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+	"time"
+)
+
+func TestIsPvzOpenAt(t *testing.T) {
+	t.Parallel()
+
+	hours := []entity.OpeningHours{
+		{Weekday: "mon", Opens: "09:00", Closes: "21:00"},
+		{Weekday: "sat", Opens: "10:00", Closes: "16:00"},
+	}
+	// 2025-04-14 — понедельник; время задано в UTC, расписание — по Москве (UTC+3)
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2025, time.April, 14, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		status   string
+		at       time.Time
+		expected bool
+	}{
+		{name: "open in the middle of the day", status: entity.PvzStatusActive, at: monday(10, 0), expected: true},
+		{name: "opening minute", status: entity.PvzStatusActive, at: monday(6, 0), expected: true},
+		{name: "closing minute", status: entity.PvzStatusActive, at: monday(18, 0), expected: false},
+		{name: "before opening", status: entity.PvzStatusActive, at: monday(5, 59), expected: false},
+		{name: "day off", status: entity.PvzStatusActive, at: monday(10, 0).AddDate(0, 0, 1), expected: false},
+		{name: "saturday", status: entity.PvzStatusActive, at: monday(8, 0).AddDate(0, 0, 5), expected: true},
+		{name: "suspended", status: entity.PvzStatusSuspended, at: monday(10, 0), expected: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			pvz := entity.Pvz{Status: tc.status, OpeningHours: hours}
+			if got := isPvzOpenAt(pvz, tc.at); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPvzService_SearchNearbyPvzs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newService := func(t *testing.T) (*pvzServiceImp, *mockRepo.Repository, *mockLog.Logger) {
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		return &pvzServiceImp{
+			repo:          repoMock,
+			logger:        loggerMock,
+			allowedCities: map[string]bool{"moscow": true},
+		}, repoMock, loggerMock
+	}
+
+	t.Run("applies defaults", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _ := newService(t)
+
+		expectedFilter := entity.NearbyPvzFilter{
+			Latitude:     55.75,
+			Longitude:    37.61,
+			RadiusMeters: defaultSearchRadiusMeters,
+			City:         "Moscow",
+			Limit:        defaultNearbyLimit,
+		}
+		closed := entity.Pvz{ID: testPvzID, Status: entity.PvzStatusClosed}
+		repoMock.On("FindNearbyPvzs", mock.Anything, expectedFilter).
+			Return([]entity.NearbyPvz{{Pvz: closed, DistanceMeters: 120}}, nil).Once()
+
+		result, err := svc.SearchNearbyPvzs(ctx, entity.NearbyPvzFilter{Latitude: 55.75, Longitude: 37.61, City: "Moscow"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result) != 1 || result[0].DistanceMeters != 120 || result[0].OpenNow {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		filter entity.NearbyPvzFilter
+		code   string
+	}{
+		{name: "latitude out of range", filter: entity.NearbyPvzFilter{Latitude: 91}, code: errs.ErrInvalidRequestCode},
+		{name: "radius too large", filter: entity.NearbyPvzFilter{RadiusMeters: maxSearchRadiusMeters + 1}, code: errs.ErrInvalidRequestCode},
+		{name: "limit too large", filter: entity.NearbyPvzFilter{Limit: maxNearbyLimit + 1}, code: errs.ErrInvalidRequestCode},
+		{name: "unknown status", filter: entity.NearbyPvzFilter{Status: "demolished"}, code: errs.ErrInvalidRequestCode},
+		{name: "city not allowed", filter: entity.NearbyPvzFilter{City: "London"}, code: errs.ErrInvalidCity},
+	}
+	for _, tc := range invalid {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			svc, _, _ := newService(t)
+
+			_, err := svc.SearchNearbyPvzs(ctx, tc.filter)
+			assertErrCode(t, err, tc.code)
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, loggerMock := newService(t)
+		expectErrorLog(loggerMock, "SearchNearbyPvzs", 6)
+
+		repoMock.On("FindNearbyPvzs", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+
+		if _, err := svc.SearchNearbyPvzs(ctx, entity.NearbyPvzFilter{}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -6,63 +6,126 @@ import (
 	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"order-pick-up-point/internal/activity"
 	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/metrics"
 	"order-pick-up-point/internal/models/entity"
 	"order-pick-up-point/internal/storage/db"
 	"order-pick-up-point/pkg/logger"
+	"regexp"
 	"strings"
 	"time"
 )
 
 type PvzService interface {
-	CreatePvz(ctx context.Context, city string) (string, error)
-	GetPvzsInfo(ctx context.Context, page, limit int, startDate, endDate *time.Time) ([]entity.PvzInfo, error)
-	CreateReception(ctx context.Context, pvzID string, dateTime time.Time) (string, error)
-	AddProduct(ctx context.Context, pvzID string, productType string) (string, error)
-	DeleteLastProduct(ctx context.Context, pvzID string) error
-	CloseReception(ctx context.Context, pvzID string) (string, error)
-	GetPvzsInfoOptimized(ctx context.Context, page, limit int, startDate, endDate *time.Time) ([]entity.PvzInfo, error)
+	CreatePvz(ctx context.Context, pvz entity.Pvz) (string, error)
+	UpdatePvz(ctx context.Context, pvzID string, update entity.PvzUpdate) (*entity.Pvz, error)
+	SearchNearbyPvzs(ctx context.Context, filter entity.NearbyPvzFilter) ([]entity.NearbyPvz, error)
+	GetPvzsInfo(ctx context.Context, page entity.PvzPageRequest, startDate, endDate *time.Time) (*entity.PvzInfoPage, error)
+	CreateReception(ctx context.Context, pvzID string, dateTime time.Time, employeeID string) (string, error)
+	AddProduct(ctx context.Context, pvzID, productType, barcode string) (string, error)
+	DeleteLastProduct(ctx context.Context, pvzID string) (*entity.Product, error)
+	AddProductsBatch(ctx context.Context, pvzID string, items []entity.ProductBatchItem, atomic bool) ([]entity.ProductBatchResult, error)
+	CloseReception(ctx context.Context, pvzID, employeeID string) (string, error)
+	GetPvzsInfoOptimized(ctx context.Context, page entity.PvzPageRequest, startDate, endDate *time.Time) (*entity.PvzInfoPage, error)
+	ExportPvzHistory(ctx context.Context, filter entity.PvzExportFilter, fn func(entity.PvzExportRow) error) error
+	StreamPvzActivity(ctx context.Context, filter entity.PvzActivityFilter, ready func() error, fn func(entity.OutboxEvent) error) error
+
+	GetReceptionsPerDay(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.DailyReceptions, error)
+	GetProductTypeCounts(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductTypeCount, error)
+	GetReceptionStats(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionStats, error)
+
+	PrepareOrder(ctx context.Context, pvzID, productID, recipientID string) (*entity.Order, error)
+	GetOrdersForPickup(ctx context.Context, pvzID, recipientID string) ([]entity.Order, error)
+	IssueOrder(ctx context.Context, pvzID, productID, pickupCode, employeeID string) (*entity.Order, error)
+	ReturnOrder(ctx context.Context, pvzID, productID string) (*entity.Order, error)
+
+	GetMyOrders(ctx context.Context, userID, status string) ([]entity.Order, error)
+	GetMyOrder(ctx context.Context, userID, productID string) (*entity.Order, error)
+	RenewMyPickupCode(ctx context.Context, userID, productID string) (*entity.Order, error)
+	GetOverdueOrders(ctx context.Context, pvzID string) ([]entity.Order, error)
+	FindProductByBarcode(ctx context.Context, barcode string) (*entity.Order, error)
+
+	CreateReturn(ctx context.Context, pvzID string) (string, error)
+	AddReturnItem(ctx context.Context, pvzID, productID, reason string) (*entity.ReturnItem, error)
+	DeleteLastReturnItem(ctx context.Context, pvzID string) error
+	CloseReturn(ctx context.Context, pvzID string) (string, error)
 }
 
+// barcodePattern — допустимый формат штрихкода / трек-номера товара
+var barcodePattern = regexp.MustCompile(`^[A-Za-z0-9-]{1,64}$`)
+
 type pvzServiceImp struct {
 	repo                db.Repository
 	txManager           db.TxManager
 	logger              logger.Logger
 	allowedCities       map[string]bool
 	allowedProductTypes map[string]bool
+	storagePeriods      map[string]int
+	activity            *activity.Hub
 }
 
-func NewPvzService(repo db.Repository, txManager db.TxManager, logger logger.Logger, cities, productTypes map[string]bool) PvzService {
+func NewPvzService(
+	repo db.Repository,
+	txManager db.TxManager,
+	logger logger.Logger,
+	cities, productTypes map[string]bool,
+	storagePeriods map[string]int,
+	activityHub *activity.Hub,
+) PvzService {
 	return &pvzServiceImp{
 		repo:                repo,
 		txManager:           txManager,
 		logger:              logger,
 		allowedCities:       cities,
 		allowedProductTypes: productTypes,
+		storagePeriods:      storagePeriods,
+		activity:            activityHub,
 	}
 }
 
-func (s *pvzServiceImp) CreatePvz(ctx context.Context, city string) (string, error) {
+func (s *pvzServiceImp) CreatePvz(ctx context.Context, pvz entity.Pvz) (string, error) {
 	ctx, span := otel.Tracer("pvzService").Start(ctx, "CreatePvzService")
 	defer span.End()
 
-	span.SetAttributes(attribute.String("city", city))
+	span.SetAttributes(attribute.String("city", pvz.City))
 
-	if !s.allowedCities[strings.ToLower(city)] {
-		return "", errs.New(errs.ErrInvalidCity, fmt.Sprintf("city '%s' is not allowed", city))
+	if !s.allowedCities[strings.ToLower(pvz.City)] {
+		return "", errs.New(errs.ErrInvalidCity, fmt.Sprintf("city '%s' is not allowed", pvz.City))
 	}
-
-	pvz := entity.Pvz{
-		City:             city,
-		RegistrationDate: time.Now(),
+	if pvz.Status == "" {
+		pvz.Status = entity.PvzStatusActive
+	}
+	if err := validatePvzDetails(pvz); err != nil {
+		return "", err
 	}
 
-	pvzID, err := s.repo.CreatePvz(ctx, pvz)
+	pvz.ID = ""
+	pvz.RegistrationDate = time.Now()
+
+	var pvzID string
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		id, err := s.repo.CreatePvz(txCtx, pvz)
+		if err != nil {
+			return err
+		}
+		pvz.ID = id
+		pvzID = id
+		if err := recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditPvzCreate,
+			EntityType: entity.AuditEntityPvz,
+			EntityID:   id,
+			PvzID:      id,
+			After:      pvz,
+		}); err != nil {
+			return err
+		}
+		return emitEvent(txCtx, s.repo, entity.EventPvzCreated, id, id, pvz)
+	})
 	if err != nil {
 		s.logger.Errorw("CreatePvz",
 			"error", err,
-			"city", city,
+			"city", pvz.City,
 		)
 		return "", err
 	}
@@ -70,16 +133,20 @@ func (s *pvzServiceImp) CreatePvz(ctx context.Context, city string) (string, err
 	return pvzID, nil
 }
 
-func (s *pvzServiceImp) GetPvzsInfo(ctx context.Context, page, limit int, startDate, endDate *time.Time) ([]entity.PvzInfo, error) {
+func (s *pvzServiceImp) GetPvzsInfo(ctx context.Context, page entity.PvzPageRequest, startDate, endDate *time.Time) (*entity.PvzInfoPage, error) {
+	if err := validatePvzPage(page); err != nil {
+		return nil, err
+	}
+
 	var pvzsInfo []entity.PvzInfo
 
 	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadOnly, func(txCtx context.Context) error {
-		pvzs, err := s.repo.GetPvzs(txCtx, page, limit)
+		pvzs, err := s.repo.GetPvzs(txCtx, pvzPageLookahead(page))
 		if err != nil {
 			s.logger.Errorw("get PVZs",
 				"error", err,
-				"page", page,
-				"limit", limit,
+				"offset", page.Offset,
+				"limit", page.Limit,
 			)
 			return errs.Wrap(err, errs.ErrInternalCode, "failed to get pvzs")
 		}
@@ -126,12 +193,21 @@ func (s *pvzServiceImp) GetPvzsInfo(ctx context.Context, page, limit int, startD
 		return nil, err
 	}
 
-	return pvzsInfo, nil
+	return newPvzInfoPage(pvzsInfo, page.Limit), nil
 }
 
-func (s *pvzServiceImp) CreateReception(ctx context.Context, pvzID string, dateTime time.Time) (string, error) {
+// CreateReception открывает приёмку в ПВЗ от имени сотрудника employeeID.
+func (s *pvzServiceImp) CreateReception(ctx context.Context, pvzID string, dateTime time.Time, employeeID string) (string, error) {
 	var receptionID string
 	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		pvz, err := s.repo.GetPvzByID(txCtx, pvzID)
+		if err != nil {
+			return err
+		}
+		if pvz.Status == entity.PvzStatusClosed {
+			return errs.New(errs.ErrPvzClosed, "pvz is closed and does not accept new receptions")
+		}
+
 		existingReception, err := s.repo.FindOpenReceptionByPvzID(txCtx, pvzID)
 		if err == nil && existingReception != nil {
 			return errs.New(errs.ErrOpenReceptionExists, "open reception already exists")
@@ -145,6 +221,7 @@ func (s *pvzServiceImp) CreateReception(ctx context.Context, pvzID string, dateT
 			PvzID:    pvzID,
 			DateTime: dateTime,
 			Status:   "in_progress",
+			OpenedBy: actorID(employeeID),
 		}
 
 		id, err := s.repo.CreateReception(txCtx, rec)
@@ -152,7 +229,17 @@ func (s *pvzServiceImp) CreateReception(ctx context.Context, pvzID string, dateT
 			return err
 		}
 		receptionID = id
-		return nil
+		rec.ID = id
+		if err := recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditReceptionCreate,
+			EntityType: entity.AuditEntityReception,
+			EntityID:   id,
+			PvzID:      pvzID,
+			After:      rec,
+		}); err != nil {
+			return err
+		}
+		return emitEvent(txCtx, s.repo, entity.EventReceptionOpened, id, pvzID, rec)
 	})
 	if err != nil {
 		s.logger.Errorw("CreateReception",
@@ -166,10 +253,13 @@ func (s *pvzServiceImp) CreateReception(ctx context.Context, pvzID string, dateT
 	return receptionID, nil
 }
 
-func (s *pvzServiceImp) AddProduct(ctx context.Context, pvzID string, productType string) (string, error) {
+func (s *pvzServiceImp) AddProduct(ctx context.Context, pvzID, productType, barcode string) (string, error) {
 	if !s.allowedProductTypes[strings.ToLower(productType)] {
 		return "", errs.New(errs.ErrInvalidProductType, fmt.Sprintf("product type '%s' is not allowed", productType))
 	}
+	if barcode != "" && !barcodePattern.MatchString(barcode) {
+		return "", errs.New(errs.ErrInvalidBarcode, "barcode must be 1-64 latin letters, digits or dashes")
+	}
 
 	var productID string
 	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
@@ -181,23 +271,38 @@ func (s *pvzServiceImp) AddProduct(ctx context.Context, pvzID string, productTyp
 			return errs.New(errs.ErrNoOpenReception, "no open reception found for this PVZ")
 		}
 
+		now := time.Now()
+		expiresAt := now.Add(s.storagePeriod(productType))
 		product := entity.Product{
 			ReceptionID: reception.ID,
 			Type:        productType,
-			DateTime:    time.Now(),
+			Barcode:     barcode,
+			DateTime:    now,
+			ExpiresAt:   &expiresAt,
 		}
 		id, err := s.repo.CreateProduct(txCtx, product)
 		if err != nil {
 			return err
 		}
 		productID = id
-		return nil
+		product.ID = id
+		if err := recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditProductCreate,
+			EntityType: entity.AuditEntityProduct,
+			EntityID:   id,
+			PvzID:      pvzID,
+			After:      product,
+		}); err != nil {
+			return err
+		}
+		return emitEvent(txCtx, s.repo, entity.EventProductAdded, id, pvzID, product)
 	})
 	if err != nil {
 		s.logger.Errorw("AddProduct",
 			"error", err,
 			"pvzID", pvzID,
 			"productType", productType,
+			"barcode", barcode,
 		)
 		return "", err
 	}
@@ -206,7 +311,10 @@ func (s *pvzServiceImp) AddProduct(ctx context.Context, pvzID string, productTyp
 	return productID, nil
 }
 
-func (s *pvzServiceImp) DeleteLastProduct(ctx context.Context, pvzID string) error {
+// DeleteLastProduct удаляет последний товар открытой приёмки и возвращает его,
+// чтобы сотрудник видел, какой штрихкод был снят.
+func (s *pvzServiceImp) DeleteLastProduct(ctx context.Context, pvzID string) (*entity.Product, error) {
+	var deleted *entity.Product
 	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
 		product, err := s.repo.FindLastProductInReception(txCtx, pvzID)
 		if err != nil {
@@ -215,19 +323,33 @@ func (s *pvzServiceImp) DeleteLastProduct(ctx context.Context, pvzID string) err
 		if product == nil {
 			return errs.New(errs.ErrNoProductsToDelete, "no product found to delete")
 		}
-		return s.repo.DeleteProduct(txCtx, product.ID)
+		if err := s.repo.DeleteProduct(txCtx, product.ID); err != nil {
+			return err
+		}
+		deleted = product
+		if err := recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditProductDelete,
+			EntityType: entity.AuditEntityProduct,
+			EntityID:   product.ID,
+			PvzID:      pvzID,
+			Before:     *product,
+		}); err != nil {
+			return err
+		}
+		return emitEvent(txCtx, s.repo, entity.EventProductRemoved, product.ID, pvzID, *product)
 	})
 	if err != nil {
 		s.logger.Errorw("DeleteLastProduct",
 			"error", err,
 			"pvzID", pvzID,
 		)
-		return err
+		return nil, err
 	}
-	return nil
+	return deleted, nil
 }
 
-func (s *pvzServiceImp) CloseReception(ctx context.Context, pvzID string) (string, error) {
+// CloseReception закрывает открытую приёмку ПВЗ от имени сотрудника employeeID.
+func (s *pvzServiceImp) CloseReception(ctx context.Context, pvzID, employeeID string) (string, error) {
 	var recID string
 	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
 		reception, err := s.repo.FindOpenReceptionByPvzID(txCtx, pvzID)
@@ -237,11 +359,26 @@ func (s *pvzServiceImp) CloseReception(ctx context.Context, pvzID string) (strin
 		if reception == nil {
 			return errs.New(errs.ErrReceptionNotFound, "no open reception found for this PVZ")
 		}
-		if err := s.repo.UpdateReceptionStatus(txCtx, reception.ID, "close"); err != nil {
+		closedBy := actorID(employeeID)
+		if err := s.repo.CloseReception(txCtx, reception.ID, closedBy); err != nil {
 			return err
 		}
 		recID = reception.ID
-		return nil
+
+		closed := *reception
+		closed.Status = "close"
+		closed.ClosedBy = closedBy
+		if err := recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditReceptionClose,
+			EntityType: entity.AuditEntityReception,
+			EntityID:   reception.ID,
+			PvzID:      pvzID,
+			Before:     *reception,
+			After:      closed,
+		}); err != nil {
+			return err
+		}
+		return emitEvent(txCtx, s.repo, entity.EventReceptionClosed, reception.ID, pvzID, closed)
 	})
 	if err != nil {
 		s.logger.Errorw("CloseReception",
@@ -255,17 +392,50 @@ func (s *pvzServiceImp) CloseReception(ctx context.Context, pvzID string) (strin
 
 func (s *pvzServiceImp) GetPvzsInfoOptimized(
 	ctx context.Context,
-	page, limit int,
+	page entity.PvzPageRequest,
 	startDate, endDate *time.Time,
-) ([]entity.PvzInfo, error) {
-	pvzs, err := s.repo.GetPvzsWithReceptionsAndProducts(ctx, page, limit, startDate, endDate)
+) (*entity.PvzInfoPage, error) {
+	if err := validatePvzPage(page); err != nil {
+		return nil, err
+	}
+
+	pvzs, err := s.repo.GetPvzsWithReceptionsAndProducts(ctx, pvzPageLookahead(page), startDate, endDate)
 	if err != nil {
 		s.logger.Errorw("GetPvzsInfoOptimized failed",
 			"error", err,
-			"page", page,
-			"limit", limit,
+			"offset", page.Offset,
+			"limit", page.Limit,
 		)
 		return nil, err
 	}
-	return pvzs, nil
+	return newPvzInfoPage(pvzs, page.Limit), nil
+}
+
+func validatePvzPage(page entity.PvzPageRequest) error {
+	if page.Limit <= 0 || page.Offset < 0 {
+		return errs.New(errs.ErrInvalidRequestCode, "limit must be positive and offset must not be negative")
+	}
+	return nil
+}
+
+// pvzPageLookahead запрашивает на один ПВЗ больше размера страницы, чтобы узнать, есть ли следующая.
+func pvzPageLookahead(page entity.PvzPageRequest) entity.PvzPageRequest {
+	page.Limit++
+	return page
+}
+
+// newPvzInfoPage отрезает лишний ПВЗ, прочитанный pvzPageLookahead, и запоминает ключ
+// последнего ПВЗ страницы как начало следующей.
+func newPvzInfoPage(items []entity.PvzInfo, limit int) *entity.PvzInfoPage {
+	page := &entity.PvzInfoPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+		page.NextCursor = pvzCursorOf(page.Items[limit-1].Pvz)
+	}
+	return page
+}
+
+func pvzCursorOf(pvz entity.Pvz) *entity.PvzCursor {
+	return &entity.PvzCursor{RegistrationDate: pvz.RegistrationDate, ID: pvz.ID}
 }
@@ -10,6 +10,7 @@ import (
 	"order-pick-up-point/internal/models/entity"
 	mockRepo "order-pick-up-point/internal/storage/db/mock"
 	mockLog "order-pick-up-point/pkg/logger/mock"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -71,9 +72,11 @@ func TestPvzService_CreatePvz(t *testing.T) {
 			ctx := context.Background()
 
 			if tc.allowedCities[strings.ToLower(tc.city)] {
+				passThroughTx(txManager)
 				repoMock.
-					On("CreatePvz", ctx, mock.MatchedBy(func(pvz entity.Pvz) bool {
+					On("CreatePvz", mock.Anything, mock.MatchedBy(func(pvz entity.Pvz) bool {
 						return strings.ToLower(pvz.City) == strings.ToLower(tc.city) &&
+							pvz.Status == entity.PvzStatusActive &&
 							time.Since(pvz.RegistrationDate) < 5*time.Second
 					})).
 					Return(func(_ context.Context, _ entity.Pvz) string {
@@ -85,10 +88,19 @@ func TestPvzService_CreatePvz(t *testing.T) {
 					loggerMock.
 						On("Errorw", "CreatePvz", "error", tc.repoReturnErr, "city", tc.city).
 						Once()
+				} else {
+					repoMock.
+						On("InsertAuditEntry", mock.Anything, mock.MatchedBy(func(e entity.AuditEntry) bool {
+							return e.Action == entity.AuditPvzCreate && e.EntityID == tc.expectedPvzID &&
+								e.PvzID != nil && *e.PvzID == tc.expectedPvzID && e.Before == nil
+						})).
+						Return(nil).
+						Once()
+					expectEvent(repoMock, entity.EventPvzCreated, tc.expectedPvzID)
 				}
 			}
 
-			pvzID, err := svc.CreatePvz(ctx, tc.city)
+			pvzID, err := svc.CreatePvz(ctx, entity.Pvz{City: tc.city})
 
 			if tc.expectedErrMsg != "" {
 				if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
@@ -117,8 +129,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 
 	tests := []struct {
 		name            string
-		page            int
-		limit           int
+		page            entity.PvzPageRequest
 		startDate       *time.Time
 		endDate         *time.Time
 		simulateError   string
@@ -127,8 +138,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 	}{
 		{
 			name:           "tx error",
-			page:           1,
-			limit:          10,
+			page:           entity.PvzPageRequest{Limit: 10},
 			startDate:      &startDate,
 			endDate:        &endDate,
 			simulateError:  "tx",
@@ -136,8 +146,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 		},
 		{
 			name:           "error in GetPvzs",
-			page:           1,
-			limit:          10,
+			page:           entity.PvzPageRequest{Limit: 10},
 			startDate:      &startDate,
 			endDate:        &endDate,
 			simulateError:  "pvzs",
@@ -145,8 +154,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 		},
 		{
 			name:           "error in GetReceptionsByPvzIDFiltered",
-			page:           1,
-			limit:          10,
+			page:           entity.PvzPageRequest{Limit: 10},
 			startDate:      &startDate,
 			endDate:        &endDate,
 			simulateError:  "receptions",
@@ -154,8 +162,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 		},
 		{
 			name:           "error in GetProductsByReceptionID",
-			page:           1,
-			limit:          10,
+			page:           entity.PvzPageRequest{Limit: 10},
 			startDate:      &startDate,
 			endDate:        &endDate,
 			simulateError:  "products",
@@ -163,8 +170,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 		},
 		{
 			name:          "success",
-			page:          1,
-			limit:         10,
+			page:          entity.PvzPageRequest{Limit: 10},
 			startDate:     &startDate,
 			endDate:       &endDate,
 			simulateError: "",
@@ -225,18 +231,18 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 				switch tc.simulateError {
 				case "pvzs":
 					repoMock.
-						On("GetPvzs", ctx, tc.page, tc.limit).
+						On("GetPvzs", ctx, pvzPageLookahead(tc.page)).
 						Return(nil, errors.New("pvzs error")).
 						Once()
 					loggerMock.
 						On("Errorw", "get PVZs", "error", mock.MatchedBy(func(err error) bool {
 							return strings.Contains(err.Error(), "pvzs error")
-						}), "page", tc.page, "limit", tc.limit).
+						}), "offset", tc.page.Offset, "limit", tc.page.Limit).
 						Return().
 						Once()
 				case "receptions":
 					repoMock.
-						On("GetPvzs", ctx, tc.page, tc.limit).
+						On("GetPvzs", ctx, pvzPageLookahead(tc.page)).
 						Return([]entity.Pvz{pvz}, nil).
 						Once()
 					repoMock.
@@ -251,7 +257,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 						Once()
 				case "products":
 					repoMock.
-						On("GetPvzs", ctx, tc.page, tc.limit).
+						On("GetPvzs", ctx, pvzPageLookahead(tc.page)).
 						Return([]entity.Pvz{pvz}, nil).
 						Once()
 					repoMock.
@@ -270,7 +276,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 						Once()
 				case "":
 					repoMock.
-						On("GetPvzs", ctx, tc.page, tc.limit).
+						On("GetPvzs", ctx, pvzPageLookahead(tc.page)).
 						Return([]entity.Pvz{pvz}, nil).
 						Once()
 					repoMock.
@@ -293,7 +299,7 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 				}
 			}
 
-			result, err := svc.GetPvzsInfo(ctx, tc.page, tc.limit, tc.startDate, tc.endDate)
+			result, err := svc.GetPvzsInfo(ctx, tc.page, tc.startDate, tc.endDate)
 
 			if tc.expectedErrMsg != "" {
 				if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
@@ -304,11 +310,11 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 			}
 
 			if tc.expectedErrMsg == "" {
-				if len(result) != len(tc.expectedResults) {
-					t.Fatalf("expected %d pvzInfo, got %d", len(tc.expectedResults), len(result))
+				if len(result.Items) != len(tc.expectedResults) {
+					t.Fatalf("expected %d pvzInfo, got %d", len(tc.expectedResults), len(result.Items))
 				}
 				for i, exp := range tc.expectedResults {
-					res := result[i]
+					res := result.Items[i]
 					if res.Pvz.ID != exp.Pvz.ID {
 						t.Errorf("expected pvz ID %q, got %q", exp.Pvz.ID, res.Pvz.ID)
 					}
@@ -332,6 +338,45 @@ func TestPvzService_GetPvzsInfo(t *testing.T) {
 	}
 }
 
+func TestPvzService_GetPvzsInfo_InvalidPage(t *testing.T) {
+	t.Parallel()
+
+	svc := &pvzServiceImp{}
+	ctx := context.Background()
+
+	for _, page := range []entity.PvzPageRequest{{Limit: 0}, {Limit: 10, Offset: -1}} {
+		_, err := svc.GetPvzsInfo(ctx, page, nil, nil)
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+
+		_, err = svc.GetPvzsInfoOptimized(ctx, page, nil, nil)
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+	}
+}
+
+func TestNewPvzInfoPage(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	items := []entity.PvzInfo{
+		{Pvz: entity.Pvz{ID: "pvz3", RegistrationDate: now}},
+		{Pvz: entity.Pvz{ID: "pvz2", RegistrationDate: now.Add(-time.Hour)}},
+		{Pvz: entity.Pvz{ID: "pvz1", RegistrationDate: now.Add(-2 * time.Hour)}},
+	}
+
+	page := newPvzInfoPage(items, 2)
+	if len(page.Items) != 2 || !page.HasMore {
+		t.Fatalf("expected 2 items and more pages, got %d items, hasMore=%v", len(page.Items), page.HasMore)
+	}
+	if page.NextCursor == nil || page.NextCursor.ID != "pvz2" || !page.NextCursor.RegistrationDate.Equal(items[1].Pvz.RegistrationDate) {
+		t.Errorf("expected cursor of pvz2, got %+v", page.NextCursor)
+	}
+
+	page = newPvzInfoPage(items, 3)
+	if len(page.Items) != 3 || page.HasMore || page.NextCursor != nil {
+		t.Errorf("expected last page with 3 items, got %d items, hasMore=%v, cursor=%+v", len(page.Items), page.HasMore, page.NextCursor)
+	}
+}
+
 func TestPvzService_CreateReception(t *testing.T) {
 	t.Parallel()
 
@@ -340,10 +385,13 @@ func TestPvzService_CreateReception(t *testing.T) {
 	fakeReceptionID := "rec456"
 
 	notFoundErr := &errs.AppError{Code: errs.ErrNoOpenReception, Message: "open reception not found"}
+	employee := testEmployee
 
 	tests := []struct {
 		name           string
 		simulateError  string
+		employeeID     string
+		openedBy       *string
 		expectedErrMsg string
 	}{
 		{
@@ -351,6 +399,11 @@ func TestPvzService_CreateReception(t *testing.T) {
 			simulateError:  "tx",
 			expectedErrMsg: "tx error",
 		},
+		{
+			name:           "pvz closed",
+			simulateError:  "closed",
+			expectedErrMsg: "pvz is closed",
+		},
 		{
 			name:           "open reception exists",
 			simulateError:  "exists",
@@ -369,6 +422,13 @@ func TestPvzService_CreateReception(t *testing.T) {
 		{
 			name:          "success",
 			simulateError: "",
+			employeeID:    testEmployee,
+			openedBy:      &employee,
+		},
+		{
+			name:          "success with dummy token",
+			simulateError: "",
+			employeeID:    "dummyID",
 		},
 	}
 
@@ -413,6 +473,15 @@ func TestPvzService_CreateReception(t *testing.T) {
 					}).
 					Once()
 
+				pvz := &entity.Pvz{ID: pvzID, Status: entity.PvzStatusActive}
+				if tc.simulateError == "closed" {
+					pvz.Status = entity.PvzStatusClosed
+				}
+				repoMock.
+					On("GetPvzByID", mock.Anything, pvzID).
+					Return(pvz, nil).
+					Once()
+
 				switch tc.simulateError {
 				case "exists":
 					// Симулируем, что открытая приёмка уже существует
@@ -448,10 +517,13 @@ func TestPvzService_CreateReception(t *testing.T) {
 						Once()
 					repoMock.
 						On("CreateReception", mock.Anything, mock.MatchedBy(func(r entity.Reception) bool {
-							return r.PvzID == pvzID && r.Status == "in_progress" && r.DateTime.Equal(now)
+							return r.PvzID == pvzID && r.Status == "in_progress" && r.DateTime.Equal(now) &&
+								reflect.DeepEqual(r.OpenedBy, tc.openedBy)
 						})).
 						Return(fakeReceptionID, nil).
 						Once()
+					expectAudit(repoMock, entity.AuditReceptionCreate)
+					expectEvent(repoMock, entity.EventReceptionOpened, fakeReceptionID)
 				}
 
 				if tc.expectedErrMsg != "" {
@@ -464,7 +536,7 @@ func TestPvzService_CreateReception(t *testing.T) {
 				}
 			}
 
-			result, err := svc.CreateReception(ctx, pvzID, now)
+			result, err := svc.CreateReception(ctx, pvzID, now, tc.employeeID)
 			if tc.expectedErrMsg != "" {
 				if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
 					t.Errorf("expected error containing %q, got %v", tc.expectedErrMsg, err)
@@ -488,6 +560,7 @@ func TestPvzService_AddProduct(t *testing.T) {
 	validProductType := "electronics"
 	invalidProductType := "toys"
 	fakeProductID := "prod789"
+	barcode := "4607001234567"
 	now := time.Now()
 
 	// Для успешных сценариев разрешим только "electronics"
@@ -501,6 +574,7 @@ func TestPvzService_AddProduct(t *testing.T) {
 	tests := []struct {
 		name           string
 		productType    string
+		barcode        string
 		allowedTypes   map[string]bool
 		simulateError  string
 		expectedErrMsg string
@@ -511,6 +585,13 @@ func TestPvzService_AddProduct(t *testing.T) {
 			allowedTypes:   allowedProductTypes,
 			expectedErrMsg: "product type 'toys' is not allowed",
 		},
+		{
+			name:           "invalid barcode",
+			productType:    validProductType,
+			barcode:        "bad barcode!",
+			allowedTypes:   allowedProductTypes,
+			expectedErrMsg: "barcode must be",
+		},
 		{
 			name:           "tx error",
 			productType:    validProductType,
@@ -545,6 +626,13 @@ func TestPvzService_AddProduct(t *testing.T) {
 			allowedTypes:  allowedProductTypes,
 			simulateError: "",
 		},
+		{
+			name:          "success with barcode",
+			productType:   validProductType,
+			barcode:       barcode,
+			allowedTypes:  allowedProductTypes,
+			simulateError: "",
+		},
 	}
 
 	for _, tc := range tests {
@@ -565,8 +653,8 @@ func TestPvzService_AddProduct(t *testing.T) {
 
 			var callbackErr error
 
-			if tc.expectedErrMsg != "" && strings.Contains(tc.expectedErrMsg, "not allowed") {
-				result, err := svc.AddProduct(ctx, pvzID, tc.productType)
+			if tc.expectedErrMsg != "" && tc.simulateError == "" {
+				result, err := svc.AddProduct(ctx, pvzID, tc.productType, tc.barcode)
 				if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
 					t.Errorf("expected error containing %q, got %v (result: %q)", tc.expectedErrMsg, err, result)
 				}
@@ -581,7 +669,7 @@ func TestPvzService_AddProduct(t *testing.T) {
 				loggerMock.
 					On("Errorw", "AddProduct", "error", mock.MatchedBy(func(err error) bool {
 						return strings.Contains(err.Error(), "tx error")
-					}), "pvzID", pvzID, "productType", tc.productType).
+					}), "pvzID", pvzID, "productType", tc.productType, "barcode", tc.barcode).
 					Return().
 					Once()
 			} else {
@@ -629,23 +717,26 @@ func TestPvzService_AddProduct(t *testing.T) {
 						Once()
 					repoMock.
 						On("CreateProduct", mock.Anything, mock.MatchedBy(func(p entity.Product) bool {
-							return p.ReceptionID == openReception.ID && strings.ToLower(p.Type) == strings.ToLower(tc.productType)
+							return p.ReceptionID == openReception.ID && strings.ToLower(p.Type) == strings.ToLower(tc.productType) &&
+								p.Barcode == tc.barcode && p.ExpiresAt != nil && p.ExpiresAt.After(p.DateTime)
 						})).
 						Return(fakeProductID, nil).
 						Once()
+					expectAudit(repoMock, entity.AuditProductCreate)
+					expectEvent(repoMock, entity.EventProductAdded, fakeProductID)
 				}
 
 				if tc.expectedErrMsg != "" {
 					loggerMock.
 						On("Errorw", "AddProduct", "error", mock.MatchedBy(func(err error) bool {
 							return strings.Contains(err.Error(), tc.expectedErrMsg)
-						}), "pvzID", pvzID, "productType", tc.productType).
+						}), "pvzID", pvzID, "productType", tc.productType, "barcode", tc.barcode).
 						Return().
 						Once()
 				}
 			}
 
-			result, err := svc.AddProduct(ctx, pvzID, tc.productType)
+			result, err := svc.AddProduct(ctx, pvzID, tc.productType, tc.barcode)
 			if tc.expectedErrMsg != "" {
 				if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
 					t.Errorf("expected error containing %q, got %v", tc.expectedErrMsg, err)
@@ -666,7 +757,7 @@ func TestPvzService_DeleteLastProduct(t *testing.T) {
 	ctx := context.Background()
 	pvzID := "pvz123"
 
-	fakeProduct := &entity.Product{ID: "prod001"}
+	fakeProduct := &entity.Product{ID: "prod001", Barcode: "4607001234567"}
 
 	tests := []struct {
 		name           string
@@ -769,6 +860,8 @@ func TestPvzService_DeleteLastProduct(t *testing.T) {
 						On("DeleteProduct", mock.Anything, fakeProduct.ID).
 						Return(nil).
 						Once()
+					expectAudit(repoMock, entity.AuditProductDelete)
+					expectEvent(repoMock, entity.EventProductRemoved, fakeProduct.ID)
 				}
 
 				if tc.expectedErrMsg != "" {
@@ -781,13 +874,15 @@ func TestPvzService_DeleteLastProduct(t *testing.T) {
 				}
 			}
 
-			err := svc.DeleteLastProduct(ctx, pvzID)
+			deleted, err := svc.DeleteLastProduct(ctx, pvzID)
 			if tc.expectedErrMsg != "" {
 				if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
 					t.Errorf("expected error containing %q, got %v", tc.expectedErrMsg, err)
 				}
 			} else if err != nil {
 				t.Errorf("unexpected error: %v", err)
+			} else if deleted == nil || deleted.Barcode != fakeProduct.Barcode {
+				t.Errorf("expected deleted product with barcode %q, got %+v", fakeProduct.Barcode, deleted)
 			}
 		})
 	}
@@ -799,10 +894,13 @@ func TestPvzService_CloseReception(t *testing.T) {
 	ctx := context.Background()
 	pvzID := "pvz123"
 	openReception := &entity.Reception{ID: "rec001", PvzID: pvzID, Status: "in_progress"}
+	employee := testEmployee
 
 	tests := []struct {
 		name           string
 		simulateError  string
+		employeeID     string
+		closedBy       *string
 		expectedErrMsg string
 	}{
 		{
@@ -821,13 +919,20 @@ func TestPvzService_CloseReception(t *testing.T) {
 			expectedErrMsg: "no open reception found for this PVZ",
 		},
 		{
-			name:           "error in UpdateReceptionStatus",
+			name:           "error in CloseReception",
 			simulateError:  "update",
 			expectedErrMsg: "update error",
 		},
 		{
 			name:          "success",
 			simulateError: "",
+			employeeID:    testEmployee,
+			closedBy:      &employee,
+		},
+		{
+			name:          "success with dummy token",
+			simulateError: "",
+			employeeID:    "dummyID",
 		},
 	}
 
@@ -889,19 +994,21 @@ func TestPvzService_CloseReception(t *testing.T) {
 						Return(openReception, nil).
 						Once()
 					repoMock.
-						On("UpdateReceptionStatus", mock.Anything, openReception.ID, "close").
+						On("CloseReception", mock.Anything, openReception.ID, (*string)(nil)).
 						Return(errors.New("update error")).
 						Once()
 				case "":
-					// Успешный сценарий: открытая приёмка найдена, обновление статуса проходит успешно
+					// Успешный сценарий: открытая приёмка найдена и закрывается от имени сотрудника из токена
 					repoMock.
 						On("FindOpenReceptionByPvzID", mock.Anything, pvzID).
 						Return(openReception, nil).
 						Once()
 					repoMock.
-						On("UpdateReceptionStatus", mock.Anything, openReception.ID, "close").
+						On("CloseReception", mock.Anything, openReception.ID, tc.closedBy).
 						Return(nil).
 						Once()
+					expectAudit(repoMock, entity.AuditReceptionClose)
+					expectEvent(repoMock, entity.EventReceptionClosed, openReception.ID)
 				}
 
 				if tc.expectedErrMsg != "" {
@@ -914,7 +1021,7 @@ func TestPvzService_CloseReception(t *testing.T) {
 				}
 			}
 
-			recID, err := svc.CloseReception(ctx, pvzID)
+			recID, err := svc.CloseReception(ctx, pvzID, tc.employeeID)
 			if tc.expectedErrMsg != "" {
 				if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
 					t.Errorf("expected error containing %q, got %v", tc.expectedErrMsg, err)
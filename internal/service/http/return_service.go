@@ -0,0 +1,226 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"time"
+)
+
+// returnReasons — допустимые причины возврата товара продавцу
+var returnReasons = map[string]bool{
+	entity.ReturnReasonRefused: true,
+	entity.ReturnReasonExpired: true,
+	entity.ReturnReasonDamaged: true,
+}
+
+// returnableStatuses — статусы товара, из которых его можно передать в отгрузку возвратов
+var returnableStatuses = map[string]bool{
+	entity.ProductStatusReceived:       true,
+	entity.ProductStatusReadyForPickup: true,
+	entity.ProductStatusReturned:       true,
+}
+
+// CreateReturn открывает отгрузку возвратов в ПВЗ. Одновременно открыта может быть только одна.
+// Закрытый ПВЗ тоже может отгрузить оставшиеся товары продавцу.
+func (s *pvzServiceImp) CreateReturn(ctx context.Context, pvzID string) (string, error) {
+	if err := validateIDs(pvzID); err != nil {
+		return "", err
+	}
+
+	var returnID string
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		if _, err := s.repo.GetPvzByID(txCtx, pvzID); err != nil {
+			return err
+		}
+
+		existing, err := s.repo.FindOpenReturnByPvzID(txCtx, pvzID)
+		if err == nil && existing != nil {
+			return errs.New(errs.ErrOpenReturnExists, "open return shipment already exists")
+		}
+		if err != nil && !errs.IsOpenReturnNotFound(err) {
+			return err
+		}
+
+		shipment := entity.ReturnShipment{
+			PvzID:    pvzID,
+			DateTime: time.Now(),
+			Status:   "in_progress",
+		}
+		id, err := s.repo.CreateReturn(txCtx, shipment)
+		if err != nil {
+			return err
+		}
+		returnID = id
+		shipment.ID = id
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditReturnCreate,
+			EntityType: entity.AuditEntityReturn,
+			EntityID:   id,
+			PvzID:      pvzID,
+			After:      shipment,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("CreateReturn",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return "", err
+	}
+
+	metrics.ReturnShipmentsCreated()
+	return returnID, nil
+}
+
+// AddReturnItem переносит товар ПВЗ в открытую отгрузку возвратов с указанной причиной.
+func (s *pvzServiceImp) AddReturnItem(ctx context.Context, pvzID, productID, reason string) (*entity.ReturnItem, error) {
+	if err := validateIDs(pvzID, productID); err != nil {
+		return nil, err
+	}
+	if !returnReasons[reason] {
+		return nil, errs.New(errs.ErrInvalidReturnReason, fmt.Sprintf("return reason '%s' is not allowed", reason))
+	}
+
+	var item *entity.ReturnItem
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		shipment, err := s.repo.FindOpenReturnByPvzID(txCtx, pvzID)
+		if err != nil {
+			return err
+		}
+
+		order, err := s.repo.FindOrderForUpdate(txCtx, pvzID, productID)
+		if err != nil {
+			return err
+		}
+		if order.ReceptionStatus != "close" {
+			return errs.New(errs.ErrInvalidProductStatus, "reception of the product is not closed yet")
+		}
+		if !returnableStatuses[order.Status] {
+			return errs.New(errs.ErrInvalidProductStatus, fmt.Sprintf("product in '%s' status cannot be returned to the seller", order.Status))
+		}
+
+		newItem := entity.ReturnItem{
+			ReturnID:       shipment.ID,
+			ProductID:      productID,
+			Reason:         reason,
+			PreviousStatus: order.Status,
+			DateTime:       time.Now(),
+		}
+		id, err := s.repo.CreateReturnItem(txCtx, newItem)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetProductStatus(txCtx, productID, entity.ProductStatusInReturn); err != nil {
+			return err
+		}
+
+		newItem.ID = id
+		item = &newItem
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditReturnItemAdd,
+			EntityType: entity.AuditEntityReturnItem,
+			EntityID:   id,
+			PvzID:      pvzID,
+			After:      newItem,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("AddReturnItem",
+			"error", err,
+			"pvzID", pvzID,
+			"productID", productID,
+			"reason", reason,
+		)
+		return nil, err
+	}
+
+	metrics.ReturnItemsAdded(reason)
+	return item, nil
+}
+
+// DeleteLastReturnItem убирает последний добавленный товар из открытой отгрузки
+// и возвращает товару статус, который был до переноса.
+func (s *pvzServiceImp) DeleteLastReturnItem(ctx context.Context, pvzID string) error {
+	if err := validateIDs(pvzID); err != nil {
+		return err
+	}
+
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		if _, err := s.repo.FindOpenReturnByPvzID(txCtx, pvzID); err != nil {
+			return err
+		}
+
+		item, err := s.repo.FindLastReturnItem(txCtx, pvzID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.DeleteReturnItem(txCtx, item.ID); err != nil {
+			return err
+		}
+		if err := s.repo.SetProductStatus(txCtx, item.ProductID, item.PreviousStatus); err != nil {
+			return err
+		}
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditReturnItemDelete,
+			EntityType: entity.AuditEntityReturnItem,
+			EntityID:   item.ID,
+			PvzID:      pvzID,
+			Before:     *item,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("DeleteLastReturnItem",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return err
+	}
+	return nil
+}
+
+// CloseReturn закрывает открытую отгрузку возвратов, товары из неё считаются отгруженными продавцу.
+func (s *pvzServiceImp) CloseReturn(ctx context.Context, pvzID string) (string, error) {
+	if err := validateIDs(pvzID); err != nil {
+		return "", err
+	}
+
+	var returnID string
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		shipment, err := s.repo.FindOpenReturnByPvzID(txCtx, pvzID)
+		if err != nil {
+			return err
+		}
+		if _, err := s.repo.ShipReturnItems(txCtx, shipment.ID); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateReturnStatus(txCtx, shipment.ID, "close"); err != nil {
+			return err
+		}
+		returnID = shipment.ID
+
+		closed := *shipment
+		closed.Status = "close"
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditReturnClose,
+			EntityType: entity.AuditEntityReturn,
+			EntityID:   shipment.ID,
+			PvzID:      pvzID,
+			Before:     *shipment,
+			After:      closed,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("CloseReturn",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return "", err
+	}
+
+	metrics.ReturnShipmentsClosed()
+	return returnID, nil
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+)
+
+const testReturnID = "3e4f5a6b-7c8d-4e9f-a0b1-c2d3e4f5a655"
+
+func TestPvzService_CreateReturn(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("pvz not found", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("GetPvzByID", mock.Anything, testPvzID).
+			Return(nil, errs.New(errs.ErrPvzNotFound, "pvz not found")).Once()
+		expectErrorLog(loggerMock, "CreateReturn", 4)
+
+		_, err := svc.CreateReturn(ctx, testPvzID)
+		assertErrCode(t, err, errs.ErrPvzNotFound)
+	})
+
+	t.Run("open return already exists", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("GetPvzByID", mock.Anything, testPvzID).Return(&entity.Pvz{ID: testPvzID}, nil).Once()
+		repoMock.On("FindOpenReturnByPvzID", mock.Anything, testPvzID).
+			Return(&entity.ReturnShipment{ID: testReturnID, Status: "in_progress"}, nil).Once()
+		expectErrorLog(loggerMock, "CreateReturn", 4)
+
+		_, err := svc.CreateReturn(ctx, testPvzID)
+		assertErrCode(t, err, errs.ErrOpenReturnExists)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("GetPvzByID", mock.Anything, testPvzID).Return(&entity.Pvz{ID: testPvzID}, nil).Once()
+		repoMock.On("FindOpenReturnByPvzID", mock.Anything, testPvzID).
+			Return(nil, errs.New(errs.ErrNoOpenReturn, "open return shipment not found")).Once()
+		repoMock.On("CreateReturn", mock.Anything, mock.MatchedBy(func(s entity.ReturnShipment) bool {
+			return s.PvzID == testPvzID && s.Status == "in_progress"
+		})).Return(testReturnID, nil).Once()
+		expectAudit(repoMock, entity.AuditReturnCreate)
+
+		id, err := svc.CreateReturn(ctx, testPvzID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if id != testReturnID {
+			t.Errorf("expected return id %s, got %s", testReturnID, id)
+		}
+	})
+}
+
+func TestPvzService_AddReturnItem(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	openReturn := &entity.ReturnShipment{ID: testReturnID, PvzID: testPvzID, Status: "in_progress"}
+
+	t.Run("unknown reason", func(t *testing.T) {
+		t.Parallel()
+		svc := &pvzServiceImp{repo: mockRepo.NewRepository(t), logger: mockLog.NewLogger(t), txManager: mockRepo.NewTxManager(t)}
+
+		_, err := svc.AddReturnItem(ctx, testPvzID, testProductID, "lost")
+		assertErrCode(t, err, errs.ErrInvalidReturnReason)
+	})
+
+	t.Run("issued product cannot be returned", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindOpenReturnByPvzID", mock.Anything, testPvzID).Return(openReturn, nil).Once()
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).
+			Return(&entity.Order{ProductID: testProductID, ReceptionStatus: "close", Status: entity.ProductStatusIssued}, nil).Once()
+		expectErrorLog(loggerMock, "AddReturnItem", 8)
+
+		_, err := svc.AddReturnItem(ctx, testPvzID, testProductID, entity.ReturnReasonRefused)
+		assertErrCode(t, err, errs.ErrInvalidProductStatus)
+	})
+
+	t.Run("no open return", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: loggerMock, txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindOpenReturnByPvzID", mock.Anything, testPvzID).
+			Return(nil, errs.New(errs.ErrNoOpenReturn, "open return shipment not found")).Once()
+		expectErrorLog(loggerMock, "AddReturnItem", 8)
+
+		_, err := svc.AddReturnItem(ctx, testPvzID, testProductID, entity.ReturnReasonExpired)
+		assertErrCode(t, err, errs.ErrNoOpenReturn)
+	})
+
+	t.Run("success remembers previous status", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		txManager := mockRepo.NewTxManager(t)
+		svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+		passThroughTx(txManager)
+		repoMock.On("FindOpenReturnByPvzID", mock.Anything, testPvzID).Return(openReturn, nil).Once()
+		repoMock.On("FindOrderForUpdate", mock.Anything, testPvzID, testProductID).
+			Return(&entity.Order{ProductID: testProductID, ReceptionStatus: "close", Status: entity.ProductStatusReadyForPickup}, nil).Once()
+		repoMock.On("CreateReturnItem", mock.Anything, mock.MatchedBy(func(i entity.ReturnItem) bool {
+			return i.ReturnID == testReturnID && i.PreviousStatus == entity.ProductStatusReadyForPickup
+		})).Return("item1", nil).Once()
+		repoMock.On("SetProductStatus", mock.Anything, testProductID, entity.ProductStatusInReturn).Return(nil).Once()
+		expectAudit(repoMock, entity.AuditReturnItemAdd)
+
+		item, err := svc.AddReturnItem(ctx, testPvzID, testProductID, entity.ReturnReasonExpired)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if item.ID != "item1" || item.Reason != entity.ReturnReasonExpired {
+			t.Errorf("unexpected return item %+v", item)
+		}
+	})
+}
+
+func TestPvzService_DeleteLastReturnItem(t *testing.T) {
+	t.Parallel()
+
+	repoMock := mockRepo.NewRepository(t)
+	txManager := mockRepo.NewTxManager(t)
+	svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+	passThroughTx(txManager)
+	repoMock.On("FindOpenReturnByPvzID", mock.Anything, testPvzID).
+		Return(&entity.ReturnShipment{ID: testReturnID}, nil).Once()
+	repoMock.On("FindLastReturnItem", mock.Anything, testPvzID).
+		Return(&entity.ReturnItem{ID: "item1", ProductID: testProductID, PreviousStatus: entity.ProductStatusReturned}, nil).Once()
+	repoMock.On("DeleteReturnItem", mock.Anything, "item1").Return(nil).Once()
+	repoMock.On("SetProductStatus", mock.Anything, testProductID, entity.ProductStatusReturned).Return(nil).Once()
+	expectAudit(repoMock, entity.AuditReturnItemDelete)
+
+	if err := svc.DeleteLastReturnItem(context.Background(), testPvzID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPvzService_CloseReturn(t *testing.T) {
+	t.Parallel()
+
+	repoMock := mockRepo.NewRepository(t)
+	txManager := mockRepo.NewTxManager(t)
+	svc := &pvzServiceImp{repo: repoMock, logger: mockLog.NewLogger(t), txManager: txManager}
+
+	passThroughTx(txManager)
+	repoMock.On("FindOpenReturnByPvzID", mock.Anything, testPvzID).
+		Return(&entity.ReturnShipment{ID: testReturnID}, nil).Once()
+	repoMock.On("ShipReturnItems", mock.Anything, testReturnID).Return(int64(2), nil).Once()
+	repoMock.On("UpdateReturnStatus", mock.Anything, testReturnID, "close").Return(nil).Once()
+	expectAudit(repoMock, entity.AuditReturnClose)
+
+	id, err := svc.CloseReturn(context.Background(), testPvzID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != testReturnID {
+		t.Errorf("expected return id %s, got %s", testReturnID, id)
+	}
+}
@@ -0,0 +1,346 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/jwt"
+)
+
+const (
+	defaultUserLimit       = 50
+	maxUserLimit           = 500
+	temporaryPasswordBytes = 12
+)
+
+// ListUsers возвращает страницу пользователей от новых к старым.
+func (s *authServiceImp) ListUsers(ctx context.Context, filter entity.UserFilter) (*entity.UserPage, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultUserLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxUserLimit {
+		return nil, errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("limit must be between 1 and %d", maxUserLimit))
+	}
+	if filter.Role != "" {
+		if err := s.validateRole(filter.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	limit := filter.Limit
+	filter.Limit++ // лишняя запись показывает, есть ли следующая страница
+	users, err := s.repo.ListUsers(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("ListUsers",
+			"error", err,
+			"email", filter.Email,
+			"role", filter.Role,
+		)
+		return nil, err
+	}
+
+	page := &entity.UserPage{Items: users}
+	if len(users) > limit {
+		page.Items = users[:limit]
+		page.HasMore = true
+		last := page.Items[limit-1]
+		page.NextCursor = &entity.UserCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return page, nil
+}
+
+func (s *authServiceImp) GetUser(ctx context.Context, userID string) (*entity.User, error) {
+	if err := validateIDs(userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("GetUser",
+			"error", err,
+			"userID", userID,
+		)
+		return nil, err
+	}
+	return user, nil
+}
+
+// ChangeUserRole меняет роль пользователя. Роль записана в уже выданных access-токенах,
+// поэтому все сессии пользователя отзываются и новая роль действует со следующего входа.
+func (s *authServiceImp) ChangeUserRole(ctx context.Context, userID string, role string) (*entity.User, error) {
+	if err := validateIDs(userID); err != nil {
+		return nil, err
+	}
+	if err := s.validateRole(role); err != nil {
+		return nil, err
+	}
+	if err := checkNotSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var updated *entity.User
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		user, err := s.findUser(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if user.Role == role {
+			updated = user
+			return nil
+		}
+
+		if err := s.repo.UpdateUserRole(txCtx, userID, role); err != nil {
+			return err
+		}
+		if err := s.repo.RevokeUserTokens(txCtx, userID, ""); err != nil {
+			return err
+		}
+
+		after := *user
+		after.Role = role
+		updated = &after
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditUserRoleChange,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   userID,
+			Before:     user,
+			After:      after,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("ChangeUserRole",
+			"error", err,
+			"userID", userID,
+			"role", role,
+		)
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// SetUserActive отключает или снова включает учётную запись. У отключённого пользователя
+// отзываются все сессии, вход и обновление токенов для него запрещены.
+func (s *authServiceImp) SetUserActive(ctx context.Context, userID string, active bool) (*entity.User, error) {
+	if err := validateIDs(userID); err != nil {
+		return nil, err
+	}
+	if err := checkNotSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	action := entity.AuditUserReactivate
+	if !active {
+		action = entity.AuditUserDeactivate
+	}
+
+	var updated *entity.User
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		user, err := s.findUser(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if user.Active == active {
+			updated = user
+			return nil
+		}
+
+		if err := s.repo.SetUserActive(txCtx, userID, active); err != nil {
+			return err
+		}
+		if !active {
+			if err := s.repo.RevokeUserTokens(txCtx, userID, ""); err != nil {
+				return err
+			}
+		}
+
+		after := *user
+		after.Active = active
+		updated = &after
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     action,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   userID,
+			Before:     user,
+			After:      after,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("SetUserActive",
+			"error", err,
+			"userID", userID,
+			"active", active,
+		)
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// ResetUserPassword заменяет пароль пользователя временным и возвращает его: модератор передаёт
+// пароль пользователю, а тот при входе получает признак passwordChangeRequired. Сессии пользователя
+// отзываются, блокировка входа по его email снимается.
+func (s *authServiceImp) ResetUserPassword(ctx context.Context, userID string) (string, error) {
+	if err := validateIDs(userID); err != nil {
+		return "", err
+	}
+
+	temporary, err := newTemporaryPassword()
+	if err != nil {
+		return "", errs.Wrap(err, errs.ErrInternalCode, "failed to generate password")
+	}
+	hashedPassword, err := s.hasher.Hash(temporary)
+	if err != nil {
+		s.logger.Errorw("hash password",
+			"error", err,
+			"userID", userID,
+		)
+		return "", errs.Wrap(err, errs.ErrPasswordHashingFailed, "failed to hash password")
+	}
+
+	err = s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		user, err := s.findUser(txCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.UpdateUserPassword(txCtx, userID, hashedPassword, true); err != nil {
+			return err
+		}
+		if err := s.repo.RevokeUserTokens(txCtx, userID, ""); err != nil {
+			return err
+		}
+		if err := s.repo.ResetLoginAttempts(txCtx, loginAttemptKeys(user.Email, "")[0]); err != nil {
+			return err
+		}
+
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditUserPasswordReset,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   userID,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("ResetUserPassword",
+			"error", err,
+			"userID", userID,
+		)
+		return "", err
+	}
+
+	return temporary, nil
+}
+
+// ChangePassword меняет пароль текущего пользователя. Текущая сессия сохраняется,
+// остальные сессии пользователя отзываются.
+func (s *authServiceImp) ChangePassword(ctx context.Context, currentPassword string, newPassword string) error {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return errs.New(errs.ErrUnauthorizedCode, "missing token claims")
+	}
+	if claims.Dummy {
+		return errs.New(errs.ErrForbiddenCode, "password change is not available for dummy tokens")
+	}
+
+	current, err := s.findUser(ctx, claims.UserID)
+	if err != nil {
+		s.logger.Errorw("ChangePassword",
+			"error", err,
+			"userID", claims.UserID,
+		)
+		return err
+	}
+
+	// Неверный текущий пароль учитывается как неудачный вход по email пользователя: украденным
+	// access-токеном нельзя перебирать пароль без задержек и блокировки
+	keys := loginAttemptKeys(current.Email, "")
+	reserved, err := s.reserveLoginAttempt(ctx, current.Email, "", keys)
+	if err != nil {
+		return err
+	}
+	if !s.hasher.Check(current.PasswordHash, currentPassword) {
+		s.recordLoginFailure(current.Email, "", reserved)
+		return errs.New(errs.ErrInvalidCurrentPassword, "current password is invalid")
+	}
+	s.releaseLoginAttempts(ctx, keys)
+
+	hashedPassword, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		s.logger.Errorw("hash password",
+			"error", err,
+			"userID", claims.UserID,
+		)
+		return errs.Wrap(err, errs.ErrPasswordHashingFailed, "failed to hash password")
+	}
+
+	err = s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		user, err := s.findUser(txCtx, claims.UserID)
+		if err != nil {
+			return err
+		}
+		// Пароль сменили параллельно: проверенный текущий пароль уже недействителен
+		if user.PasswordHash != current.PasswordHash {
+			return errs.New(errs.ErrInvalidCurrentPassword, "current password is invalid")
+		}
+
+		if err := s.repo.UpdateUserPassword(txCtx, user.ID, hashedPassword, false); err != nil {
+			return err
+		}
+
+		familyID, err := s.repo.GetTokenFamilyByAccessJTI(txCtx, claims.ID)
+		if err != nil && !errs.IsNotFound(err) {
+			return err
+		}
+		if err := s.repo.RevokeUserTokens(txCtx, user.ID, familyID); err != nil {
+			return err
+		}
+
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditUserPasswordChange,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   user.ID,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("ChangePassword",
+			"error", err,
+			"userID", claims.UserID,
+		)
+		return err
+	}
+
+	return nil
+}
+
+// findUser ищет пользователя по ID и возвращает USER_NOT_FOUND, если его нет.
+func (s *authServiceImp) findUser(ctx context.Context, userID string) (*entity.User, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return nil, errs.New(errs.ErrUserNotFound, "user not found")
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkNotSelf не даёт модератору сменить роль или отключить собственную учётную запись
+// и так лишиться доступа к управлению пользователями.
+func checkNotSelf(ctx context.Context, userID string) error {
+	if claims, ok := jwt.ClaimsFromContext(ctx); ok && claims.UserID == userID {
+		return errs.New(errs.ErrInvalidRequestCode, "moderators cannot change the role or status of their own account")
+	}
+	return nil
+}
+
+func newTemporaryPassword() (string, error) {
+	buf := make([]byte, temporaryPasswordBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,395 @@
+package http
+
+import (
+	"context"
+	"errors"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	"order-pick-up-point/pkg/jwt"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	mockPass "order-pick-up-point/pkg/password/mock"
+	"testing"
+	"time"
+)
+
+const testModeratorID = "3b7e9f2a-1c4d-4e5f-8a9b-0c1d2e3f4a55"
+
+func newUserTestService(t *testing.T) (*authServiceImp, *mockRepo.Repository, *mockRepo.TxManager, *mockPass.PasswordHasher, *mockLog.Logger) {
+	repoMock := mockRepo.NewRepository(t)
+	txManager := mockRepo.NewTxManager(t)
+	hasherMock := mockPass.NewPasswordHasher(t)
+	loggerMock := mockLog.NewLogger(t)
+	svc := &authServiceImp{
+		repo:         repoMock,
+		txManager:    txManager,
+		hasher:       hasherMock,
+		logger:       loggerMock,
+		allowedRoles: map[string]bool{"client": true, "employee": true, "moderator": true},
+	}
+	return svc, repoMock, txManager, hasherMock, loggerMock
+}
+
+func moderatorContext() context.Context {
+	return jwt.ContextWithClaims(context.Background(), &jwt.CustomClaims{UserID: testModeratorID, Role: "moderator"})
+}
+
+func expectUserAudit(repoMock *mockRepo.Repository, action string, userID string) {
+	repoMock.
+		On("InsertAuditEntry", mock.Anything, mock.MatchedBy(func(e entity.AuditEntry) bool {
+			return e.Action == action && e.EntityType == entity.AuditEntityUser && e.EntityID == userID
+		})).
+		Return(nil).
+		Once()
+}
+
+func TestAuthService_ListUsers(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 5, 8, 9, 0, 0, 0, time.UTC)
+	users := []entity.User{
+		{ID: "u3", CreatedAt: created.Add(2 * time.Hour)},
+		{ID: "u2", CreatedAt: created.Add(time.Hour)},
+		{ID: "u1", CreatedAt: created},
+	}
+
+	tests := []struct {
+		name           string
+		filter         entity.UserFilter
+		repoFilter     *entity.UserFilter
+		repoResult     []entity.User
+		repoErr        error
+		expectedLen    int
+		expectedCursor *entity.UserCursor
+		expectedCode   string
+	}{
+		{
+			name:           "next page exists",
+			filter:         entity.UserFilter{Role: "employee", Limit: 2},
+			repoFilter:     &entity.UserFilter{Role: "employee", Limit: 3},
+			repoResult:     users,
+			expectedLen:    2,
+			expectedCursor: &entity.UserCursor{CreatedAt: users[1].CreatedAt, ID: "u2"},
+		},
+		{
+			name:        "default limit and last page",
+			filter:      entity.UserFilter{},
+			repoFilter:  &entity.UserFilter{Limit: defaultUserLimit + 1},
+			repoResult:  users,
+			expectedLen: 3,
+		},
+		{
+			name:         "limit too large",
+			filter:       entity.UserFilter{Limit: maxUserLimit + 1},
+			expectedCode: errs.ErrInvalidRequestCode,
+		},
+		{
+			name:         "unknown role",
+			filter:       entity.UserFilter{Role: "admin"},
+			expectedCode: errs.ErrInvalidRoleCode,
+		},
+		{
+			name:         "repository error",
+			filter:       entity.UserFilter{Limit: 10},
+			repoFilter:   &entity.UserFilter{Limit: 11},
+			repoErr:      errs.New(errs.ErrInternalCode, "failed to list users"),
+			expectedCode: errs.ErrInternalCode,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			svc, repoMock, _, _, loggerMock := newUserTestService(t)
+
+			if tc.repoFilter != nil {
+				repoMock.On("ListUsers", mock.Anything, *tc.repoFilter).Return(tc.repoResult, tc.repoErr).Once()
+			}
+			if tc.repoErr != nil {
+				expectErrorLog(loggerMock, "ListUsers", 6)
+			}
+
+			page, err := svc.ListUsers(context.Background(), tc.filter)
+			if tc.expectedCode != "" {
+				assertErrCode(t, err, tc.expectedCode)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(page.Items) != tc.expectedLen {
+				t.Errorf("expected %d users, got %d", tc.expectedLen, len(page.Items))
+			}
+			if page.HasMore != (tc.expectedCursor != nil) {
+				t.Errorf("unexpected hasMore %v", page.HasMore)
+			}
+			if tc.expectedCursor != nil && (page.NextCursor == nil || *page.NextCursor != *tc.expectedCursor) {
+				t.Errorf("expected cursor %+v, got %+v", tc.expectedCursor, page.NextCursor)
+			}
+		})
+	}
+}
+
+func TestAuthService_ChangeUserRole(t *testing.T) {
+	t.Parallel()
+
+	user := func() *entity.User {
+		return &entity.User{ID: testClientID, Email: "client@example.com", Role: "client", Active: true}
+	}
+
+	t.Run("updates role, revokes sessions and records audit", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, _ := newUserTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user(), nil).Once()
+		repoMock.On("UpdateUserRole", mock.Anything, testClientID, "employee").Return(nil).Once()
+		repoMock.On("RevokeUserTokens", mock.Anything, testClientID, "").Return(nil).Once()
+		expectUserAudit(repoMock, entity.AuditUserRoleChange, testClientID)
+
+		updated, err := svc.ChangeUserRole(moderatorContext(), testClientID, "employee")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Role != "employee" {
+			t.Errorf("expected role employee, got %s", updated.Role)
+		}
+	})
+
+	t.Run("same role is a no-op", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, _ := newUserTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user(), nil).Once()
+
+		if _, err := svc.ChangeUserRole(moderatorContext(), testClientID, "client"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("own role", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _, _ := newUserTestService(t)
+
+		_, err := svc.ChangeUserRole(moderatorContext(), testModeratorID, "client")
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+	})
+
+	t.Run("role is not allowed", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _, _ := newUserTestService(t)
+
+		_, err := svc.ChangeUserRole(moderatorContext(), testClientID, "admin")
+		assertErrCode(t, err, errs.ErrInvalidRoleCode)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, loggerMock := newUserTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(nil, errs.New(errs.ErrNotFoundCode, "user not found")).Once()
+		expectErrorLog(loggerMock, "ChangeUserRole", 6)
+
+		_, err := svc.ChangeUserRole(moderatorContext(), testClientID, "employee")
+		assertErrCode(t, err, errs.ErrUserNotFound)
+	})
+}
+
+func TestAuthService_SetUserActive(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deactivation revokes sessions", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, _ := newUserTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(&entity.User{ID: testClientID, Active: true}, nil).Once()
+		repoMock.On("SetUserActive", mock.Anything, testClientID, false).Return(nil).Once()
+		repoMock.On("RevokeUserTokens", mock.Anything, testClientID, "").Return(nil).Once()
+		expectUserAudit(repoMock, entity.AuditUserDeactivate, testClientID)
+
+		updated, err := svc.SetUserActive(moderatorContext(), testClientID, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Active {
+			t.Error("expected user to be inactive")
+		}
+	})
+
+	t.Run("reactivation", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, _ := newUserTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(&entity.User{ID: testClientID}, nil).Once()
+		repoMock.On("SetUserActive", mock.Anything, testClientID, true).Return(nil).Once()
+		expectUserAudit(repoMock, entity.AuditUserReactivate, testClientID)
+
+		updated, err := svc.SetUserActive(moderatorContext(), testClientID, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !updated.Active {
+			t.Error("expected user to be active")
+		}
+	})
+
+	t.Run("already inactive", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, _, _ := newUserTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(&entity.User{ID: testClientID}, nil).Once()
+
+		if _, err := svc.SetUserActive(moderatorContext(), testClientID, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("own account", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _, _ := newUserTestService(t)
+
+		_, err := svc.SetUserActive(moderatorContext(), testModeratorID, false)
+		assertErrCode(t, err, errs.ErrInvalidRequestCode)
+	})
+}
+
+func TestAuthService_ResetUserPassword(t *testing.T) {
+	t.Parallel()
+
+	user := &entity.User{ID: testClientID, Email: "Client@Example.com", Active: true}
+
+	t.Run("sets temporary password", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, hasherMock, _ := newUserTestService(t)
+		passThroughTx(txManager)
+
+		var temporary string
+		hasherMock.
+			On("Hash", mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { temporary = args.String(0) }).
+			Return("hash", nil).
+			Once()
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user, nil).Once()
+		repoMock.On("UpdateUserPassword", mock.Anything, testClientID, "hash", true).Return(nil).Once()
+		repoMock.On("RevokeUserTokens", mock.Anything, testClientID, "").Return(nil).Once()
+		repoMock.
+			On("ResetLoginAttempts", mock.Anything, entity.LoginAttemptKey{Scope: entity.LoginScopeEmail, Key: "client@example.com"}).
+			Return(nil).
+			Once()
+		expectUserAudit(repoMock, entity.AuditUserPasswordReset, testClientID)
+
+		got, err := svc.ResetUserPassword(moderatorContext(), testClientID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == "" || got != temporary {
+			t.Errorf("expected hashed temporary password %q, got %q", temporary, got)
+		}
+	})
+
+	t.Run("hashing error", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, hasherMock, loggerMock := newUserTestService(t)
+
+		hasherMock.On("Hash", mock.AnythingOfType("string")).Return("", errors.New("hash failed")).Once()
+		expectErrorLog(loggerMock, "hash password", 4)
+
+		_, err := svc.ResetUserPassword(moderatorContext(), testClientID)
+		assertErrCode(t, err, errs.ErrPasswordHashingFailed)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, hasherMock, loggerMock := newUserTestService(t)
+		passThroughTx(txManager)
+
+		hasherMock.On("Hash", mock.AnythingOfType("string")).Return("hash", nil).Once()
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(nil, errs.New(errs.ErrNotFoundCode, "user not found")).Once()
+		expectErrorLog(loggerMock, "ResetUserPassword", 4)
+
+		_, err := svc.ResetUserPassword(moderatorContext(), testClientID)
+		assertErrCode(t, err, errs.ErrUserNotFound)
+	})
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	claims := &jwt.CustomClaims{
+		UserID:           testClientID,
+		Role:             "client",
+		RegisteredClaims: jwtlib.RegisteredClaims{ID: "jti1"},
+	}
+	ctx := jwt.ContextWithClaims(context.Background(), claims)
+	user := &entity.User{ID: testClientID, Email: "client@example.com", PasswordHash: "old-hash", Active: true, PasswordChangeRequired: true}
+
+	t.Run("keeps current session and revokes the others", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, hasherMock, _ := newUserTestService(t)
+		passThroughTx(txManager)
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user, nil).Twice()
+		hasherMock.On("Check", "old-hash", "old-password").Return(true).Once()
+		hasherMock.On("Hash", "new-password").Return("new-hash", nil).Once()
+		repoMock.On("UpdateUserPassword", mock.Anything, testClientID, "new-hash", false).Return(nil).Once()
+		repoMock.On("GetTokenFamilyByAccessJTI", mock.Anything, "jti1").Return("family1", nil).Once()
+		repoMock.On("RevokeUserTokens", mock.Anything, testClientID, "family1").Return(nil).Once()
+		expectUserAudit(repoMock, entity.AuditUserPasswordChange, testClientID)
+
+		if err := svc.ChangePassword(ctx, "old-password", "new-password"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("wrong current password is checked before hashing", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, _, hasherMock, loggerMock := newUserTestService(t)
+
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user, nil).Once()
+		hasherMock.On("Check", "old-hash", "wrong").Return(false).Once()
+		loggerMock.On("Warnw", "login failed", "email", user.Email, "ip", "").Return().Once()
+
+		assertErrCode(t, svc.ChangePassword(ctx, "wrong", "new-password"), errs.ErrInvalidCurrentPassword)
+		hasherMock.AssertNotCalled(t, "Hash", mock.Anything)
+	})
+
+	t.Run("password changed concurrently", func(t *testing.T) {
+		t.Parallel()
+		svc, repoMock, txManager, hasherMock, loggerMock := newUserTestService(t)
+		passThroughTx(txManager)
+
+		changed := *user
+		changed.PasswordHash = "other-hash"
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(user, nil).Once()
+		hasherMock.On("Check", "old-hash", "old-password").Return(true).Once()
+		hasherMock.On("Hash", "new-password").Return("new-hash", nil).Once()
+		repoMock.On("FindByID", mock.Anything, testClientID).Return(&changed, nil).Once()
+		expectErrorLog(loggerMock, "ChangePassword", 4)
+
+		assertErrCode(t, svc.ChangePassword(ctx, "old-password", "new-password"), errs.ErrInvalidCurrentPassword)
+		repoMock.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("dummy token", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _, _ := newUserTestService(t)
+
+		dummyCtx := jwt.ContextWithClaims(context.Background(), &jwt.CustomClaims{UserID: jwt.DummyUserID, Role: "client", Dummy: true})
+		assertErrCode(t, svc.ChangePassword(dummyCtx, "old-password", "new-password"), errs.ErrForbiddenCode)
+	})
+
+	t.Run("missing claims", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _, _ := newUserTestService(t)
+
+		assertErrCode(t, svc.ChangePassword(context.Background(), "old-password", "new-password"), errs.ErrUnauthorizedCode)
+	})
+}
@@ -0,0 +1,333 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"net/url"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/pkg/logger"
+	"order-pick-up-point/pkg/webhook"
+	"sort"
+	"time"
+)
+
+const (
+	minWebhookSecretLength   = 16
+	maxWebhookURLLength      = 2048
+	defaultWebhookDeliveries = 50
+	maxWebhookDeliveries     = 500
+)
+
+// WebhookService управляет подписками на вебхуки и историей их доставок. Доставки создаются
+// вместе с доменными событиями (см. emitEvent), а отправляет их worker.WebhookWorker.
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, sub entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]entity.WebhookSubscription, error)
+	GetWebhook(ctx context.Context, id string) (*entity.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, id string, update entity.WebhookSubscriptionUpdate) (*entity.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) (*entity.WebhookDeliveryPage, error)
+	GetWebhookDelivery(ctx context.Context, subscriptionID string, deliveryID int64) (*entity.WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, subscriptionID string, deliveryID int64) (*entity.WebhookDelivery, error)
+}
+
+type webhookServiceImp struct {
+	repo      db.Repository
+	txManager db.TxManager
+	logger    logger.Logger
+}
+
+func NewWebhookService(repo db.Repository, txManager db.TxManager, logger logger.Logger) WebhookService {
+	return &webhookServiceImp{
+		repo:      repo,
+		txManager: txManager,
+		logger:    logger,
+	}
+}
+
+// webhookDeliveryStatuses — допустимые значения фильтра истории доставок
+var webhookDeliveryStatuses = map[string]bool{
+	entity.WebhookDeliveryPending:   true,
+	entity.WebhookDeliveryDelivered: true,
+	entity.WebhookDeliveryFailed:    true,
+}
+
+// CreateWebhook создаёт подписку. Если секрет не задан, он генерируется;
+// возвращённая подписка — единственное место, где клиент видит секрет.
+func (s *webhookServiceImp) CreateWebhook(ctx context.Context, sub entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	sub.EventTypes = normalizeEventTypes(sub.EventTypes)
+	if err := validateWebhook(sub); err != nil {
+		return nil, err
+	}
+	if sub.Secret == "" {
+		secret, err := webhook.GenerateSecret()
+		if err != nil {
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to generate webhook secret")
+		}
+		sub.Secret = secret
+	} else if len(sub.Secret) < minWebhookSecretLength {
+		return nil, errs.New(errs.ErrInvalidWebhook, fmt.Sprintf("secret must be at least %d characters", minWebhookSecretLength))
+	}
+	sub.Active = true
+
+	var created *entity.WebhookSubscription
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		var err error
+		created, err = s.repo.CreateWebhookSubscription(txCtx, sub)
+		if err != nil {
+			return err
+		}
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditWebhookCreate,
+			EntityType: entity.AuditEntityWebhook,
+			EntityID:   created.ID,
+			After:      *created,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("CreateWebhook",
+			"error", err,
+			"url", sub.URL,
+		)
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *webhookServiceImp) ListWebhooks(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	subs, err := s.repo.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		s.logger.Errorw("ListWebhooks",
+			"error", err,
+		)
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (s *webhookServiceImp) GetWebhook(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	if err := validateIDs(id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetWebhookSubscription(ctx, id)
+}
+
+// UpdateWebhook меняет адрес, типы событий или активность подписки. Приостановленная подписка
+// копит доставки и получает их после повторного включения.
+func (s *webhookServiceImp) UpdateWebhook(ctx context.Context, id string, update entity.WebhookSubscriptionUpdate) (*entity.WebhookSubscription, error) {
+	if err := validateIDs(id); err != nil {
+		return nil, err
+	}
+
+	var updated *entity.WebhookSubscription
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		sub, err := s.repo.GetWebhookSubscription(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		before := *sub
+		if update.URL != nil {
+			sub.URL = *update.URL
+		}
+		if update.EventTypes != nil {
+			sub.EventTypes = normalizeEventTypes(update.EventTypes)
+		}
+		if update.Active != nil {
+			sub.Active = *update.Active
+		}
+		sub.UpdatedAt = time.Now()
+		if err := validateWebhook(*sub); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateWebhookSubscription(txCtx, *sub); err != nil {
+			return err
+		}
+		updated = sub
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditWebhookUpdate,
+			EntityType: entity.AuditEntityWebhook,
+			EntityID:   id,
+			Before:     before,
+			After:      *sub,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("UpdateWebhook",
+			"error", err,
+			"webhookID", id,
+		)
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteWebhook удаляет подписку вместе с её доставками и их историей.
+func (s *webhookServiceImp) DeleteWebhook(ctx context.Context, id string) error {
+	if err := validateIDs(id); err != nil {
+		return err
+	}
+
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		sub, err := s.repo.GetWebhookSubscription(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.DeleteWebhookSubscription(txCtx, id); err != nil {
+			return err
+		}
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditWebhookDelete,
+			EntityType: entity.AuditEntityWebhook,
+			EntityID:   id,
+			Before:     *sub,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("DeleteWebhook",
+			"error", err,
+			"webhookID", id,
+		)
+		return err
+	}
+	return nil
+}
+
+// GetWebhookDeliveries возвращает историю доставок подписки от новых к старым.
+func (s *webhookServiceImp) GetWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) (*entity.WebhookDeliveryPage, error) {
+	if err := validateIDs(filter.SubscriptionID); err != nil {
+		return nil, err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultWebhookDeliveries
+	}
+	if filter.Limit < 0 || filter.Limit > maxWebhookDeliveries {
+		return nil, errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("limit must be between 1 and %d", maxWebhookDeliveries))
+	}
+	if filter.Status != "" && !webhookDeliveryStatuses[filter.Status] {
+		return nil, errs.New(errs.ErrInvalidRequestCode, fmt.Sprintf("unknown delivery status '%s'", filter.Status))
+	}
+
+	// Пустая история и несуществующая подписка различаются
+	if _, err := s.repo.GetWebhookSubscription(ctx, filter.SubscriptionID); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+	deliveries, err := s.repo.ListWebhookDeliveries(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("GetWebhookDeliveries",
+			"error", err,
+			"webhookID", filter.SubscriptionID,
+		)
+		return nil, err
+	}
+
+	page := &entity.WebhookDeliveryPage{Items: deliveries}
+	if len(deliveries) > limit {
+		page.Items = deliveries[:limit]
+		page.HasMore = true
+		page.NextCursor = &page.Items[limit-1].ID
+	}
+	return page, nil
+}
+
+// GetWebhookDelivery возвращает доставку с историей всех попыток.
+func (s *webhookServiceImp) GetWebhookDelivery(ctx context.Context, subscriptionID string, deliveryID int64) (*entity.WebhookDelivery, error) {
+	if err := validateIDs(subscriptionID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.repo.GetWebhookDelivery(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	delivery.History, err = s.repo.ListWebhookDeliveryAttempts(ctx, deliveryID)
+	if err != nil {
+		s.logger.Errorw("GetWebhookDelivery",
+			"error", err,
+			"deliveryID", deliveryID,
+		)
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// RetryWebhookDelivery возвращает в очередь доставку, исчерпавшую попытки. В журнал аудита
+// действие пишется на подписку, состояние до и после — сама доставка.
+func (s *webhookServiceImp) RetryWebhookDelivery(ctx context.Context, subscriptionID string, deliveryID int64) (*entity.WebhookDelivery, error) {
+	if err := validateIDs(subscriptionID); err != nil {
+		return nil, err
+	}
+
+	var retried *entity.WebhookDelivery
+	err := s.txManager.WithTx(ctx, pgx.ReadCommitted, pgx.ReadWrite, func(txCtx context.Context) error {
+		delivery, err := s.repo.GetWebhookDelivery(txCtx, subscriptionID, deliveryID)
+		if err != nil {
+			return err
+		}
+		if delivery.Status != entity.WebhookDeliveryFailed {
+			return errs.New(errs.ErrDeliveryNotRetryable, fmt.Sprintf("delivery in '%s' status cannot be retried", delivery.Status))
+		}
+		if err := s.repo.RetryWebhookDelivery(txCtx, deliveryID); err != nil {
+			return err
+		}
+		before := *delivery
+		delivery.Status = entity.WebhookDeliveryPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = time.Now()
+		retried = delivery
+		return recordAudit(txCtx, s.repo, auditChange{
+			Action:     entity.AuditWebhookRetry,
+			EntityType: entity.AuditEntityWebhook,
+			EntityID:   subscriptionID,
+			Before:     before,
+			After:      *delivery,
+		})
+	})
+	if err != nil {
+		s.logger.Errorw("RetryWebhookDelivery",
+			"error", err,
+			"deliveryID", deliveryID,
+		)
+		return nil, err
+	}
+	return retried, nil
+}
+
+func validateWebhook(sub entity.WebhookSubscription) error {
+	if len(sub.URL) > maxWebhookURLLength {
+		return errs.New(errs.ErrInvalidWebhook, "url is too long")
+	}
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return errs.New(errs.ErrInvalidWebhook, "url must be an absolute http(s) URL without credentials")
+	}
+	if len(sub.EventTypes) == 0 {
+		return errs.New(errs.ErrInvalidWebhook, "at least one event type is required")
+	}
+	for _, t := range sub.EventTypes {
+		if !entity.WebhookEventTypes[t] {
+			return errs.New(errs.ErrInvalidWebhook, fmt.Sprintf("unknown event type '%s'", t))
+		}
+	}
+	return nil
+}
+
+// normalizeEventTypes убирает повторы и сортирует типы событий, чтобы подписка хранилась в одном виде.
+func normalizeEventTypes(types []string) []string {
+	seen := make(map[string]bool, len(types))
+	result := make([]string, 0, len(types))
+	for _, t := range types {
+		if !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
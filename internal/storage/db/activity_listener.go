@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// pvzActivityChannel — канал NOTIFY, в который пишет триггер notify_pvz_activity
+const pvzActivityChannel = "pvz_activity"
+
+// pvzActivityNotification — полезная нагрузка уведомления, собираемая триггером из строки outbox_event
+type pvzActivityNotification struct {
+	ID          int64           `json:"id"`
+	EventID     string          `json:"event_id"`
+	EventType   string          `json:"event_type"`
+	AggregateID string          `json:"aggregate_id"`
+	PvzID       string          `json:"pvz_id"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// PvzActivityListener держит отдельное соединение с LISTEN pvz_activity и передаёт
+// уведомления обработчику. Каждый экземпляр приложения слушает канал сам, поэтому
+// событие, зафиксированное на любой реплике, получают подписчики всех реплик.
+type PvzActivityListener struct {
+	pool       *pgxpool.Pool
+	logger     logger.Logger
+	retryDelay time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewPvzActivityListener(pool *pgxpool.Pool, log logger.Logger) *PvzActivityListener {
+	return &PvzActivityListener{
+		pool:       pool,
+		logger:     log,
+		retryDelay: time.Second,
+	}
+}
+
+// Start запускает прослушивание в фоне. onEvent вызывается для каждого события,
+// onConnected — после каждого (пере)подключения: уведомления, пришедшие, пока
+// соединения не было, потеряны, и подписчики должны дочитать их из БД.
+func (l *PvzActivityListener) Start(ctx context.Context, onEvent func(entity.OutboxEvent), onConnected func()) {
+	ctx, l.cancel = context.WithCancel(ctx)
+	l.done = make(chan struct{})
+
+	go func() {
+		defer close(l.done)
+		delay := l.retryDelay
+		for {
+			err := l.listen(ctx, onEvent, func() {
+				delay = l.retryDelay
+				onConnected()
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			l.logger.Warnw("PVZ activity listener disconnected",
+				"error", err,
+				"retryIn", delay,
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, 30*time.Second)
+		}
+	}()
+}
+
+func (l *PvzActivityListener) Stop(ctx context.Context) error {
+	if l.cancel == nil {
+		return nil
+	}
+	l.cancel()
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *PvzActivityListener) listen(ctx context.Context, onEvent func(entity.OutboxEvent), onConnected func()) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// Соединение с LISTEN не возвращается в пул: после отмены ожидания оно непригодно
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pvzActivityChannel); err != nil {
+		return err
+	}
+	onConnected()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var msg pvzActivityNotification
+		if err := json.Unmarshal([]byte(n.Payload), &msg); err != nil {
+			l.logger.Errorw("decoding PVZ activity notification",
+				"error", err,
+			)
+			continue
+		}
+		onEvent(entity.OutboxEvent{
+			ID:          msg.ID,
+			EventID:     msg.EventID,
+			Type:        msg.EventType,
+			AggregateID: msg.AggregateID,
+			PvzID:       msg.PvzID,
+			Payload:     msg.Payload,
+			CreatedAt:   msg.CreatedAt,
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// AuditRepository — журнал аудита изменяющих операций. Записи только добавляются.
+type AuditRepository interface {
+	InsertAuditEntry(ctx context.Context, entry entity.AuditEntry) error
+	ListAuditEntries(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+}
+
+type postgresAuditRepository struct {
+	conn   TxManager
+	logger logger.Logger
+}
+
+func NewAuditRepository(conn TxManager, log logger.Logger) AuditRepository {
+	return &postgresAuditRepository{conn: conn, logger: log}
+}
+
+func (r *postgresAuditRepository) InsertAuditEntry(ctx context.Context, entry entity.AuditEntry) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("InsertAuditEntry", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		INSERT INTO audit_log (actor_id, actor_role, actor_dummy, action, entity_type, entity_id, pvz_id,
+			before, after, request_id, trace_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := pool.Exec(ctx, query,
+		entry.ActorID, entry.ActorRole, entry.ActorDummy, entry.Action, entry.EntityType, entry.EntityID, entry.PvzID,
+		entry.Before, entry.After, entry.RequestID, entry.TraceID,
+	)
+	if err != nil {
+		r.logger.Errorw("inserting audit entry",
+			"error", err,
+			"action", entry.Action,
+			"entityID", entry.EntityID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to write audit entry")
+	}
+	return nil
+}
+
+func (r *postgresAuditRepository) ListAuditEntries(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ListAuditEntries", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		SELECT id, created_at, actor_id, actor_role, actor_dummy, action, entity_type, entity_id, pvz_id,
+			before, after, request_id, trace_id
+		FROM audit_log
+		WHERE ($1::uuid IS NULL OR actor_id = $1)
+			AND ($2 = '' OR action = $2)
+			AND ($3 = '' OR entity_type = $3)
+			AND ($4::uuid IS NULL OR entity_id = $4)
+			AND ($5::uuid IS NULL OR pvz_id = $5)
+			AND ($6::timestamptz IS NULL OR created_at >= $6)
+			AND ($7::timestamptz IS NULL OR created_at <= $7)
+			AND ($8::bigint IS NULL OR id < $8)
+		ORDER BY id DESC
+		LIMIT $9
+	`
+	rows, err := pool.Query(ctx, query,
+		emptyToNil(filter.ActorID), filter.Action, filter.EntityType, emptyToNil(filter.EntityID), emptyToNil(filter.PvzID),
+		filter.StartDate, filter.EndDate, filter.BeforeID, filter.Limit,
+	)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "ListAuditEntries",
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to query audit log")
+	}
+	defer rows.Close()
+
+	var entries []entity.AuditEntry
+	for rows.Next() {
+		var e entity.AuditEntry
+		err := rows.Scan(&e.ID, &e.CreatedAt, &e.ActorID, &e.ActorRole, &e.ActorDummy, &e.Action, &e.EntityType, &e.EntityID,
+			&e.PvzID, &e.Before, &e.After, &e.RequestID, &e.TraceID)
+		if err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", "ListAuditEntries",
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan audit entry")
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return entries, nil
+}
+
+// emptyToNil превращает пустую строку в NULL, чтобы фильтр по UUID-колонке отключался.
+func emptyToNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// LoginAttemptRepository — счётчики неудачных попыток входа по email и IP.
+type LoginAttemptRepository interface {
+	GetLoginAttempts(ctx context.Context, keys []entity.LoginAttemptKey) ([]entity.LoginAttempts, error)
+	ReserveLoginAttempt(ctx context.Context, key entity.LoginAttemptKey, policy entity.LoginAttemptPolicy) (*entity.LoginAttempts, error)
+	ReleaseLoginAttempt(ctx context.Context, key entity.LoginAttemptKey, maxFailures int) error
+	ResetLoginAttempts(ctx context.Context, key entity.LoginAttemptKey) error
+	DeleteStaleLoginAttempts(ctx context.Context, before time.Time) (int64, error)
+}
+
+type postgresLoginAttemptRepository struct {
+	conn   TxManager
+	logger logger.Logger
+}
+
+func NewLoginAttemptRepository(conn TxManager, log logger.Logger) LoginAttemptRepository {
+	return &postgresLoginAttemptRepository{conn: conn, logger: log}
+}
+
+func (r *postgresLoginAttemptRepository) GetLoginAttempts(ctx context.Context, keys []entity.LoginAttemptKey) ([]entity.LoginAttempts, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetLoginAttempts", time.Since(start).Seconds())
+	}()
+
+	scopes := make([]string, 0, len(keys))
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		scopes = append(scopes, k.Scope)
+		values = append(values, k.Key)
+	}
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		SELECT a.scope, a.key, a.failures, a.last_failure_at, a.locked_until
+		FROM login_attempt a
+		JOIN unnest($1::text[], $2::text[]) AS k(scope, key) ON a.scope = k.scope AND a.key = k.key
+	`
+	rows, err := pool.Query(ctx, query, scopes, values)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "GetLoginAttempts",
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get login attempts")
+	}
+	defer rows.Close()
+
+	var attempts []entity.LoginAttempts
+	for rows.Next() {
+		var a entity.LoginAttempts
+		if err := rows.Scan(&a.Scope, &a.Key, &a.Failures, &a.LastFailureAt, &a.LockedUntil); err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", "GetLoginAttempts",
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan login attempts")
+		}
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return attempts, nil
+}
+
+// ReserveLoginAttempt атомарно проверяет и учитывает попытку входа до проверки пароля: попытка
+// считается неудачей, пока её не отменит ReleaseLoginAttempt или ResetLoginAttempts. Если ключ
+// заблокирован или с последней попытки не прошла задержка BaseDelay·2^(k-1) (не больше MaxDelay),
+// счётчик не меняется и возвращается nil. Попытка, на которой счётчик достигает MaxFailures,
+// блокирует ключ на Lockout. Если неудач не было дольше FailureWindow или блокировка истекла,
+// счёт начинается заново.
+func (r *postgresLoginAttemptRepository) ReserveLoginAttempt(ctx context.Context, key entity.LoginAttemptKey, policy entity.LoginAttemptPolicy) (*entity.LoginAttempts, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ReserveLoginAttempt", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		INSERT INTO login_attempt AS a (scope, key, failures, last_failure_at, locked_until)
+		VALUES ($1, $2, 1, now(), CASE WHEN $4 = 1 THEN now() + make_interval(secs => $5) END)
+		ON CONFLICT (scope, key) DO UPDATE SET
+			failures = CASE
+				WHEN a.last_failure_at < now() - make_interval(secs => $3) OR a.locked_until IS NOT NULL THEN 1
+				ELSE a.failures + 1
+			END,
+			locked_until = CASE
+				WHEN $4 > 0 AND CASE
+					WHEN a.last_failure_at < now() - make_interval(secs => $3) OR a.locked_until IS NOT NULL THEN 1
+					ELSE a.failures + 1
+				END >= $4 THEN now() + make_interval(secs => $5)
+			END,
+			last_failure_at = now()
+		WHERE (a.locked_until IS NULL OR a.locked_until <= now())
+			AND (a.failures = 0
+				OR a.last_failure_at < now() - make_interval(secs => $3)
+				OR a.last_failure_at + LEAST(
+					make_interval(secs => $6 * power(2, LEAST(a.failures, 31) - 1)),
+					make_interval(secs => $7)
+				) <= now())
+		RETURNING scope, key, failures, last_failure_at, locked_until
+	`
+	var a entity.LoginAttempts
+	err := pool.QueryRow(ctx, query, key.Scope, key.Key, policy.FailureWindow.Seconds(), policy.MaxFailures,
+		policy.Lockout.Seconds(), policy.BaseDelay.Seconds(), policy.MaxDelay.Seconds()).
+		Scan(&a.Scope, &a.Key, &a.Failures, &a.LastFailureAt, &a.LockedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Errorw("reserving login attempt",
+			"error", err,
+			"scope", key.Scope,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to reserve login attempt")
+	}
+	return &a, nil
+}
+
+// ReleaseLoginAttempt отменяет попытку, учтённую ReserveLoginAttempt, и снимает блокировку,
+// если счётчик опустился ниже maxFailures.
+func (r *postgresLoginAttemptRepository) ReleaseLoginAttempt(ctx context.Context, key entity.LoginAttemptKey, maxFailures int) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ReleaseLoginAttempt", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE login_attempt
+		SET failures = GREATEST(failures - 1, 0),
+			locked_until = CASE WHEN failures - 1 < $3 THEN NULL ELSE locked_until END
+		WHERE scope = $1 AND key = $2
+	`
+	if _, err := pool.Exec(ctx, query, key.Scope, key.Key, maxFailures); err != nil {
+		r.logger.Errorw("releasing login attempt",
+			"error", err,
+			"scope", key.Scope,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to release login attempt")
+	}
+	return nil
+}
+
+// ResetLoginAttempts снимает блокировку и обнуляет счётчик.
+func (r *postgresLoginAttemptRepository) ResetLoginAttempts(ctx context.Context, key entity.LoginAttemptKey) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ResetLoginAttempts", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `DELETE FROM login_attempt WHERE scope = $1 AND key = $2`
+	if _, err := pool.Exec(ctx, query, key.Scope, key.Key); err != nil {
+		r.logger.Errorw("resetting login attempts",
+			"error", err,
+			"scope", key.Scope,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to reset login attempts")
+	}
+	return nil
+}
+
+// DeleteStaleLoginAttempts удаляет счётчики без неудач после before и без действующей блокировки.
+func (r *postgresLoginAttemptRepository) DeleteStaleLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("DeleteStaleLoginAttempts", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		DELETE FROM login_attempt
+		WHERE last_failure_at < $1
+			AND (locked_until IS NULL OR locked_until < now())
+	`
+	tag, err := pool.Exec(ctx, query, before)
+	if err != nil {
+		r.logger.Errorw("deleting stale login attempts",
+			"error", err,
+		)
+		return 0, errs.Wrap(err, errs.ErrInternalCode, "failed to delete stale login attempts")
+	}
+	return tag.RowsAffected(), nil
+}
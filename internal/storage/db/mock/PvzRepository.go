@@ -8,6 +8,8 @@ import (
 	entity "order-pick-up-point/internal/models/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PvzRepository is an autogenerated mock type for the PvzRepository type
@@ -43,6 +45,54 @@ func (_m *PvzRepository) CreatePvz(ctx context.Context, pvz entity.Pvz) (string,
 	return r0, r1
 }
 
+// ExportPvzHistory provides a mock function with given fields: ctx, filter, fn
+func (_m *PvzRepository) ExportPvzHistory(ctx context.Context, filter entity.PvzExportFilter, fn func(entity.PvzExportRow) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportPvzHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzExportFilter, func(entity.PvzExportRow) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindNearbyPvzs provides a mock function with given fields: ctx, filter
+func (_m *PvzRepository) FindNearbyPvzs(ctx context.Context, filter entity.NearbyPvzFilter) ([]entity.NearbyPvz, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindNearbyPvzs")
+	}
+
+	var r0 []entity.NearbyPvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NearbyPvzFilter) ([]entity.NearbyPvz, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NearbyPvzFilter) []entity.NearbyPvz); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.NearbyPvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.NearbyPvzFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetListOfPvzs provides a mock function with given fields: ctx
 func (_m *PvzRepository) GetListOfPvzs(ctx context.Context) ([]entity.Pvz, error) {
 	ret := _m.Called(ctx)
@@ -73,9 +123,69 @@ func (_m *PvzRepository) GetListOfPvzs(ctx context.Context) ([]entity.Pvz, error
 	return r0, r1
 }
 
-// GetPvzs provides a mock function with given fields: ctx, page, limit
-func (_m *PvzRepository) GetPvzs(ctx context.Context, page int, limit int) ([]entity.Pvz, error) {
-	ret := _m.Called(ctx, page, limit)
+// GetPvzByID provides a mock function with given fields: ctx, pvzID
+func (_m *PvzRepository) GetPvzByID(ctx context.Context, pvzID string) (*entity.Pvz, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPvzByID")
+	}
+
+	var r0 *entity.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Pvz, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Pvz); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzByIDForUpdate provides a mock function with given fields: ctx, pvzID
+func (_m *PvzRepository) GetPvzByIDForUpdate(ctx context.Context, pvzID string) (*entity.Pvz, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPvzByIDForUpdate")
+	}
+
+	var r0 *entity.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Pvz, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Pvz); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzs provides a mock function with given fields: ctx, page
+func (_m *PvzRepository) GetPvzs(ctx context.Context, page entity.PvzPageRequest) ([]entity.Pvz, error) {
+	ret := _m.Called(ctx, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPvzs")
@@ -83,19 +193,49 @@ func (_m *PvzRepository) GetPvzs(ctx context.Context, page int, limit int) ([]en
 
 	var r0 []entity.Pvz
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.Pvz, error)); ok {
-		return rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest) ([]entity.Pvz, error)); ok {
+		return rf(ctx, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Pvz); ok {
-		r0 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest) []entity.Pvz); ok {
+		r0 = rf(ctx, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Pvz)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PvzPageRequest) error); ok {
+		r1 = rf(ctx, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzsWithReceptionsAndProducts provides a mock function with given fields: ctx, page, startDate, endDate
+func (_m *PvzRepository) GetPvzsWithReceptionsAndProducts(ctx context.Context, page entity.PvzPageRequest, startDate *time.Time, endDate *time.Time) ([]entity.PvzInfo, error) {
+	ret := _m.Called(ctx, page, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPvzsWithReceptionsAndProducts")
+	}
+
+	var r0 []entity.PvzInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) ([]entity.PvzInfo, error)); ok {
+		return rf(ctx, page, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) []entity.PvzInfo); ok {
+		r0 = rf(ctx, page, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PvzInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, page, startDate, endDate)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -103,6 +243,24 @@ func (_m *PvzRepository) GetPvzs(ctx context.Context, page int, limit int) ([]en
 	return r0, r1
 }
 
+// UpdatePvz provides a mock function with given fields: ctx, pvz
+func (_m *PvzRepository) UpdatePvz(ctx context.Context, pvz entity.Pvz) error {
+	ret := _m.Called(ctx, pvz)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePvz")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pvz) error); ok {
+		r0 = rf(ctx, pvz)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPvzRepository creates a new instance of PvzRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPvzRepository(t interface {
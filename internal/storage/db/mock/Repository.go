@@ -17,27 +17,29 @@ type Repository struct {
 	mock.Mock
 }
 
-// CreateProduct provides a mock function with given fields: ctx, product
-func (_m *Repository) CreateProduct(ctx context.Context, product entity.Product) (string, error) {
-	ret := _m.Called(ctx, product)
+// ClaimDueWebhookDeliveries provides a mock function with given fields: ctx, limit, lease
+func (_m *Repository) ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDeliveryTask, error) {
+	ret := _m.Called(ctx, limit, lease)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateProduct")
+		panic("no return value specified for ClaimDueWebhookDeliveries")
 	}
 
-	var r0 string
+	var r0 []entity.WebhookDeliveryTask
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Product) (string, error)); ok {
-		return rf(ctx, product)
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]entity.WebhookDeliveryTask, error)); ok {
+		return rf(ctx, limit, lease)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Product) string); ok {
-		r0 = rf(ctx, product)
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []entity.WebhookDeliveryTask); ok {
+		r0 = rf(ctx, limit, lease)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WebhookDeliveryTask)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entity.Product) error); ok {
-		r1 = rf(ctx, product)
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -45,27 +47,63 @@ func (_m *Repository) CreateProduct(ctx context.Context, product entity.Product)
 	return r0, r1
 }
 
-// CreatePvz provides a mock function with given fields: ctx, pvz
-func (_m *Repository) CreatePvz(ctx context.Context, pvz entity.Pvz) (string, error) {
-	ret := _m.Called(ctx, pvz)
+// CloseReception provides a mock function with given fields: ctx, receptionID, closedBy
+func (_m *Repository) CloseReception(ctx context.Context, receptionID string, closedBy *string) error {
+	ret := _m.Called(ctx, receptionID, closedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePvz")
+		panic("no return value specified for CloseReception")
 	}
 
-	var r0 string
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string) error); ok {
+		r0 = rf(ctx, receptionID, closedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteWebhookAttempt provides a mock function with given fields: ctx, attempt, status, nextAttemptAt
+func (_m *Repository) CompleteWebhookAttempt(ctx context.Context, attempt entity.WebhookDeliveryAttempt, status string, nextAttemptAt *time.Time) error {
+	ret := _m.Called(ctx, attempt, status, nextAttemptAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteWebhookAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookDeliveryAttempt, string, *time.Time) error); ok {
+		r0 = rf(ctx, attempt, status, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountDeadOutboxEvents provides a mock function with given fields: ctx
+func (_m *Repository) CountDeadOutboxEvents(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDeadOutboxEvents")
+	}
+
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Pvz) (string, error)); ok {
-		return rf(ctx, pvz)
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Pvz) string); ok {
-		r0 = rf(ctx, pvz)
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entity.Pvz) error); ok {
-		r1 = rf(ctx, pvz)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,27 +111,27 @@ func (_m *Repository) CreatePvz(ctx context.Context, pvz entity.Pvz) (string, er
 	return r0, r1
 }
 
-// CreateReception provides a mock function with given fields: ctx, reception
-func (_m *Repository) CreateReception(ctx context.Context, reception entity.Reception) (string, error) {
-	ret := _m.Called(ctx, reception)
+// CountOverdueOrders provides a mock function with given fields: ctx
+func (_m *Repository) CountOverdueOrders(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateReception")
+		panic("no return value specified for CountOverdueOrders")
 	}
 
-	var r0 string
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Reception) (string, error)); ok {
-		return rf(ctx, reception)
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Reception) string); ok {
-		r0 = rf(ctx, reception)
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entity.Reception) error); ok {
-		r1 = rf(ctx, reception)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -101,27 +139,29 @@ func (_m *Repository) CreateReception(ctx context.Context, reception entity.Rece
 	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: ctx, user
-func (_m *Repository) CreateUser(ctx context.Context, user entity.User) (string, error) {
-	ret := _m.Called(ctx, user)
+// CountProductsByType provides a mock function with given fields: ctx, filter
+func (_m *Repository) CountProductsByType(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductTypeCount, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for CountProductsByType")
 	}
 
-	var r0 string
+	var r0 []entity.ProductTypeCount
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.User) (string, error)); ok {
-		return rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) ([]entity.ProductTypeCount, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entity.User) string); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) []entity.ProductTypeCount); ok {
+		r0 = rf(ctx, filter)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProductTypeCount)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entity.User) error); ok {
-		r1 = rf(ctx, user)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AnalyticsFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,47 +169,57 @@ func (_m *Repository) CreateUser(ctx context.Context, user entity.User) (string,
 	return r0, r1
 }
 
-// DeleteProduct provides a mock function with given fields: ctx, productID
-func (_m *Repository) DeleteProduct(ctx context.Context, productID string) error {
-	ret := _m.Called(ctx, productID)
+// CountReceptionsPerDay provides a mock function with given fields: ctx, filter
+func (_m *Repository) CountReceptionsPerDay(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.DailyReceptions, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteProduct")
+		panic("no return value specified for CountReceptionsPerDay")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, productID)
+	var r0 []entity.DailyReceptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) ([]entity.DailyReceptions, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) []entity.DailyReceptions); ok {
+		r0 = rf(ctx, filter)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DailyReceptions)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AnalyticsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// FindByEmail provides a mock function with given fields: ctx, email
-func (_m *Repository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
-	ret := _m.Called(ctx, email)
+// CreateProduct provides a mock function with given fields: ctx, product
+func (_m *Repository) CreateProduct(ctx context.Context, product entity.Product) (string, error) {
+	ret := _m.Called(ctx, product)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByEmail")
+		panic("no return value specified for CreateProduct")
 	}
 
-	var r0 *entity.User
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
-		return rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Product) (string, error)); ok {
+		return rf(ctx, product)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
-		r0 = rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Product) string); ok {
+		r0 = rf(ctx, product)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.User)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, email)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Product) error); ok {
+		r1 = rf(ctx, product)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -177,29 +227,29 @@ func (_m *Repository) FindByEmail(ctx context.Context, email string) (*entity.Us
 	return r0, r1
 }
 
-// FindLastProductInReception provides a mock function with given fields: ctx, pvzID
-func (_m *Repository) FindLastProductInReception(ctx context.Context, pvzID string) (*entity.Product, error) {
-	ret := _m.Called(ctx, pvzID)
+// CreateProducts provides a mock function with given fields: ctx, products, skipDuplicates
+func (_m *Repository) CreateProducts(ctx context.Context, products []entity.Product, skipDuplicates bool) ([]string, error) {
+	ret := _m.Called(ctx, products, skipDuplicates)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindLastProductInReception")
+		panic("no return value specified for CreateProducts")
 	}
 
-	var r0 *entity.Product
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Product, error)); ok {
-		return rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Product, bool) ([]string, error)); ok {
+		return rf(ctx, products, skipDuplicates)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Product); ok {
-		r0 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Product, bool) []string); ok {
+		r0 = rf(ctx, products, skipDuplicates)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Product)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Product, bool) error); ok {
+		r1 = rf(ctx, products, skipDuplicates)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -207,29 +257,27 @@ func (_m *Repository) FindLastProductInReception(ctx context.Context, pvzID stri
 	return r0, r1
 }
 
-// FindOpenReceptionByPvzID provides a mock function with given fields: ctx, pvzID
-func (_m *Repository) FindOpenReceptionByPvzID(ctx context.Context, pvzID string) (*entity.Reception, error) {
-	ret := _m.Called(ctx, pvzID)
+// CreatePvz provides a mock function with given fields: ctx, pvz
+func (_m *Repository) CreatePvz(ctx context.Context, pvz entity.Pvz) (string, error) {
+	ret := _m.Called(ctx, pvz)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindOpenReceptionByPvzID")
+		panic("no return value specified for CreatePvz")
 	}
 
-	var r0 *entity.Reception
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Reception, error)); ok {
-		return rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pvz) (string, error)); ok {
+		return rf(ctx, pvz)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Reception); ok {
-		r0 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pvz) string); ok {
+		r0 = rf(ctx, pvz)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Reception)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pvz) error); ok {
+		r1 = rf(ctx, pvz)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -237,29 +285,27 @@ func (_m *Repository) FindOpenReceptionByPvzID(ctx context.Context, pvzID string
 	return r0, r1
 }
 
-// GetListOfPvzs provides a mock function with given fields: ctx
-func (_m *Repository) GetListOfPvzs(ctx context.Context) ([]entity.Pvz, error) {
-	ret := _m.Called(ctx)
+// CreateReception provides a mock function with given fields: ctx, reception
+func (_m *Repository) CreateReception(ctx context.Context, reception entity.Reception) (string, error) {
+	ret := _m.Called(ctx, reception)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListOfPvzs")
+		panic("no return value specified for CreateReception")
 	}
 
-	var r0 []entity.Pvz
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Pvz, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Reception) (string, error)); ok {
+		return rf(ctx, reception)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []entity.Pvz); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Reception) string); ok {
+		r0 = rf(ctx, reception)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Pvz)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Reception) error); ok {
+		r1 = rf(ctx, reception)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -267,29 +313,45 @@ func (_m *Repository) GetListOfPvzs(ctx context.Context) ([]entity.Pvz, error) {
 	return r0, r1
 }
 
-// GetProductsByReceptionID provides a mock function with given fields: ctx, receptionID
-func (_m *Repository) GetProductsByReceptionID(ctx context.Context, receptionID string) ([]entity.Product, error) {
-	ret := _m.Called(ctx, receptionID)
+// CreateRefreshToken provides a mock function with given fields: ctx, token
+func (_m *Repository) CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	ret := _m.Called(ctx, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProductsByReceptionID")
+		panic("no return value specified for CreateRefreshToken")
 	}
 
-	var r0 []entity.Product
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RefreshToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateReturn provides a mock function with given fields: ctx, shipment
+func (_m *Repository) CreateReturn(ctx context.Context, shipment entity.ReturnShipment) (string, error) {
+	ret := _m.Called(ctx, shipment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReturn")
+	}
+
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Product, error)); ok {
-		return rf(ctx, receptionID)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReturnShipment) (string, error)); ok {
+		return rf(ctx, shipment)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Product); ok {
-		r0 = rf(ctx, receptionID)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReturnShipment) string); ok {
+		r0 = rf(ctx, shipment)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Product)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, receptionID)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReturnShipment) error); ok {
+		r1 = rf(ctx, shipment)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -297,29 +359,27 @@ func (_m *Repository) GetProductsByReceptionID(ctx context.Context, receptionID
 	return r0, r1
 }
 
-// GetPvzs provides a mock function with given fields: ctx, page, limit
-func (_m *Repository) GetPvzs(ctx context.Context, page int, limit int) ([]entity.Pvz, error) {
-	ret := _m.Called(ctx, page, limit)
+// CreateReturnItem provides a mock function with given fields: ctx, item
+func (_m *Repository) CreateReturnItem(ctx context.Context, item entity.ReturnItem) (string, error) {
+	ret := _m.Called(ctx, item)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPvzs")
+		panic("no return value specified for CreateReturnItem")
 	}
 
-	var r0 []entity.Pvz
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.Pvz, error)); ok {
-		return rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReturnItem) (string, error)); ok {
+		return rf(ctx, item)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Pvz); ok {
-		r0 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReturnItem) string); ok {
+		r0 = rf(ctx, item)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Pvz)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReturnItem) error); ok {
+		r1 = rf(ctx, item)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -327,29 +387,27 @@ func (_m *Repository) GetPvzs(ctx context.Context, page int, limit int) ([]entit
 	return r0, r1
 }
 
-// GetReceptionsByPvzIDFiltered provides a mock function with given fields: ctx, pvzID, startDate, endDate
-func (_m *Repository) GetReceptionsByPvzIDFiltered(ctx context.Context, pvzID string, startDate *time.Time, endDate *time.Time) ([]entity.Reception, error) {
-	ret := _m.Called(ctx, pvzID, startDate, endDate)
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *Repository) CreateUser(ctx context.Context, user entity.User) (string, error) {
+	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReceptionsByPvzIDFiltered")
+		panic("no return value specified for CreateUser")
 	}
 
-	var r0 []entity.Reception
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) ([]entity.Reception, error)); ok {
-		return rf(ctx, pvzID, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) (string, error)); ok {
+		return rf(ctx, user)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) []entity.Reception); ok {
-		r0 = rf(ctx, pvzID, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) string); ok {
+		r0 = rf(ctx, user)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Reception)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *time.Time, *time.Time) error); ok {
-		r1 = rf(ctx, pvzID, startDate, endDate)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.User) error); ok {
+		r1 = rf(ctx, user)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -357,17 +415,1779 @@ func (_m *Repository) GetReceptionsByPvzIDFiltered(ctx context.Context, pvzID st
 	return r0, r1
 }
 
-// UpdateReceptionStatus provides a mock function with given fields: ctx, receptionID, status
-func (_m *Repository) UpdateReceptionStatus(ctx context.Context, receptionID string, status string) error {
-	ret := _m.Called(ctx, receptionID, status)
+// CreateWebhookSubscription provides a mock function with given fields: ctx, sub
+func (_m *Repository) CreateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	ret := _m.Called(ctx, sub)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateReceptionStatus")
+		panic("no return value specified for CreateWebhookSubscription")
 	}
 
-	var r0 error
+	var r0 *entity.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookSubscription) (*entity.WebhookSubscription, error)); ok {
+		return rf(ctx, sub)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookSubscription) *entity.WebhookSubscription); ok {
+		r0 = rf(ctx, sub)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.WebhookSubscription) error); ok {
+		r1 = rf(ctx, sub)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExpiredTokens provides a mock function with given fields: ctx
+func (_m *Repository) DeleteExpiredTokens(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredTokens")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteProduct provides a mock function with given fields: ctx, productID
+func (_m *Repository) DeleteProduct(ctx context.Context, productID string) error {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePublishedOutboxEvents provides a mock function with given fields: ctx, before, limit
+func (_m *Repository) DeletePublishedOutboxEvents(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePublishedOutboxEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) (int64, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteReturnItem provides a mock function with given fields: ctx, itemID
+func (_m *Repository) DeleteReturnItem(ctx context.Context, itemID string) error {
+	ret := _m.Called(ctx, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReturnItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteStaleLoginAttempts provides a mock function with given fields: ctx, before
+func (_m *Repository) DeleteStaleLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStaleLoginAttempts")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhookSubscription provides a mock function with given fields: ctx, id
+func (_m *Repository) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnqueueWebhookDeliveries provides a mock function with given fields: ctx, event
+func (_m *Repository) EnqueueWebhookDeliveries(ctx context.Context, event entity.OutboxEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueWebhookDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.OutboxEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportPvzHistory provides a mock function with given fields: ctx, filter, fn
+func (_m *Repository) ExportPvzHistory(ctx context.Context, filter entity.PvzExportFilter, fn func(entity.PvzExportRow) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportPvzHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzExportFilter, func(entity.PvzExportRow) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchDueOutboxEvents provides a mock function with given fields: ctx, limit
+func (_m *Repository) FetchDueOutboxEvents(ctx context.Context, limit int) ([]entity.OutboxEvent, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDueOutboxEvents")
+	}
+
+	var r0 []entity.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entity.OutboxEvent, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.OutboxEvent); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindActiveBarcodes provides a mock function with given fields: ctx, barcodes
+func (_m *Repository) FindActiveBarcodes(ctx context.Context, barcodes []string) ([]string, error) {
+	ret := _m.Called(ctx, barcodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveBarcodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, barcodes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, barcodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, barcodes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByEmail provides a mock function with given fields: ctx, email
+func (_m *Repository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByEmail")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindByID(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLastProductInReception provides a mock function with given fields: ctx, pvzID
+func (_m *Repository) FindLastProductInReception(ctx context.Context, pvzID string) (*entity.Product, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLastProductInReception")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Product, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Product); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLastReturnItem provides a mock function with given fields: ctx, pvzID
+func (_m *Repository) FindLastReturnItem(ctx context.Context, pvzID string) (*entity.ReturnItem, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLastReturnItem")
+	}
+
+	var r0 *entity.ReturnItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.ReturnItem, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.ReturnItem); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ReturnItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindNearbyPvzs provides a mock function with given fields: ctx, filter
+func (_m *Repository) FindNearbyPvzs(ctx context.Context, filter entity.NearbyPvzFilter) ([]entity.NearbyPvz, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindNearbyPvzs")
+	}
+
+	var r0 []entity.NearbyPvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NearbyPvzFilter) ([]entity.NearbyPvz, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NearbyPvzFilter) []entity.NearbyPvz); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.NearbyPvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.NearbyPvzFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOpenReceptionByPvzID provides a mock function with given fields: ctx, pvzID
+func (_m *Repository) FindOpenReceptionByPvzID(ctx context.Context, pvzID string) (*entity.Reception, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOpenReceptionByPvzID")
+	}
+
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Reception, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Reception); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOpenReturnByPvzID provides a mock function with given fields: ctx, pvzID
+func (_m *Repository) FindOpenReturnByPvzID(ctx context.Context, pvzID string) (*entity.ReturnShipment, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOpenReturnByPvzID")
+	}
+
+	var r0 *entity.ReturnShipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.ReturnShipment, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.ReturnShipment); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ReturnShipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOrderByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *Repository) FindOrderByBarcode(ctx context.Context, barcode string) (*entity.Order, error) {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrderByBarcode")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Order, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Order); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOrderForUpdate provides a mock function with given fields: ctx, pvzID, productID
+func (_m *Repository) FindOrderForUpdate(ctx context.Context, pvzID string, productID string) (*entity.Order, error) {
+	ret := _m.Called(ctx, pvzID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrderForUpdate")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Order, error)); ok {
+		return rf(ctx, pvzID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Order); ok {
+		r0 = rf(ctx, pvzID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRecipientOrder provides a mock function with given fields: ctx, recipientID, productID
+func (_m *Repository) FindRecipientOrder(ctx context.Context, recipientID string, productID string) (*entity.Order, error) {
+	ret := _m.Called(ctx, recipientID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecipientOrder")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Order, error)); ok {
+		return rf(ctx, recipientID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Order); ok {
+		r0 = rf(ctx, recipientID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, recipientID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetListOfPvzs provides a mock function with given fields: ctx
+func (_m *Repository) GetListOfPvzs(ctx context.Context) ([]entity.Pvz, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListOfPvzs")
+	}
+
+	var r0 []entity.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Pvz, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Pvz); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginAttempts provides a mock function with given fields: ctx, keys
+func (_m *Repository) GetLoginAttempts(ctx context.Context, keys []entity.LoginAttemptKey) ([]entity.LoginAttempts, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoginAttempts")
+	}
+
+	var r0 []entity.LoginAttempts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.LoginAttemptKey) ([]entity.LoginAttempts, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.LoginAttemptKey) []entity.LoginAttempts); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LoginAttempts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.LoginAttemptKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrdersByRecipient provides a mock function with given fields: ctx, pvzID, recipientID, status
+func (_m *Repository) GetOrdersByRecipient(ctx context.Context, pvzID string, recipientID string, status string) ([]entity.Order, error) {
+	ret := _m.Called(ctx, pvzID, recipientID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrdersByRecipient")
+	}
+
+	var r0 []entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]entity.Order, error)); ok {
+		return rf(ctx, pvzID, recipientID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []entity.Order); ok {
+		r0 = rf(ctx, pvzID, recipientID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, pvzID, recipientID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverdueOrders provides a mock function with given fields: ctx, pvzID
+func (_m *Repository) GetOverdueOrders(ctx context.Context, pvzID string) ([]entity.Order, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdueOrders")
+	}
+
+	var r0 []entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Order, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Order); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProductsByReceptionID provides a mock function with given fields: ctx, receptionID
+func (_m *Repository) GetProductsByReceptionID(ctx context.Context, receptionID string) ([]entity.Product, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsByReceptionID")
+	}
+
+	var r0 []entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Product, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Product); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzByID provides a mock function with given fields: ctx, pvzID
+func (_m *Repository) GetPvzByID(ctx context.Context, pvzID string) (*entity.Pvz, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPvzByID")
+	}
+
+	var r0 *entity.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Pvz, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Pvz); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzByIDForUpdate provides a mock function with given fields: ctx, pvzID
+func (_m *Repository) GetPvzByIDForUpdate(ctx context.Context, pvzID string) (*entity.Pvz, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPvzByIDForUpdate")
+	}
+
+	var r0 *entity.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Pvz, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Pvz); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzs provides a mock function with given fields: ctx, page
+func (_m *Repository) GetPvzs(ctx context.Context, page entity.PvzPageRequest) ([]entity.Pvz, error) {
+	ret := _m.Called(ctx, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPvzs")
+	}
+
+	var r0 []entity.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest) ([]entity.Pvz, error)); ok {
+		return rf(ctx, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest) []entity.Pvz); ok {
+		r0 = rf(ctx, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PvzPageRequest) error); ok {
+		r1 = rf(ctx, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPvzsWithReceptionsAndProducts provides a mock function with given fields: ctx, page, startDate, endDate
+func (_m *Repository) GetPvzsWithReceptionsAndProducts(ctx context.Context, page entity.PvzPageRequest, startDate *time.Time, endDate *time.Time) ([]entity.PvzInfo, error) {
+	ret := _m.Called(ctx, page, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPvzsWithReceptionsAndProducts")
+	}
+
+	var r0 []entity.PvzInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) ([]entity.PvzInfo, error)); ok {
+		return rf(ctx, page, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) []entity.PvzInfo); ok {
+		r0 = rf(ctx, page, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PvzInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PvzPageRequest, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, page, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceptionStats provides a mock function with given fields: ctx, filter
+func (_m *Repository) GetReceptionStats(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionStats, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptionStats")
+	}
+
+	var r0 []entity.ReceptionStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) ([]entity.ReceptionStats, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AnalyticsFilter) []entity.ReceptionStats); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReceptionStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AnalyticsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceptionsByPvzIDFiltered provides a mock function with given fields: ctx, pvzID, startDate, endDate
+func (_m *Repository) GetReceptionsByPvzIDFiltered(ctx context.Context, pvzID string, startDate *time.Time, endDate *time.Time) ([]entity.Reception, error) {
+	ret := _m.Called(ctx, pvzID, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptionsByPvzIDFiltered")
+	}
+
+	var r0 []entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) ([]entity.Reception, error)); ok {
+		return rf(ctx, pvzID, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) []entity.Reception); ok {
+		r0 = rf(ctx, pvzID, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, pvzID, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecipientOrders provides a mock function with given fields: ctx, recipientID, status
+func (_m *Repository) GetRecipientOrders(ctx context.Context, recipientID string, status *string) ([]entity.Order, error) {
+	ret := _m.Called(ctx, recipientID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipientOrders")
+	}
+
+	var r0 []entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string) ([]entity.Order, error)); ok {
+		return rf(ctx, recipientID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string) []entity.Order); ok {
+		r0 = rf(ctx, recipientID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *string) error); ok {
+		r1 = rf(ctx, recipientID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshTokenForUpdate provides a mock function with given fields: ctx, tokenHash
+func (_m *Repository) GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshTokenForUpdate")
+	}
+
+	var r0 *entity.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.RefreshToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.RefreshToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokenFamilyByAccessJTI provides a mock function with given fields: ctx, jti
+func (_m *Repository) GetTokenFamilyByAccessJTI(ctx context.Context, jti string) (string, error) {
+	ret := _m.Called(ctx, jti)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenFamilyByAccessJTI")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, jti)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, jti)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDelivery provides a mock function with given fields: ctx, subscriptionID, id
+func (_m *Repository) GetWebhookDelivery(ctx context.Context, subscriptionID string, id int64) (*entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 *entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*entity.WebhookDelivery, error)); ok {
+		return rf(ctx, subscriptionID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *entity.WebhookDelivery); ok {
+		r0 = rf(ctx, subscriptionID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, subscriptionID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookSubscription provides a mock function with given fields: ctx, id
+func (_m *Repository) GetWebhookSubscription(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookSubscription")
+	}
+
+	var r0 *entity.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.WebhookSubscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.WebhookSubscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertAuditEntry provides a mock function with given fields: ctx, entry
+func (_m *Repository) InsertAuditEntry(ctx context.Context, entry entity.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertAuditEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertOutboxEvent provides a mock function with given fields: ctx, event
+func (_m *Repository) InsertOutboxEvent(ctx context.Context, event entity.OutboxEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.OutboxEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsTokenRevoked provides a mock function with given fields: ctx, jti
+func (_m *Repository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	ret := _m.Called(ctx, jti)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTokenRevoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, jti)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, jti)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuditEntries provides a mock function with given fields: ctx, filter
+func (_m *Repository) ListAuditEntries(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEntries")
+	}
+
+	var r0 []entity.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditFilter) ([]entity.AuditEntry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditFilter) []entity.AuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListConcurrentPvzActivity provides a mock function with given fields: ctx, pvzIDs, lastEventID, afterID, limit
+func (_m *Repository) ListConcurrentPvzActivity(ctx context.Context, pvzIDs []string, lastEventID int64, afterID int64, limit int) ([]entity.OutboxEvent, error) {
+	ret := _m.Called(ctx, pvzIDs, lastEventID, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListConcurrentPvzActivity")
+	}
+
+	var r0 []entity.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int64, int64, int) ([]entity.OutboxEvent, error)); ok {
+		return rf(ctx, pvzIDs, lastEventID, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int64, int64, int) []entity.OutboxEvent); ok {
+		r0 = rf(ctx, pvzIDs, lastEventID, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int64, int64, int) error); ok {
+		r1 = rf(ctx, pvzIDs, lastEventID, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPvzActivity provides a mock function with given fields: ctx, pvzIDs, afterID, limit
+func (_m *Repository) ListPvzActivity(ctx context.Context, pvzIDs []string, afterID int64, limit int) ([]entity.OutboxEvent, error) {
+	ret := _m.Called(ctx, pvzIDs, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPvzActivity")
+	}
+
+	var r0 []entity.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int64, int) ([]entity.OutboxEvent, error)); ok {
+		return rf(ctx, pvzIDs, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int64, int) []entity.OutboxEvent); ok {
+		r0 = rf(ctx, pvzIDs, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int64, int) error); ok {
+		r1 = rf(ctx, pvzIDs, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter
+func (_m *Repository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserFilter) ([]entity.User, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserFilter) []entity.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, filter
+func (_m *Repository) ListWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookDeliveryFilter) []entity.WebhookDelivery); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.WebhookDeliveryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookDeliveryAttempts provides a mock function with given fields: ctx, deliveryID
+func (_m *Repository) ListWebhookDeliveryAttempts(ctx context.Context, deliveryID int64) ([]entity.WebhookDeliveryAttempt, error) {
+	ret := _m.Called(ctx, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveryAttempts")
+	}
+
+	var r0 []entity.WebhookDeliveryAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]entity.WebhookDeliveryAttempt, error)); ok {
+		return rf(ctx, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.WebhookDeliveryAttempt); ok {
+		r0 = rf(ctx, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WebhookDeliveryAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookSubscriptions provides a mock function with given fields: ctx
+func (_m *Repository) ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookSubscriptions")
+	}
+
+	var r0 []entity.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkExpiredOrders provides a mock function with given fields: ctx, batchSize
+func (_m *Repository) MarkExpiredOrders(ctx context.Context, batchSize int) (int64, error) {
+	ret := _m.Called(ctx, batchSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkExpiredOrders")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
+		return rf(ctx, batchSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+		r0 = rf(ctx, batchSize)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkOrderIssued provides a mock function with given fields: ctx, productID, issuedBy, issuedAt
+func (_m *Repository) MarkOrderIssued(ctx context.Context, productID string, issuedBy *string, issuedAt time.Time) error {
+	ret := _m.Called(ctx, productID, issuedBy, issuedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOrderIssued")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string, time.Time) error); ok {
+		r0 = rf(ctx, productID, issuedBy, issuedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkOrderReturned provides a mock function with given fields: ctx, productID
+func (_m *Repository) MarkOrderReturned(ctx context.Context, productID string) error {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOrderReturned")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkOutboxEventFailed provides a mock function with given fields: ctx, id, nextAttemptAt, lastError
+func (_m *Repository) MarkOutboxEventFailed(ctx context.Context, id int64, nextAttemptAt *time.Time, lastError string) error {
+	ret := _m.Called(ctx, id, nextAttemptAt, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxEventFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *time.Time, string) error); ok {
+		r0 = rf(ctx, id, nextAttemptAt, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkOutboxEventPublished provides a mock function with given fields: ctx, id
+func (_m *Repository) MarkOutboxEventPublished(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxEventPublished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkRefreshTokenUsed provides a mock function with given fields: ctx, id
+func (_m *Repository) MarkRefreshTokenUsed(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRefreshTokenUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordPickupCodeFailure provides a mock function with given fields: ctx, productID
+func (_m *Repository) RecordPickupCodeFailure(ctx context.Context, productID string) error {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPickupCodeFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseLoginAttempt provides a mock function with given fields: ctx, key, maxFailures
+func (_m *Repository) ReleaseLoginAttempt(ctx context.Context, key entity.LoginAttemptKey, maxFailures int) error {
+	ret := _m.Called(ctx, key, maxFailures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseLoginAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginAttemptKey, int) error); ok {
+		r0 = rf(ctx, key, maxFailures)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplacePickupCode provides a mock function with given fields: ctx, productID, pickupCodeHash
+func (_m *Repository) ReplacePickupCode(ctx context.Context, productID string, pickupCodeHash string) error {
+	ret := _m.Called(ctx, productID, pickupCodeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplacePickupCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, productID, pickupCodeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveLoginAttempt provides a mock function with given fields: ctx, key, policy
+func (_m *Repository) ReserveLoginAttempt(ctx context.Context, key entity.LoginAttemptKey, policy entity.LoginAttemptPolicy) (*entity.LoginAttempts, error) {
+	ret := _m.Called(ctx, key, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveLoginAttempt")
+	}
+
+	var r0 *entity.LoginAttempts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginAttemptKey, entity.LoginAttemptPolicy) (*entity.LoginAttempts, error)); ok {
+		return rf(ctx, key, policy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginAttemptKey, entity.LoginAttemptPolicy) *entity.LoginAttempts); ok {
+		r0 = rf(ctx, key, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LoginAttempts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.LoginAttemptKey, entity.LoginAttemptPolicy) error); ok {
+		r1 = rf(ctx, key, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetLoginAttempts provides a mock function with given fields: ctx, key
+func (_m *Repository) ResetLoginAttempts(ctx context.Context, key entity.LoginAttemptKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLoginAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginAttemptKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetryWebhookDelivery provides a mock function with given fields: ctx, id
+func (_m *Repository) RetryWebhookDelivery(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAccessToken provides a mock function with given fields: ctx, jti, expiresAt
+func (_m *Repository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, jti, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAccessToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, jti, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeTokenFamily provides a mock function with given fields: ctx, familyID
+func (_m *Repository) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	ret := _m.Called(ctx, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserTokens provides a mock function with given fields: ctx, userID, exceptFamilyID
+func (_m *Repository) RevokeUserTokens(ctx context.Context, userID string, exceptFamilyID string) error {
+	ret := _m.Called(ctx, userID, exceptFamilyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, exceptFamilyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetOrderRecipient provides a mock function with given fields: ctx, productID, recipientID
+func (_m *Repository) SetOrderRecipient(ctx context.Context, productID string, recipientID string) error {
+	ret := _m.Called(ctx, productID, recipientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOrderRecipient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, productID, recipientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetProductStatus provides a mock function with given fields: ctx, productID, status
+func (_m *Repository) SetProductStatus(ctx context.Context, productID string, status string) error {
+	ret := _m.Called(ctx, productID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProductStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, productID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserActive provides a mock function with given fields: ctx, userID, active
+func (_m *Repository) SetUserActive(ctx context.Context, userID string, active bool) error {
+	ret := _m.Called(ctx, userID, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, userID, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShipReturnItems provides a mock function with given fields: ctx, returnID
+func (_m *Repository) ShipReturnItems(ctx context.Context, returnID string) (int64, error) {
+	ret := _m.Called(ctx, returnID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShipReturnItems")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, returnID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, returnID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, returnID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePvz provides a mock function with given fields: ctx, pvz
+func (_m *Repository) UpdatePvz(ctx context.Context, pvz entity.Pvz) error {
+	ret := _m.Called(ctx, pvz)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePvz")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pvz) error); ok {
+		r0 = rf(ctx, pvz)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateReturnStatus provides a mock function with given fields: ctx, returnID, status
+func (_m *Repository) UpdateReturnStatus(ctx context.Context, returnID string, status string) error {
+	ret := _m.Called(ctx, returnID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReturnStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, returnID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserPassword provides a mock function with given fields: ctx, userID, passwordHash, changeRequired
+func (_m *Repository) UpdateUserPassword(ctx context.Context, userID string, passwordHash string, changeRequired bool) error {
+	ret := _m.Called(ctx, userID, passwordHash, changeRequired)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, userID, passwordHash, changeRequired)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserRole provides a mock function with given fields: ctx, userID, role
+func (_m *Repository) UpdateUserRole(ctx context.Context, userID string, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, receptionID, status)
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWebhookSubscription provides a mock function with given fields: ctx, sub
+func (_m *Repository) UpdateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) error {
+	ret := _m.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookSubscription) error); ok {
+		r0 = rf(ctx, sub)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,446 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// OrderRepository — операции выдачи товаров получателям (таблица product).
+type OrderRepository interface {
+	FindOrderForUpdate(ctx context.Context, pvzID, productID string) (*entity.Order, error)
+	SetOrderRecipient(ctx context.Context, productID, recipientID string) error
+	RecordPickupCodeFailure(ctx context.Context, productID string) error
+	ReplacePickupCode(ctx context.Context, productID, pickupCodeHash string) error
+	MarkOrderIssued(ctx context.Context, productID string, issuedBy *string, issuedAt time.Time) error
+	MarkOrderReturned(ctx context.Context, productID string) error
+	GetOrdersByRecipient(ctx context.Context, pvzID, recipientID, status string) ([]entity.Order, error)
+	GetRecipientOrders(ctx context.Context, recipientID string, status *string) ([]entity.Order, error)
+	FindRecipientOrder(ctx context.Context, recipientID, productID string) (*entity.Order, error)
+	MarkExpiredOrders(ctx context.Context, batchSize int) (int64, error)
+	CountOverdueOrders(ctx context.Context) (int64, error)
+	GetOverdueOrders(ctx context.Context, pvzID string) ([]entity.Order, error)
+	FindOrderByBarcode(ctx context.Context, barcode string) (*entity.Order, error)
+}
+
+type postgresOrderRepository struct {
+	conn   TxManager
+	logger logger.Logger
+}
+
+func NewOrderRepository(conn TxManager, log logger.Logger) OrderRepository {
+	return &postgresOrderRepository{
+		conn:   conn,
+		logger: log,
+	}
+}
+
+const orderColumns = `
+	p.id, p.type, p.reception_id, r.status, r.pvz_id, v.city, v.address, p.status,
+	p.recipient_id, p.pickup_code_hash, p.pickup_code_failures, p.date_time, p.issued_at, p.issued_by,
+	p.expires_at, p.expired_at, COALESCE(p.barcode, '')
+`
+
+const orderTables = `
+	FROM product p
+	JOIN reception r ON p.reception_id = r.id
+	JOIN pvz v ON r.pvz_id = v.id
+`
+
+func scanOrder(row pgx.Row) (*entity.Order, error) {
+	var order entity.Order
+	err := row.Scan(
+		&order.ProductID,
+		&order.ProductType,
+		&order.ReceptionID,
+		&order.ReceptionStatus,
+		&order.PvzID,
+		&order.PvzCity,
+		&order.PvzAddress,
+		&order.Status,
+		&order.RecipientID,
+		&order.PickupCodeHash,
+		&order.PickupFailures,
+		&order.ReceivedAt,
+		&order.IssuedAt,
+		&order.IssuedBy,
+		&order.ExpiresAt,
+		&order.ExpiredAt,
+		&order.Barcode,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *postgresOrderRepository) FindOrderForUpdate(ctx context.Context, pvzID, productID string) (*entity.Order, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FindOrderForUpdate", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+
+	// Блокируем строку товара, чтобы параллельная выдача не прошла дважды
+	query := `
+		SELECT` + orderColumns + orderTables + `
+		WHERE p.id = $1 AND r.pvz_id = $2
+		FOR UPDATE OF p
+	`
+
+	order, err := scanOrder(pool.QueryRow(ctx, query, productID, pvzID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrProductNotFound, "product not found in this PVZ")
+		}
+		r.logger.Errorw("finding order",
+			"error", err,
+			"pvzID", pvzID,
+			"productID", productID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find order")
+	}
+	return order, nil
+}
+
+// SetOrderRecipient назначает получателя. Кода выдачи у заказа нет, пока его не запросит получатель.
+func (r *postgresOrderRepository) SetOrderRecipient(ctx context.Context, productID, recipientID string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("SetOrderRecipient", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE product
+		SET status = 'ready_for_pickup', recipient_id = $2, pickup_code_hash = NULL, pickup_code_failures = 0
+		WHERE id = $1 AND status = 'received'
+	`
+	cmdTag, err := pool.Exec(ctx, query, productID, recipientID)
+	if err != nil {
+		r.logger.Errorw("setting order recipient",
+			"error", err,
+			"productID", productID,
+			"recipientID", recipientID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to set order recipient")
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return errs.New(errs.ErrInvalidProductStatus, "product is not in 'received' status")
+	}
+	return nil
+}
+
+// RecordPickupCodeFailure учитывает неверный код, введённый при выдаче товара.
+func (r *postgresOrderRepository) RecordPickupCodeFailure(ctx context.Context, productID string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("RecordPickupCodeFailure", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE product
+		SET pickup_code_failures = pickup_code_failures + 1
+		WHERE id = $1
+	`
+	if _, err := pool.Exec(ctx, query, productID); err != nil {
+		r.logger.Errorw("recording pickup code failure",
+			"error", err,
+			"productID", productID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to record pickup code failure")
+	}
+	return nil
+}
+
+// ReplacePickupCode сохраняет хеш нового кода выдачи и сбрасывает счётчик неверных кодов.
+func (r *postgresOrderRepository) ReplacePickupCode(ctx context.Context, productID, pickupCodeHash string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ReplacePickupCode", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE product
+		SET pickup_code_hash = $2, pickup_code_failures = 0
+		WHERE id = $1 AND status = 'ready_for_pickup'
+	`
+	cmdTag, err := pool.Exec(ctx, query, productID, pickupCodeHash)
+	if err != nil {
+		r.logger.Errorw("replacing pickup code",
+			"error", err,
+			"productID", productID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to replace pickup code")
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return errs.New(errs.ErrInvalidProductStatus, "product is not ready for pickup")
+	}
+	return nil
+}
+
+func (r *postgresOrderRepository) MarkOrderIssued(ctx context.Context, productID string, issuedBy *string, issuedAt time.Time) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("MarkOrderIssued", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE product
+		SET status = 'issued', issued_at = $2, issued_by = $3
+		WHERE id = $1 AND status = 'ready_for_pickup'
+	`
+	cmdTag, err := pool.Exec(ctx, query, productID, issuedAt, issuedBy)
+	if err != nil {
+		r.logger.Errorw("marking order issued",
+			"error", err,
+			"productID", productID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to mark order issued")
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return errs.New(errs.ErrInvalidProductStatus, "product is not ready for pickup")
+	}
+	return nil
+}
+
+func (r *postgresOrderRepository) MarkOrderReturned(ctx context.Context, productID string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("MarkOrderReturned", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE product
+		SET status = 'returned'
+		WHERE id = $1 AND status = 'ready_for_pickup'
+	`
+	cmdTag, err := pool.Exec(ctx, query, productID)
+	if err != nil {
+		r.logger.Errorw("marking order returned",
+			"error", err,
+			"productID", productID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to mark order returned")
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return errs.New(errs.ErrInvalidProductStatus, "product is not ready for pickup")
+	}
+	return nil
+}
+
+func (r *postgresOrderRepository) GetOrdersByRecipient(ctx context.Context, pvzID, recipientID, status string) ([]entity.Order, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetOrdersByRecipient", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT` + orderColumns + orderTables + `
+		WHERE r.pvz_id = $1 AND p.recipient_id = $2 AND p.status = $3
+		ORDER BY p.date_time
+	`
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, pvzID, recipientID, status)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"pvzID", pvzID,
+			"recipientID", recipientID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get orders")
+	}
+
+	return r.collectOrders(rows, recipientID)
+}
+
+func (r *postgresOrderRepository) GetRecipientOrders(ctx context.Context, recipientID string, status *string) ([]entity.Order, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetRecipientOrders", time.Since(start).Seconds())
+	}()
+
+	// status == nil — все заказы получателя во всех ПВЗ
+	query := `
+		SELECT` + orderColumns + orderTables + `
+		WHERE p.recipient_id = $1 AND ($2::text IS NULL OR p.status = $2)
+		ORDER BY p.date_time DESC
+	`
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, recipientID, status)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"recipientID", recipientID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get orders")
+	}
+
+	return r.collectOrders(rows, recipientID)
+}
+
+func (r *postgresOrderRepository) FindRecipientOrder(ctx context.Context, recipientID, productID string) (*entity.Order, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FindRecipientOrder", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT` + orderColumns + orderTables + `
+		WHERE p.id = $1 AND p.recipient_id = $2
+	`
+	order, err := scanOrder(r.conn.GetExecutor(ctx).QueryRow(ctx, query, productID, recipientID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrProductNotFound, "order not found")
+		}
+		r.logger.Errorw("finding recipient order",
+			"error", err,
+			"recipientID", recipientID,
+			"productID", productID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find order")
+	}
+	return order, nil
+}
+
+func (r *postgresOrderRepository) MarkExpiredOrders(ctx context.Context, batchSize int) (int64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("MarkExpiredOrders", time.Since(start).Seconds())
+	}()
+
+	// SKIP LOCKED не даёт нескольким экземплярам приложения обработать одни и те же строки:
+	// строки, заблокированные соседним воркером, пропускаются и достанутся следующему проходу
+	query := `
+		UPDATE product
+		SET expired_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM product
+			WHERE expired_at IS NULL
+				AND expires_at <= NOW()
+				AND status IN ('received', 'ready_for_pickup')
+			ORDER BY expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+	`
+	cmdTag, err := r.conn.GetExecutor(ctx).Exec(ctx, query, batchSize)
+	if err != nil {
+		r.logger.Errorw("marking expired orders",
+			"error", err,
+			"batchSize", batchSize,
+		)
+		return 0, errs.Wrap(err, errs.ErrInternalCode, "failed to mark expired orders")
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+func (r *postgresOrderRepository) CountOverdueOrders(ctx context.Context) (int64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("CountOverdueOrders", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT COUNT(*)
+		FROM product
+		WHERE expired_at IS NOT NULL AND status IN ('received', 'ready_for_pickup')
+	`
+	var count int64
+	if err := r.conn.GetExecutor(ctx).QueryRow(ctx, query).Scan(&count); err != nil {
+		r.logger.Errorw("counting overdue orders",
+			"error", err,
+		)
+		return 0, errs.Wrap(err, errs.ErrInternalCode, "failed to count overdue orders")
+	}
+	return count, nil
+}
+
+func (r *postgresOrderRepository) GetOverdueOrders(ctx context.Context, pvzID string) ([]entity.Order, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetOverdueOrders", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT` + orderColumns + orderTables + `
+		WHERE r.pvz_id = $1
+			AND p.expired_at IS NOT NULL
+			AND p.status IN ('received', 'ready_for_pickup')
+		ORDER BY p.expires_at
+	`
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, pvzID)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get overdue orders")
+	}
+
+	return r.collectOrders(rows, pvzID)
+}
+
+// collectOrders читает заказы из rows; key попадает в логи для идентификации запроса.
+func (r *postgresOrderRepository) collectOrders(rows pgx.Rows, key string) ([]entity.Order, error) {
+	defer rows.Close()
+
+	var orders []entity.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"key", key,
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan order")
+		}
+		orders = append(orders, *order)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("rows error",
+			"error", err,
+			"key", key,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return orders, nil
+}
+
+func (r *postgresOrderRepository) FindOrderByBarcode(ctx context.Context, barcode string) (*entity.Order, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FindOrderByBarcode", time.Since(start).Seconds())
+	}()
+
+	// Штрихкод может повторяться у уже выданных или отгруженных товаров,
+	// поэтому сначала берём товар, который сейчас в ПВЗ, затем самый свежий
+	query := `
+		SELECT` + orderColumns + orderTables + `
+		WHERE p.barcode = $1
+		ORDER BY (p.status NOT IN ('issued', 'shipped_back')) DESC, p.date_time DESC
+		LIMIT 1
+	`
+	order, err := scanOrder(r.conn.GetExecutor(ctx).QueryRow(ctx, query, barcode))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrProductNotFound, "product with this barcode not found")
+		}
+		r.logger.Errorw("finding order by barcode",
+			"error", err,
+			"barcode", barcode,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find order by barcode")
+	}
+	return order, nil
+}
@@ -0,0 +1,265 @@
+package db
+
+import (
+	"context"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// OutboxRepository — очередь доменных событий, ожидающих публикации.
+type OutboxRepository interface {
+	InsertOutboxEvent(ctx context.Context, event entity.OutboxEvent) error
+	FetchDueOutboxEvents(ctx context.Context, limit int) ([]entity.OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	MarkOutboxEventFailed(ctx context.Context, id int64, nextAttemptAt *time.Time, lastError string) error
+	ListPvzActivity(ctx context.Context, pvzIDs []string, afterID int64, limit int) ([]entity.OutboxEvent, error)
+	ListConcurrentPvzActivity(ctx context.Context, pvzIDs []string, lastEventID, afterID int64, limit int) ([]entity.OutboxEvent, error)
+	DeletePublishedOutboxEvents(ctx context.Context, before time.Time, limit int) (int64, error)
+	CountDeadOutboxEvents(ctx context.Context) (int64, error)
+}
+
+type postgresOutboxRepository struct {
+	conn   TxManager
+	logger logger.Logger
+}
+
+func NewOutboxRepository(conn TxManager, log logger.Logger) OutboxRepository {
+	return &postgresOutboxRepository{conn: conn, logger: log}
+}
+
+func (r *postgresOutboxRepository) InsertOutboxEvent(ctx context.Context, event entity.OutboxEvent) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("InsertOutboxEvent", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		INSERT INTO outbox_event (event_id, event_type, aggregate_id, pvz_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := pool.Exec(ctx, query, event.EventID, event.Type, event.AggregateID, event.PvzID, event.Payload, event.CreatedAt)
+	if err != nil {
+		r.logger.Errorw("inserting outbox event",
+			"error", err,
+			"eventType", event.Type,
+			"aggregateID", event.AggregateID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to write outbox event")
+	}
+	return nil
+}
+
+// FetchDueOutboxEvents блокирует до limit ожидающих событий, срок попытки которых наступил.
+// Вызывается внутри транзакции: SKIP LOCKED не даёт двум relay взять одно событие.
+func (r *postgresOutboxRepository) FetchDueOutboxEvents(ctx context.Context, limit int) ([]entity.OutboxEvent, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FetchDueOutboxEvents", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		SELECT id, event_id, event_type, aggregate_id, pvz_id, payload, created_at,
+			status, attempts, next_attempt_at, last_error
+		FROM outbox_event
+		WHERE status = 'pending' AND next_attempt_at <= now()
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := pool.Query(ctx, query, limit)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "FetchDueOutboxEvents",
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to query outbox events")
+	}
+	defer rows.Close()
+
+	var events []entity.OutboxEvent
+	for rows.Next() {
+		var e entity.OutboxEvent
+		err := rows.Scan(&e.ID, &e.EventID, &e.Type, &e.AggregateID, &e.PvzID, &e.Payload, &e.CreatedAt,
+			&e.Status, &e.Attempts, &e.NextAttemptAt, &e.LastError)
+		if err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", "FetchDueOutboxEvents",
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan outbox event")
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return events, nil
+}
+
+func (r *postgresOutboxRepository) MarkOutboxEventPublished(ctx context.Context, id int64) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("MarkOutboxEventPublished", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE outbox_event
+		SET status = 'published', attempts = attempts + 1, published_at = now(), last_error = ''
+		WHERE id = $1
+	`
+	if _, err := pool.Exec(ctx, query, id); err != nil {
+		r.logger.Errorw("marking outbox event published",
+			"error", err,
+			"id", id,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to mark outbox event published")
+	}
+	return nil
+}
+
+// MarkOutboxEventFailed учитывает неудачную попытку. Без nextAttemptAt событие переводится в dead.
+func (r *postgresOutboxRepository) MarkOutboxEventFailed(ctx context.Context, id int64, nextAttemptAt *time.Time, lastError string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("MarkOutboxEventFailed", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE outbox_event
+		SET attempts = attempts + 1,
+			last_error = $3,
+			status = CASE WHEN $2::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+			next_attempt_at = COALESCE($2, next_attempt_at)
+		WHERE id = $1
+	`
+	if _, err := pool.Exec(ctx, query, id, nextAttemptAt, lastError); err != nil {
+		r.logger.Errorw("marking outbox event failed",
+			"error", err,
+			"id", id,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to mark outbox event failed")
+	}
+	return nil
+}
+
+// DeletePublishedOutboxEvents удаляет до limit событий, опубликованных раньше before.
+// Ожидающие и dead события не удаляются.
+func (r *postgresOutboxRepository) DeletePublishedOutboxEvents(ctx context.Context, before time.Time, limit int) (int64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("DeletePublishedOutboxEvents", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		DELETE FROM outbox_event
+		WHERE id IN (
+			SELECT id FROM outbox_event
+			WHERE status = 'published' AND published_at < $1
+			LIMIT $2
+		)
+	`
+	tag, err := pool.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Errorw("deleting published outbox events",
+			"error", err,
+		)
+		return 0, errs.Wrap(err, errs.ErrInternalCode, "failed to delete published outbox events")
+	}
+	return tag.RowsAffected(), nil
+}
+
+// CountDeadOutboxEvents возвращает число событий, ожидающих разбора в dead letter.
+func (r *postgresOutboxRepository) CountDeadOutboxEvents(ctx context.Context) (int64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("CountDeadOutboxEvents", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	var count int64
+	if err := pool.QueryRow(ctx, `SELECT count(*) FROM outbox_event WHERE status = 'dead'`).Scan(&count); err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "CountDeadOutboxEvents",
+		)
+		return 0, errs.Wrap(err, errs.ErrInternalCode, "failed to count dead outbox events")
+	}
+	return count, nil
+}
+
+// ListPvzActivity возвращает события ленты активности указанных ПВЗ с id больше afterID в порядке записи.
+func (r *postgresOutboxRepository) ListPvzActivity(ctx context.Context, pvzIDs []string, afterID int64, limit int) ([]entity.OutboxEvent, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ListPvzActivity", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT id, event_id, event_type, aggregate_id, pvz_id, payload, created_at
+		FROM outbox_event
+		WHERE pvz_id = ANY($1::uuid[]) AND event_type = ANY($2) AND id > $3
+		ORDER BY id
+		LIMIT $4
+	`
+	return r.queryPvzActivity(ctx, "ListPvzActivity", query, pvzIDs, entity.PvzActivityEvents, afterID, limit)
+}
+
+// ListConcurrentPvzActivity возвращает события ленты указанных ПВЗ с id в (afterID, lastEventID),
+// записанные транзакциями, которые ещё выполнялись при записи события lastEventID. Только такие
+// события могли зафиксироваться после него и не попасть к клиенту, получившему lastEventID.
+// Часть из них клиент мог уже получить: дедупликация остаётся за ним.
+func (r *postgresOutboxRepository) ListConcurrentPvzActivity(ctx context.Context, pvzIDs []string, lastEventID, afterID int64, limit int) ([]entity.OutboxEvent, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ListConcurrentPvzActivity", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT id, event_id, event_type, aggregate_id, pvz_id, payload, created_at
+		FROM outbox_event
+		WHERE pvz_id = ANY($1::uuid[]) AND event_type = ANY($2)
+			AND id > $4 AND id < $3
+			AND txid >= (SELECT snapshot_xmin FROM outbox_event WHERE id = $3)
+		ORDER BY id
+		LIMIT $5
+	`
+	return r.queryPvzActivity(ctx, "ListConcurrentPvzActivity", query, pvzIDs, entity.PvzActivityEvents, lastEventID, afterID, limit)
+}
+
+func (r *postgresOutboxRepository) queryPvzActivity(ctx context.Context, name, query string, args ...any) ([]entity.OutboxEvent, error) {
+	pool := r.conn.GetExecutor(ctx)
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", name,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to query PVZ activity")
+	}
+	defer rows.Close()
+
+	var events []entity.OutboxEvent
+	for rows.Next() {
+		var e entity.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.EventID, &e.Type, &e.AggregateID, &e.PvzID, &e.Payload, &e.CreatedAt); err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", name,
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan PVZ activity event")
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return events, nil
+}
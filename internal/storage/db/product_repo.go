@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/metrics"
 	"order-pick-up-point/internal/models/entity"
@@ -16,6 +17,8 @@ type ProductRepository interface {
 	FindLastProductInReception(ctx context.Context, pvzID string) (*entity.Product, error)
 	DeleteProduct(ctx context.Context, productID string) error
 	GetProductsByReceptionID(ctx context.Context, receptionID string) ([]entity.Product, error)
+	CreateProducts(ctx context.Context, products []entity.Product, skipDuplicates bool) ([]string, error)
+	FindActiveBarcodes(ctx context.Context, barcodes []string) ([]string, error)
 }
 
 type postgresProductRepository struct {
@@ -37,14 +40,21 @@ func (r *postgresProductRepository) CreateProduct(ctx context.Context, product e
 	}()
 	pool := r.conn.GetExecutor(ctx)
 	query := `
-		INSERT INTO product (date_time, type, reception_id)
-		VALUES ($1, $2, $3)
+		INSERT INTO product (date_time, type, reception_id, expires_at, barcode)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
 		RETURNING id
 	`
 
 	var productID string
-	err := pool.QueryRow(ctx, query, product.DateTime, product.Type, product.ReceptionID).Scan(&productID)
+	err := pool.QueryRow(ctx, query,
+		product.DateTime, product.Type, product.ReceptionID, product.ExpiresAt, product.Barcode,
+	).Scan(&productID)
 	if err != nil {
+		// Повторный штрихкод среди товаров в ПВЗ отсекает частичный уникальный индекс
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return "", errs.New(errs.ErrDuplicateBarcode, "product with this barcode is already at a PVZ")
+		}
 		r.logger.Errorw("creating product",
 			"error", err,
 			"productType", product.Type,
@@ -64,7 +74,7 @@ func (r *postgresProductRepository) FindLastProductInReception(ctx context.Conte
 
 	// Выполняем JOIN с таблицей reception для выбора последнего товара из открытой приёмки данного ПВЗ
 	query := `
-		SELECT p.id, p.date_time, p.type, p.reception_id
+		SELECT p.id, p.date_time, p.type, COALESCE(p.barcode, ''), p.reception_id
 		FROM product p
 		JOIN reception r ON p.reception_id = r.id
 		WHERE r.pvz_id = $1 AND r.status = 'in_progress'
@@ -77,6 +87,7 @@ func (r *postgresProductRepository) FindLastProductInReception(ctx context.Conte
 		&prod.ID,
 		&prod.DateTime,
 		&prod.Type,
+		&prod.Barcode,
 		&prod.ReceptionID,
 	)
 	if err != nil {
@@ -124,10 +135,10 @@ func (r *postgresProductRepository) GetProductsByReceptionID(ctx context.Context
 	}()
 
 	query := `
-		SELECT id, date_time, type, reception_id
+		SELECT id, date_time, type, COALESCE(barcode, ''), reception_id
 		FROM product
 		WHERE reception_id = $1
-		ORDER BY date_time
+		ORDER BY date_time, id
 	`
 	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, receptionID)
 	if err != nil {
@@ -142,7 +153,7 @@ func (r *postgresProductRepository) GetProductsByReceptionID(ctx context.Context
 	var products []entity.Product
 	for rows.Next() {
 		var prod entity.Product
-		if err := rows.Scan(&prod.ID, &prod.DateTime, &prod.Type, &prod.ReceptionID); err != nil {
+		if err := rows.Scan(&prod.ID, &prod.DateTime, &prod.Type, &prod.Barcode, &prod.ReceptionID); err != nil {
 			r.logger.Errorw("scan error",
 				"error", err,
 				"receptionID", receptionID,
@@ -160,3 +171,86 @@ func (r *postgresProductRepository) GetProductsByReceptionID(ctx context.Context
 	}
 	return products, nil
 }
+
+// CreateProducts вставляет товары одним пакетом запросов и возвращает их ID в порядке входного среза.
+// При skipDuplicates товар, штрихкод которого успел занять другой товар, не вставляется и получает
+// пустой ID; иначе такой конфликт отклоняет весь пакет с DUPLICATE_BARCODE.
+func (r *postgresProductRepository) CreateProducts(ctx context.Context, products []entity.Product, skipDuplicates bool) ([]string, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("CreateProducts", time.Since(start).Seconds())
+	}()
+
+	query := `
+		INSERT INTO product (date_time, type, reception_id, expires_at, barcode)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id
+	`
+	if skipDuplicates {
+		// Условие совпадает с предикатом idx_product_barcode_active
+		query = `
+			INSERT INTO product (date_time, type, reception_id, expires_at, barcode)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+			ON CONFLICT (barcode) WHERE barcode IS NOT NULL AND status NOT IN ('issued', 'shipped_back') DO NOTHING
+			RETURNING id
+		`
+	}
+	batch := &pgx.Batch{}
+	for _, product := range products {
+		batch.Queue(query, product.DateTime, product.Type, product.ReceptionID, product.ExpiresAt, product.Barcode)
+	}
+
+	br := r.conn.GetExecutor(ctx).SendBatch(ctx, batch)
+	defer br.Close()
+
+	ids := make([]string, len(products))
+	for i := range products {
+		if err := br.QueryRow().Scan(&ids[i]); err != nil {
+			if skipDuplicates && errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return nil, errs.New(errs.ErrDuplicateBarcode, "product with this barcode is already at a PVZ")
+			}
+			r.logger.Errorw("creating products batch",
+				"error", err,
+				"index", i,
+				"count", len(products),
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to create products")
+		}
+	}
+	return ids, nil
+}
+
+// FindActiveBarcodes возвращает те из переданных штрихкодов, которые уже заняты товарами в ПВЗ.
+func (r *postgresProductRepository) FindActiveBarcodes(ctx context.Context, barcodes []string) ([]string, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FindActiveBarcodes", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT barcode
+		FROM product
+		WHERE barcode = ANY($1) AND status NOT IN ('issued', 'shipped_back')
+	`
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, barcodes)
+	if err != nil {
+		r.logger.Errorw("finding active barcodes",
+			"error", err,
+			"count", len(barcodes),
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find active barcodes")
+	}
+
+	found, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		r.logger.Errorw("scanning active barcodes",
+			"error", err,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan active barcodes")
+	}
+	return found, nil
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"time"
+)
+
+// pvzExportFetchSize — сколько строк выгрузки читается из курсора за один FETCH.
+const pvzExportFetchSize = 1000
+
+// pvzExportQuery — история ПВЗ: все ПВЗ, их приёмки за период ($1, $2) и товары приёмок.
+// Фильтр по датам стоит в условии соединения, поэтому ПВЗ без приёмок за период не пропадают.
+const pvzExportQuery = `
+	SELECT p.id, p.registration_date, p.city, p.address, p.status,
+		r.id, r.date_time, r.status,
+		pr.id, pr.date_time, pr.type, pr.barcode
+	FROM pvz p
+	LEFT JOIN reception r ON r.pvz_id = p.id
+		AND ($1::timestamptz IS NULL OR r.date_time >= $1)
+		AND ($2::timestamptz IS NULL OR r.date_time <= $2)
+	LEFT JOIN product pr ON pr.reception_id = r.id
+	ORDER BY p.registration_date, p.id, r.date_time, r.id, pr.date_time, pr.id
+`
+
+// ExportPvzHistory читает историю ПВЗ через серверный курсор порциями по pvzExportFetchSize
+// и передаёт строки в fn по одной, поэтому память не растёт с размером выгрузки.
+// Курсор живёт только внутри транзакции, вызывать метод нужно из TxManager.WithTx.
+func (r *postgresPvzRepository) ExportPvzHistory(ctx context.Context, filter entity.PvzExportFilter, fn func(entity.PvzExportRow) error) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ExportPvzHistory", time.Since(start).Seconds())
+	}()
+
+	exec := r.conn.GetExecutor(ctx)
+	if _, err := exec.Exec(ctx, `DECLARE pvz_export NO SCROLL CURSOR FOR `+pvzExportQuery, filter.StartDate, filter.EndDate); err != nil {
+		r.logger.Errorw("declaring PVZ export cursor",
+			"error", err,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to start pvz export")
+	}
+
+	fetch := fmt.Sprintf(`FETCH FORWARD %d FROM pvz_export`, pvzExportFetchSize)
+	for {
+		fetched, err := r.fetchPvzExportRows(ctx, fetch, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < pvzExportFetchSize {
+			break
+		}
+	}
+
+	if _, err := exec.Exec(ctx, `CLOSE pvz_export`); err != nil {
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to close pvz export cursor")
+	}
+	return nil
+}
+
+func (r *postgresPvzRepository) fetchPvzExportRows(ctx context.Context, fetch string, fn func(entity.PvzExportRow) error) (int, error) {
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, fetch)
+	if err != nil {
+		r.logger.Errorw("fetching PVZ export rows",
+			"error", err,
+		)
+		return 0, errs.Wrap(err, errs.ErrInternalCode, "failed to fetch pvz export rows")
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		var (
+			row                                                           entity.PvzExportRow
+			receptionID, receptionStatus, productID, productType, barcode *string
+		)
+		if err := rows.Scan(
+			&row.PvzID, &row.PvzRegistrationDate, &row.PvzCity, &row.PvzAddress, &row.PvzStatus,
+			&receptionID, &row.ReceptionDateTime, &receptionStatus,
+			&productID, &row.ProductDateTime, &productType, &barcode,
+		); err != nil {
+			return fetched, errs.Wrap(err, errs.ErrInternalCode, "failed to scan pvz export row")
+		}
+		row.ReceptionID = derefString(receptionID)
+		row.ReceptionStatus = derefString(receptionStatus)
+		row.ProductID = derefString(productID)
+		row.ProductType = derefString(productType)
+		row.ProductBarcode = derefString(barcode)
+
+		fetched++
+		if err := fn(row); err != nil {
+			return fetched, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fetched, errs.Wrap(err, errs.ErrInternalCode, "rows error")
+	}
+	return fetched, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
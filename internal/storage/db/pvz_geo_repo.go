@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"time"
+)
+
+// nearbyPvzFilters — общие для обоих вариантов поиска фильтры по городу и статусу ($4, $5)
+const nearbyPvzFilters = `
+	AND ($4 = '' OR lower(city) = lower($4))
+	AND ($5 = '' OR status = $5)
+`
+
+// nearbyPvzEarthQuery использует GiST-индекс idx_pvz_earth: earth_box отбирает кандидатов
+// по индексу, earth_distance отсекает углы куба и даёт точное расстояние.
+const nearbyPvzEarthQuery = `
+	SELECT ` + pvzColumns + `,
+		earth_distance(ll_to_earth($1, $2), ll_to_earth(latitude, longitude)) AS distance
+	FROM pvz
+	WHERE latitude IS NOT NULL
+		AND earth_box(ll_to_earth($1, $2), $3) @> ll_to_earth(latitude, longitude)
+		AND earth_distance(ll_to_earth($1, $2), ll_to_earth(latitude, longitude)) <= $3
+	` + nearbyPvzFilters + `
+	ORDER BY distance, id
+	LIMIT $6
+`
+
+// nearbyPvzFallbackQuery — вариант без расширений: bounding box по индексу idx_pvz_coordinates
+// (111320 м — длина градуса широты) и формула гаверсинуса для расстояния.
+const nearbyPvzFallbackQuery = `
+	SELECT ` + pvzColumns + `, distance
+	FROM (
+		SELECT ` + pvzColumns + `,
+			2 * 6371008.8 * asin(least(1, sqrt(
+				power(sin(radians(latitude - $1) / 2), 2) +
+				cos(radians($1)) * cos(radians(latitude)) * power(sin(radians(longitude - $2) / 2), 2)
+			))) AS distance
+		FROM pvz
+		WHERE latitude BETWEEN $1 - $3 / 111320.0 AND $1 + $3 / 111320.0
+			AND longitude BETWEEN $2 - $3 / (111320.0 * greatest(cos(radians($1)), 0.01))
+				AND $2 + $3 / (111320.0 * greatest(cos(radians($1)), 0.01))
+		` + nearbyPvzFilters + `
+	) nearby
+	WHERE distance <= $3
+	ORDER BY distance, id
+	LIMIT $6
+`
+
+func (r *postgresPvzRepository) FindNearbyPvzs(ctx context.Context, filter entity.NearbyPvzFilter) ([]entity.NearbyPvz, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FindNearbyPvzs", time.Since(start).Seconds())
+	}()
+
+	query := nearbyPvzFallbackQuery
+	if r.earthdistanceAvailable(ctx) {
+		query = nearbyPvzEarthQuery
+	}
+
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query,
+		filter.Latitude, filter.Longitude, filter.RadiusMeters, filter.City, filter.Status, filter.Limit,
+	)
+	if err != nil {
+		r.logger.Errorw("finding nearby PVZs",
+			"error", err,
+			"latitude", filter.Latitude,
+			"longitude", filter.Longitude,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find nearby pvzs")
+	}
+	defer rows.Close()
+
+	var result []entity.NearbyPvz
+	for rows.Next() {
+		var distance float64
+		pvz, err := scanPvz(rows, &distance)
+		if err != nil {
+			r.logger.Errorw("scanning nearby PVZ",
+				"error", err,
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan pvz")
+		}
+		result = append(result, entity.NearbyPvz{Pvz: *pvz, DistanceMeters: distance})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows error")
+	}
+	return result, nil
+}
+
+// earthdistanceAvailable проверяет, установлено ли расширение earthdistance (его ставит миграция,
+// если это возможно). Результат успешной проверки запоминается на время жизни репозитория.
+// При ошибке поиск идёт без расширения, а проверка повторяется при следующем запросе, чтобы
+// временный сбой БД не отключил индекс idx_pvz_earth до перезапуска.
+func (r *postgresPvzRepository) earthdistanceAvailable(ctx context.Context) bool {
+	r.earthdistanceMu.Lock()
+	defer r.earthdistanceMu.Unlock()
+
+	if r.earthdistanceChecked {
+		return r.hasEarthdistance
+	}
+
+	var available bool
+	err := r.conn.GetExecutor(ctx).QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'earthdistance')`,
+	).Scan(&available)
+	if err != nil {
+		r.logger.Errorw("checking earthdistance extension",
+			"error", err,
+		)
+		return false
+	}
+	r.hasEarthdistance = available
+	r.earthdistanceChecked = true
+	return available
+}
@@ -2,26 +2,41 @@ package db
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"order-pick-up-point/internal/errs"
 	"order-pick-up-point/internal/metrics"
 	"order-pick-up-point/internal/models/entity"
 	"order-pick-up-point/pkg/logger"
+	"sync"
 	"time"
 )
 
 type PvzRepository interface {
 	CreatePvz(ctx context.Context, pvz entity.Pvz) (string, error)
-	GetPvzs(ctx context.Context, page, limit int) ([]entity.Pvz, error)
+	GetPvzs(ctx context.Context, page entity.PvzPageRequest) ([]entity.Pvz, error)
 	GetListOfPvzs(ctx context.Context) ([]entity.Pvz, error)
-	GetPvzsWithReceptionsAndProducts(ctx context.Context, page, limit int, startDate, endDate *time.Time) ([]entity.PvzInfo, error)
+	GetPvzsWithReceptionsAndProducts(ctx context.Context, page entity.PvzPageRequest, startDate, endDate *time.Time) ([]entity.PvzInfo, error)
+	GetPvzByID(ctx context.Context, pvzID string) (*entity.Pvz, error)
+	GetPvzByIDForUpdate(ctx context.Context, pvzID string) (*entity.Pvz, error)
+	UpdatePvz(ctx context.Context, pvz entity.Pvz) error
+	FindNearbyPvzs(ctx context.Context, filter entity.NearbyPvzFilter) ([]entity.NearbyPvz, error)
+	ExportPvzHistory(ctx context.Context, filter entity.PvzExportFilter, fn func(entity.PvzExportRow) error) error
 }
 
 type postgresPvzRepository struct {
 	conn   TxManager
 	logger logger.Logger
+
+	// earthdistanceMu защищает результат проверки расширения earthdistance: он запоминается
+	// после первой успешной проверки, ошибка проверки не кэшируется
+	earthdistanceMu      sync.Mutex
+	earthdistanceChecked bool
+	hasEarthdistance     bool
 }
 
 func NewPvzRepository(conn TxManager, log logger.Logger) PvzRepository {
@@ -31,6 +46,37 @@ func NewPvzRepository(conn TxManager, log logger.Logger) PvzRepository {
 	}
 }
 
+const pvzColumns = `id, registration_date, city, address, latitude, longitude, phone, status, opening_hours`
+
+// scanPvz читает колонки pvzColumns; extra — дополнительные колонки запроса после них.
+func scanPvz(row pgx.Row, extra ...any) (*entity.Pvz, error) {
+	var p entity.Pvz
+	dest := []any{
+		&p.ID,
+		&p.RegistrationDate,
+		&p.City,
+		&p.Address,
+		&p.Latitude,
+		&p.Longitude,
+		&p.Phone,
+		&p.Status,
+		&p.OpeningHours,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// openingHoursValue не даёт записать SQL NULL вместо пустого расписания
+func openingHoursValue(hours []entity.OpeningHours) []entity.OpeningHours {
+	if hours == nil {
+		return []entity.OpeningHours{}
+	}
+	return hours
+}
+
 func (r *postgresPvzRepository) CreatePvz(ctx context.Context, pvz entity.Pvz) (string, error) {
 	ctx, span := otel.Tracer("postgresPvzRepository").Start(ctx, "CreatePvzRepo")
 	defer span.End()
@@ -50,13 +96,16 @@ func (r *postgresPvzRepository) CreatePvz(ctx context.Context, pvz entity.Pvz) (
 	spanN.End()
 
 	query := `
-		INSERT INTO pvz (registration_date, city)
-		VALUES ($1, $2)
+		INSERT INTO pvz (registration_date, city, address, latitude, longitude, phone, status, opening_hours)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
 
 	var pvzID string
-	err := pool.QueryRow(ctx, query, pvz.RegistrationDate, pvz.City).Scan(&pvzID)
+	err := pool.QueryRow(ctx, query,
+		pvz.RegistrationDate, pvz.City, pvz.Address, pvz.Latitude, pvz.Longitude,
+		pvz.Phone, pvz.Status, openingHoursValue(pvz.OpeningHours),
+	).Scan(&pvzID)
 
 	if err != nil {
 		r.logger.Errorw("creating PVZ",
@@ -68,21 +117,35 @@ func (r *postgresPvzRepository) CreatePvz(ctx context.Context, pvz entity.Pvz) (
 	return pvzID, nil
 }
 
-func (r *postgresPvzRepository) GetPvzs(ctx context.Context, page, limit int) ([]entity.Pvz, error) {
+// pvzPageClause строит условие и сортировку страницы ПВЗ по ключу (registration_date, id).
+// Курсорная страница начинается сразу после ключа After, иначе используется LIMIT/OFFSET.
+func pvzPageClause(page entity.PvzPageRequest) (string, []interface{}) {
+	if page.After != nil {
+		return `
+			WHERE (registration_date, id) < ($1, $2)
+			ORDER BY registration_date DESC, id DESC
+			LIMIT $3
+		`, []interface{}{page.After.RegistrationDate, page.After.ID, page.Limit}
+	}
+	return `
+		ORDER BY registration_date DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`, []interface{}{page.Limit, page.Offset}
+}
+
+func (r *postgresPvzRepository) GetPvzs(ctx context.Context, page entity.PvzPageRequest) ([]entity.Pvz, error) {
 	start := time.Now()
 	defer func() {
 		metrics.RecordDBQueryDuration("GetPvzs", time.Since(start).Seconds())
 	}()
 
-	offset := (page - 1) * limit
+	clause, args := pvzPageClause(page)
 	query := `
-		SELECT id, registration_date, city
+		SELECT ` + pvzColumns + `
 		FROM pvz
-		ORDER BY registration_date DESC
-		LIMIT $1 OFFSET $2
-	`
+	` + clause
 
-	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, limit, offset)
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, args...)
 	if err != nil {
 		r.logger.Errorw("getting PVZs",
 			"error", err,
@@ -93,14 +156,14 @@ func (r *postgresPvzRepository) GetPvzs(ctx context.Context, page, limit int) ([
 
 	var pvzs []entity.Pvz
 	for rows.Next() {
-		var p entity.Pvz
-		if err := rows.Scan(&p.ID, &p.RegistrationDate, &p.City); err != nil {
+		p, err := scanPvz(rows)
+		if err != nil {
 			r.logger.Errorw("scanning PVZ",
 				"error", err,
 			)
 			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan pvz")
 		}
-		pvzs = append(pvzs, p)
+		pvzs = append(pvzs, *p)
 	}
 	if err = rows.Err(); err != nil {
 		r.logger.Errorw("Rows error in GetPvzs",
@@ -118,7 +181,7 @@ func (r *postgresPvzRepository) GetListOfPvzs(ctx context.Context) ([]entity.Pvz
 	}()
 
 	query := `
-		SELECT id, registration_date, city
+		SELECT ` + pvzColumns + `
 		FROM pvz
 		ORDER BY registration_date DESC
 	`
@@ -133,14 +196,14 @@ func (r *postgresPvzRepository) GetListOfPvzs(ctx context.Context) ([]entity.Pvz
 
 	var pvzs []entity.Pvz
 	for rows.Next() {
-		var pvz entity.Pvz
-		if err := rows.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City); err != nil {
+		pvz, err := scanPvz(rows)
+		if err != nil {
 			r.logger.Errorw("scan error",
 				"error", err,
 			)
 			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan PVZ")
 		}
-		pvzs = append(pvzs, pvz)
+		pvzs = append(pvzs, *pvz)
 	}
 	if err = rows.Err(); err != nil {
 		r.logger.Errorw("rows error",
@@ -151,9 +214,16 @@ func (r *postgresPvzRepository) GetListOfPvzs(ctx context.Context) ([]entity.Pvz
 	return pvzs, nil
 }
 
+// pvzReceptionsJSON — приёмка ПВЗ с товарами в виде, который собирает json_agg
+// в GetPvzsWithReceptionsAndProducts. Ключи совпадают с json-тегами сущностей.
+type pvzReceptionsJSON struct {
+	entity.Reception
+	Products []entity.Product `json:"products"`
+}
+
 func (r *postgresPvzRepository) GetPvzsWithReceptionsAndProducts(
 	ctx context.Context,
-	page, limit int,
+	page entity.PvzPageRequest,
 	startDate, endDate *time.Time,
 ) ([]entity.PvzInfo, error) {
 	start := time.Now()
@@ -161,109 +231,167 @@ func (r *postgresPvzRepository) GetPvzsWithReceptionsAndProducts(
 		metrics.RecordDBQueryDuration("GetPvzsWithReceptionsAndProducts", time.Since(start).Seconds())
 	}()
 
-	offset := (page - 1) * limit
-	args := []interface{}{limit, offset}
-	argIndex := 3
-
-	query := `
-		SELECT
-			p.id AS pvz_id,
-			p.registration_date,
-			p.city,
-			r.id AS reception_id,
-			r.date_time AS reception_date,
-			r.status,
-			pr.id AS product_id,
-			pr.date_time AS product_date,
-			pr.type
-		FROM pvz p
-		LEFT JOIN reception r ON r.pvz_id = p.id
-		LEFT JOIN product pr ON pr.reception_id = r.id
-	`
-
+	// Сначала выбирается страница ПВЗ, затем к каждому ПВЗ одной строкой собираются приёмки
+	// с товарами, поэтому LIMIT относится к ПВЗ, а не к строкам соединения. Фильтр по датам
+	// отбирает только приёмки: ПВЗ без подходящих приёмок остаются с пустым списком.
+	clause, args := pvzPageClause(page)
+	receptionFilter := ""
 	if startDate != nil && endDate != nil {
-		query += fmt.Sprintf(" WHERE r.date_time BETWEEN $%d AND $%d", argIndex, argIndex+1)
+		receptionFilter = fmt.Sprintf(" AND r.date_time BETWEEN $%d AND $%d", len(args)+1, len(args)+2)
 		args = append(args, startDate, endDate)
-		argIndex += 2
 	}
 
-	query += " ORDER BY p.registration_date DESC LIMIT $1 OFFSET $2"
+	query := `
+		WITH page AS (
+			SELECT ` + pvzColumns + `
+			FROM pvz
+		` + clause + `
+		)
+		SELECT p.id, p.registration_date, p.city, p.address, p.latitude, p.longitude,
+			p.phone, p.status, p.opening_hours,
+			COALESCE((
+				SELECT json_agg(json_build_object(
+					'id', r.id,
+					'date_time', r.date_time,
+					'pvz_id', r.pvz_id,
+					'status', r.status,
+					'opened_by', r.opened_by,
+					'closed_by', r.closed_by,
+					'closed_at', r.closed_at,
+					'products', COALESCE((
+						SELECT json_agg(json_build_object(
+							'id', pr.id,
+							'date_time', pr.date_time,
+							'type', pr.type,
+							'barcode', COALESCE(pr.barcode, ''),
+							'reception_id', pr.reception_id
+						) ORDER BY pr.date_time, pr.id)
+						FROM product pr
+						WHERE pr.reception_id = r.id
+					), '[]'::json)
+				) ORDER BY r.date_time, r.id)
+				FROM reception r
+				WHERE r.pvz_id = p.id` + receptionFilter + `
+			), '[]'::json) AS receptions
+		FROM page p
+		ORDER BY p.registration_date DESC, p.id DESC
+	`
 
 	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, args...)
 	if err != nil {
+		r.logger.Errorw("getting PVZs with receptions and products",
+			"error", err,
+		)
 		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get pvz with receptions and products")
 	}
 	defer rows.Close()
 
-	pvzMap := make(map[string]*entity.PvzInfo)
-
+	var result []entity.PvzInfo
 	for rows.Next() {
-		var (
-			pvzID, city, receptionID, status, productID, productType *string
-			pvzRegDate, receptionDate, productDate                   *time.Time
-		)
-
-		if err := rows.Scan(&pvzID, &pvzRegDate, &city, &receptionID, &receptionDate, &status, &productID, &productDate, &productType); err != nil {
+		var receptionsRaw []byte
+		pvz, err := scanPvz(rows, &receptionsRaw)
+		if err != nil {
 			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan pvz row")
 		}
 
-		if _, exists := pvzMap[*pvzID]; !exists {
-			pvzMap[*pvzID] = &entity.PvzInfo{
-				Pvz: entity.Pvz{
-					ID:               *pvzID,
-					RegistrationDate: *pvzRegDate,
-					City:             *city,
-				},
-			}
+		var receptions []pvzReceptionsJSON
+		if err := json.Unmarshal(receptionsRaw, &receptions); err != nil {
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to decode pvz receptions")
 		}
 
-		pvzInfo := pvzMap[*pvzID]
-
-		if receptionID != nil {
-			receptionExists := false
-			for i := range pvzInfo.Receptions {
-				if pvzInfo.Receptions[i].Reception.ID == *receptionID {
-					receptionExists = true
-					if productID != nil {
-						pvzInfo.Receptions[i].Products = append(
-							pvzInfo.Receptions[i].Products,
-							entity.Product{
-								ID:          *productID,
-								DateTime:    *productDate,
-								Type:        *productType,
-								ReceptionID: *receptionID,
-							},
-						)
-					}
-					break
-				}
-			}
-			if !receptionExists {
-				newReception := entity.ReceptionInfo{
-					Reception: entity.Reception{
-						ID:       *receptionID,
-						DateTime: *receptionDate,
-						PvzID:    *pvzID,
-						Status:   *status,
-					},
-				}
-				if productID != nil {
-					newReception.Products = append(newReception.Products, entity.Product{
-						ID:          *productID,
-						DateTime:    *productDate,
-						Type:        *productType,
-						ReceptionID: *receptionID,
-					})
-				}
-				pvzInfo.Receptions = append(pvzInfo.Receptions, newReception)
+		info := entity.PvzInfo{Pvz: *pvz}
+		for _, rec := range receptions {
+			recInfo := entity.ReceptionInfo{Reception: rec.Reception}
+			if len(rec.Products) > 0 {
+				recInfo.Products = rec.Products
 			}
+			info.Receptions = append(info.Receptions, recInfo)
 		}
+		result = append(result, info)
 	}
-
-	var result []entity.PvzInfo
-	for _, info := range pvzMap {
-		result = append(result, *info)
+	if err = rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows error")
 	}
 
 	return result, nil
 }
+
+func (r *postgresPvzRepository) GetPvzByID(ctx context.Context, pvzID string) (*entity.Pvz, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetPvzByID", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT ` + pvzColumns + `
+		FROM pvz
+		WHERE id = $1
+	`
+	pvz, err := scanPvz(r.conn.GetExecutor(ctx).QueryRow(ctx, query, pvzID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrPvzNotFound, "pvz not found")
+		}
+		r.logger.Errorw("getting PVZ by id",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get pvz")
+	}
+	return pvz, nil
+}
+
+// GetPvzByIDForUpdate находит ПВЗ и блокирует строку до конца транзакции, чтобы два
+// параллельных частичных обновления не перезаписали изменения друг друга.
+func (r *postgresPvzRepository) GetPvzByIDForUpdate(ctx context.Context, pvzID string) (*entity.Pvz, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetPvzByIDForUpdate", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT ` + pvzColumns + `
+		FROM pvz
+		WHERE id = $1
+		FOR UPDATE
+	`
+	pvz, err := scanPvz(r.conn.GetExecutor(ctx).QueryRow(ctx, query, pvzID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrPvzNotFound, "pvz not found")
+		}
+		r.logger.Errorw("getting PVZ by id for update",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get pvz")
+	}
+	return pvz, nil
+}
+
+func (r *postgresPvzRepository) UpdatePvz(ctx context.Context, pvz entity.Pvz) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("UpdatePvz", time.Since(start).Seconds())
+	}()
+
+	query := `
+		UPDATE pvz
+		SET address = $2, latitude = $3, longitude = $4, phone = $5, status = $6, opening_hours = $7
+		WHERE id = $1
+	`
+	cmdTag, err := r.conn.GetExecutor(ctx).Exec(ctx, query,
+		pvz.ID, pvz.Address, pvz.Latitude, pvz.Longitude, pvz.Phone, pvz.Status, openingHoursValue(pvz.OpeningHours),
+	)
+	if err != nil {
+		r.logger.Errorw("updating PVZ",
+			"error", err,
+			"pvzID", pvz.ID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to update pvz")
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return errs.New(errs.ErrPvzNotFound, "pvz not found")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"time"
+)
+
+// analyticsReceptionFilter — общий фильтр аналитики по приёмкам r и ПВЗ p:
+// город ($1), ПВЗ ($2) и период по дате приёмки ($3, $4). Пустые значения фильтр отключают,
+// сравнение по UUID без приведения колонки к тексту использует индекс по pvz_id.
+const analyticsReceptionFilter = `
+	WHERE ($1 = '' OR lower(p.city) = lower($1))
+		AND ($2::uuid IS NULL OR r.pvz_id = $2)
+		AND ($3::timestamptz IS NULL OR r.date_time >= $3)
+		AND ($4::timestamptz IS NULL OR r.date_time <= $4)
+`
+
+// receptionsPerDayQuery группирует приёмки по дням московского времени, как и расписание ПВЗ.
+const receptionsPerDayQuery = `
+	SELECT r.pvz_id, p.city, (r.date_time AT TIME ZONE 'Europe/Moscow')::date AS day, count(*)
+	FROM reception r
+	JOIN pvz p ON p.id = r.pvz_id
+	` + analyticsReceptionFilter + `
+	GROUP BY r.pvz_id, p.city, day
+	ORDER BY p.city, r.pvz_id, day
+`
+
+const productTypeCountsQuery = `
+	SELECT r.pvz_id, p.city, pr.type, count(*)
+	FROM product pr
+	JOIN reception r ON r.id = pr.reception_id
+	JOIN pvz p ON p.id = r.pvz_id
+	` + analyticsReceptionFilter + `
+	GROUP BY r.pvz_id, p.city, pr.type
+	ORDER BY p.city, r.pvz_id, pr.type
+`
+
+// receptionStatsQuery считает товары каждой приёмки отдельно, чтобы пустые приёмки
+// тоже входили в среднее число товаров. Длительность — от открытия до closed_at.
+const receptionStatsQuery = `
+	SELECT r.pvz_id, p.city,
+		count(*),
+		count(r.closed_at),
+		coalesce(sum(pc.products), 0),
+		avg(extract(epoch FROM r.closed_at - r.date_time))::float8,
+		coalesce(avg(pc.products), 0)::float8
+	FROM reception r
+	JOIN pvz p ON p.id = r.pvz_id
+	CROSS JOIN LATERAL (
+		SELECT count(*) AS products FROM product pr WHERE pr.reception_id = r.id
+	) pc
+	` + analyticsReceptionFilter + `
+	GROUP BY r.pvz_id, p.city
+	ORDER BY p.city, r.pvz_id
+`
+
+func (r *postgresReceptionRepository) CountReceptionsPerDay(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.DailyReceptions, error) {
+	var result []entity.DailyReceptions
+	err := r.queryAnalytics(ctx, "CountReceptionsPerDay", receptionsPerDayQuery, filter, func(rows pgx.Rows) error {
+		var d entity.DailyReceptions
+		if err := rows.Scan(&d.PvzID, &d.City, &d.Day, &d.Receptions); err != nil {
+			return err
+		}
+		result = append(result, d)
+		return nil
+	})
+	return result, err
+}
+
+func (r *postgresReceptionRepository) CountProductsByType(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductTypeCount, error) {
+	var result []entity.ProductTypeCount
+	err := r.queryAnalytics(ctx, "CountProductsByType", productTypeCountsQuery, filter, func(rows pgx.Rows) error {
+		var c entity.ProductTypeCount
+		if err := rows.Scan(&c.PvzID, &c.City, &c.Type, &c.Products); err != nil {
+			return err
+		}
+		result = append(result, c)
+		return nil
+	})
+	return result, err
+}
+
+func (r *postgresReceptionRepository) GetReceptionStats(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionStats, error) {
+	var result []entity.ReceptionStats
+	err := r.queryAnalytics(ctx, "GetReceptionStats", receptionStatsQuery, filter, func(rows pgx.Rows) error {
+		var s entity.ReceptionStats
+		err := rows.Scan(&s.PvzID, &s.City, &s.Receptions, &s.ClosedReceptions, &s.Products,
+			&s.AvgDurationSeconds, &s.AvgProductsPerReception)
+		if err != nil {
+			return err
+		}
+		result = append(result, s)
+		return nil
+	})
+	return result, err
+}
+
+// queryAnalytics выполняет агрегирующий запрос с фильтром analyticsReceptionFilter и передаёт строки в scan.
+func (r *postgresReceptionRepository) queryAnalytics(
+	ctx context.Context,
+	name, query string,
+	filter entity.AnalyticsFilter,
+	scan func(pgx.Rows) error,
+) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration(name, time.Since(start).Seconds())
+	}()
+
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, filter.City, emptyToNil(filter.PvzID), filter.StartDate, filter.EndDate)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", name,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to query reception analytics")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", name,
+			)
+			return errs.Wrap(err, errs.ErrInternalCode, "failed to scan reception analytics")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return nil
+}
@@ -15,8 +15,11 @@ import (
 type ReceptionRepository interface {
 	FindOpenReceptionByPvzID(ctx context.Context, pvzID string) (*entity.Reception, error)
 	CreateReception(ctx context.Context, reception entity.Reception) (string, error)
-	UpdateReceptionStatus(ctx context.Context, receptionID string, status string) error
+	CloseReception(ctx context.Context, receptionID string, closedBy *string) error
 	GetReceptionsByPvzIDFiltered(ctx context.Context, pvzID string, startDate, endDate *time.Time) ([]entity.Reception, error)
+	CountReceptionsPerDay(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.DailyReceptions, error)
+	CountProductsByType(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductTypeCount, error)
+	GetReceptionStats(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionStats, error)
 }
 
 type postgresReceptionRepository struct {
@@ -28,6 +31,18 @@ func NewReceptionRepository(conn TxManager, log logger.Logger) ReceptionReposito
 	return &postgresReceptionRepository{conn: conn, logger: log}
 }
 
+const receptionColumns = `id, date_time, pvz_id, status, opened_by, closed_by, closed_at`
+
+// scanReception читает колонки receptionColumns.
+func scanReception(row pgx.Row) (*entity.Reception, error) {
+	var rec entity.Reception
+	err := row.Scan(&rec.ID, &rec.DateTime, &rec.PvzID, &rec.Status, &rec.OpenedBy, &rec.ClosedBy, &rec.ClosedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
 func (r *postgresReceptionRepository) FindOpenReceptionByPvzID(ctx context.Context, pvzID string) (*entity.Reception, error) {
 	start := time.Now()
 	defer func() {
@@ -36,14 +51,13 @@ func (r *postgresReceptionRepository) FindOpenReceptionByPvzID(ctx context.Conte
 
 	pool := r.conn.GetExecutor(ctx)
 	query := `
-		SELECT id, date_time, pvz_id, status
+		SELECT ` + receptionColumns + `
 		FROM reception
 		WHERE pvz_id = $1 AND status = 'in_progress'
 		ORDER BY date_time DESC
 		LIMIT 1
 	`
-	var rec entity.Reception
-	err := pool.QueryRow(ctx, query, pvzID).Scan(&rec.ID, &rec.DateTime, &rec.PvzID, &rec.Status)
+	rec, err := scanReception(pool.QueryRow(ctx, query, pvzID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.New(errs.ErrNoOpenReception, "open reception not found")
@@ -54,7 +68,7 @@ func (r *postgresReceptionRepository) FindOpenReceptionByPvzID(ctx context.Conte
 		)
 		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find open reception by pvz id")
 	}
-	return &rec, nil
+	return rec, nil
 }
 
 func (r *postgresReceptionRepository) CreateReception(ctx context.Context, reception entity.Reception) (string, error) {
@@ -65,13 +79,13 @@ func (r *postgresReceptionRepository) CreateReception(ctx context.Context, recep
 
 	pool := r.conn.GetExecutor(ctx)
 	query := `
-		INSERT INTO reception (date_time, pvz_id, status)
-		VALUES ($1, $2, $3)
+		INSERT INTO reception (date_time, pvz_id, status, opened_by)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id
 	`
 
 	var receptionID string
-	err := pool.QueryRow(ctx, query, reception.DateTime, reception.PvzID, reception.Status).Scan(&receptionID)
+	err := pool.QueryRow(ctx, query, reception.DateTime, reception.PvzID, reception.Status, reception.OpenedBy).Scan(&receptionID)
 	if err != nil {
 		r.logger.Errorw("creating reception",
 			"error", err,
@@ -82,26 +96,26 @@ func (r *postgresReceptionRepository) CreateReception(ctx context.Context, recep
 	return receptionID, nil
 }
 
-func (r *postgresReceptionRepository) UpdateReceptionStatus(ctx context.Context, receptionID string, status string) error {
+// CloseReception закрывает приёмку и фиксирует время закрытия и закрывшего её сотрудника.
+func (r *postgresReceptionRepository) CloseReception(ctx context.Context, receptionID string, closedBy *string) error {
 	start := time.Now()
 	defer func() {
-		metrics.RecordDBQueryDuration("UpdateReceptionStatus", time.Since(start).Seconds())
+		metrics.RecordDBQueryDuration("CloseReception", time.Since(start).Seconds())
 	}()
 
 	pool := r.conn.GetExecutor(ctx)
 	query := `
 		UPDATE reception
-		SET status = $1
-		WHERE id = $2
+		SET status = 'close', closed_at = now(), closed_by = $2
+		WHERE id = $1
 	`
-	cmdTag, err := pool.Exec(ctx, query, status, receptionID)
+	cmdTag, err := pool.Exec(ctx, query, receptionID, closedBy)
 	if err != nil {
-		r.logger.Errorw("updating reception status",
+		r.logger.Errorw("closing reception",
 			"error", err,
 			"receptionID", receptionID,
-			"status", status,
 		)
-		return errs.Wrap(err, errs.ErrInternalCode, "failed to update reception status")
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to close reception")
 	}
 
 	if cmdTag.RowsAffected() == 0 {
@@ -117,8 +131,8 @@ func (r *postgresReceptionRepository) GetReceptionsByPvzIDFiltered(ctx context.C
 	}()
 
 	query := `
-		SELECT id, date_time, pvz_id, status 
-		FROM reception 
+		SELECT ` + receptionColumns + `
+		FROM reception
 		WHERE pvz_id = $1
 	`
 
@@ -130,7 +144,7 @@ func (r *postgresReceptionRepository) GetReceptionsByPvzIDFiltered(ctx context.C
 		args = append(args, startDate, endDate)
 		argIndex += 2
 	}
-	query += " ORDER BY date_time"
+	query += " ORDER BY date_time, id"
 
 	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, args...)
 	if err != nil {
@@ -144,15 +158,15 @@ func (r *postgresReceptionRepository) GetReceptionsByPvzIDFiltered(ctx context.C
 
 	var receptions []entity.Reception
 	for rows.Next() {
-		var rec entity.Reception
-		if err := rows.Scan(&rec.ID, &rec.DateTime, &rec.PvzID, &rec.Status); err != nil {
+		rec, err := scanReception(rows)
+		if err != nil {
 			r.logger.Errorw("scan error",
 				"error", err,
 				"pvzID", pvzID,
 			)
 			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan reception")
 		}
-		receptions = append(receptions, rec)
+		receptions = append(receptions, *rec)
 	}
 	if err := rows.Err(); err != nil {
 		r.logger.Errorw("rows error",
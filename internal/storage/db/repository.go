@@ -5,6 +5,13 @@ type Repository interface {
 	PvzRepository
 	ReceptionRepository
 	ProductRepository
+	OrderRepository
+	ReturnRepository
+	AuditRepository
+	OutboxRepository
+	WebhookRepository
+	TokenRepository
+	LoginAttemptRepository
 }
 
 type postgresRepository struct {
@@ -12,6 +19,13 @@ type postgresRepository struct {
 	PvzRepository
 	ReceptionRepository
 	ProductRepository
+	OrderRepository
+	ReturnRepository
+	AuditRepository
+	OutboxRepository
+	WebhookRepository
+	TokenRepository
+	LoginAttemptRepository
 }
 
 func NewRepository(
@@ -19,11 +33,25 @@ func NewRepository(
 	pvzRepo PvzRepository,
 	receptionRepo ReceptionRepository,
 	productRepo ProductRepository,
+	orderRepo OrderRepository,
+	returnRepo ReturnRepository,
+	auditRepo AuditRepository,
+	outboxRepo OutboxRepository,
+	webhookRepo WebhookRepository,
+	tokenRepo TokenRepository,
+	loginAttemptRepo LoginAttemptRepository,
 ) Repository {
 	return &postgresRepository{
-		UserRepository:      userRepo,
-		PvzRepository:       pvzRepo,
-		ReceptionRepository: receptionRepo,
-		ProductRepository:   productRepo,
+		UserRepository:         userRepo,
+		PvzRepository:          pvzRepo,
+		ReceptionRepository:    receptionRepo,
+		ProductRepository:      productRepo,
+		OrderRepository:        orderRepo,
+		ReturnRepository:       returnRepo,
+		AuditRepository:        auditRepo,
+		OutboxRepository:       outboxRepo,
+		WebhookRepository:      webhookRepo,
+		TokenRepository:        tokenRepo,
+		LoginAttemptRepository: loginAttemptRepo,
 	}
 }
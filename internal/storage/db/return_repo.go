@@ -0,0 +1,263 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// ReturnRepository — отгрузки возвратов продавцу и товары в них.
+type ReturnRepository interface {
+	FindOpenReturnByPvzID(ctx context.Context, pvzID string) (*entity.ReturnShipment, error)
+	CreateReturn(ctx context.Context, shipment entity.ReturnShipment) (string, error)
+	UpdateReturnStatus(ctx context.Context, returnID string, status string) error
+	CreateReturnItem(ctx context.Context, item entity.ReturnItem) (string, error)
+	FindLastReturnItem(ctx context.Context, pvzID string) (*entity.ReturnItem, error)
+	DeleteReturnItem(ctx context.Context, itemID string) error
+	SetProductStatus(ctx context.Context, productID string, status string) error
+	ShipReturnItems(ctx context.Context, returnID string) (int64, error)
+}
+
+type postgresReturnRepository struct {
+	conn   TxManager
+	logger logger.Logger
+}
+
+func NewReturnRepository(conn TxManager, log logger.Logger) ReturnRepository {
+	return &postgresReturnRepository{conn: conn, logger: log}
+}
+
+func (r *postgresReturnRepository) FindOpenReturnByPvzID(ctx context.Context, pvzID string) (*entity.ReturnShipment, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FindOpenReturnByPvzID", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		SELECT id, date_time, pvz_id, status
+		FROM return_shipment
+		WHERE pvz_id = $1 AND status = 'in_progress'
+		LIMIT 1
+	`
+	var ret entity.ReturnShipment
+	err := pool.QueryRow(ctx, query, pvzID).Scan(&ret.ID, &ret.DateTime, &ret.PvzID, &ret.Status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrNoOpenReturn, "open return shipment not found")
+		}
+		r.logger.Errorw("finding open return shipment by pvz",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find open return shipment by pvz id")
+	}
+	return &ret, nil
+}
+
+func (r *postgresReturnRepository) CreateReturn(ctx context.Context, shipment entity.ReturnShipment) (string, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("CreateReturn", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		INSERT INTO return_shipment (date_time, pvz_id, status)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	var returnID string
+	err := pool.QueryRow(ctx, query, shipment.DateTime, shipment.PvzID, shipment.Status).Scan(&returnID)
+	if err != nil {
+		// Параллельное открытие второй отгрузки отсекает частичный уникальный индекс
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return "", errs.New(errs.ErrOpenReturnExists, "open return shipment already exists")
+		}
+		r.logger.Errorw("creating return shipment",
+			"error", err,
+			"pvzID", shipment.PvzID,
+		)
+		return "", errs.Wrap(err, errs.ErrInternalCode, "failed to create return shipment")
+	}
+	return returnID, nil
+}
+
+func (r *postgresReturnRepository) UpdateReturnStatus(ctx context.Context, returnID string, status string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("UpdateReturnStatus", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE return_shipment
+		SET status = $1
+		WHERE id = $2
+	`
+	cmdTag, err := pool.Exec(ctx, query, status, returnID)
+	if err != nil {
+		r.logger.Errorw("updating return shipment status",
+			"error", err,
+			"returnID", returnID,
+			"status", status,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to update return shipment status")
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return errs.New(errs.ErrNoOpenReturn, "no return shipment found to update")
+	}
+	return nil
+}
+
+func (r *postgresReturnRepository) CreateReturnItem(ctx context.Context, item entity.ReturnItem) (string, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("CreateReturnItem", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		INSERT INTO return_item (date_time, return_id, product_id, reason, previous_status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var itemID string
+	err := pool.QueryRow(ctx, query,
+		item.DateTime, item.ReturnID, item.ProductID, item.Reason, item.PreviousStatus,
+	).Scan(&itemID)
+	if err != nil {
+		r.logger.Errorw("creating return item",
+			"error", err,
+			"returnID", item.ReturnID,
+			"productID", item.ProductID,
+		)
+		return "", errs.Wrap(err, errs.ErrInternalCode, "failed to create return item")
+	}
+	return itemID, nil
+}
+
+func (r *postgresReturnRepository) FindLastReturnItem(ctx context.Context, pvzID string) (*entity.ReturnItem, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FindLastReturnItem", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+
+	// Последний товар из незакрытой отгрузки возвратов данного ПВЗ
+	query := `
+		SELECT i.id, i.date_time, i.return_id, i.product_id, i.reason, i.previous_status
+		FROM return_item i
+		JOIN return_shipment s ON i.return_id = s.id
+		WHERE s.pvz_id = $1 AND s.status = 'in_progress'
+		ORDER BY i.date_time DESC
+		LIMIT 1
+	`
+
+	var item entity.ReturnItem
+	err := pool.QueryRow(ctx, query, pvzID).Scan(
+		&item.ID,
+		&item.DateTime,
+		&item.ReturnID,
+		&item.ProductID,
+		&item.Reason,
+		&item.PreviousStatus,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrNoReturnItemsToDelete, "no return item found to delete")
+		}
+		r.logger.Errorw("finding last return item",
+			"error", err,
+			"pvzID", pvzID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find last return item")
+	}
+	return &item, nil
+}
+
+func (r *postgresReturnRepository) DeleteReturnItem(ctx context.Context, itemID string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("DeleteReturnItem", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		DELETE FROM return_item
+		WHERE id = $1
+	`
+	cmdTag, err := pool.Exec(ctx, query, itemID)
+	if err != nil {
+		r.logger.Errorw("deleting return item",
+			"error", err,
+			"itemID", itemID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to delete return item")
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return errs.New(errs.ErrNoReturnItemsToDelete, "no return item found with provided id")
+	}
+	return nil
+}
+
+func (r *postgresReturnRepository) SetProductStatus(ctx context.Context, productID string, status string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("SetProductStatus", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE product
+		SET status = $2
+		WHERE id = $1
+	`
+	cmdTag, err := pool.Exec(ctx, query, productID, status)
+	if err != nil {
+		r.logger.Errorw("setting product status",
+			"error", err,
+			"productID", productID,
+			"status", status,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to set product status")
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return errs.New(errs.ErrProductNotFound, "product not found")
+	}
+	return nil
+}
+
+func (r *postgresReturnRepository) ShipReturnItems(ctx context.Context, returnID string) (int64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ShipReturnItems", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE product p
+		SET status = 'shipped_back'
+		FROM return_item i
+		WHERE i.product_id = p.id AND i.return_id = $1
+	`
+	cmdTag, err := pool.Exec(ctx, query, returnID)
+	if err != nil {
+		r.logger.Errorw("shipping return items",
+			"error", err,
+			"returnID", returnID,
+		)
+		return 0, errs.Wrap(err, errs.ErrInternalCode, "failed to ship return items")
+	}
+	return cmdTag.RowsAffected(), nil
+}
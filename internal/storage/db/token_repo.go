@@ -0,0 +1,278 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// TokenRepository — refresh-токены сессий и список отозванных access-токенов.
+type TokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error
+	GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	GetTokenFamilyByAccessJTI(ctx context.Context, jti string) (string, error)
+	MarkRefreshTokenUsed(ctx context.Context, id string) error
+	RevokeTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserTokens(ctx context.Context, userID string, exceptFamilyID string) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	DeleteExpiredTokens(ctx context.Context) (int64, error)
+}
+
+type postgresTokenRepository struct {
+	conn   TxManager
+	logger logger.Logger
+}
+
+func NewTokenRepository(conn TxManager, log logger.Logger) TokenRepository {
+	return &postgresTokenRepository{conn: conn, logger: log}
+}
+
+func (r *postgresTokenRepository) CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("CreateRefreshToken", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		INSERT INTO refresh_token (user_id, family_id, token_hash, access_jti, access_expires_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := pool.Exec(ctx, query, token.UserID, token.FamilyID, token.TokenHash, token.AccessJTI, token.AccessExpiresAt, token.ExpiresAt)
+	if err != nil {
+		r.logger.Errorw("creating refresh token",
+			"error", err,
+			"userID", token.UserID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to create refresh token")
+	}
+	return nil
+}
+
+// GetRefreshTokenForUpdate находит токен по хешу и блокирует строку до конца транзакции,
+// чтобы два параллельных обновления одним токеном не выдали две новые пары.
+func (r *postgresTokenRepository) GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetRefreshTokenForUpdate", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		SELECT id, user_id, family_id, token_hash, access_jti, access_expires_at, expires_at, created_at, used_at, revoked_at
+		FROM refresh_token
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+	var t entity.RefreshToken
+	err := pool.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.FamilyID,
+		&t.TokenHash,
+		&t.AccessJTI,
+		&t.AccessExpiresAt,
+		&t.ExpiresAt,
+		&t.CreatedAt,
+		&t.UsedAt,
+		&t.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrInvalidRefreshToken, "invalid refresh token")
+		}
+		r.logger.Errorw("getting refresh token",
+			"error", err,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get refresh token")
+	}
+	return &t, nil
+}
+
+// GetTokenFamilyByAccessJTI возвращает семейство refresh-токенов, вместе с которым был выдан access-токен.
+func (r *postgresTokenRepository) GetTokenFamilyByAccessJTI(ctx context.Context, jti string) (string, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetTokenFamilyByAccessJTI", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		SELECT family_id
+		FROM refresh_token
+		WHERE access_jti = $1
+	`
+	var familyID string
+	if err := pool.QueryRow(ctx, query, jti).Scan(&familyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", errs.New(errs.ErrNotFoundCode, "token family not found")
+		}
+		r.logger.Errorw("getting token family",
+			"error", err,
+			"jti", jti,
+		)
+		return "", errs.Wrap(err, errs.ErrInternalCode, "failed to get token family")
+	}
+	return familyID, nil
+}
+
+func (r *postgresTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("MarkRefreshTokenUsed", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		UPDATE refresh_token
+		SET used_at = now()
+		WHERE id = $1
+	`
+	if _, err := pool.Exec(ctx, query, id); err != nil {
+		r.logger.Errorw("marking refresh token used",
+			"error", err,
+			"id", id,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to mark refresh token used")
+	}
+	return nil
+}
+
+// RevokeTokenFamily отзывает все refresh-токены семейства и ещё не истёкшие access-токены,
+// выданные вместе с ними.
+func (r *postgresTokenRepository) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("RevokeTokenFamily", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		WITH revoked AS (
+			UPDATE refresh_token
+			SET revoked_at = COALESCE(revoked_at, now())
+			WHERE family_id = $1
+			RETURNING access_jti, access_expires_at
+		)
+		INSERT INTO revoked_token (jti, expires_at)
+		SELECT access_jti, access_expires_at
+		FROM revoked
+		WHERE access_expires_at > now()
+		ON CONFLICT (jti) DO NOTHING
+	`
+	if _, err := pool.Exec(ctx, query, familyID); err != nil {
+		r.logger.Errorw("revoking token family",
+			"error", err,
+			"familyID", familyID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to revoke token family")
+	}
+	return nil
+}
+
+// RevokeUserTokens отзывает все сессии пользователя, кроме exceptFamilyID (пустая строка —
+// отозвать все), вместе с ещё не истёкшими access-токенами.
+func (r *postgresTokenRepository) RevokeUserTokens(ctx context.Context, userID string, exceptFamilyID string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("RevokeUserTokens", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		WITH revoked AS (
+			UPDATE refresh_token
+			SET revoked_at = COALESCE(revoked_at, now())
+			WHERE user_id = $1 AND ($2 = '' OR family_id::text <> $2)
+			RETURNING access_jti, access_expires_at
+		)
+		INSERT INTO revoked_token (jti, expires_at)
+		SELECT access_jti, access_expires_at
+		FROM revoked
+		WHERE access_expires_at > now()
+		ON CONFLICT (jti) DO NOTHING
+	`
+	if _, err := pool.Exec(ctx, query, userID, exceptFamilyID); err != nil {
+		r.logger.Errorw("revoking user tokens",
+			"error", err,
+			"userID", userID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to revoke user tokens")
+	}
+	return nil
+}
+
+func (r *postgresTokenRepository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("RevokeAccessToken", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		INSERT INTO revoked_token (jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING
+	`
+	if _, err := pool.Exec(ctx, query, jti, expiresAt); err != nil {
+		r.logger.Errorw("revoking access token",
+			"error", err,
+			"jti", jti,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to revoke access token")
+	}
+	return nil
+}
+
+func (r *postgresTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("IsTokenRevoked", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_token WHERE jti = $1)`
+
+	var revoked bool
+	if err := pool.QueryRow(ctx, query, jti).Scan(&revoked); err != nil {
+		r.logger.Errorw("checking token revocation",
+			"error", err,
+			"jti", jti,
+		)
+		return false, errs.Wrap(err, errs.ErrInternalCode, "failed to check token revocation")
+	}
+	return revoked, nil
+}
+
+// DeleteExpiredTokens удаляет истёкшие refresh-токены и записи об отозванных токенах,
+// срок которых уже вышел: такие токены отклоняются и без списка.
+func (r *postgresTokenRepository) DeleteExpiredTokens(ctx context.Context) (int64, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("DeleteExpiredTokens", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		WITH refresh AS (
+			DELETE FROM refresh_token WHERE expires_at < now() RETURNING 1
+		), revoked AS (
+			DELETE FROM revoked_token WHERE expires_at < now() RETURNING 1
+		)
+		SELECT (SELECT count(*) FROM refresh) + (SELECT count(*) FROM revoked)
+	`
+	var deleted int64
+	if err := pool.QueryRow(ctx, query).Scan(&deleted); err != nil {
+		r.logger.Errorw("deleting expired tokens",
+			"error", err,
+		)
+		return 0, errs.Wrap(err, errs.ErrInternalCode, "failed to delete expired tokens")
+	}
+	return deleted, nil
+}
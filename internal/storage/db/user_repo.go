@@ -13,7 +13,31 @@ import (
 
 type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	FindByID(ctx context.Context, userID string) (*entity.User, error)
 	CreateUser(ctx context.Context, user entity.User) (string, error)
+	ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, error)
+	UpdateUserRole(ctx context.Context, userID string, role string) error
+	SetUserActive(ctx context.Context, userID string, active bool) error
+	UpdateUserPassword(ctx context.Context, userID string, passwordHash string, changeRequired bool) error
+}
+
+const userColumns = `id, email, password, role, created_at, active, password_change_required`
+
+func scanUser(row pgx.Row) (*entity.User, error) {
+	var user entity.User
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Role,
+		&user.CreatedAt,
+		&user.Active,
+		&user.PasswordChangeRequired,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
 }
 
 type postgresUserRepository struct {
@@ -64,19 +88,12 @@ func (r *postgresUserRepository) FindByEmail(ctx context.Context, email string)
 	pool := r.conn.GetExecutor(ctx)
 
 	query := `
-		SELECT id, email, password, role, created_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1
 	`
 
-	var user entity.User
-	err := pool.QueryRow(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.Role,
-		&user.CreatedAt,
-	)
+	user, err := scanUser(pool.QueryRow(ctx, query, email))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.New(errs.ErrNotFoundCode, "user not found")
@@ -88,5 +105,133 @@ func (r *postgresUserRepository) FindByEmail(ctx context.Context, email string)
 		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find user by email")
 	}
 
-	return &user, nil
+	return user, nil
+}
+
+func (r *postgresUserRepository) FindByID(ctx context.Context, userID string) (*entity.User, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("FindUserByID", time.Since(start).Seconds())
+	}()
+
+	pool := r.conn.GetExecutor(ctx)
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE id = $1
+	`
+
+	user, err := scanUser(pool.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrNotFoundCode, "user not found")
+		}
+		r.logger.Errorw("finding a user by id",
+			"error", err,
+			"userID", userID,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to find user by id")
+	}
+
+	return user, nil
+}
+
+// ListUsers возвращает пользователей от новых к старым. Если задан filter.After, страница
+// начинается сразу после этого ключа.
+func (r *postgresUserRepository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ListUsers", time.Since(start).Seconds())
+	}()
+
+	var afterCreatedAt *time.Time
+	var afterID *string
+	if filter.After != nil {
+		afterCreatedAt, afterID = &filter.After.CreatedAt, &filter.After.ID
+	}
+
+	pool := r.conn.GetExecutor(ctx)
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE ($1 = '' OR strpos(lower(email), lower($1)) > 0)
+			AND ($2 = '' OR role = $2)
+			AND ($3::boolean IS NULL OR active = $3)
+			AND ($4::timestamptz IS NULL OR (created_at, id) < ($4, $5::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $6
+	`
+	rows, err := pool.Query(ctx, query, filter.Email, filter.Role, filter.Active, afterCreatedAt, afterID, filter.Limit)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "ListUsers",
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to list users")
+	}
+	defer rows.Close()
+
+	var users []entity.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			r.logger.Errorw("scanning user",
+				"error", err,
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan user")
+		}
+		users = append(users, *user)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Errorw("Rows error in ListUsers",
+			"error", err,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows error")
+	}
+	return users, nil
+}
+
+func (r *postgresUserRepository) UpdateUserRole(ctx context.Context, userID string, role string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("UpdateUserRole", time.Since(start).Seconds())
+	}()
+
+	return r.updateUser(ctx, "updating user role", userID, `UPDATE users SET role = $2 WHERE id = $1`, role)
+}
+
+func (r *postgresUserRepository) SetUserActive(ctx context.Context, userID string, active bool) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("SetUserActive", time.Since(start).Seconds())
+	}()
+
+	return r.updateUser(ctx, "updating user status", userID, `UPDATE users SET active = $2 WHERE id = $1`, active)
+}
+
+func (r *postgresUserRepository) UpdateUserPassword(ctx context.Context, userID string, passwordHash string, changeRequired bool) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("UpdateUserPassword", time.Since(start).Seconds())
+	}()
+
+	return r.updateUser(ctx, "updating user password", userID,
+		`UPDATE users SET password = $2, password_change_required = $3 WHERE id = $1`, passwordHash, changeRequired)
+}
+
+// updateUser выполняет UPDATE одного пользователя; $1 в запросе — ID пользователя.
+func (r *postgresUserRepository) updateUser(ctx context.Context, op string, userID string, query string, args ...any) error {
+	tag, err := r.conn.GetExecutor(ctx).Exec(ctx, query, append([]any{userID}, args...)...)
+	if err != nil {
+		r.logger.Errorw(op,
+			"error", err,
+			"userID", userID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to update user")
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.New(errs.ErrNotFoundCode, "user not found")
+	}
+	return nil
 }
@@ -0,0 +1,441 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// WebhookRepository — подписки на вебхуки, очередь доставок и история попыток.
+type WebhookRepository interface {
+	CreateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id string) (*entity.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) error
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+
+	EnqueueWebhookDeliveries(ctx context.Context, event entity.OutboxEvent) error
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDeliveryTask, error)
+	CompleteWebhookAttempt(ctx context.Context, attempt entity.WebhookDeliveryAttempt, status string, nextAttemptAt *time.Time) error
+
+	ListWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, subscriptionID string, id int64) (*entity.WebhookDelivery, error)
+	ListWebhookDeliveryAttempts(ctx context.Context, deliveryID int64) ([]entity.WebhookDeliveryAttempt, error)
+	RetryWebhookDelivery(ctx context.Context, id int64) error
+}
+
+type postgresWebhookRepository struct {
+	conn   TxManager
+	logger logger.Logger
+}
+
+func NewWebhookRepository(conn TxManager, log logger.Logger) WebhookRepository {
+	return &postgresWebhookRepository{conn: conn, logger: log}
+}
+
+const webhookSubscriptionColumns = `id, url, event_types, secret, active, created_at, updated_at`
+
+func scanWebhookSubscription(row pgx.Row) (*entity.WebhookSubscription, error) {
+	var sub entity.WebhookSubscription
+	err := row.Scan(&sub.ID, &sub.URL, &sub.EventTypes, &sub.Secret, &sub.Active, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+const webhookDeliveryColumns = `d.id, d.subscription_id, d.event_id, d.event_type, d.aggregate_id, d.pvz_id, d.payload,
+	d.occurred_at, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at`
+
+func webhookDeliveryFields(d *entity.WebhookDelivery) []any {
+	return []any{&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.AggregateID, &d.PvzID, &d.Payload,
+		&d.OccurredAt, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt}
+}
+
+func (r *postgresWebhookRepository) CreateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("CreateWebhookSubscription", time.Since(start).Seconds())
+	}()
+
+	query := `
+		INSERT INTO webhook_subscription (url, event_types, secret, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + webhookSubscriptionColumns
+	created, err := scanWebhookSubscription(r.conn.GetExecutor(ctx).QueryRow(ctx, query, sub.URL, sub.EventTypes, sub.Secret, sub.Active))
+	if err != nil {
+		r.logger.Errorw("creating webhook subscription",
+			"error", err,
+			"url", sub.URL,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to create webhook subscription")
+	}
+	return created, nil
+}
+
+func (r *postgresWebhookRepository) GetWebhookSubscription(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetWebhookSubscription", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscription
+		WHERE id = $1
+	`
+	sub, err := scanWebhookSubscription(r.conn.GetExecutor(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrWebhookNotFound, "webhook not found")
+		}
+		r.logger.Errorw("getting webhook subscription",
+			"error", err,
+			"webhookID", id,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get webhook subscription")
+	}
+	return sub, nil
+}
+
+func (r *postgresWebhookRepository) ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ListWebhookSubscriptions", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscription
+		ORDER BY created_at, id
+	`
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "ListWebhookSubscriptions",
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to query webhook subscriptions")
+	}
+	defer rows.Close()
+
+	var subs []entity.WebhookSubscription
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", "ListWebhookSubscriptions",
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan webhook subscription")
+		}
+		subs = append(subs, *sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return subs, nil
+}
+
+func (r *postgresWebhookRepository) UpdateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("UpdateWebhookSubscription", time.Since(start).Seconds())
+	}()
+
+	query := `
+		UPDATE webhook_subscription
+		SET url = $2, event_types = $3, active = $4, updated_at = $5
+		WHERE id = $1
+	`
+	tag, err := r.conn.GetExecutor(ctx).Exec(ctx, query, sub.ID, sub.URL, sub.EventTypes, sub.Active, sub.UpdatedAt)
+	if err != nil {
+		r.logger.Errorw("updating webhook subscription",
+			"error", err,
+			"webhookID", sub.ID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to update webhook subscription")
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.New(errs.ErrWebhookNotFound, "webhook not found")
+	}
+	return nil
+}
+
+func (r *postgresWebhookRepository) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("DeleteWebhookSubscription", time.Since(start).Seconds())
+	}()
+
+	tag, err := r.conn.GetExecutor(ctx).Exec(ctx, `DELETE FROM webhook_subscription WHERE id = $1`, id)
+	if err != nil {
+		r.logger.Errorw("deleting webhook subscription",
+			"error", err,
+			"webhookID", id,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to delete webhook subscription")
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.New(errs.ErrWebhookNotFound, "webhook not found")
+	}
+	return nil
+}
+
+// EnqueueWebhookDeliveries создаёт доставки события всем активным подпискам на его тип.
+func (r *postgresWebhookRepository) EnqueueWebhookDeliveries(ctx context.Context, event entity.OutboxEvent) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("EnqueueWebhookDeliveries", time.Since(start).Seconds())
+	}()
+
+	query := `
+		INSERT INTO webhook_delivery (subscription_id, event_id, event_type, aggregate_id, pvz_id, payload, occurred_at)
+		SELECT id, $1, $2, $3, $4, $5, $6
+		FROM webhook_subscription
+		WHERE active AND $2 = ANY(event_types)
+	`
+	_, err := r.conn.GetExecutor(ctx).Exec(ctx, query,
+		event.EventID, event.Type, event.AggregateID, event.PvzID, event.Payload, event.CreatedAt)
+	if err != nil {
+		r.logger.Errorw("enqueueing webhook deliveries",
+			"error", err,
+			"eventType", event.Type,
+			"aggregateID", event.AggregateID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to enqueue webhook deliveries")
+	}
+	return nil
+}
+
+// ClaimDueWebhookDeliveries берёт в работу до limit доставок активных подписок и откладывает
+// их следующую попытку на lease. Транзакция не держится на время HTTP-запроса: если воркер упадёт,
+// доставка вернётся в очередь по истечении lease.
+func (r *postgresWebhookRepository) ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDeliveryTask, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ClaimDueWebhookDeliveries", time.Since(start).Seconds())
+	}()
+
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_delivery d
+			JOIN webhook_subscription s ON s.id = d.subscription_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= now() AND s.active
+			ORDER BY d.next_attempt_at, d.id
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_delivery d
+		SET next_attempt_at = now() + make_interval(secs => $2)
+		FROM due, webhook_subscription s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING ` + webhookDeliveryColumns + `, s.url, s.secret
+	`
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "ClaimDueWebhookDeliveries",
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to claim webhook deliveries")
+	}
+	defer rows.Close()
+
+	var tasks []entity.WebhookDeliveryTask
+	for rows.Next() {
+		var t entity.WebhookDeliveryTask
+		if err := rows.Scan(append(webhookDeliveryFields(&t.WebhookDelivery), &t.URL, &t.Secret)...); err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", "ClaimDueWebhookDeliveries",
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan webhook delivery")
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return tasks, nil
+}
+
+// CompleteWebhookAttempt записывает попытку в историю и переводит доставку в status.
+// nextAttemptAt задаётся только для status = pending.
+func (r *postgresWebhookRepository) CompleteWebhookAttempt(
+	ctx context.Context,
+	attempt entity.WebhookDeliveryAttempt,
+	status string,
+	nextAttemptAt *time.Time,
+) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("CompleteWebhookAttempt", time.Since(start).Seconds())
+	}()
+
+	query := `
+		WITH attempt AS (
+			INSERT INTO webhook_delivery_attempt (delivery_id, attempted_at, duration_ms, status_code, error)
+			VALUES ($1, $2, $3, $4, $5)
+		)
+		UPDATE webhook_delivery
+		SET attempts = attempts + 1,
+			status = $6,
+			next_attempt_at = COALESCE($7, next_attempt_at),
+			last_status_code = $4,
+			last_error = $5,
+			delivered_at = CASE WHEN $6 = 'delivered' THEN now() END
+		WHERE id = $1
+	`
+	_, err := r.conn.GetExecutor(ctx).Exec(ctx, query,
+		attempt.DeliveryID, attempt.AttemptedAt, attempt.Duration.Milliseconds(), attempt.StatusCode, attempt.Error,
+		status, nextAttemptAt,
+	)
+	if err != nil {
+		r.logger.Errorw("completing webhook attempt",
+			"error", err,
+			"deliveryID", attempt.DeliveryID,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to record webhook attempt")
+	}
+	return nil
+}
+
+func (r *postgresWebhookRepository) ListWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ListWebhookDeliveries", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_delivery d
+		WHERE d.subscription_id = $1
+			AND ($2 = '' OR d.status = $2)
+			AND ($3::bigint IS NULL OR d.id < $3)
+		ORDER BY d.id DESC
+		LIMIT $4
+	`
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, filter.SubscriptionID, filter.Status, filter.BeforeID, filter.Limit)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "ListWebhookDeliveries",
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to query webhook deliveries")
+	}
+	defer rows.Close()
+
+	var deliveries []entity.WebhookDelivery
+	for rows.Next() {
+		var d entity.WebhookDelivery
+		if err := rows.Scan(webhookDeliveryFields(&d)...); err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", "ListWebhookDeliveries",
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan webhook delivery")
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return deliveries, nil
+}
+
+func (r *postgresWebhookRepository) GetWebhookDelivery(ctx context.Context, subscriptionID string, id int64) (*entity.WebhookDelivery, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("GetWebhookDelivery", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_delivery d
+		WHERE d.id = $1 AND d.subscription_id = $2
+	`
+	var d entity.WebhookDelivery
+	err := r.conn.GetExecutor(ctx).QueryRow(ctx, query, id, subscriptionID).Scan(webhookDeliveryFields(&d)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.New(errs.ErrWebhookDeliveryNotFound, "webhook delivery not found")
+		}
+		r.logger.Errorw("getting webhook delivery",
+			"error", err,
+			"deliveryID", id,
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to get webhook delivery")
+	}
+	return &d, nil
+}
+
+func (r *postgresWebhookRepository) ListWebhookDeliveryAttempts(ctx context.Context, deliveryID int64) ([]entity.WebhookDeliveryAttempt, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("ListWebhookDeliveryAttempts", time.Since(start).Seconds())
+	}()
+
+	query := `
+		SELECT id, delivery_id, attempted_at, duration_ms, status_code, error
+		FROM webhook_delivery_attempt
+		WHERE delivery_id = $1
+		ORDER BY id
+	`
+	rows, err := r.conn.GetExecutor(ctx).Query(ctx, query, deliveryID)
+	if err != nil {
+		r.logger.Errorw("query error",
+			"error", err,
+			"query", "ListWebhookDeliveryAttempts",
+		)
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to query webhook delivery attempts")
+	}
+	defer rows.Close()
+
+	var attempts []entity.WebhookDeliveryAttempt
+	for rows.Next() {
+		var a entity.WebhookDeliveryAttempt
+		var durationMs int64
+		if err := rows.Scan(&a.ID, &a.DeliveryID, &a.AttemptedAt, &durationMs, &a.StatusCode, &a.Error); err != nil {
+			r.logger.Errorw("scan error",
+				"error", err,
+				"query", "ListWebhookDeliveryAttempts",
+			)
+			return nil, errs.Wrap(err, errs.ErrInternalCode, "failed to scan webhook delivery attempt")
+		}
+		a.Duration = time.Duration(durationMs) * time.Millisecond
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.Wrap(err, errs.ErrInternalCode, "rows iteration error")
+	}
+	return attempts, nil
+}
+
+// RetryWebhookDelivery возвращает доставку в очередь с полным запасом попыток.
+func (r *postgresWebhookRepository) RetryWebhookDelivery(ctx context.Context, id int64) error {
+	start := time.Now()
+	defer func() {
+		metrics.RecordDBQueryDuration("RetryWebhookDelivery", time.Since(start).Seconds())
+	}()
+
+	query := `
+		UPDATE webhook_delivery
+		SET status = 'pending', attempts = 0, next_attempt_at = now()
+		WHERE id = $1
+	`
+	if _, err := r.conn.GetExecutor(ctx).Exec(ctx, query, id); err != nil {
+		r.logger.Errorw("retrying webhook delivery",
+			"error", err,
+			"deliveryID", id,
+		)
+		return errs.Wrap(err, errs.ErrInternalCode, "failed to retry webhook delivery")
+	}
+	return nil
+}
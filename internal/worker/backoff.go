@@ -0,0 +1,15 @@
+package worker
+
+import "time"
+
+// backoff — задержка перед попыткой attempts+1: base, 2×base, 4×base… но не больше max.
+func backoff(base, max time.Duration, attempts int) time.Duration {
+	d := base
+	for i := 1; i < attempts && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// ExpiryWorker периодически помечает товары с истёкшим сроком хранения
+// и обновляет метрику просроченных товаров.
+type ExpiryWorker struct {
+	repo      db.OrderRepository
+	logger    logger.Logger
+	interval  time.Duration
+	batchSize int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewExpiryWorker(repo db.OrderRepository, log logger.Logger, interval time.Duration, batchSize int) *ExpiryWorker {
+	return &ExpiryWorker{
+		repo:      repo,
+		logger:    log,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Start запускает воркер в отдельной горутине. Первый проход выполняется сразу.
+func (w *ExpiryWorker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.Sweep(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop останавливает воркер и дожидается завершения текущего прохода.
+func (w *ExpiryWorker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Sweep помечает просроченные товары пачками по batchSize, пока они не закончатся.
+func (w *ExpiryWorker) Sweep(ctx context.Context) {
+	var total int64
+	for ctx.Err() == nil {
+		marked, err := w.repo.MarkExpiredOrders(ctx, w.batchSize)
+		if err != nil {
+			w.logger.Errorw("expiry sweep",
+				"error", err,
+			)
+			break
+		}
+		total += marked
+		if marked < int64(w.batchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		metrics.ProductsExpired(total)
+		w.logger.Infow("products expired",
+			"count", total,
+		)
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	overdue, err := w.repo.CountOverdueOrders(ctx)
+	if err != nil {
+		w.logger.Errorw("counting overdue products",
+			"error", err,
+		)
+		return
+	}
+	metrics.SetOverdueProducts(overdue)
+}
@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+	"time"
+)
+
+func TestExpiryWorker_Sweep(t *testing.T) {
+	t.Parallel()
+
+	t.Run("marks batches until exhausted", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		w := NewExpiryWorker(repoMock, loggerMock, time.Minute, 2)
+
+		repoMock.On("MarkExpiredOrders", mock.Anything, 2).Return(int64(2), nil).Twice()
+		repoMock.On("MarkExpiredOrders", mock.Anything, 2).Return(int64(1), nil).Once()
+		repoMock.On("CountOverdueOrders", mock.Anything).Return(int64(5), nil).Once()
+		loggerMock.On("Infow", "products expired", "count", int64(5)).Return().Once()
+
+		w.Sweep(context.Background())
+	})
+
+	t.Run("stops on repository error", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		w := NewExpiryWorker(repoMock, loggerMock, time.Minute, 10)
+
+		repoMock.On("MarkExpiredOrders", mock.Anything, 10).Return(int64(0), errors.New("db down")).Once()
+		repoMock.On("CountOverdueOrders", mock.Anything).Return(int64(0), nil).Once()
+		loggerMock.On("Errorw", "expiry sweep", "error", mock.Anything).Return().Once()
+
+		w.Sweep(context.Background())
+	})
+}
+
+func TestExpiryWorker_StartStop(t *testing.T) {
+	t.Parallel()
+
+	repoMock := mockRepo.NewRepository(t)
+	w := NewExpiryWorker(repoMock, mockLog.NewLogger(t), time.Hour, 10)
+
+	swept := make(chan struct{})
+	repoMock.On("MarkExpiredOrders", mock.Anything, 10).Return(int64(0), nil).Once()
+	repoMock.On("CountOverdueOrders", mock.Anything).
+		Run(func(mock.Arguments) { close(swept) }).
+		Return(int64(0), nil).Once()
+
+	w.Start(context.Background())
+	<-swept
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := w.Stop(ctx); err != nil {
+		t.Fatalf("unexpected error on stop: %v", err)
+	}
+}
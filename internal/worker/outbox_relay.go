@@ -0,0 +1,196 @@
+package worker
+
+import (
+	"context"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/outbox"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// OutboxRelayOptions — параметры публикации и повторов. Retention — срок хранения
+// опубликованных событий.
+type OutboxRelayOptions struct {
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Retention   time.Duration
+}
+
+// OutboxRelay периодически забирает ожидающие события outbox и публикует их через Publisher.
+// Доставка at-least-once: событие помечается опубликованным только после успешного Publish.
+// После каждого прохода relay удаляет опубликованные события старше Retention и обновляет
+// метрику событий в dead letter.
+type OutboxRelay struct {
+	repo      db.OutboxRepository
+	txManager db.TxManager
+	publisher outbox.Publisher
+	logger    logger.Logger
+	opts      OutboxRelayOptions
+
+	now    func() time.Time
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewOutboxRelay(repo db.OutboxRepository, txManager db.TxManager, publisher outbox.Publisher, log logger.Logger, opts OutboxRelayOptions) *OutboxRelay {
+	return &OutboxRelay{
+		repo:      repo,
+		txManager: txManager,
+		publisher: publisher,
+		logger:    log,
+		opts:      opts,
+		now:       time.Now,
+	}
+}
+
+// Start запускает relay в отдельной горутине. Первый проход выполняется сразу.
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.opts.Interval)
+		defer ticker.Stop()
+
+		for {
+			r.Relay(ctx)
+			r.Cleanup(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop останавливает relay, дожидается текущего прохода и закрывает publisher.
+func (r *OutboxRelay) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return r.publisher.Close()
+	}
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return r.publisher.Close()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Relay публикует пачки событий, пока не останется событий, срок попытки которых наступил.
+func (r *OutboxRelay) Relay(ctx context.Context) {
+	for ctx.Err() == nil {
+		fetched, err := r.RelayBatch(ctx)
+		if err != nil {
+			r.logger.Errorw("outbox relay",
+				"error", err,
+			)
+			return
+		}
+		if fetched < r.opts.BatchSize {
+			return
+		}
+	}
+}
+
+// Cleanup удаляет пачками опубликованные события старше Retention и обновляет число событий
+// в dead letter. Ожидающие и dead события не удаляются.
+func (r *OutboxRelay) Cleanup(ctx context.Context) {
+	before := r.now().Add(-r.opts.Retention)
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := r.repo.DeletePublishedOutboxEvents(ctx, before, r.opts.BatchSize)
+		if err != nil {
+			r.logger.Errorw("outbox cleanup",
+				"error", err,
+			)
+			break
+		}
+		total += deleted
+		if deleted < int64(r.opts.BatchSize) {
+			break
+		}
+	}
+	if total > 0 {
+		metrics.OutboxEventsDeleted(total)
+		r.logger.Infow("published outbox events deleted",
+			"count", total,
+		)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	dead, err := r.repo.CountDeadOutboxEvents(ctx)
+	if err != nil {
+		r.logger.Errorw("outbox dead letter count",
+			"error", err,
+		)
+		return
+	}
+	metrics.SetOutboxDeadEvents(dead)
+}
+
+// RelayBatch публикует одну пачку событий в транзакции, удерживающей их блокировку,
+// и возвращает число выбранных событий. Неудачные события откладываются с экспоненциальной
+// задержкой, после MaxAttempts попыток переводятся в dead.
+func (r *OutboxRelay) RelayBatch(ctx context.Context) (int, error) {
+	var fetched int
+	err := r.txManager.WithTx(ctx, db.IsolationLevelReadCommitted, db.AccessModeReadWrite, func(txCtx context.Context) error {
+		events, err := r.repo.FetchDueOutboxEvents(txCtx, r.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+		fetched = len(events)
+
+		for _, event := range events {
+			if err := r.publisher.Publish(txCtx, outbox.MessageFromEvent(event)); err != nil {
+				if err := r.markFailed(txCtx, event, err); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.repo.MarkOutboxEventPublished(txCtx, event.ID); err != nil {
+				return err
+			}
+			metrics.OutboxEvent(metrics.OutboxResultPublished)
+			metrics.ObserveOutboxPublishLag(r.now().Sub(event.CreatedAt).Seconds())
+		}
+		return nil
+	})
+	return fetched, err
+}
+
+func (r *OutboxRelay) markFailed(ctx context.Context, event entity.OutboxEvent, publishErr error) error {
+	attempts := event.Attempts + 1
+	if attempts >= r.opts.MaxAttempts {
+		r.logger.Errorw("outbox event moved to dead letter",
+			"error", publishErr,
+			"eventID", event.EventID,
+			"eventType", event.Type,
+			"attempts", attempts,
+		)
+		metrics.OutboxEvent(metrics.OutboxResultDead)
+		return r.repo.MarkOutboxEventFailed(ctx, event.ID, nil, publishErr.Error())
+	}
+
+	next := r.now().Add(backoff(r.opts.BaseBackoff, r.opts.MaxBackoff, attempts))
+	r.logger.Warnw("outbox publish failed",
+		"error", publishErr,
+		"eventID", event.EventID,
+		"attempts", attempts,
+		"nextAttemptAt", next,
+	)
+	metrics.OutboxEvent(metrics.OutboxResultRetry)
+	return r.repo.MarkOutboxEventFailed(ctx, event.ID, &next, publishErr.Error())
+}
@@ -0,0 +1,219 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/outbox"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyPublisher отклоняет события из failing и запоминает остальные
+type flakyPublisher struct {
+	mu        sync.Mutex
+	failing   map[string]bool
+	published []string
+	closed    bool
+}
+
+func (p *flakyPublisher) Publish(_ context.Context, msg outbox.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failing[msg.ID] {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, msg.ID)
+	return nil
+}
+
+func (p *flakyPublisher) Close() error {
+	p.closed = true
+	return nil
+}
+
+func passThroughTx(txManager *mockRepo.TxManager) {
+	txManager.
+		On("WithTx", mock.Anything, pgx.ReadCommitted, pgx.ReadWrite, mock.Anything).
+		Return(func(ctx context.Context, _ pgx.TxIsoLevel, _ pgx.TxAccessMode, f func(context.Context) error) error {
+			return f(ctx)
+		}).Maybe()
+}
+
+func newTestRelay(t *testing.T, publisher outbox.Publisher) (*OutboxRelay, *mockRepo.Repository, *mockLog.Logger) {
+	repoMock := mockRepo.NewRepository(t)
+	txManager := mockRepo.NewTxManager(t)
+	loggerMock := mockLog.NewLogger(t)
+	passThroughTx(txManager)
+
+	r := NewOutboxRelay(repoMock, txManager, publisher, loggerMock, OutboxRelayOptions{
+		Interval:    time.Hour,
+		BatchSize:   2,
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		MaxBackoff:  5 * time.Second,
+		Retention:   24 * time.Hour,
+	})
+	now := time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	return r, repoMock, loggerMock
+}
+
+func TestOutboxRelay_RelayBatch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("publishes and retries with backoff", func(t *testing.T) {
+		t.Parallel()
+		pub := &flakyPublisher{failing: map[string]bool{"e2": true}}
+		r, repoMock, loggerMock := newTestRelay(t, pub)
+
+		repoMock.On("FetchDueOutboxEvents", mock.Anything, 2).Return([]entity.OutboxEvent{
+			{ID: 1, EventID: "e1", Type: entity.EventReceptionOpened, CreatedAt: now.Add(-time.Second)},
+			{ID: 2, EventID: "e2", Type: entity.EventProductAdded, Attempts: 1},
+		}, nil).Once()
+		repoMock.On("MarkOutboxEventPublished", mock.Anything, int64(1)).Return(nil).Once()
+		// вторая неудачная попытка — задержка удваивается
+		next := now.Add(2 * time.Second)
+		repoMock.On("MarkOutboxEventFailed", mock.Anything, int64(2), &next, "broker unavailable").Return(nil).Once()
+		loggerMock.On("Warnw", "outbox publish failed", "error", mock.Anything, "eventID", "e2", "attempts", 2, "nextAttemptAt", next).Return().Once()
+
+		fetched, err := r.RelayBatch(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fetched != 2 {
+			t.Errorf("expected 2 fetched events, got %d", fetched)
+		}
+		if len(pub.published) != 1 || pub.published[0] != "e1" {
+			t.Errorf("unexpected published events: %v", pub.published)
+		}
+	})
+
+	t.Run("moves event to dead letter after max attempts", func(t *testing.T) {
+		t.Parallel()
+		pub := &flakyPublisher{failing: map[string]bool{"e3": true}}
+		r, repoMock, loggerMock := newTestRelay(t, pub)
+
+		repoMock.On("FetchDueOutboxEvents", mock.Anything, 2).Return([]entity.OutboxEvent{
+			{ID: 3, EventID: "e3", Type: entity.EventReceptionClosed, Attempts: 2},
+		}, nil).Once()
+		repoMock.On("MarkOutboxEventFailed", mock.Anything, int64(3), (*time.Time)(nil), "broker unavailable").Return(nil).Once()
+		loggerMock.On("Errorw", "outbox event moved to dead letter", "error", mock.Anything, "eventID", "e3",
+			"eventType", entity.EventReceptionClosed, "attempts", 3).Return().Once()
+
+		if _, err := r.RelayBatch(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("mark error rolls back the batch", func(t *testing.T) {
+		t.Parallel()
+		r, repoMock, _ := newTestRelay(t, &flakyPublisher{})
+
+		repoMock.On("FetchDueOutboxEvents", mock.Anything, 2).Return([]entity.OutboxEvent{{ID: 4, EventID: "e4"}}, nil).Once()
+		repoMock.On("MarkOutboxEventPublished", mock.Anything, int64(4)).Return(errors.New("db error")).Once()
+
+		if _, err := r.RelayBatch(context.Background()); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestOutboxRelay_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	before := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("deletes published events in batches and reports dead letter", func(t *testing.T) {
+		t.Parallel()
+		r, repoMock, loggerMock := newTestRelay(t, &flakyPublisher{})
+
+		repoMock.On("DeletePublishedOutboxEvents", mock.Anything, before, 2).Return(int64(2), nil).Once()
+		repoMock.On("DeletePublishedOutboxEvents", mock.Anything, before, 2).Return(int64(1), nil).Once()
+		repoMock.On("CountDeadOutboxEvents", mock.Anything).Return(int64(4), nil).Once()
+		loggerMock.On("Infow", "published outbox events deleted", "count", int64(3)).Return().Once()
+
+		r.Cleanup(context.Background())
+	})
+
+	t.Run("delete error still updates dead letter count", func(t *testing.T) {
+		t.Parallel()
+		r, repoMock, loggerMock := newTestRelay(t, &flakyPublisher{})
+
+		repoMock.On("DeletePublishedOutboxEvents", mock.Anything, before, 2).Return(int64(0), errors.New("db error")).Once()
+		repoMock.On("CountDeadOutboxEvents", mock.Anything).Return(int64(0), nil).Once()
+		loggerMock.On("Errorw", "outbox cleanup", "error", mock.Anything).Return().Once()
+
+		r.Cleanup(context.Background())
+	})
+
+	t.Run("count error is logged", func(t *testing.T) {
+		t.Parallel()
+		r, repoMock, loggerMock := newTestRelay(t, &flakyPublisher{})
+
+		repoMock.On("DeletePublishedOutboxEvents", mock.Anything, before, 2).Return(int64(0), nil).Once()
+		repoMock.On("CountDeadOutboxEvents", mock.Anything).Return(int64(0), errors.New("db error")).Once()
+		loggerMock.On("Errorw", "outbox dead letter count", "error", mock.Anything).Return().Once()
+
+		r.Cleanup(context.Background())
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := backoff(time.Second, 5*time.Second, i+1); got != want {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestOutboxRelay_RelayDrainsFullBatches(t *testing.T) {
+	t.Parallel()
+	pub := &flakyPublisher{}
+	r, repoMock, _ := newTestRelay(t, pub)
+
+	repoMock.On("FetchDueOutboxEvents", mock.Anything, 2).Return([]entity.OutboxEvent{{ID: 1, EventID: "e1"}, {ID: 2, EventID: "e2"}}, nil).Once()
+	repoMock.On("FetchDueOutboxEvents", mock.Anything, 2).Return([]entity.OutboxEvent{{ID: 3, EventID: "e3"}}, nil).Once()
+	repoMock.On("MarkOutboxEventPublished", mock.Anything, mock.Anything).Return(nil).Times(3)
+
+	r.Relay(context.Background())
+
+	if len(pub.published) != 3 {
+		t.Errorf("expected 3 published events, got %v", pub.published)
+	}
+}
+
+func TestOutboxRelay_StopClosesPublisher(t *testing.T) {
+	t.Parallel()
+	pub := &flakyPublisher{}
+	r, repoMock, _ := newTestRelay(t, pub)
+
+	fetched := make(chan struct{})
+	repoMock.On("FetchDueOutboxEvents", mock.Anything, 2).
+		Run(func(mock.Arguments) { close(fetched) }).
+		Return(nil, nil).Once()
+	repoMock.On("DeletePublishedOutboxEvents", mock.Anything, mock.Anything, 2).Return(int64(0), nil).Maybe()
+	repoMock.On("CountDeadOutboxEvents", mock.Anything).Return(int64(0), nil).Maybe()
+
+	r.Start(context.Background())
+	<-fetched
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.Stop(ctx); err != nil {
+		t.Fatalf("unexpected error on stop: %v", err)
+	}
+	if !pub.closed {
+		t.Error("expected publisher to be closed")
+	}
+}
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/pkg/logger"
+	"time"
+)
+
+// TokenCleanupWorker периодически удаляет истёкшие refresh-токены и записи об отозванных
+// access-токенах, срок которых вышел, чтобы список отзыва не рос бесконечно. Заодно удаляются
+// счётчики неудачных входов без неудач дольше attemptRetention и без действующей блокировки.
+type TokenCleanupWorker struct {
+	repo             db.TokenRepository
+	attempts         db.LoginAttemptRepository
+	logger           logger.Logger
+	interval         time.Duration
+	attemptRetention time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewTokenCleanupWorker(
+	repo db.TokenRepository,
+	attempts db.LoginAttemptRepository,
+	log logger.Logger,
+	interval time.Duration,
+	attemptRetention time.Duration,
+) *TokenCleanupWorker {
+	return &TokenCleanupWorker{
+		repo:             repo,
+		attempts:         attempts,
+		logger:           log,
+		interval:         interval,
+		attemptRetention: attemptRetention,
+	}
+}
+
+// Start запускает воркер в отдельной горутине. Первый проход выполняется сразу.
+func (w *TokenCleanupWorker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.Cleanup(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop останавливает воркер и дожидается завершения текущего прохода.
+func (w *TokenCleanupWorker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *TokenCleanupWorker) Cleanup(ctx context.Context) {
+	deleted, err := w.repo.DeleteExpiredTokens(ctx)
+	if err != nil {
+		w.logger.Errorw("token cleanup",
+			"error", err,
+		)
+	} else if deleted > 0 {
+		w.logger.Infow("expired tokens deleted",
+			"count", deleted,
+		)
+	}
+
+	deleted, err = w.attempts.DeleteStaleLoginAttempts(ctx, time.Now().Add(-w.attemptRetention))
+	if err != nil {
+		w.logger.Errorw("login attempts cleanup",
+			"error", err,
+		)
+		return
+	}
+	if deleted > 0 {
+		w.logger.Infow("stale login attempts deleted",
+			"count", deleted,
+		)
+	}
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"testing"
+	"time"
+)
+
+func TestTokenCleanupWorker_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logs deleted tokens", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		w := NewTokenCleanupWorker(repoMock, repoMock, loggerMock, time.Minute, time.Hour)
+
+		repoMock.On("DeleteExpiredTokens", mock.Anything).Return(int64(3), nil).Once()
+		repoMock.On("DeleteStaleLoginAttempts", mock.Anything, mock.Anything).Return(int64(2), nil).Once()
+		loggerMock.On("Infow", "expired tokens deleted", "count", int64(3)).Return().Once()
+		loggerMock.On("Infow", "stale login attempts deleted", "count", int64(2)).Return().Once()
+
+		w.Cleanup(context.Background())
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		w := NewTokenCleanupWorker(repoMock, repoMock, mockLog.NewLogger(t), time.Minute, time.Hour)
+
+		repoMock.On("DeleteExpiredTokens", mock.Anything).Return(int64(0), nil).Once()
+		repoMock.On("DeleteStaleLoginAttempts", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+
+		w.Cleanup(context.Background())
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		loggerMock := mockLog.NewLogger(t)
+		w := NewTokenCleanupWorker(repoMock, repoMock, loggerMock, time.Minute, time.Hour)
+
+		repoMock.On("DeleteExpiredTokens", mock.Anything).Return(int64(0), errors.New("db down")).Once()
+		repoMock.On("DeleteStaleLoginAttempts", mock.Anything, mock.Anything).Return(int64(0), errors.New("db down")).Once()
+		loggerMock.On("Errorw", "token cleanup", "error", mock.Anything).Return().Once()
+		loggerMock.On("Errorw", "login attempts cleanup", "error", mock.Anything).Return().Once()
+
+		w.Cleanup(context.Background())
+	})
+
+	t.Run("removes attempts older than retention", func(t *testing.T) {
+		t.Parallel()
+		repoMock := mockRepo.NewRepository(t)
+		w := NewTokenCleanupWorker(repoMock, repoMock, mockLog.NewLogger(t), time.Minute, time.Hour)
+
+		repoMock.On("DeleteExpiredTokens", mock.Anything).Return(int64(0), nil).Once()
+		repoMock.
+			On("DeleteStaleLoginAttempts", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+				return time.Since(before) >= time.Hour && time.Since(before) < time.Hour+time.Minute
+			})).
+			Return(int64(0), nil).
+			Once()
+
+		w.Cleanup(context.Background())
+	})
+}
@@ -0,0 +1,207 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"order-pick-up-point/internal/metrics"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/outbox"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/pkg/logger"
+	"order-pick-up-point/pkg/webhook"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxWebhookErrorBody — сколько байт ответа получателя сохраняется в истории при ошибке
+const maxWebhookErrorBody = 512
+
+// WebhookWorkerOptions — параметры доставки и повторов. Timeout ограничивает один HTTP-запрос.
+// AllowPrivateNetworks разрешает доставку на внутренние адреса (только для разработки и тестов).
+type WebhookWorkerOptions struct {
+	Interval             time.Duration
+	BatchSize            int
+	MaxAttempts          int
+	BaseBackoff          time.Duration
+	MaxBackoff           time.Duration
+	Timeout              time.Duration
+	AllowPrivateNetworks bool
+}
+
+// WebhookWorker доставляет вебхуки подписчикам: POST с телом события, подписанным HMAC-SHA256.
+// Доставка считается успешной при ответе 2xx, иначе повторяется с экспоненциальной задержкой,
+// а после MaxAttempts попыток переходит в failed.
+type WebhookWorker struct {
+	repo   db.WebhookRepository
+	client *http.Client
+	logger logger.Logger
+	opts   WebhookWorkerOptions
+
+	now    func() time.Time
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewWebhookWorker(repo db.WebhookRepository, log logger.Logger, opts WebhookWorkerOptions) *WebhookWorker {
+	return &WebhookWorker{
+		repo:   repo,
+		client: webhook.NewClient(opts.Timeout, opts.AllowPrivateNetworks),
+		logger: log,
+		opts:   opts,
+		now:    time.Now,
+	}
+}
+
+// Start запускает воркер в отдельной горутине. Первый проход выполняется сразу.
+func (w *WebhookWorker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.opts.Interval)
+		defer ticker.Stop()
+
+		for {
+			w.Deliver(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop останавливает воркер и дожидается завершения текущих доставок.
+func (w *WebhookWorker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Deliver обрабатывает пачки доставок, пока не останется доставок, срок попытки которых наступил.
+func (w *WebhookWorker) Deliver(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := w.DeliverBatch(ctx)
+		if err != nil {
+			w.logger.Errorw("webhook delivery",
+				"error", err,
+			)
+			return
+		}
+		if claimed < w.opts.BatchSize {
+			return
+		}
+	}
+}
+
+// DeliverBatch берёт в работу пачку доставок и отправляет их параллельно.
+// Lease с запасом перекрывает таймаут запроса, чтобы доставку не взял другой экземпляр.
+func (w *WebhookWorker) DeliverBatch(ctx context.Context) (int, error) {
+	tasks, err := w.repo.ClaimDueWebhookDeliveries(ctx, w.opts.BatchSize, 2*w.opts.Timeout+time.Minute)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task entity.WebhookDeliveryTask) {
+			defer wg.Done()
+			w.deliver(ctx, task)
+		}(task)
+	}
+	wg.Wait()
+	return len(tasks), nil
+}
+
+func (w *WebhookWorker) deliver(ctx context.Context, task entity.WebhookDeliveryTask) {
+	started := w.now()
+	statusCode, sendErr := w.send(ctx, task)
+	attempt := entity.WebhookDeliveryAttempt{
+		DeliveryID:  task.ID,
+		AttemptedAt: started,
+		Duration:    w.now().Sub(started),
+		StatusCode:  statusCode,
+	}
+
+	status, result := entity.WebhookDeliveryDelivered, metrics.WebhookResultDelivered
+	var next *time.Time
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+		attempts := task.Attempts + 1
+		if attempts >= w.opts.MaxAttempts {
+			status, result = entity.WebhookDeliveryFailed, metrics.WebhookResultFailed
+			w.logger.Errorw("webhook delivery failed",
+				"error", sendErr,
+				"deliveryID", task.ID,
+				"webhookID", task.SubscriptionID,
+				"attempts", attempts,
+			)
+		} else {
+			status, result = entity.WebhookDeliveryPending, metrics.WebhookResultRetry
+			at := w.now().Add(backoff(w.opts.BaseBackoff, w.opts.MaxBackoff, attempts))
+			next = &at
+		}
+	}
+	metrics.WebhookDelivery(task.EventType, result, attempt.Duration.Seconds())
+
+	// Результат записывается и после остановки воркера, иначе успешная доставка повторится
+	if err := w.repo.CompleteWebhookAttempt(context.WithoutCancel(ctx), attempt, status, next); err != nil {
+		w.logger.Errorw("recording webhook attempt",
+			"error", err,
+			"deliveryID", task.ID,
+		)
+	}
+}
+
+// send отправляет событие и возвращает код ответа (nil, если ответа нет) и ошибку доставки.
+func (w *WebhookWorker) send(ctx context.Context, task entity.WebhookDeliveryTask) (*int, error) {
+	body, err := json.Marshal(outbox.MessageFromEvent(task.Event()))
+	if err != nil {
+		return nil, fmt.Errorf("encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, task.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	timestamp := w.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "order-pick-up-point-webhooks/1.0")
+	req.Header.Set(webhook.HeaderEventID, task.EventID)
+	req.Header.Set(webhook.HeaderDelivery, strconv.FormatInt(task.ID, 10))
+	req.Header.Set(webhook.HeaderEvent, task.EventType)
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(task.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	code := resp.StatusCode
+	if code >= 200 && code < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookErrorBody))
+		return &code, nil
+	}
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorBody))
+	return &code, fmt.Errorf("unexpected status %d: %s", code, bytes.TrimSpace(snippet))
+}
@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/outbox"
+	mockRepo "order-pick-up-point/internal/storage/db/mock"
+	mockLog "order-pick-up-point/pkg/logger/mock"
+	"order-pick-up-point/pkg/webhook"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestWebhookWorker(t *testing.T) (*WebhookWorker, *mockRepo.Repository, *mockLog.Logger) {
+	repoMock := mockRepo.NewRepository(t)
+	loggerMock := mockLog.NewLogger(t)
+	w := NewWebhookWorker(repoMock, loggerMock, WebhookWorkerOptions{
+		Interval:    time.Hour,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+		Timeout:     time.Second,
+		// httptest-сервер слушает loopback
+		AllowPrivateNetworks: true,
+	})
+	return w, repoMock, loggerMock
+}
+
+func webhookTask(id int64, url string, attempts int) entity.WebhookDeliveryTask {
+	return entity.WebhookDeliveryTask{
+		WebhookDelivery: entity.WebhookDelivery{
+			ID:             id,
+			SubscriptionID: "sub1",
+			EventID:        "e" + strconv.FormatInt(id, 10),
+			EventType:      entity.EventOrderIssued,
+			AggregateID:    "prod1",
+			PvzID:          "pvz1",
+			Payload:        json.RawMessage(`{"product_id":"prod1"}`),
+			Attempts:       attempts,
+		},
+		URL:    url,
+		Secret: "partner-secret-123",
+	}
+}
+
+func TestWebhookWorker_DeliverBatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("signed request is delivered", func(t *testing.T) {
+		t.Parallel()
+
+		received := make(chan outbox.Message, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			err := webhook.Verify("partner-secret-123", r.Header.Get(webhook.HeaderSignature),
+				r.Header.Get(webhook.HeaderTimestamp), body, time.Now(), time.Minute)
+			if err != nil || r.Header.Get(webhook.HeaderEvent) != entity.EventOrderIssued ||
+				r.Header.Get(webhook.HeaderEventID) != "e1" || r.Header.Get(webhook.HeaderDelivery) != "1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var msg outbox.Message
+			_ = json.Unmarshal(body, &msg)
+			received <- msg
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		w, repoMock, _ := newTestWebhookWorker(t)
+		repoMock.On("ClaimDueWebhookDeliveries", mock.Anything, 10, mock.Anything).
+			Return([]entity.WebhookDeliveryTask{webhookTask(1, srv.URL, 0)}, nil).Once()
+		repoMock.On("CompleteWebhookAttempt", mock.Anything, mock.MatchedBy(func(a entity.WebhookDeliveryAttempt) bool {
+			return a.DeliveryID == 1 && a.StatusCode != nil && *a.StatusCode == http.StatusNoContent && a.Error == ""
+		}), entity.WebhookDeliveryDelivered, (*time.Time)(nil)).Return(nil).Once()
+
+		claimed, err := w.DeliverBatch(context.Background())
+		if err != nil || claimed != 1 {
+			t.Fatalf("unexpected result: %d, %v", claimed, err)
+		}
+		msg := <-received
+		if msg.ID != "e1" || msg.Type != entity.EventOrderIssued || string(msg.Payload) != `{"product_id":"prod1"}` {
+			t.Errorf("unexpected message: %+v", msg)
+		}
+	})
+
+	t.Run("error response is retried with backoff", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		w, repoMock, _ := newTestWebhookWorker(t)
+		now := time.Date(2025, 5, 3, 10, 0, 0, 0, time.UTC)
+		w.now = func() time.Time { return now }
+		next := now.Add(2 * time.Second)
+
+		repoMock.On("ClaimDueWebhookDeliveries", mock.Anything, 10, mock.Anything).
+			Return([]entity.WebhookDeliveryTask{webhookTask(2, srv.URL, 1)}, nil).Once()
+		repoMock.On("CompleteWebhookAttempt", mock.Anything, mock.MatchedBy(func(a entity.WebhookDeliveryAttempt) bool {
+			return a.StatusCode != nil && *a.StatusCode == http.StatusServiceUnavailable &&
+				a.Error == "unexpected status 503: maintenance"
+		}), entity.WebhookDeliveryPending, &next).Return(nil).Once()
+
+		if _, err := w.DeliverBatch(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unreachable receiver fails after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.NotFoundHandler())
+		url := srv.URL
+		srv.Close()
+
+		w, repoMock, loggerMock := newTestWebhookWorker(t)
+		repoMock.On("ClaimDueWebhookDeliveries", mock.Anything, 10, mock.Anything).
+			Return([]entity.WebhookDeliveryTask{webhookTask(3, url, 2)}, nil).Once()
+		repoMock.On("CompleteWebhookAttempt", mock.Anything, mock.MatchedBy(func(a entity.WebhookDeliveryAttempt) bool {
+			return a.StatusCode == nil && a.Error != ""
+		}), entity.WebhookDeliveryFailed, (*time.Time)(nil)).Return(nil).Once()
+		loggerMock.On("Errorw", "webhook delivery failed", "error", mock.Anything, "deliveryID", int64(3),
+			"webhookID", "sub1", "attempts", 3).Return().Once()
+
+		if _, err := w.DeliverBatch(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		t.Parallel()
+
+		w, repoMock, loggerMock := newTestWebhookWorker(t)
+		repoMock.On("ClaimDueWebhookDeliveries", mock.Anything, 10, mock.Anything).
+			Return(nil, errors.New("db down")).Once()
+		loggerMock.On("Errorw", "webhook delivery", "error", mock.Anything).Return().Once()
+
+		w.Deliver(context.Background())
+	})
+}
@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE product
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'received'
+        CHECK (status IN ('received', 'ready_for_pickup', 'issued', 'returned')),
+    ADD COLUMN recipient_id UUID REFERENCES users(id),
+    ADD COLUMN pickup_code VARCHAR(16),
+    ADD COLUMN issued_at TIMESTAMPTZ,
+    ADD COLUMN issued_by UUID;
+
+CREATE INDEX idx_product_recipient_status ON product (recipient_id, status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_product_recipient_status;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS issued_by,
+    DROP COLUMN IF EXISTS issued_at,
+    DROP COLUMN IF EXISTS pickup_code,
+    DROP COLUMN IF EXISTS recipient_id,
+    DROP COLUMN IF EXISTS status;
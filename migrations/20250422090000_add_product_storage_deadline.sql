@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE product
+    ADD COLUMN storage_deadline TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE product
+    DROP COLUMN IF EXISTS storage_deadline;
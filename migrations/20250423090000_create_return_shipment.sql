@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE return_shipment (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    date_time TIMESTAMPTZ NOT NULL,
+    pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('in_progress', 'close'))
+);
+
+-- В каждом ПВЗ может быть только одна незакрытая отгрузка возвратов
+CREATE UNIQUE INDEX idx_return_shipment_open_per_pvz ON return_shipment (pvz_id) WHERE status = 'in_progress';
+
+CREATE TABLE return_item (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    date_time TIMESTAMPTZ NOT NULL,
+    return_id UUID NOT NULL REFERENCES return_shipment(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL UNIQUE REFERENCES product(id) ON DELETE CASCADE,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('refused', 'expired', 'damaged')),
+    previous_status VARCHAR(20) NOT NULL
+);
+
+CREATE INDEX idx_return_item_return_id ON return_item (return_id, date_time);
+
+ALTER TABLE product DROP CONSTRAINT product_status_check;
+ALTER TABLE product ADD CONSTRAINT product_status_check
+    CHECK (status IN ('received', 'ready_for_pickup', 'issued', 'returned', 'in_return', 'shipped_back'));
+
+-- +goose Down
+ALTER TABLE product DROP CONSTRAINT product_status_check;
+ALTER TABLE product ADD CONSTRAINT product_status_check
+    CHECK (status IN ('received', 'ready_for_pickup', 'issued', 'returned'));
+
+DROP TABLE IF EXISTS return_item;
+DROP TABLE IF EXISTS return_shipment;
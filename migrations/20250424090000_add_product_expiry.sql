@@ -0,0 +1,18 @@
+-- +goose Up
+-- Срок хранения теперь считается для каждого товара при приёмке по его типу
+ALTER TABLE product RENAME COLUMN storage_deadline TO expires_at;
+
+ALTER TABLE product
+    ADD COLUMN expired_at TIMESTAMPTZ;
+
+CREATE INDEX idx_product_expires_at ON product (expires_at) WHERE expired_at IS NULL;
+CREATE INDEX idx_product_expired_at ON product (expired_at) WHERE expired_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_product_expired_at;
+DROP INDEX IF EXISTS idx_product_expires_at;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS expired_at;
+
+ALTER TABLE product RENAME COLUMN expires_at TO storage_deadline;
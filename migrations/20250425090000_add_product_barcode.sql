@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE product
+    ADD COLUMN barcode VARCHAR(64);
+
+-- Штрихкод уникален среди товаров, которые физически находятся в ПВЗ.
+-- После выдачи или отгрузки продавцу тот же штрихкод может прийти повторно.
+CREATE UNIQUE INDEX idx_product_barcode_active ON product (barcode)
+    WHERE barcode IS NOT NULL AND status NOT IN ('issued', 'shipped_back');
+
+CREATE INDEX idx_product_barcode ON product (barcode) WHERE barcode IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_product_barcode;
+DROP INDEX IF EXISTS idx_product_barcode_active;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS barcode;
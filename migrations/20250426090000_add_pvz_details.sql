@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE pvz
+    ADD COLUMN address       TEXT             NOT NULL DEFAULT '',
+    ADD COLUMN latitude      DOUBLE PRECISION,
+    ADD COLUMN longitude     DOUBLE PRECISION,
+    ADD COLUMN phone         VARCHAR(32)      NOT NULL DEFAULT '',
+    ADD COLUMN status        VARCHAR(16)      NOT NULL DEFAULT 'active',
+    -- Часы работы по дням недели: [{"weekday": "mon", "opens": "09:00", "closes": "21:00"}, ...]
+    ADD COLUMN opening_hours JSONB            NOT NULL DEFAULT '[]';
+
+ALTER TABLE pvz
+    ADD CONSTRAINT pvz_status_check CHECK (status IN ('active', 'suspended', 'closed')),
+    ADD CONSTRAINT pvz_latitude_check CHECK (latitude BETWEEN -90 AND 90),
+    ADD CONSTRAINT pvz_longitude_check CHECK (longitude BETWEEN -180 AND 180),
+    -- Координаты задаются только парой
+    ADD CONSTRAINT pvz_coordinates_check CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+-- +goose Down
+ALTER TABLE pvz
+    DROP CONSTRAINT IF EXISTS pvz_coordinates_check,
+    DROP CONSTRAINT IF EXISTS pvz_longitude_check,
+    DROP CONSTRAINT IF EXISTS pvz_latitude_check,
+    DROP CONSTRAINT IF EXISTS pvz_status_check;
+
+ALTER TABLE pvz
+    DROP COLUMN IF EXISTS opening_hours,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS phone,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS address;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Запасной вариант поиска: bounding box по обычному B-tree индексу
+CREATE INDEX idx_pvz_coordinates ON pvz (latitude, longitude) WHERE latitude IS NOT NULL;
+
+-- Основной вариант: GiST-индекс earthdistance. Если расширения недоступны,
+-- миграция не падает, а поиск работает через bounding box и формулу гаверсинуса.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS cube;
+    CREATE EXTENSION IF NOT EXISTS earthdistance;
+    CREATE INDEX idx_pvz_earth ON pvz USING gist (ll_to_earth(latitude, longitude)) WHERE latitude IS NOT NULL;
+EXCEPTION WHEN OTHERS THEN
+    RAISE NOTICE 'earthdistance is not available, nearby PVZ search falls back to plain SQL: %', SQLERRM;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_pvz_earth;
+DROP INDEX IF EXISTS idx_pvz_coordinates;
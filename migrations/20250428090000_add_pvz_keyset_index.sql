@@ -0,0 +1,6 @@
+-- +goose Up
+-- Ключ keyset-пагинации списков ПВЗ: (registration_date, id) по убыванию
+CREATE INDEX idx_pvz_registration_date_id ON pvz (registration_date DESC, id DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_pvz_registration_date_id;
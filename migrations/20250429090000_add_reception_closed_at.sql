@@ -0,0 +1,11 @@
+-- +goose Up
+-- Время закрытия приёмки: нужно для средней длительности приёмки в аналитике.
+-- У приёмок, закрытых до миграции, оно неизвестно и остаётся NULL.
+ALTER TABLE reception ADD COLUMN closed_at TIMESTAMPTZ;
+
+-- Аналитика фильтрует приёмки по ПВЗ и периоду
+CREATE INDEX idx_reception_pvz_date_time ON reception (pvz_id, date_time);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reception_pvz_date_time;
+ALTER TABLE reception DROP COLUMN IF EXISTS closed_at;
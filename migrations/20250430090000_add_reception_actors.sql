@@ -0,0 +1,11 @@
+-- +goose Up
+-- Сотрудники, открывшие и закрывшие приёмку. NULL — приёмки пользователей /dummyLogin
+-- и приёмки, созданные до миграции.
+ALTER TABLE reception
+    ADD COLUMN opened_by UUID,
+    ADD COLUMN closed_by UUID;
+
+-- +goose Down
+ALTER TABLE reception
+    DROP COLUMN IF EXISTS closed_by,
+    DROP COLUMN IF EXISTS opened_by;
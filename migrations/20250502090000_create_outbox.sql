@@ -0,0 +1,24 @@
+-- +goose Up
+-- Transactional outbox: доменные события пишутся в той же транзакции, что и изменение,
+-- а relay-воркер публикует их во внешний брокер.
+CREATE TABLE outbox_event (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    event_id UUID NOT NULL DEFAULT uuid_generate_v4() UNIQUE,
+    event_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    pvz_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'published', 'dead')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT NOT NULL DEFAULT '',
+    published_at TIMESTAMPTZ
+);
+
+-- Relay выбирает только ожидающие события, поэтому индекс частичный
+CREATE INDEX idx_outbox_event_pending ON outbox_event (next_attempt_at, id) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_event;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- Живая лента активности ПВЗ. События приёмок и товаров рассылаются через NOTIFY
+-- всем экземплярам приложения; уведомление уходит только при фиксации транзакции.
+-- Пропущенные после переподключения события клиент дочитывает из outbox_event по id.
+CREATE INDEX idx_outbox_event_pvz ON outbox_event (pvz_id, id);
+
+-- +goose StatementBegin
+CREATE FUNCTION notify_pvz_activity() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('pvz_activity', json_build_object(
+        'id', NEW.id,
+        'event_id', NEW.event_id,
+        'event_type', NEW.event_type,
+        'aggregate_id', NEW.aggregate_id,
+        'pvz_id', NEW.pvz_id,
+        'payload', NEW.payload,
+        'created_at', NEW.created_at
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_outbox_event_pvz_activity
+    AFTER INSERT ON outbox_event
+    FOR EACH ROW
+    WHEN (NEW.event_type IN ('ReceptionOpened', 'ReceptionClosed', 'ProductAdded', 'ProductRemoved'))
+    EXECUTE FUNCTION notify_pvz_activity();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_outbox_event_pvz_activity ON outbox_event;
+DROP FUNCTION IF EXISTS notify_pvz_activity();
+DROP INDEX IF EXISTS idx_outbox_event_pvz;
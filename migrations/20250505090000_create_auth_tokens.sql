@@ -0,0 +1,32 @@
+-- +goose Up
+-- Refresh-токены. Хранится только хеш токена; токены одной сессии связаны family_id,
+-- повторное использование уже обменянного токена отзывает всё семейство.
+CREATE TABLE refresh_token (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    access_jti TEXT NOT NULL,
+    access_expires_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_refresh_token_family ON refresh_token (family_id);
+CREATE INDEX idx_refresh_token_access_jti ON refresh_token (access_jti);
+CREATE INDEX idx_refresh_token_expires ON refresh_token (expires_at);
+
+-- Отозванные access-токены. Запись нужна только до истечения срока токена.
+CREATE TABLE revoked_token (
+    jti TEXT PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_revoked_token_expires ON revoked_token (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS revoked_token;
+DROP TABLE IF EXISTS refresh_token;
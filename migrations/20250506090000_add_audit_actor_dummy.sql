@@ -0,0 +1,6 @@
+-- +goose Up
+-- Отмечает записи, сделанные по токену /dummyLogin: за таким автором нет реального пользователя.
+ALTER TABLE audit_log ADD COLUMN actor_dummy BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE audit_log DROP COLUMN IF EXISTS actor_dummy;
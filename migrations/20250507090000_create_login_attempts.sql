@@ -0,0 +1,17 @@
+-- +goose Up
+-- Неудачные попытки входа подряд по email и по IP. Счётчик общий для всех реплик сервиса;
+-- строка заводится и для email, которого нет в users, чтобы блокировка не выдавала,
+-- зарегистрирован ли адрес.
+CREATE TABLE login_attempt (
+    scope VARCHAR(10) NOT NULL CHECK (scope IN ('email', 'ip')),
+    key TEXT NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until TIMESTAMPTZ,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idx_login_attempt_last_failure ON login_attempt (last_failure_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS login_attempt;
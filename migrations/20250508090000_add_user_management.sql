@@ -0,0 +1,18 @@
+-- +goose Up
+-- active = false — учётная запись отключена модератором: вход и обновление токенов запрещены.
+-- password_change_required — пароль сброшен модератором на временный, пользователь должен его сменить.
+ALTER TABLE users
+    ADD COLUMN active BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN password_change_required BOOLEAN NOT NULL DEFAULT false;
+
+-- Ключ keyset-пагинации списка пользователей: (created_at, id) по убыванию
+CREATE INDEX idx_users_created_at_id ON users (created_at DESC, id DESC);
+-- Отзыв всех сессий пользователя
+CREATE INDEX idx_refresh_token_user ON refresh_token (user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_refresh_token_user;
+DROP INDEX IF EXISTS idx_users_created_at_id;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS password_change_required,
+    DROP COLUMN IF EXISTS active;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- id из identity выдаётся при вставке, а видимой строка становится при коммите, поэтому
+-- событие с меньшим id может зафиксироваться позже события с большим. txid — транзакция,
+-- записавшая событие, snapshot_xmin — самая старая транзакция, ещё выполнявшаяся в момент
+-- вставки. Событие пишется после доменного изменения, так что у транзакции уже есть txid.
+-- При продолжении ленты после события L заново читаются события с id < L из транзакций
+-- с txid >= snapshot_xmin(L): только они могли зафиксироваться после L.
+ALTER TABLE outbox_event
+    ADD COLUMN txid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+    ADD COLUMN snapshot_xmin xid8 NOT NULL DEFAULT pg_snapshot_xmin(pg_current_snapshot());
+
+CREATE INDEX idx_outbox_event_pvz_txid ON outbox_event (pvz_id, txid);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_outbox_event_pvz_txid;
+ALTER TABLE outbox_event
+    DROP COLUMN IF EXISTS snapshot_xmin,
+    DROP COLUMN IF EXISTS txid;
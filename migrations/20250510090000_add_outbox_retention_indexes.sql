@@ -0,0 +1,9 @@
+-- +goose Up
+-- Relay удаляет опубликованные события старше outbox.retention_days и считает события
+-- в dead letter для метрики; частичные индексы не дают этим запросам сканировать всю таблицу.
+CREATE INDEX idx_outbox_event_published ON outbox_event (published_at) WHERE status = 'published';
+CREATE INDEX idx_outbox_event_dead ON outbox_event (id) WHERE status = 'dead';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_outbox_event_dead;
+DROP INDEX IF EXISTS idx_outbox_event_published;
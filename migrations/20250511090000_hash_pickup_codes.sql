@@ -0,0 +1,22 @@
+-- +goose Up
+-- Код выдачи хранится только в виде bcrypt-хеша, pickup_code_failures считает неверные коды
+-- при выдаче. crypt() из pgcrypto с gen_salt('bf') даёт хеш в формате bcrypt, который проверяет
+-- golang.org/x/crypto/bcrypt, поэтому коды заказов, ожидающих выдачи, продолжают действовать.
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+ALTER TABLE product
+    ADD COLUMN pickup_code_hash VARCHAR(60),
+    ADD COLUMN pickup_code_failures INT NOT NULL DEFAULT 0;
+
+UPDATE product
+SET pickup_code_hash = crypt(pickup_code, gen_salt('bf'))
+WHERE pickup_code IS NOT NULL AND status = 'ready_for_pickup';
+
+ALTER TABLE product DROP COLUMN pickup_code;
+
+-- +goose Down
+-- Коды из хешей не восстановить: после отката клиентам нужно выпустить коды заново
+ALTER TABLE product
+    ADD COLUMN pickup_code VARCHAR(16),
+    DROP COLUMN IF EXISTS pickup_code_failures,
+    DROP COLUMN IF EXISTS pickup_code_hash;
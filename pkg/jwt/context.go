@@ -0,0 +1,16 @@
+package jwt
+
+import "context"
+
+type claimsKey struct{}
+
+// ContextWithClaims возвращает контекст, содержащий claims аутентифицированного пользователя.
+func ContextWithClaims(ctx context.Context, claims *CustomClaims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext извлекает claims, положенные в контекст через ContextWithClaims.
+func ClaimsFromContext(ctx context.Context) (*CustomClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*CustomClaims)
+	return claims, ok && claims != nil
+}
@@ -3,30 +3,76 @@ package jwt
 import (
 	"fmt"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"time"
 )
 
+// DummyUserID — ID пользователя в токенах /dummyLogin, за ним нет записи в users.
+const DummyUserID = "dummyID"
+
 type TokenService interface {
-	GenerateToken(userID string, role string) (string, error)
+	GenerateToken(userID string, role string) (*Token, error)
+	GenerateDummyToken(role string) (*Token, error)
 	ParseJWTToken(tokenString string) (*CustomClaims, error)
+	JWKS() JWKS
 }
 
+// CustomClaims — Dummy отмечает токены /dummyLogin: они выдаются без проверки учётных данных.
 type CustomClaims struct {
 	UserID string `json:"user_id"`
 	Role   string `json:"role"`
+	Dummy  bool   `json:"dummy,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Token — подписанный access-токен. ID (claim jti) нужен, чтобы отозвать токен до истечения срока.
+type Token struct {
+	Value     string
+	ID        string
+	ExpiresAt time.Time
+}
+
 type TokenServiceImpl struct {
-	secretKey           string
+	keys                *KeySet
+	issuer              string
+	audience            string
 	tokenExpirationTime int
+	allowDummy          bool
+	parser              *jwt.Parser
+}
+
+// NewTokenService создаёт сервис, который подписывает токены активным ключом набора и принимает
+// только токены своего издателя (iss) и своей аудитории (aud), подписанные алгоритмами набора.
+// Без allowDummy токены /dummyLogin не выдаются и не принимаются.
+func NewTokenService(keys *KeySet, issuer, audience string, tokenExpTime int, allowDummy bool) TokenService {
+	return &TokenServiceImpl{
+		keys:                keys,
+		issuer:              issuer,
+		audience:            audience,
+		tokenExpirationTime: tokenExpTime,
+		allowDummy:          allowDummy,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(keys.Algorithms()),
+			jwt.WithExpirationRequired(),
+			jwt.WithIssuedAt(),
+			jwt.WithIssuer(issuer),
+			jwt.WithAudience(audience),
+		),
+	}
 }
 
-func NewTokenService(secretKey string, tokenExpTime int) TokenService {
-	return &TokenServiceImpl{secretKey: secretKey, tokenExpirationTime: tokenExpTime}
+func (t *TokenServiceImpl) GenerateToken(userID string, role string) (*Token, error) {
+	return t.generate(userID, role, false)
 }
 
-func (t *TokenServiceImpl) GenerateToken(userID string, role string) (string, error) {
+func (t *TokenServiceImpl) GenerateDummyToken(role string) (*Token, error) {
+	if !t.allowDummy {
+		return nil, fmt.Errorf("dummy tokens are disabled")
+	}
+	return t.generate(DummyUserID, role, true)
+}
+
+func (t *TokenServiceImpl) generate(userID, role string, dummy bool) (*Token, error) {
 	now := time.Now()
 
 	expiration := now.Add(time.Duration(t.tokenExpirationTime) * time.Second)
@@ -34,21 +80,31 @@ func (t *TokenServiceImpl) GenerateToken(userID string, role string) (string, er
 	claims := CustomClaims{
 		UserID: userID,
 		Role:   role,
+		Dummy:  dummy,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    t.issuer,
+			Subject:   userID,
+			Audience:  jwt.ClaimStrings{t.audience},
 			ExpiresAt: jwt.NewNumericDate(expiration),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	active := t.keys.active
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(active.Algorithm), claims)
+	token.Header["kid"] = active.ID
 
-	return token.SignedString([]byte(t.secretKey))
+	signed, err := token.SignedString(active.private)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Token{Value: signed, ID: claims.ID, ExpiresAt: claims.ExpiresAt.Time}, nil
 }
 
 func (t *TokenServiceImpl) ParseJWTToken(tokenString string) (*CustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(t.secretKey), nil
-	})
+	token, err := t.parser.ParseWithClaims(tokenString, &CustomClaims{}, t.verificationKey)
 	if err != nil {
 		return nil, err
 	}
@@ -58,5 +114,34 @@ func (t *TokenServiceImpl) ParseJWTToken(tokenString string) (*CustomClaims, err
 		return nil, fmt.Errorf("invalid token")
 	}
 
+	// Токен без jti нельзя отозвать, поэтому он не принимается
+	if claims.ID == "" {
+		return nil, fmt.Errorf("token has no id")
+	}
+	if claims.Subject == "" || claims.Subject != claims.UserID {
+		return nil, fmt.Errorf("token subject mismatch")
+	}
+	if claims.Dummy && !t.allowDummy {
+		return nil, fmt.Errorf("dummy tokens are disabled")
+	}
+
 	return claims, nil
 }
+
+func (t *TokenServiceImpl) JWKS() JWKS {
+	return t.keys.JWKS()
+}
+
+// verificationKey выбирает ключ по kid из заголовка. Алгоритм токена должен совпадать с алгоритмом
+// ключа, иначе открытый RSA-ключ можно было бы подсунуть как HMAC-секрет.
+func (t *TokenServiceImpl) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := t.keys.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+	return key.public, nil
+}
@@ -0,0 +1,364 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "order-pick-up-point"
+	testAudience = "order-pick-up-point"
+)
+
+func newRSAKey(t *testing.T, id string) *Key {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := NewKey(id, private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newECKey(t *testing.T, id string) *Key {
+	t.Helper()
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := NewKey(id, private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newService(t *testing.T, activeID string, keys ...*Key) TokenService {
+	t.Helper()
+	set, err := NewKeySet(activeID, keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTokenService(set, testIssuer, testAudience, 60, false)
+}
+
+// publicOnly оставляет у ключа только открытую часть, как у ключа, выводимого из ротации.
+func publicOnly(key *Key) *Key {
+	return &Key{ID: key.ID, Algorithm: key.Algorithm, public: key.public}
+}
+
+func sign(t *testing.T, key *Key, claims CustomClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func validClaims() CustomClaims {
+	now := time.Now()
+	return CustomClaims{
+		UserID: "user1",
+		Role:   "client",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti1",
+			Issuer:    testIssuer,
+			Subject:   "user1",
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+	}
+}
+
+func TestTokenService_GenerateAndParse(t *testing.T) {
+	t.Parallel()
+
+	hmacKey, err := NewHMACKey("hs", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []*Key{newRSAKey(t, "rsa"), newECKey(t, "ec"), hmacKey} {
+		svc := newService(t, key.ID, key)
+
+		token, err := svc.GenerateToken("user1", "moderator")
+		if err != nil {
+			t.Fatalf("%s: generate: %v", key.Algorithm, err)
+		}
+
+		claims, err := svc.ParseJWTToken(token.Value)
+		if err != nil {
+			t.Fatalf("%s: parse: %v", key.Algorithm, err)
+		}
+		if claims.UserID != "user1" || claims.Subject != "user1" || claims.Role != "moderator" {
+			t.Errorf("%s: unexpected claims %+v", key.Algorithm, claims)
+		}
+		if claims.ID != token.ID || claims.Issuer != testIssuer || len(claims.Audience) != 1 || claims.Audience[0] != testAudience {
+			t.Errorf("%s: unexpected registered claims %+v", key.Algorithm, claims.RegisteredClaims)
+		}
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(token.Value, &CustomClaims{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.Header["kid"] != key.ID || parsed.Method.Alg() != key.Algorithm {
+			t.Errorf("unexpected header %v", parsed.Header)
+		}
+	}
+}
+
+func TestTokenService_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	oldKey := newRSAKey(t, "old")
+	newKey := newECKey(t, "new")
+
+	oldToken, err := newService(t, "old", oldKey).GenerateToken("user1", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// После ротации старый ключ только проверяет подпись
+	rotated := newService(t, "new", newKey, publicOnly(oldKey))
+	if _, err := rotated.ParseJWTToken(oldToken.Value); err != nil {
+		t.Errorf("token signed with retiring key must stay valid: %v", err)
+	}
+	newToken, err := rotated.GenerateToken("user1", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, _, _ := jwt.NewParser().ParseUnverified(newToken.Value, &CustomClaims{})
+	if parsed.Header["kid"] != "new" {
+		t.Errorf("expected new tokens to be signed with the active key, got kid %v", parsed.Header["kid"])
+	}
+
+	// Когда старый ключ удалён из набора, его токены больше не принимаются
+	if _, err := newService(t, "new", newKey).ParseJWTToken(oldToken.Value); err == nil {
+		t.Error("expected error for token signed with removed key")
+	}
+}
+
+func TestTokenService_ParseRejects(t *testing.T) {
+	t.Parallel()
+
+	key := newRSAKey(t, "k1")
+	svc := newService(t, "k1", key)
+
+	// Открытый RSA-ключ, использованный как HMAC-секрет: классическая подмена алгоритма
+	publicDER, err := x509.MarshalPKIXPublicKey(key.public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+	confused.Header["kid"] = "k1"
+	confusedToken, err := confused.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+	unsigned.Header["kid"] = "k1"
+	noneToken, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withClaims := func(modify func(c *CustomClaims)) string {
+		c := validClaims()
+		modify(&c)
+		return sign(t, key, c)
+	}
+	unknownKid := newRSAKey(t, "k2")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "algorithm confusion", token: confusedToken},
+		{name: "none algorithm", token: noneToken},
+		{name: "unknown kid", token: sign(t, unknownKid, validClaims())},
+		{name: "wrong issuer", token: withClaims(func(c *CustomClaims) { c.Issuer = "evil" })},
+		{name: "missing issuer", token: withClaims(func(c *CustomClaims) { c.Issuer = "" })},
+		{name: "wrong audience", token: withClaims(func(c *CustomClaims) { c.Audience = jwt.ClaimStrings{"other-service"} })},
+		{name: "missing audience", token: withClaims(func(c *CustomClaims) { c.Audience = nil })},
+		{name: "missing subject", token: withClaims(func(c *CustomClaims) { c.Subject = "" })},
+		{name: "subject mismatch", token: withClaims(func(c *CustomClaims) { c.Subject = "user2" })},
+		{name: "missing jti", token: withClaims(func(c *CustomClaims) { c.ID = "" })},
+		{name: "missing expiration", token: withClaims(func(c *CustomClaims) { c.ExpiresAt = nil })},
+		{name: "expired", token: withClaims(func(c *CustomClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) })},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := svc.ParseJWTToken(tc.token); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestKeySet(t *testing.T) {
+	t.Parallel()
+
+	rsaKey := newRSAKey(t, "b-rsa")
+	ecKey := newECKey(t, "a-ec")
+	hmacKey, err := NewHMACKey("hs", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := NewKeySet("b-rsa", rsaKey, ecKey, hmacKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	algs := set.Algorithms()
+	if len(algs) != 3 || algs[0] != AlgorithmES256 || algs[1] != AlgorithmHS256 || algs[2] != AlgorithmRS256 {
+		t.Errorf("unexpected algorithms %v", algs)
+	}
+
+	// Симметричный ключ не публикуется
+	jwks := set.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("expected 2 public keys, got %+v", jwks.Keys)
+	}
+	ec, rsaJWK := jwks.Keys[0], jwks.Keys[1]
+	if ec.Kid != "a-ec" || ec.Kty != "EC" || ec.Crv != "P-256" || len(ec.X) != 43 || len(ec.Y) != 43 || ec.Use != "sig" {
+		t.Errorf("unexpected EC key %+v", ec)
+	}
+	if rsaJWK.Kid != "b-rsa" || rsaJWK.Kty != "RSA" || rsaJWK.Alg != AlgorithmRS256 || rsaJWK.E != "AQAB" || rsaJWK.N == "" {
+		t.Errorf("unexpected RSA key %+v", rsaJWK)
+	}
+
+	if _, err := NewKeySet("missing", rsaKey); err == nil {
+		t.Error("expected error for unknown active key")
+	}
+	if _, err := NewKeySet("b-rsa", publicOnly(rsaKey)); err == nil {
+		t.Error("expected error for active key without private part")
+	}
+	if _, err := NewKeySet("b-rsa", rsaKey, newECKey(t, "b-rsa")); err == nil {
+		t.Error("expected error for duplicate key id")
+	}
+
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewKey("small", small); err == nil {
+		t.Error("expected error for short RSA key")
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewKey("p384", p384); err == nil {
+		t.Error("expected error for unsupported curve")
+	}
+}
+
+func TestLoadKeyFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateFile := filepath.Join(dir, "key.pem")
+	publicFile := filepath.Join(dir, "key.pub.pem")
+	if err := os.WriteFile(privateFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signing, err := LoadKeyFiles("k1", privateFile, "")
+	if err != nil {
+		t.Fatalf("load private key: %v", err)
+	}
+	if signing.Algorithm != AlgorithmES256 || signing.private == nil {
+		t.Errorf("unexpected key %+v", signing)
+	}
+
+	verifying, err := LoadKeyFiles("k1", "", publicFile)
+	if err != nil {
+		t.Fatalf("load public key: %v", err)
+	}
+	if verifying.private != nil {
+		t.Error("public key file must give a verification-only key")
+	}
+
+	if _, err := LoadKeyFiles("k1", "", ""); err == nil {
+		t.Error("expected error without key files")
+	}
+	if _, err := LoadKeyFiles("k1", filepath.Join(dir, "missing.pem"), ""); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestTokenService_DummyTokens(t *testing.T) {
+	t.Parallel()
+
+	key := newECKey(t, "k1")
+	set, err := NewKeySet("k1", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabled := NewTokenService(set, testIssuer, testAudience, 60, true)
+	disabled := NewTokenService(set, testIssuer, testAudience, 60, false)
+
+	token, err := enabled.GenerateDummyToken("moderator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := enabled.ParseJWTToken(token.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !claims.Dummy || claims.UserID != DummyUserID || claims.Role != "moderator" {
+		t.Errorf("unexpected dummy claims %+v", claims)
+	}
+
+	real, err := enabled.GenerateToken("user1", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims, err := disabled.ParseJWTToken(real.Value); err != nil || claims.Dummy {
+		t.Errorf("regular token must stay valid without dummy flag: %+v, %v", claims, err)
+	}
+
+	// Выключенный /dummyLogin не выдаёт токены и не принимает выданные ранее
+	if _, err := disabled.GenerateDummyToken("moderator"); err == nil {
+		t.Error("expected error when dummy tokens are disabled")
+	}
+	if _, err := disabled.ParseJWTToken(token.Value); err == nil {
+		t.Error("expected dummy token to be rejected when dummy tokens are disabled")
+	}
+}
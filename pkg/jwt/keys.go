@@ -0,0 +1,231 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmHS256 = "HS256"
+
+	minRSABits = 2048
+)
+
+// Key — ключ подписи с идентификатором kid. Алгоритм определяется типом ключа: RSA — RS256,
+// EC P-256 — ES256. У ключа без закрытой части можно только проверять подпись.
+type Key struct {
+	ID        string
+	Algorithm string
+	private   crypto.PrivateKey
+	public    crypto.PublicKey
+}
+
+// NewHMACKey — симметричный ключ HS256. Такой ключ не публикуется в JWKS: проверить
+// подпись может только тот, кто знает секрет.
+func NewHMACKey(id, secret string) (*Key, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("key %q: empty secret", id)
+	}
+	return &Key{ID: id, Algorithm: AlgorithmHS256, private: []byte(secret), public: []byte(secret)}, nil
+}
+
+// NewKey создаёт ключ из закрытого ключа RSA или ECDSA P-256.
+func NewKey(id string, private crypto.Signer) (*Key, error) {
+	key, err := newPublicKey(id, private.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.private = private
+	return key, nil
+}
+
+// LoadKeyFiles читает PEM-файлы ключа. Для ключа, которым подписываются токены, нужен закрытый
+// ключ; для выводимого из ротации достаточно открытого.
+func LoadKeyFiles(id, privateKeyFile, publicKeyFile string) (*Key, error) {
+	if privateKeyFile != "" {
+		data, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		private, err := parsePrivateKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		return NewKey(id, private)
+	}
+
+	if publicKeyFile == "" {
+		return nil, fmt.Errorf("key %q: no key file", id)
+	}
+	data, err := os.ReadFile(publicKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", id, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %q: no PEM data", id)
+	}
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", id, err)
+	}
+	return newPublicKey(id, public)
+}
+
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", parsed)
+	}
+	return signer, nil
+}
+
+func newPublicKey(id string, public crypto.PublicKey) (*Key, error) {
+	if id == "" {
+		return nil, fmt.Errorf("key id is required")
+	}
+
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("key %q: RSA key must be at least %d bits", id, minRSABits)
+		}
+		return &Key{ID: id, Algorithm: AlgorithmRS256, public: pub}, nil
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("key %q: only P-256 curve is supported", id)
+		}
+		return &Key{ID: id, Algorithm: AlgorithmES256, public: pub}, nil
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %T", id, public)
+	}
+}
+
+// KeySet — ключи сервиса. Активным ключом подписываются новые токены, остальные только проверяют
+// подпись: так выданные старым ключом токены доживают свой срок после ротации.
+type KeySet struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+func NewKeySet(activeID string, keys ...*Key) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]*Key, len(keys))}
+	for _, key := range keys {
+		if _, ok := set.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+
+	active, ok := set.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q is not configured", activeID)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active key %q has no private key", activeID)
+	}
+	set.active = active
+
+	return set, nil
+}
+
+// Algorithms — алгоритмы, с которыми принимаются токены. Остальные, в том числе "none",
+// отклоняются до проверки подписи.
+func (s *KeySet) Algorithms() []string {
+	seen := make(map[string]bool)
+	algs := make([]string, 0, 2)
+	for _, key := range s.keys {
+		if !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algs = append(algs, key.Algorithm)
+		}
+	}
+	sort.Strings(algs)
+	return algs
+}
+
+// JWK — открытый ключ в формате RFC 7517.
+type JWK struct {
+	Kty string `json:"kty" example:"RSA"`
+	Kid string `json:"kid" example:"2025-05"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"RS256"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty" example:"AQAB"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает открытые части асимметричных ключей, отсортированные по kid.
+func (s *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(s.keys))}
+	for _, key := range s.keys {
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Algorithm,
+				N:   encodeBigInt(pub.N, 0),
+				E:   encodeBigInt(big.NewInt(int64(pub.E)), 0),
+			})
+		case *ecdsa.PublicKey:
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			set.Keys = append(set.Keys, JWK{
+				Kty: "EC",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Algorithm,
+				Crv: pub.Curve.Params().Name,
+				X:   encodeBigInt(pub.X, size),
+				Y:   encodeBigInt(pub.Y, size),
+			})
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+// encodeBigInt кодирует число в base64url без дополнения; координаты EC дополняются нулями до size байт.
+func encodeBigInt(n *big.Int, size int) string {
+	b := n.Bytes()
+	if len(b) < size {
+		padded := make([]byte, size)
+		copy(padded[size-len(b):], b)
+		b = padded
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
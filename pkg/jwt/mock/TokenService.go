@@ -13,23 +13,55 @@ type TokenService struct {
 	mock.Mock
 }
 
+// GenerateDummyToken provides a mock function with given fields: role
+func (_m *TokenService) GenerateDummyToken(role string) (*jwt.Token, error) {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateDummyToken")
+	}
+
+	var r0 *jwt.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*jwt.Token, error)); ok {
+		return rf(role)
+	}
+	if rf, ok := ret.Get(0).(func(string) *jwt.Token); ok {
+		r0 = rf(role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateToken provides a mock function with given fields: userID, role
-func (_m *TokenService) GenerateToken(userID string, role string) (string, error) {
+func (_m *TokenService) GenerateToken(userID string, role string) (*jwt.Token, error) {
 	ret := _m.Called(userID, role)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateToken")
 	}
 
-	var r0 string
+	var r0 *jwt.Token
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string, string) (*jwt.Token, error)); ok {
 		return rf(userID, role)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+	if rf, ok := ret.Get(0).(func(string, string) *jwt.Token); ok {
 		r0 = rf(userID, role)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.Token)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
@@ -41,6 +73,24 @@ func (_m *TokenService) GenerateToken(userID string, role string) (string, error
 	return r0, r1
 }
 
+// JWKS provides a mock function with no fields
+func (_m *TokenService) JWKS() jwt.JWKS {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 jwt.JWKS
+	if rf, ok := ret.Get(0).(func() jwt.JWKS); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(jwt.JWKS)
+	}
+
+	return r0
+}
+
 // ParseJWTToken provides a mock function with given fields: tokenString
 func (_m *TokenService) ParseJWTToken(tokenString string) (*jwt.CustomClaims, error) {
 	ret := _m.Called(tokenString)
@@ -0,0 +1,9 @@
+package jwt
+
+import "context"
+
+// RevocationChecker проверяет, отозван ли access-токен с данным jti (logout, повторное
+// использование refresh-токена). Список отозванных токенов общий для всех реплик.
+type RevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
@@ -0,0 +1,37 @@
+package requestid
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"regexp"
+)
+
+// Header — HTTP-заголовок с идентификатором запроса. В gRPC metadata он передаётся как MetadataKey.
+const (
+	Header      = "X-Request-ID"
+	MetadataKey = "x-request-id"
+)
+
+// validID ограничивает идентификатор от клиента, чтобы в журналы не попадал произвольный текст.
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestIDKey struct{}
+
+// Resolve возвращает идентификатор клиента, если он корректен, иначе генерирует новый.
+func Resolve(fromClient string) string {
+	if validID.MatchString(fromClient) {
+		return fromClient
+	}
+	return uuid.NewString()
+}
+
+// ContextWithID возвращает контекст, содержащий идентификатор запроса.
+func ContextWithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext извлекает идентификатор запроса. Если его нет, возвращает "".
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,35 @@
+package requestid
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	if got := Resolve("req-42"); got != "req-42" {
+		t.Errorf("expected client id to be kept, got %q", got)
+	}
+
+	for _, bad := range []string{"", "has space", strings.Repeat("a", 129), "line\nbreak"} {
+		got := Resolve(bad)
+		if _, err := uuid.Parse(got); err != nil {
+			t.Errorf("Resolve(%q) = %q, expected generated uuid", bad, got)
+		}
+	}
+}
+
+func TestContextWithID(t *testing.T) {
+	t.Parallel()
+
+	if got := FromContext(context.Background()); got != "" {
+		t.Errorf("expected empty id, got %q", got)
+	}
+	ctx := ContextWithID(context.Background(), "req-1")
+	if got := FromContext(ctx); got != "req-1" {
+		t.Errorf("expected req-1, got %q", got)
+	}
+}
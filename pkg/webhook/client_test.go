@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "93.184.216.34", expected: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", expected: true},
+		{ip: "127.0.0.1", expected: false},
+		{ip: "::1", expected: false},
+		{ip: "10.1.2.3", expected: false},
+		{ip: "172.16.0.1", expected: false},
+		{ip: "192.168.1.1", expected: false},
+		{ip: "169.254.169.254", expected: false},
+		{ip: "fe80::1", expected: false},
+		{ip: "fd00::1", expected: false},
+		{ip: "0.0.0.0", expected: false},
+		{ip: "::ffff:127.0.0.1", expected: false},
+		{ip: "224.0.0.1", expected: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.ip, func(t *testing.T) {
+			t.Parallel()
+			if got := IsPublicIP(net.ParseIP(tc.ip)); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("loopback target is rejected at dial time", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("request must not reach the server")
+		}))
+		defer srv.Close()
+
+		_, err := NewClient(time.Second, false).Post(srv.URL, "application/json", nil)
+		if !errors.Is(err, ErrForbiddenAddress) {
+			t.Fatalf("expected ErrForbiddenAddress, got %v", err)
+		}
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/internal" {
+				t.Error("redirect must not be followed")
+			}
+			http.Redirect(w, r, "/internal", http.StatusFound)
+		}))
+		defer srv.Close()
+
+		resp, err := NewClient(time.Second, true).Post(srv.URL, "application/json", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusFound {
+			t.Errorf("expected 302, got %d", resp.StatusCode)
+		}
+	})
+}
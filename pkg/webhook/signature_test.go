@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1746180000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"id":"e1","type":"OrderIssued"}`)
+	sig := Sign("secret", now.Unix(), body)
+
+	if !strings.HasPrefix(sig, "sha256=") || len(sig) != len("sha256=")+64 {
+		t.Fatalf("unexpected signature format %q", sig)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		now       time.Time
+		expected  error
+	}{
+		{name: "valid", secret: "secret", signature: sig, timestamp: ts, body: body, now: now.Add(time.Minute)},
+		{name: "other secret", secret: "other", signature: sig, timestamp: ts, body: body, now: now, expected: ErrInvalidSignature},
+		{name: "tampered body", secret: "secret", signature: sig, timestamp: ts, body: []byte(`{}`), now: now, expected: ErrInvalidSignature},
+		{name: "replayed with new timestamp", secret: "secret", signature: sig, timestamp: strconv.FormatInt(now.Unix()+60, 10), body: body, now: now, expected: ErrInvalidSignature},
+		{name: "stale timestamp", secret: "secret", signature: sig, timestamp: ts, body: body, now: now.Add(6 * time.Minute), expected: ErrExpiredTimestamp},
+		{name: "missing prefix", secret: "secret", signature: strings.TrimPrefix(sig, "sha256="), timestamp: ts, body: body, now: now, expected: ErrInvalidSignature},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := Verify(tc.secret, tc.signature, tc.timestamp, tc.body, tc.now, 5*time.Minute)
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	if err := Verify("secret", sig, "yesterday", body, now, time.Minute); err == nil {
+		t.Error("expected error for malformed timestamp")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := GenerateSecret()
+	if a == b || !strings.HasPrefix(a, "whsec_") {
+		t.Errorf("unexpected secrets %q, %q", a, b)
+	}
+}
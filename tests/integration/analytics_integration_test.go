@@ -0,0 +1,128 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"order-pick-up-point/internal/models/dto"
+	"time"
+)
+
+// seedAnalytics создаёт ПВЗ в Москве с тремя закрытыми приёмками (2, 0 и 1 товар)
+// и ПВЗ в Казани с одной приёмкой и одним товаром.
+func (s *TestSuite) seedAnalytics(day time.Time) (moscowID, kazanID string) {
+	modToken := s.getToken("moderator")
+	empToken := s.getToken("employee")
+
+	seed := func(city string, receptions map[time.Duration][]string, order []time.Duration) string {
+		pvzResp, _, err := s.createPvz(city, modToken)
+		s.Require().NoError(err)
+		for _, offset := range order {
+			_, status, err := s.createReception(pvzResp.PvzId, empToken, day.Add(offset))
+			s.Require().NoError(err)
+			s.Require().Equal(http.StatusCreated, status)
+			for _, prodType := range receptions[offset] {
+				_, status, err = s.addProduct(pvzResp.PvzId, empToken, prodType)
+				s.Require().NoError(err)
+				s.Require().Equal(http.StatusCreated, status)
+			}
+			_, _, err = s.closeReception(pvzResp.PvzId, empToken)
+			s.Require().NoError(err)
+		}
+		return pvzResp.PvzId
+	}
+
+	// 22:00 UTC — это уже следующий день по Москве
+	moscowID = seed("Moscow", map[time.Duration][]string{
+		10 * time.Hour: {"electronics", "electronics"},
+		12 * time.Hour: nil,
+		22 * time.Hour: {"shoes"},
+	}, []time.Duration{10 * time.Hour, 12 * time.Hour, 22 * time.Hour})
+	kazanID = seed("Kazan", map[time.Duration][]string{
+		10 * time.Hour: {"clothes"},
+	}, []time.Duration{10 * time.Hour})
+	return moscowID, kazanID
+}
+
+func (s *TestSuite) TestAnalytics_ReceptionsPerDay() {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	moscowID, _ := s.seedAnalytics(day)
+	token := s.getToken("moderator")
+
+	var result []dto.DailyReceptionsDTO
+	status := s.getJSON("/analytics/receptions/daily?city=Moscow", token, &result)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal([]dto.DailyReceptionsDTO{
+		{PvzId: moscowID, City: "Moscow", Day: "2024-03-01", Receptions: 2},
+		{PvzId: moscowID, City: "Moscow", Day: "2024-03-02", Receptions: 1},
+	}, result)
+
+	// период отсекает вечернюю приёмку
+	query := fmt.Sprintf("/analytics/receptions/daily?startDate=%s&endDate=%s",
+		url.QueryEscape(day.Format(time.RFC3339)),
+		url.QueryEscape(day.Add(12*time.Hour).Format(time.RFC3339)),
+	)
+	status = s.getJSON(query, token, &result)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(result, 2)
+	for _, d := range result {
+		s.Require().Equal("2024-03-01", d.Day)
+	}
+}
+
+func (s *TestSuite) TestAnalytics_ProductTypeCounts() {
+	moscowID, kazanID := s.seedAnalytics(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	token := s.getToken("employee")
+
+	var result []dto.ProductTypeCountDTO
+	status := s.getJSON("/analytics/products/types", token, &result)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal([]dto.ProductTypeCountDTO{
+		{PvzId: kazanID, City: "Kazan", Type: "clothes", Products: 1},
+		{PvzId: moscowID, City: "Moscow", Type: "electronics", Products: 2},
+		{PvzId: moscowID, City: "Moscow", Type: "shoes", Products: 1},
+	}, result)
+
+	status = s.getJSON("/analytics/products/types?pvzId="+kazanID, token, &result)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(result, 1)
+
+	s.Require().Equal(http.StatusBadRequest, s.getJSON("/analytics/products/types?pvzId=kazan", token, nil))
+}
+
+func (s *TestSuite) TestAnalytics_ReceptionStats() {
+	moscowID, _ := s.seedAnalytics(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	token := s.getToken("moderator")
+
+	var result []dto.ReceptionStatsDTO
+	status := s.getJSON("/analytics/receptions/stats?city=moscow", token, &result)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(result, 1)
+
+	stats := result[0]
+	s.Require().Equal(moscowID, stats.PvzId)
+	s.Require().Equal(3, stats.Receptions)
+	s.Require().Equal(3, stats.ClosedReceptions)
+	s.Require().Equal(3, stats.Products)
+	// пустая приёмка тоже входит в среднее
+	s.Require().InDelta(1.0, stats.AvgProductsPerReception, 1e-9)
+	s.Require().NotNil(stats.AvgDurationSeconds)
+	s.Require().Greater(*stats.AvgDurationSeconds, 0.0)
+}
+
+func (s *TestSuite) TestAnalytics_InvalidFilters() {
+	token := s.getToken("moderator")
+
+	var errResp dto.Error
+	status := s.getJSON("/analytics/receptions/stats?city=London", token, &errResp)
+	s.Require().Equal(http.StatusBadRequest, status)
+	s.Require().Equal("INVALID_CITY", errResp.Code)
+
+	status = s.getJSON("/analytics/receptions/daily?startDate=2024-03-02T00:00:00Z&endDate=2024-03-01T00:00:00Z", token, &errResp)
+	s.Require().Equal(http.StatusBadRequest, status)
+
+	status = s.getJSON("/analytics/products/types", s.getToken("client"), nil)
+	s.Require().Equal(http.StatusForbidden, status)
+}
@@ -0,0 +1,114 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"order-pick-up-point/internal/models/dto"
+	"time"
+)
+
+func (s *TestSuite) TestAudit_RecordsReceptionLifecycle() {
+	modToken := s.getToken("moderator")
+	pvzResp, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+
+	empID, empToken := s.registerAndLogin("auditor@example.com", "employee")
+	_, _, err = s.createReception(pvzResp.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+	prodResp, _, err := s.addProduct(pvzResp.PvzId, empToken, "shoes")
+	s.Require().NoError(err)
+	_, status, err := s.closeReception(pvzResp.PvzId, empToken)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, status)
+
+	var entries []dto.AuditEntryDTO
+	status = s.getJSON("/audit?pvzId="+pvzResp.PvzId, modToken, &entries)
+	s.Require().Equal(http.StatusOK, status)
+
+	// новые записи идут первыми
+	actions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		actions = append(actions, e.Action)
+	}
+	s.Require().Equal([]string{"reception.close", "product.create", "reception.create", "pvz.create"}, actions)
+
+	closed := entries[0]
+	s.Require().Equal(empID, closed.ActorId)
+	s.Require().Equal("employee", closed.ActorRole)
+	s.Require().NotEmpty(closed.RequestId)
+
+	var before, after struct {
+		Status string `json:"status"`
+	}
+	s.Require().NoError(json.Unmarshal(closed.Before, &before))
+	s.Require().NoError(json.Unmarshal(closed.After, &after))
+	s.Require().Equal("in_progress", before.Status)
+	s.Require().Equal("close", after.Status)
+
+	s.Require().Equal(prodResp.ProductId, entries[1].EntityId)
+	// токен dummyLogin не связан с пользователем, автор не записывается
+	s.Require().Empty(entries[3].ActorId)
+	s.Require().Equal("moderator", entries[3].ActorRole)
+	s.Require().True(entries[3].ActorDummy)
+	s.Require().False(closed.ActorDummy)
+
+	status = s.getJSON("/audit?actorId="+empID+"&action=product.create", modToken, &entries)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(entries, 1)
+}
+
+func (s *TestSuite) TestAudit_PaginationAndRequestID() {
+	modToken := s.getToken("moderator")
+	for _, city := range []string{"Moscow", "Kazan", "Saint Petersburg"} {
+		_, _, err := s.createPvz(city, modToken)
+		s.Require().NoError(err)
+	}
+
+	req, err := http.NewRequest("GET", s.server.URL+"/audit?action=pvz.create&limit=2", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", modToken))
+	req.Header.Set("X-Request-ID", "audit-test-1")
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().Equal("audit-test-1", resp.Header.Get("X-Request-ID"))
+	s.Require().Equal("true", resp.Header.Get("X-Has-More"))
+	cursor := resp.Header.Get("X-Next-Cursor")
+	s.Require().NotEmpty(cursor)
+
+	var entries []dto.AuditEntryDTO
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&entries))
+	s.Require().Len(entries, 2)
+
+	var rest []dto.AuditEntryDTO
+	status := s.getJSON("/audit?action=pvz.create&limit=2&cursor="+cursor, modToken, &rest)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(rest, 1)
+	s.Require().Less(rest[0].Id, entries[1].Id)
+}
+
+func (s *TestSuite) TestAudit_AppendOnlyAndModeratorOnly() {
+	modToken := s.getToken("moderator")
+	_, _, err := s.createPvz("Kazan", modToken)
+	s.Require().NoError(err)
+
+	status := s.getJSON("/audit", s.getToken("employee"), nil)
+	s.Require().Equal(http.StatusForbidden, status)
+
+	ctx := context.Background()
+	_, err = s.pool.Exec(ctx, `UPDATE audit_log SET action = 'pvz.delete'`)
+	s.Require().Error(err)
+	_, err = s.pool.Exec(ctx, `DELETE FROM audit_log`)
+	s.Require().Error(err)
+
+	var errResp dto.Error
+	status = s.getJSON("/audit?cursor=broken!", modToken, &errResp)
+	s.Require().Equal(http.StatusBadRequest, status)
+	s.Require().Equal("INVALID_CURSOR", errResp.Code)
+}
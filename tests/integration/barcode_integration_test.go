@@ -0,0 +1,85 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"time"
+)
+
+func (s *TestSuite) getProductByBarcode(token, barcode string) (*dto.OrderDTO, int) {
+	req, err := http.NewRequest("GET", s.server.URL+"/products/barcode/"+barcode, nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+	var order dto.OrderDTO
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&order))
+	return &order, resp.StatusCode
+}
+
+func (s *TestSuite) TestBarcode_DuplicateRejectedAndLookup() {
+	modToken := s.getToken("moderator")
+	pvzResp, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+
+	empToken := s.getToken("employee")
+	_, _, err = s.createReception(pvzResp.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+
+	var added dto.ProductsPostResponse
+	status := s.postJSON("/products", empToken,
+		dto.ProductsPostRequest{PvzId: pvzResp.PvzId, Type: "electronics", Barcode: "4607001234567"}, &added)
+	s.Require().Equal(http.StatusCreated, status)
+
+	// Тот же штрихкод, пока товар в ПВЗ, принять нельзя
+	var dupErr dto.Error
+	status = s.postJSON("/products", empToken,
+		dto.ProductsPostRequest{PvzId: pvzResp.PvzId, Type: "clothes", Barcode: "4607001234567"}, &dupErr)
+	s.Require().Equal(http.StatusConflict, status)
+	s.Require().Equal(errs.ErrDuplicateBarcode, dupErr.Code)
+
+	order, status := s.getProductByBarcode(modToken, "4607001234567")
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal(added.ProductId, order.ProductId)
+	s.Require().Equal(pvzResp.PvzId, order.PvzId)
+	s.Require().Equal("electronics", order.Type)
+
+	_, status = s.getProductByBarcode(modToken, "0000000000000")
+	s.Require().Equal(http.StatusNotFound, status)
+}
+
+func (s *TestSuite) TestBarcode_DeleteLastProductReportsBarcode() {
+	modToken := s.getToken("moderator")
+	pvzResp, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+
+	empToken := s.getToken("employee")
+	_, _, err = s.createReception(pvzResp.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+
+	status := s.postJSON("/products", empToken,
+		dto.ProductsPostRequest{PvzId: pvzResp.PvzId, Type: "shoes", Barcode: "TRACK-42"}, nil)
+	s.Require().Equal(http.StatusCreated, status)
+
+	var deleted dto.DeleteProductResponse
+	status = s.postJSON(fmt.Sprintf("/pvz/%s/delete_last_product", pvzResp.PvzId), empToken, struct{}{}, &deleted)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal("TRACK-42", deleted.Barcode)
+	s.Require().NotEmpty(deleted.ProductId)
+
+	// После удаления штрихкод снова свободен
+	status = s.postJSON("/products", empToken,
+		dto.ProductsPostRequest{PvzId: pvzResp.PvzId, Type: "shoes", Barcode: "TRACK-42"}, nil)
+	s.Require().Equal(http.StatusCreated, status)
+}
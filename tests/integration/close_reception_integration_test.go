@@ -5,6 +5,7 @@ package integration
 import (
 	"fmt"
 	"net/http"
+	"order-pick-up-point/internal/models/dto"
 	"time"
 )
 
@@ -67,3 +68,65 @@ func (s *TestSuite) TestCloseReception_Twice_Fail() {
 	defer resp2.Body.Close()
 	s.Require().NotEqual(http.StatusOK, resp2.StatusCode)
 }
+
+// registerAndLogin регистрирует пользователя и возвращает его ID и токен.
+func (s *TestSuite) registerAndLogin(email, role string) (string, string) {
+	regResp, _, err := s.registerUser(dto.RegisterPostRequest{Email: email, Password: "secret", Role: role})
+	s.Require().NoError(err)
+	tokenResp, _, err := s.loginUser(dto.LoginPostRequest{Email: email, Password: "secret"})
+	s.Require().NoError(err)
+	return regResp.UserID, tokenResp.Token
+}
+
+func (s *TestSuite) TestReception_RecordsOpenedAndClosedBy() {
+	modToken := s.getToken("moderator")
+	pvzResp, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+
+	openerID, openerToken := s.registerAndLogin("opener@example.com", "employee")
+	closerID, closerToken := s.registerAndLogin("closer@example.com", "employee")
+
+	_, _, err = s.createReception(pvzResp.PvzId, openerToken, time.Now())
+	s.Require().NoError(err)
+
+	// пока приёмка открыта, закрывший и время закрытия не заданы
+	items, _, _ := s.getPvzPage("/pvz?page=1&limit=10", modToken)
+	s.Require().Len(items, 1)
+	s.Require().Len(items[0].Receptions, 1)
+	rec := items[0].Receptions[0].Reception
+	s.Require().Equal(openerID, rec.OpenedBy)
+	s.Require().Empty(rec.ClosedBy)
+	s.Require().Nil(rec.ClosedAt)
+
+	before := time.Now().Add(-time.Minute)
+	_, status, err := s.closeReception(pvzResp.PvzId, closerToken)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, status)
+
+	// оптимизированный запрос отдаёт те же поля
+	items, _ = s.requirePvzInfoEqual("page=1&limit=10", modToken)
+	rec = items[0].Receptions[0].Reception
+	s.Require().Equal(openerID, rec.OpenedBy)
+	s.Require().Equal(closerID, rec.ClosedBy)
+	s.Require().NotNil(rec.ClosedAt)
+	s.Require().True(rec.ClosedAt.After(before))
+}
+
+func (s *TestSuite) TestReception_DummyTokenLeavesActorsEmpty() {
+	modToken := s.getToken("moderator")
+	pvzResp, _, err := s.createPvz("Kazan", modToken)
+	s.Require().NoError(err)
+
+	empToken := s.getToken("employee")
+	_, _, err = s.createReception(pvzResp.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+	_, _, err = s.closeReception(pvzResp.PvzId, empToken)
+	s.Require().NoError(err)
+
+	items, _, _ := s.getPvzPage("/pvz?page=1&limit=10", modToken)
+	s.Require().Len(items, 1)
+	rec := items[0].Receptions[0].Reception
+	s.Require().Empty(rec.OpenedBy)
+	s.Require().Empty(rec.ClosedBy)
+	s.Require().NotNil(rec.ClosedAt)
+}
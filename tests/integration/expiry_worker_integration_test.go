@@ -0,0 +1,70 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/internal/worker"
+	"order-pick-up-point/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Два воркера, работающие с одной БД, не должны пометить один товар дважды
+func (s *TestSuite) TestExpiryWorker_ConcurrentInstances_FlagOnce() {
+	ctx := context.Background()
+	log := logger.NewLogger("dev")
+
+	pvzID, productID, _, empToken := s.prepareReceivedProduct()
+
+	_, err := s.pool.Exec(ctx, `UPDATE product SET expires_at = NOW() - INTERVAL '1 hour' WHERE id = $1`, productID)
+	s.Require().NoError(err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo := db.NewOrderRepository(db.NewTxManager(s.pool, log), log)
+			worker.NewExpiryWorker(repo, log, time.Minute, 1).Sweep(ctx)
+		}()
+	}
+	wg.Wait()
+
+	var expiredAt time.Time
+	err = s.pool.QueryRow(ctx, `SELECT expired_at FROM product WHERE id = $1`, productID).Scan(&expiredAt)
+	s.Require().NoError(err)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/pvz/%s/overdue", s.server.URL, pvzID), nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", empToken))
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var overdue []dto.OrderDTO
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&overdue))
+	s.Require().Len(overdue, 1)
+	s.Require().Equal(productID, overdue[0].ProductId)
+	s.Require().NotNil(overdue[0].ExpiresAt)
+}
+
+func (s *TestSuite) TestAddProduct_SetsExpiresAtByType() {
+	ctx := context.Background()
+
+	_, productID, _, _ := s.prepareReceivedProduct()
+
+	var receivedAt, expiresAt time.Time
+	err := s.pool.QueryRow(ctx, `SELECT date_time, expires_at FROM product WHERE id = $1`, productID).
+		Scan(&receivedAt, &expiresAt)
+	s.Require().NoError(err)
+
+	// В configs/config.yaml для shoes задан срок хранения 7 дней
+	s.Require().WithinDuration(receivedAt.Add(7*24*time.Hour), expiresAt, time.Second)
+}
@@ -0,0 +1,201 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"time"
+)
+
+// postJSON отправляет POST-запрос с JSON-телом и декодирует ответ в out
+func (s *TestSuite) postJSON(path, token string, payload, out interface{}) int {
+	return s.sendJSON("POST", path, token, payload, out)
+}
+
+func (s *TestSuite) sendJSON(method, path, token string, payload, out interface{}) int {
+	body, err := json.Marshal(payload)
+	s.Require().NoError(err)
+
+	req, err := http.NewRequest(method, s.server.URL+path, bytes.NewBuffer(body))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	if out != nil {
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func (s *TestSuite) getJSON(path, token string, out interface{}) int {
+	req, err := http.NewRequest("GET", s.server.URL+path, nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	if out != nil {
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+// prepareReceivedProduct создаёт ПВЗ, закрытую приёмку с одним товаром и клиента-получателя
+func (s *TestSuite) prepareReceivedProduct() (pvzID, productID, clientID, empToken string) {
+	modToken := s.getToken("moderator")
+	pvzResp, _, err := s.createPvz("Kazan", modToken)
+	s.Require().NoError(err)
+
+	empToken = s.getToken("employee")
+	_, _, err = s.createReception(pvzResp.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+
+	prodResp, _, err := s.addProduct(pvzResp.PvzId, empToken, "shoes")
+	s.Require().NoError(err)
+
+	_, _, err = s.closeReception(pvzResp.PvzId, empToken)
+	s.Require().NoError(err)
+
+	regResp, _, err := s.registerUser(dto.RegisterPostRequest{
+		Email:    "client@example.com",
+		Password: "secret",
+		Role:     "client",
+	})
+	s.Require().NoError(err)
+
+	return pvzResp.PvzId, prodResp.ProductId, regResp.UserID, empToken
+}
+
+// requestPickupCode выпускает код выдачи от имени получателя из prepareReceivedProduct:
+// сотрудник, подготовивший заказ, кода не видит
+func (s *TestSuite) requestPickupCode(productID string) string {
+	tokenResp, status, err := s.loginUser(dto.LoginPostRequest{Email: "client@example.com", Password: "secret"})
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, status)
+
+	var order dto.ClientOrderDTO
+	s.Require().Equal(http.StatusOK, s.postJSON("/my/orders/"+productID+"/pickup_code", tokenResp.Token, nil, &order))
+	s.Require().Len(order.PickupCode, 6)
+	return order.PickupCode
+}
+
+func (s *TestSuite) TestIssueOrder_FullFlow_Success() {
+	pvzID, productID, clientID, empToken := s.prepareReceivedProduct()
+
+	var prepared dto.PrepareOrderResponse
+	status := s.postJSON(fmt.Sprintf("/pvz/%s/orders", pvzID), empToken,
+		dto.PrepareOrderRequest{ProductId: productID, RecipientId: clientID}, &prepared)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal("ready_for_pickup", prepared.Order.Status)
+
+	// Без кода, выпущенного получателем, заказ не выдать
+	var errResp dto.Error
+	issuePath := fmt.Sprintf("/pvz/%s/orders/%s/issue", pvzID, productID)
+	status = s.postJSON(issuePath, empToken, dto.IssueOrderRequest{PickupCode: "000000"}, &errResp)
+	s.Require().Equal(http.StatusForbidden, status)
+	s.Require().Equal(errs.ErrInvalidPickupCode, errResp.Code)
+
+	pickupCode := s.requestPickupCode(productID)
+
+	// Сотрудник находит заказ получателя на стойке выдачи
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/pvz/%s/orders?recipientId=%s", s.server.URL, pvzID, clientID), nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", empToken))
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var orders []dto.OrderDTO
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&orders))
+	s.Require().Len(orders, 1)
+	s.Require().Equal(productID, orders[0].ProductId)
+
+	var issued dto.OrderDTO
+	status = s.postJSON(issuePath, empToken, dto.IssueOrderRequest{PickupCode: pickupCode}, &issued)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal("issued", issued.Status)
+	s.Require().NotNil(issued.IssuedAt)
+
+	// Повторная выдача невозможна
+	status = s.postJSON(issuePath, empToken, dto.IssueOrderRequest{PickupCode: pickupCode}, &errResp)
+	s.Require().Equal(http.StatusConflict, status)
+	s.Require().Equal(errs.ErrInvalidProductStatus, errResp.Code)
+}
+
+func (s *TestSuite) TestIssueOrder_WrongPickupCode_Fail() {
+	pvzID, productID, clientID, empToken := s.prepareReceivedProduct()
+
+	var prepared dto.PrepareOrderResponse
+	status := s.postJSON(fmt.Sprintf("/pvz/%s/orders", pvzID), empToken,
+		dto.PrepareOrderRequest{ProductId: productID, RecipientId: clientID}, &prepared)
+	s.Require().Equal(http.StatusOK, status)
+
+	wrongCode := "000000"
+	if s.requestPickupCode(productID) == wrongCode {
+		wrongCode = "111111"
+	}
+
+	var errResp dto.Error
+	status = s.postJSON(fmt.Sprintf("/pvz/%s/orders/%s/issue", pvzID, productID), empToken,
+		dto.IssueOrderRequest{PickupCode: wrongCode}, &errResp)
+	s.Require().Equal(http.StatusForbidden, status)
+	s.Require().Equal(errs.ErrInvalidPickupCode, errResp.Code)
+
+	// Невостребованный заказ можно вернуть
+	var returned dto.OrderDTO
+	status = s.postJSON(fmt.Sprintf("/pvz/%s/orders/%s/return", pvzID, productID), empToken, nil, &returned)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal("returned", returned.Status)
+}
+
+// После maxPickupCodeFailures неверных кодов выдача блокируется, пока получатель не выпустит новый код
+func (s *TestSuite) TestIssueOrder_PickupCodeLockedUntilRenewed() {
+	pvzID, productID, clientID, empToken := s.prepareReceivedProduct()
+
+	var prepared dto.PrepareOrderResponse
+	status := s.postJSON(fmt.Sprintf("/pvz/%s/orders", pvzID), empToken,
+		dto.PrepareOrderRequest{ProductId: productID, RecipientId: clientID}, &prepared)
+	s.Require().Equal(http.StatusOK, status)
+	pickupCode := s.requestPickupCode(productID)
+
+	// В БД лежит хеш, а не сам код
+	var stored string
+	err := s.pool.QueryRow(context.Background(), `SELECT pickup_code_hash FROM product WHERE id = $1`, productID).Scan(&stored)
+	s.Require().NoError(err)
+	s.Require().NotContains(stored, pickupCode)
+
+	wrongCode := "000000"
+	if pickupCode == wrongCode {
+		wrongCode = "111111"
+	}
+	issuePath := fmt.Sprintf("/pvz/%s/orders/%s/issue", pvzID, productID)
+	for i := 0; i < 5; i++ {
+		s.Require().Equal(http.StatusForbidden, s.postJSON(issuePath, empToken, dto.IssueOrderRequest{PickupCode: wrongCode}, nil))
+	}
+
+	var errResp dto.Error
+	status = s.postJSON(issuePath, empToken, dto.IssueOrderRequest{PickupCode: pickupCode}, &errResp)
+	s.Require().Equal(http.StatusTooManyRequests, status)
+	s.Require().Equal(errs.ErrPickupCodeLocked, errResp.Code)
+
+	renewed := s.requestPickupCode(productID)
+	if renewed != pickupCode {
+		s.Require().Equal(http.StatusForbidden, s.postJSON(issuePath, empToken, dto.IssueOrderRequest{PickupCode: pickupCode}, nil))
+	}
+	var issued dto.OrderDTO
+	s.Require().Equal(http.StatusOK, s.postJSON(issuePath, empToken, dto.IssueOrderRequest{PickupCode: renewed}, &issued))
+	s.Require().Equal("issued", issued.Status)
+}
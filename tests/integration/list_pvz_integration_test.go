@@ -105,3 +105,67 @@ func (s *TestSuite) TestGetPvzList_InvalidPaginationParams_Fail() {
 
 	s.Require().NotEqual(http.StatusOK, resp.StatusCode)
 }
+
+// getPvzPage запрашивает страницу списка ПВЗ и возвращает её вместе с заголовками курсора.
+func (s *TestSuite) getPvzPage(path, token string) ([]dto.PvzGet200ResponseInner, string, string) {
+	req, err := http.NewRequest("GET", s.server.URL+path, nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var page []dto.PvzGet200ResponseInner
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&page))
+	return page, resp.Header.Get("X-Next-Cursor"), resp.Header.Get("X-Has-More")
+}
+
+func (s *TestSuite) TestGetPvzList_CursorPagination_Success() {
+	token := s.getToken("moderator")
+
+	created := map[string]bool{}
+	for _, city := range []string{"Moscow", "Kazan", "Saint Petersburg"} {
+		pvzResp, _, err := s.createPvz(city, token)
+		s.Require().NoError(err)
+		created[pvzResp.PvzId] = true
+	}
+
+	for _, endpoint := range []string{"/pvz", "/pvz/optimized"} {
+		seen := map[string]bool{}
+		page, cursor, hasMore := s.getPvzPage(endpoint+"?page=1&limit=2", token)
+		for {
+			s.Require().LessOrEqual(len(page), 2)
+			for _, item := range page {
+				s.Require().False(seen[item.Pvz.Id], "duplicate PVZ %s on %s", item.Pvz.Id, endpoint)
+				seen[item.Pvz.Id] = true
+			}
+			if hasMore != "true" {
+				s.Require().Equal("false", hasMore)
+				s.Require().Empty(cursor)
+				break
+			}
+			s.Require().NotEmpty(cursor)
+
+			// ПВЗ, созданный посреди пролистывания, не сдвигает следующие страницы
+			_, _, err := s.createPvz("Moscow", token)
+			s.Require().NoError(err)
+
+			page, cursor, hasMore = s.getPvzPage(endpoint+"?limit=2&cursor="+cursor, token)
+		}
+
+		for id := range created {
+			s.Require().True(seen[id], "PVZ %s missing on %s", id, endpoint)
+		}
+	}
+}
+
+func (s *TestSuite) TestGetPvzList_InvalidCursor_Fail() {
+	token := s.getToken("moderator")
+
+	var errResp dto.Error
+	status := s.getJSON("/pvz?limit=2&cursor=not-a-cursor", token, &errResp)
+	s.Require().Equal(http.StatusBadRequest, status)
+	s.Require().Equal("INVALID_CURSOR", errResp.Code)
+}
@@ -0,0 +1,145 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"sync"
+)
+
+// postLogin выполняет /login и возвращает код ответа, ошибку и заголовок Retry-After
+func (s *TestSuite) postLogin(email, password string) (int, dto.Error, string) {
+	body, err := json.Marshal(dto.LoginPostRequest{Email: email, Password: password})
+	s.Require().NoError(err)
+
+	resp, err := s.server.Client().Post(s.server.URL+"/login", "application/json", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var errResp dto.Error
+	if resp.StatusCode != http.StatusOK {
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&errResp))
+	}
+	return resp.StatusCode, errResp, resp.Header.Get("Retry-After")
+}
+
+func (s *TestSuite) unlockUser(token, userID string) int {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/users/%s/unlock", s.server.URL, userID), nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (s *TestSuite) TestLoginProtection_LocksAfterFailures() {
+	registered, _, err := s.registerUser(dto.RegisterPostRequest{Email: "locked@example.com", Password: "secret", Role: "client"})
+	s.Require().NoError(err)
+
+	// Существующий и несуществующий email блокируются одинаково
+	for _, email := range []string{"locked@example.com", "nobody@example.com"} {
+		for i := 0; i < testEmailMaxFailures; i++ {
+			status, errResp, _ := s.postLogin(email, "wrong")
+			s.Require().Equal(http.StatusUnauthorized, status)
+			s.Require().Equal(errs.ErrInvalidCredentials, errResp.Code)
+		}
+
+		status, errResp, retryAfter := s.postLogin(email, "secret")
+		s.Require().Equal(http.StatusTooManyRequests, status)
+		s.Require().Equal(errs.ErrTooManyLoginAttempts, errResp.Code)
+		s.Require().NotEmpty(retryAfter)
+	}
+
+	// Клиент и сотрудник не могут снять блокировку
+	s.Require().Equal(http.StatusForbidden, s.unlockUser(s.getToken("client"), registered.UserID))
+
+	moderator := s.getToken("moderator")
+	s.Require().Equal(http.StatusNoContent, s.unlockUser(moderator, registered.UserID))
+
+	status, _, _ := s.postLogin("locked@example.com", "secret")
+	s.Require().Equal(http.StatusOK, status)
+
+	var entries []dto.AuditEntryDTO
+	s.Require().Equal(http.StatusOK, s.getJSON("/audit?action=user.unlock", moderator, &entries))
+	s.Require().Len(entries, 1)
+	s.Require().Equal(registered.UserID, entries[0].EntityId)
+}
+
+func (s *TestSuite) TestLoginProtection_UnlockUnknownUser() {
+	moderator := s.getToken("moderator")
+	s.Require().Equal(http.StatusNotFound, s.unlockUser(moderator, "6f1c2a4e-8a57-4d4b-9a4f-2b0e5d1c9e01"))
+	s.Require().Equal(http.StatusBadRequest, s.unlockUser(moderator, "not-a-uuid"))
+}
+
+func (s *TestSuite) TestLoginProtection_ConcurrentAttemptsRespectLimit() {
+	// Параллельные попытки резервируются до проверки пароля, поэтому лимит не обходится
+	const attempts = 10
+	statuses := make(chan int, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body, err := json.Marshal(dto.LoginPostRequest{Email: "burst@example.com", Password: "wrong"})
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			resp, err := s.server.Client().Post(s.server.URL+"/login", "application/json", bytes.NewBuffer(body))
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	counts := make(map[int]int)
+	for status := range statuses {
+		counts[status]++
+	}
+	s.Require().Equal(testEmailMaxFailures, counts[http.StatusUnauthorized])
+	s.Require().Equal(attempts-testEmailMaxFailures, counts[http.StatusTooManyRequests])
+}
+
+// Неверный текущий пароль при смене пароля считается неудачным входом по email пользователя
+func (s *TestSuite) TestLoginProtection_ChangePasswordCountsFailures() {
+	_, token := s.registerAndLogin("changer@example.com", "client")
+
+	for i := 0; i < testEmailMaxFailures; i++ {
+		var errResp dto.Error
+		s.Require().Equal(http.StatusBadRequest, s.postJSON("/my/password", token,
+			dto.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "newsecret"}, &errResp))
+		s.Require().Equal(errs.ErrInvalidCurrentPassword, errResp.Code)
+	}
+
+	body, err := json.Marshal(dto.ChangePasswordRequest{CurrentPassword: "secret", NewPassword: "newsecret"})
+	s.Require().NoError(err)
+	req, err := http.NewRequest("POST", s.server.URL+"/my/password", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var errResp dto.Error
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&errResp))
+	s.Require().Equal(http.StatusTooManyRequests, resp.StatusCode)
+	s.Require().Equal(errs.ErrTooManyLoginAttempts, errResp.Code)
+	s.Require().NotEmpty(resp.Header.Get("Retry-After"))
+
+	// Блокировка общая со входом
+	status, _, _ := s.postLogin("changer@example.com", "secret")
+	s.Require().Equal(http.StatusTooManyRequests, status)
+}
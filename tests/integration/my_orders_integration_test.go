@@ -0,0 +1,64 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"order-pick-up-point/internal/models/dto"
+)
+
+// getMyOrders запрашивает заказы текущего клиента
+func (s *TestSuite) getMyOrders(token string) ([]dto.ClientOrderDTO, int) {
+	req, err := http.NewRequest("GET", s.server.URL+"/my/orders", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var orders []dto.ClientOrderDTO
+	if resp.StatusCode == http.StatusOK {
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&orders))
+	}
+	return orders, resp.StatusCode
+}
+
+func (s *TestSuite) TestMyOrders_ClientSeesOwnOrder() {
+	pvzID, productID, clientID, empToken := s.prepareReceivedProduct()
+
+	var prepared dto.PrepareOrderResponse
+	status := s.postJSON(fmt.Sprintf("/pvz/%s/orders", pvzID), empToken,
+		dto.PrepareOrderRequest{ProductId: productID, RecipientId: clientID}, &prepared)
+	s.Require().Equal(http.StatusOK, status)
+
+	tokenResp, status, err := s.loginUser(dto.LoginPostRequest{Email: "client@example.com", Password: "secret"})
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, status)
+
+	orders, status := s.getMyOrders(tokenResp.Token)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(orders, 1)
+	s.Require().Equal(productID, orders[0].ProductId)
+	s.Require().Equal("Kazan", orders[0].PvzCity)
+	// Хранится только хеш кода, поэтому в списке кода нет
+	s.Require().Empty(orders[0].PickupCode)
+	s.Require().NotNil(orders[0].StorageDeadline)
+
+	// Другой клиент чужих заказов не видит
+	_, _, err = s.registerUser(dto.RegisterPostRequest{Email: "other@example.com", Password: "secret", Role: "client"})
+	s.Require().NoError(err)
+	otherToken, _, err := s.loginUser(dto.LoginPostRequest{Email: "other@example.com", Password: "secret"})
+	s.Require().NoError(err)
+
+	orders, status = s.getMyOrders(otherToken.Token)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Empty(orders)
+}
+
+func (s *TestSuite) TestMyOrders_NotAllowedForEmployee() {
+	_, status := s.getMyOrders(s.getToken("employee"))
+	s.Require().Equal(http.StatusForbidden, status)
+}
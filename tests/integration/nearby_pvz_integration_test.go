@@ -0,0 +1,57 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"order-pick-up-point/internal/models/dto"
+)
+
+func (s *TestSuite) createPvzAt(lat, lon float64) string {
+	var resp dto.CreatePvzResponse
+	status := s.postJSON("/pvz", s.getToken("moderator"), dto.CreatePvzPostRequest{
+		City:      "Kazan",
+		Latitude:  &lat,
+		Longitude: &lon,
+	}, &resp)
+	s.Require().Equal(http.StatusCreated, status)
+	return resp.PvzId
+}
+
+func (s *TestSuite) TestNearbyPvz_SortedByDistance() {
+	// Точки в стороне от остальных тестов: ~111 м, ~1.1 км и ~11 км к северу от (10, 10)
+	near := s.createPvzAt(10.001, 10)
+	middle := s.createPvzAt(10.01, 10)
+	far := s.createPvzAt(10.1, 10)
+
+	var result []dto.NearbyPvzDTO
+	status := s.getJSON("/pvz/nearby?lat=10&lon=10&radius=2000", s.getToken("client"), &result)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(result, 2)
+	s.Require().Equal(near, result[0].Pvz.Id)
+	s.Require().Equal(middle, result[1].Pvz.Id)
+	s.Require().InDelta(111, result[0].DistanceMeters, 2)
+	s.Require().InDelta(1112, result[1].DistanceMeters, 5)
+	s.Require().False(result[0].OpenNow, "PVZ without opening hours is never open")
+
+	status = s.getJSON("/pvz/nearby?lat=10&lon=10&radius=20000&limit=1", s.getToken("employee"), &result)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(result, 1)
+	s.Require().Equal(near, result[0].Pvz.Id)
+
+	closed := "closed"
+	status = s.sendJSON("PATCH", "/pvz/"+near, s.getToken("moderator"), dto.UpdatePvzRequest{Status: &closed}, nil)
+	s.Require().Equal(http.StatusOK, status)
+
+	status = s.getJSON("/pvz/nearby?lat=10&lon=10&radius=20000&status=active&city=kazan", s.getToken("client"), &result)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(result, 2)
+	s.Require().Equal(middle, result[0].Pvz.Id)
+	s.Require().Equal(far, result[1].Pvz.Id)
+}
+
+func (s *TestSuite) TestNearbyPvz_InvalidQuery() {
+	var errResp dto.Error
+	status := s.getJSON("/pvz/nearby?lat=10", s.getToken("client"), &errResp)
+	s.Require().Equal(http.StatusBadRequest, status)
+}
@@ -0,0 +1,170 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/outbox"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/internal/worker"
+	"order-pick-up-point/pkg/logger"
+	"sync"
+	"time"
+)
+
+// failingPublisher имитирует недоступный брокер
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(context.Context, outbox.Message) error {
+	return errors.New("broker unavailable")
+}
+
+func (failingPublisher) Close() error { return nil }
+
+func (s *TestSuite) newOutboxRelay(publisher outbox.Publisher, maxAttempts int) *worker.OutboxRelay {
+	log := logger.NewLogger("dev")
+	txManager := db.NewTxManager(s.pool, log)
+	return worker.NewOutboxRelay(db.NewOutboxRepository(txManager, log), txManager, publisher, log, worker.OutboxRelayOptions{
+		Interval:    time.Minute,
+		BatchSize:   2,
+		MaxAttempts: maxAttempts,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  time.Hour,
+		Retention:   24 * time.Hour,
+	})
+}
+
+// runReceptionLifecycle создаёт ПВЗ, приёмку с двумя товарами, удаляет один товар и закрывает приёмку
+func (s *TestSuite) runReceptionLifecycle() string {
+	pvzResp, _, err := s.createPvz("Moscow", s.getToken("moderator"))
+	s.Require().NoError(err)
+	empToken := s.getToken("employee")
+	_, _, err = s.createReception(pvzResp.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+	for _, prodType := range []string{"shoes", "clothes"} {
+		_, _, err = s.addProduct(pvzResp.PvzId, empToken, prodType)
+		s.Require().NoError(err)
+	}
+	status := s.postJSON("/pvz/"+pvzResp.PvzId+"/delete_last_product", empToken, nil, nil)
+	s.Require().Equal(http.StatusOK, status)
+	_, _, err = s.closeReception(pvzResp.PvzId, empToken)
+	s.Require().NoError(err)
+	return pvzResp.PvzId
+}
+
+func (s *TestSuite) TestOutbox_PublishesEventsInOrder() {
+	pvzID := s.runReceptionLifecycle()
+
+	publisher := outbox.NewMemoryPublisher()
+	s.newOutboxRelay(publisher, 3).Relay(context.Background())
+
+	msgs := publisher.Messages()
+	types := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		s.Require().Equal(pvzID, m.PvzID)
+		s.Require().NotEmpty(m.ID)
+		types = append(types, m.Type)
+	}
+	s.Require().Equal([]string{
+		entity.EventPvzCreated, entity.EventReceptionOpened, entity.EventProductAdded,
+		entity.EventProductAdded, entity.EventProductRemoved, entity.EventReceptionClosed,
+	}, types)
+
+	// опубликованные события повторно не отправляются
+	s.newOutboxRelay(publisher, 3).Relay(context.Background())
+	s.Require().Len(publisher.Messages(), len(msgs))
+}
+
+func (s *TestSuite) TestOutbox_FailedEventsGoToDeadLetter() {
+	ctx := context.Background()
+	s.runReceptionLifecycle()
+
+	relay := s.newOutboxRelay(failingPublisher{}, 2)
+	relay.Relay(ctx)
+
+	var pending, attempts int
+	err := s.pool.QueryRow(ctx, `SELECT count(*), min(attempts) FROM outbox_event WHERE status = 'pending'`).Scan(&pending, &attempts)
+	s.Require().NoError(err)
+	s.Require().Equal(6, pending)
+	s.Require().Equal(1, attempts)
+
+	// вторая попытка — последняя, события уходят в dead letter
+	_, err = s.pool.Exec(ctx, `UPDATE outbox_event SET next_attempt_at = now()`)
+	s.Require().NoError(err)
+	relay.Relay(ctx)
+
+	var dead int
+	var lastError string
+	err = s.pool.QueryRow(ctx, `SELECT count(*), max(last_error) FROM outbox_event WHERE status = 'dead'`).Scan(&dead, &lastError)
+	s.Require().NoError(err)
+	s.Require().Equal(6, dead)
+	s.Require().Equal("broker unavailable", lastError)
+}
+
+// Опубликованные события старше срока хранения удаляются, ожидающие и dead — остаются
+func (s *TestSuite) TestOutbox_CleanupDeletesOldPublishedEvents() {
+	ctx := context.Background()
+	s.runReceptionLifecycle()
+
+	relay := s.newOutboxRelay(outbox.NewMemoryPublisher(), 3)
+	relay.Relay(ctx)
+
+	// четыре события опубликованы давно, одно — недавно, одно в dead letter
+	_, err := s.pool.Exec(ctx, `UPDATE outbox_event SET published_at = now() - interval '2 days'
+		WHERE id IN (SELECT id FROM outbox_event ORDER BY id LIMIT 4)`)
+	s.Require().NoError(err)
+	_, err = s.pool.Exec(ctx, `UPDATE outbox_event SET status = 'dead', published_at = NULL
+		WHERE id = (SELECT max(id) FROM outbox_event)`)
+	s.Require().NoError(err)
+
+	relay.Cleanup(ctx)
+
+	var published, dead int
+	err = s.pool.QueryRow(ctx, `SELECT count(*) FILTER (WHERE status = 'published'), count(*) FILTER (WHERE status = 'dead')
+		FROM outbox_event`).Scan(&published, &dead)
+	s.Require().NoError(err)
+	s.Require().Equal(1, published)
+	s.Require().Equal(1, dead)
+}
+
+// Два relay не должны опубликовать одно событие дважды
+func (s *TestSuite) TestOutbox_ConcurrentRelaysPublishOnce() {
+	s.runReceptionLifecycle()
+
+	publisher := outbox.NewMemoryPublisher()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.newOutboxRelay(publisher, 3).Relay(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for _, m := range publisher.Messages() {
+		s.Require().False(seen[m.ID], "event %s published twice", m.ID)
+		seen[m.ID] = true
+	}
+	s.Require().Len(seen, 6)
+}
+
+// Событие не пишется, если изменение откатилось
+func (s *TestSuite) TestOutbox_NoEventForFailedOperation() {
+	ctx := context.Background()
+	pvzResp, _, err := s.createPvz("Kazan", s.getToken("moderator"))
+	s.Require().NoError(err)
+
+	// товар без открытой приёмки не добавляется
+	_, _, err = s.addProduct(pvzResp.PvzId, s.getToken("employee"), "shoes")
+	s.Require().NoError(err)
+
+	var count int
+	err = s.pool.QueryRow(ctx, `SELECT count(*) FROM outbox_event WHERE event_type <> $1`, entity.EventPvzCreated).Scan(&count)
+	s.Require().NoError(err)
+	s.Require().Zero(count)
+}
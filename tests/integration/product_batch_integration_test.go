@@ -0,0 +1,126 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"time"
+)
+
+func (s *TestSuite) openReceptionForBatch() (pvzID, empToken string) {
+	modToken := s.getToken("moderator")
+	pvzResp, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+
+	empToken = s.getToken("employee")
+	_, _, err = s.createReception(pvzResp.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+	return pvzResp.PvzId, empToken
+}
+
+func (s *TestSuite) countReceptionProducts(pvzID string) int {
+	var count int
+	err := s.pool.QueryRow(context.Background(), `
+		SELECT count(*) FROM product p JOIN reception r ON p.reception_id = r.id WHERE r.pvz_id = $1
+	`, pvzID).Scan(&count)
+	s.Require().NoError(err)
+	return count
+}
+
+func (s *TestSuite) TestProductsBatch_PartialMode() {
+	pvzID, empToken := s.openReceptionForBatch()
+
+	var resp dto.ProductsBatchResponse
+	status := s.postJSON("/products/batch", empToken, dto.ProductsBatchRequest{
+		PvzId: pvzID,
+		Mode:  "partial",
+		Products: []dto.ProductsBatchItem{
+			{Type: "electronics", Barcode: "BATCH-1"},
+			{Type: "toys"},
+			{Type: "clothes", Barcode: "BATCH-1"},
+			{Type: "shoes"},
+		},
+	}, &resp)
+
+	s.Require().Equal(http.StatusMultiStatus, status)
+	s.Require().Equal(2, resp.Created)
+	s.Require().Equal(2, resp.Failed)
+	s.Require().Len(resp.Items, 4)
+	s.Require().Equal(errs.ErrInvalidProductType, resp.Items[1].Error.Code)
+	s.Require().Equal(errs.ErrDuplicateBarcode, resp.Items[2].Error.Code)
+	s.Require().NotEmpty(resp.Items[3].ProductId)
+	s.Require().Equal(2, s.countReceptionProducts(pvzID))
+}
+
+// Товары пакета удаляются с конца, как и добавленные по одному
+func (s *TestSuite) TestProductsBatch_DeleteLastRemovesLastItem() {
+	pvzID, empToken := s.openReceptionForBatch()
+
+	var resp dto.ProductsBatchResponse
+	status := s.postJSON("/products/batch", empToken, dto.ProductsBatchRequest{
+		PvzId: pvzID,
+		Products: []dto.ProductsBatchItem{
+			{Type: "electronics", Barcode: "LIFO-1"},
+			{Type: "clothes", Barcode: "LIFO-2"},
+			{Type: "shoes", Barcode: "LIFO-3"},
+		},
+	}, &resp)
+	s.Require().Equal(http.StatusCreated, status)
+
+	for _, barcode := range []string{"LIFO-3", "LIFO-2", "LIFO-1"} {
+		var deleted dto.DeleteProductResponse
+		s.Require().Equal(http.StatusOK, s.postJSON(fmt.Sprintf("/pvz/%s/delete_last_product", pvzID), empToken, struct{}{}, &deleted))
+		s.Require().Equal(barcode, deleted.Barcode)
+	}
+}
+
+func (s *TestSuite) TestProductsBatch_AtomicModeRejectsAll() {
+	pvzID, empToken := s.openReceptionForBatch()
+
+	var resp dto.ProductsBatchResponse
+	status := s.postJSON("/products/batch", empToken, dto.ProductsBatchRequest{
+		PvzId:    pvzID,
+		Products: []dto.ProductsBatchItem{{Type: "electronics"}, {Type: "toys"}},
+	}, &resp)
+
+	s.Require().Equal(http.StatusUnprocessableEntity, status)
+	s.Require().Equal("atomic", resp.Mode)
+	s.Require().Equal(0, resp.Created)
+	s.Require().Equal("skipped", resp.Items[0].Status)
+	s.Require().Equal(0, s.countReceptionProducts(pvzID))
+}
+
+func (s *TestSuite) TestProductsBatch_CSVUpload() {
+	pvzID, empToken := s.openReceptionForBatch()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	s.Require().NoError(writer.WriteField("pvzId", pvzID))
+	part, err := writer.CreateFormFile("file", "products.csv")
+	s.Require().NoError(err)
+	_, err = part.Write([]byte("type,barcode\nelectronics,CSV-1\nclothes,CSV-2\nshoes,\n"))
+	s.Require().NoError(err)
+	s.Require().NoError(writer.Close())
+
+	req, err := http.NewRequest("POST", s.server.URL+"/products/batch/csv", &body)
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", empToken))
+
+	httpResp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer httpResp.Body.Close()
+	s.Require().Equal(http.StatusCreated, httpResp.StatusCode)
+
+	var resp dto.ProductsBatchResponse
+	s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(&resp))
+	s.Require().Equal(3, resp.Created)
+	s.Require().Equal(3, s.countReceptionProducts(pvzID))
+}
@@ -0,0 +1,187 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"order-pick-up-point/internal/models/dto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sseEvent — одно событие потока text/event-stream
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// openActivityStream подписывается на ленту активности ПВЗ и разбирает события в канал.
+// Возвращает после того, как сервер начал поток, то есть подписка уже оформлена.
+func (s *TestSuite) openActivityStream(ctx context.Context, token, lastEventID string, pvzIDs ...string) <-chan sseEvent {
+	query := make([]string, 0, len(pvzIDs))
+	for _, id := range pvzIDs {
+		query = append(query, "pvzId="+id)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", s.server.URL+"/pvz/activity?"+strings.Join(query, "&"), nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 16)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		var e sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if e.Event != "" {
+					events <- e
+				}
+				e = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				e.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				e.Event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				e.Data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return events
+}
+
+func (s *TestSuite) nextActivity(events <-chan sseEvent) dto.PvzActivityEventDTO {
+	select {
+	case e, ok := <-events:
+		s.Require().True(ok, "activity stream closed")
+		var dtoEvent dto.PvzActivityEventDTO
+		s.Require().NoError(json.Unmarshal([]byte(e.Data), &dtoEvent))
+		s.Require().Equal(e.Event, dtoEvent.Type)
+		s.Require().Equal(e.ID, strconv.FormatInt(dtoEvent.Id, 10))
+		return dtoEvent
+	case <-time.After(5 * time.Second):
+		s.FailNow("activity event was not delivered")
+		return dto.PvzActivityEventDTO{}
+	}
+}
+
+func (s *TestSuite) TestPvzActivity_LiveAndResume() {
+	modToken := s.getToken("moderator")
+	empToken := s.getToken("employee")
+
+	pvzResp, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+	otherResp, _, err := s.createPvz("Kazan", modToken)
+	s.Require().NoError(err)
+	pvzID := pvzResp.PvzId
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := s.openActivityStream(ctx, empToken, "", pvzID)
+
+	_, status, err := s.createReception(pvzID, empToken, time.Now())
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusCreated, status)
+	// событие другого ПВЗ в поток не попадает
+	_, _, err = s.createReception(otherResp.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+	product, _, err := s.addProduct(pvzID, empToken, "electronics")
+	s.Require().NoError(err)
+
+	opened := s.nextActivity(events)
+	s.Require().Equal("ReceptionOpened", opened.Type)
+	s.Require().Equal(pvzID, opened.PvzId)
+	added := s.nextActivity(events)
+	s.Require().Equal("ProductAdded", added.Type)
+	s.Require().Equal(product.ProductId, added.AggregateId)
+
+	// клиент отключился, пока товар удаляли и приёмку закрывали
+	cancel()
+	status = s.postJSON("/pvz/"+pvzID+"/delete_last_product", empToken, nil, nil)
+	s.Require().Equal(http.StatusOK, status)
+	_, _, err = s.closeReception(pvzID, empToken)
+	s.Require().NoError(err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	events = s.openActivityStream(ctx, empToken, strconv.FormatInt(added.Id, 10), pvzID)
+	s.Require().Equal("ProductRemoved", s.nextActivity(events).Type)
+	s.Require().Equal("ReceptionClosed", s.nextActivity(events).Type)
+
+	_, _, err = s.createReception(pvzID, empToken, time.Now())
+	s.Require().NoError(err)
+	s.Require().Equal("ReceptionOpened", s.nextActivity(events).Type)
+}
+
+func (s *TestSuite) TestPvzActivity_ResumeAfterOutOfOrderCommit() {
+	modToken := s.getToken("moderator")
+	empToken := s.getToken("employee")
+
+	pvzResp, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+	pvzID := pvzResp.PvzId
+
+	// транзакция получает меньший id, но фиксируется позже события из API
+	ctx := context.Background()
+	tx, err := s.pool.Begin(ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback(ctx)
+	var heldID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO outbox_event (event_type, aggregate_id, pvz_id, payload)
+		VALUES ('ProductAdded', uuid_generate_v4(), $1, '{}')
+		RETURNING id
+	`, pvzID).Scan(&heldID)
+	s.Require().NoError(err)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	events := s.openActivityStream(streamCtx, empToken, "", pvzID)
+	_, _, err = s.createReception(pvzID, empToken, time.Now())
+	s.Require().NoError(err)
+	opened := s.nextActivity(events)
+	s.Require().Equal("ReceptionOpened", opened.Type)
+	s.Require().Greater(opened.Id, heldID)
+
+	// клиент отключился до фиксации более раннего события
+	cancel()
+	s.Require().NoError(tx.Commit(ctx))
+
+	streamCtx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	events = s.openActivityStream(streamCtx, empToken, strconv.FormatInt(opened.Id, 10), pvzID)
+	held := s.nextActivity(events)
+	s.Require().Equal(heldID, held.Id)
+	s.Require().Equal("ProductAdded", held.Type)
+}
+
+func (s *TestSuite) TestPvzActivity_InvalidRequest() {
+	empToken := s.getToken("employee")
+
+	var errResp dto.Error
+	status := s.getJSON("/pvz/activity", empToken, &errResp)
+	s.Require().Equal(http.StatusBadRequest, status)
+	s.Require().Equal("INVALID_REQUEST", errResp.Code)
+
+	status = s.getJSON("/pvz/activity?pvzId=0b0f3c52-6c1f-4a52-9a9c-4f4a2b0a3d11", empToken, &errResp)
+	s.Require().Equal(http.StatusNotFound, status)
+	s.Require().Equal("PVZ_NOT_FOUND", errResp.Code)
+
+	status = s.getJSON("/pvz/activity?pvzId=0b0f3c52-6c1f-4a52-9a9c-4f4a2b0a3d11", s.getToken("client"), nil)
+	s.Require().Equal(http.StatusForbidden, status)
+}
@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"time"
+)
+
+func (s *TestSuite) TestPvzDetails_CreateAndUpdate() {
+	modToken := s.getToken("moderator")
+	lat, lon := 55.7642, 37.6056
+
+	var created dto.CreatePvzResponse
+	status := s.postJSON("/pvz", modToken, dto.CreatePvzPostRequest{
+		City:      "Moscow",
+		Address:   "ul. Tverskaya, 7",
+		Latitude:  &lat,
+		Longitude: &lon,
+		Phone:     "+7 495 123-45-67",
+		OpeningHours: []dto.OpeningHoursDTO{
+			{Weekday: "mon", Opens: "09:00", Closes: "21:00"},
+			{Weekday: "sat", Opens: "10:00", Closes: "18:00"},
+		},
+	}, &created)
+	s.Require().Equal(http.StatusCreated, status)
+
+	closed := "closed"
+	var updated dto.PvzDTO
+	status = s.sendJSON("PATCH", "/pvz/"+created.PvzId, modToken, dto.UpdatePvzRequest{Status: &closed}, &updated)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal("closed", updated.Status)
+	s.Require().Equal("ul. Tverskaya, 7", updated.Address)
+	s.Require().Len(updated.OpeningHours, 2)
+	s.Require().NotNil(updated.Latitude)
+	s.Require().InDelta(lat, *updated.Latitude, 1e-9)
+
+	// Координаты удаляются явно, пропущенные поля не меняются
+	var cleared dto.PvzDTO
+	status = s.sendJSON("PATCH", "/pvz/"+created.PvzId, modToken, dto.UpdatePvzRequest{ClearCoordinates: true}, &cleared)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Nil(cleared.Latitude)
+	s.Require().Nil(cleared.Longitude)
+	s.Require().Equal("closed", cleared.Status)
+
+	var errResp dto.Error
+	status = s.postJSON("/receptions", s.getToken("employee"), dto.CreateReceptionRequest{
+		PvzId:    created.PvzId,
+		DateTime: time.Now(),
+	}, &errResp)
+	s.Require().Equal(http.StatusConflict, status)
+	s.Require().Equal(errs.ErrPvzClosed, errResp.Code)
+}
+
+func (s *TestSuite) TestPvzDetails_InvalidOpeningHours() {
+	var errResp dto.Error
+	status := s.postJSON("/pvz", s.getToken("moderator"), dto.CreatePvzPostRequest{
+		City:         "Kazan",
+		OpeningHours: []dto.OpeningHoursDTO{{Weekday: "mon", Opens: "21:00", Closes: "09:00"}},
+	}, &errResp)
+	s.Require().Equal(http.StatusBadRequest, status)
+	s.Require().Equal(errs.ErrInvalidPvzDetails, errResp.Code)
+}
+
+func (s *TestSuite) TestPvzDetails_UpdateUnknownPvz() {
+	phone := "+7 843 000-00-00"
+	var errResp dto.Error
+	status := s.sendJSON("PATCH", "/pvz/00000000-0000-0000-0000-000000000000", s.getToken("moderator"),
+		dto.UpdatePvzRequest{Phone: &phone}, &errResp)
+	s.Require().Equal(http.StatusNotFound, status)
+	s.Require().Equal(errs.ErrPvzNotFound, errResp.Code)
+}
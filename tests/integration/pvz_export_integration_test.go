@@ -0,0 +1,147 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"net/http"
+	"order-pick-up-point/internal/models/dto"
+	"time"
+)
+
+// exportPvzHistory скачивает выгрузку истории ПВЗ и возвращает тело ответа.
+func (s *TestSuite) exportPvzHistory(query, token string) (int, http.Header, []byte) {
+	req, err := http.NewRequest("GET", s.server.URL+"/pvz/export?"+query, nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	return resp.StatusCode, resp.Header, body
+}
+
+func (s *TestSuite) TestExportPvzHistory_AllFormats() {
+	modToken := s.getToken("moderator")
+	empToken := s.getToken("employee")
+
+	withReception, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+	withoutReception, _, err := s.createPvz("Kazan", modToken)
+	s.Require().NoError(err)
+
+	_, _, err = s.createReception(withReception.PvzId, empToken, time.Now())
+	s.Require().NoError(err)
+	for _, prodType := range []string{"electronics", "shoes"} {
+		_, _, err = s.addProduct(withReception.PvzId, empToken, prodType)
+		s.Require().NoError(err)
+	}
+	_, _, err = s.closeReception(withReception.PvzId, empToken)
+	s.Require().NoError(err)
+
+	// NDJSON: по строке на товар и строка ПВЗ без приёмок
+	status, header, body := s.exportPvzHistory("format=ndjson", modToken)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal("application/x-ndjson", header.Get("Content-Type"))
+
+	var rows []dto.PvzExportRowDTO
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		var row dto.PvzExportRowDTO
+		s.Require().NoError(json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	s.Require().Len(rows, 3)
+	productRows := 0
+	for _, row := range rows {
+		switch row.PvzId {
+		case withReception.PvzId:
+			s.Require().NotEmpty(row.ProductId)
+			s.Require().Equal("close", row.ReceptionStatus)
+			productRows++
+		case withoutReception.PvzId:
+			s.Require().Empty(row.ReceptionId)
+		default:
+			s.Failf("unexpected pvz", "pvz %s", row.PvzId)
+		}
+	}
+	s.Require().Equal(2, productRows)
+
+	// CSV: заголовок и те же три строки
+	status, _, body = s.exportPvzHistory("format=csv", modToken)
+	s.Require().Equal(http.StatusOK, status)
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, 4)
+	s.Require().Equal("pvz_id", records[0][0])
+
+	// XLSX: тот же набор строк на первом листе
+	status, _, body = s.exportPvzHistory("format=xlsx", modToken)
+	s.Require().Equal(http.StatusOK, status)
+	f, err := excelize.OpenReader(bytes.NewReader(body))
+	s.Require().NoError(err)
+	defer f.Close()
+	sheetRows, err := f.GetRows("Sheet1")
+	s.Require().NoError(err)
+	s.Require().Len(sheetRows, 4)
+
+	// период без приёмок оставляет ПВЗ, но без приёмок и товаров
+	status, _, body = s.exportPvzHistory("format=csv&startDate=2020-01-01T00:00:00Z&endDate=2020-01-02T00:00:00Z", modToken)
+	s.Require().Equal(http.StatusOK, status)
+	records, err = csv.NewReader(bytes.NewReader(body)).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, 3)
+	for _, record := range records[1:] {
+		s.Require().Empty(record[5])
+	}
+}
+
+func (s *TestSuite) TestExportPvzHistory_ReadsSeveralCursorBatches() {
+	modToken := s.getToken("moderator")
+	pvzResp, _, err := s.createPvz("Moscow", modToken)
+	s.Require().NoError(err)
+
+	// больше двух порций FETCH, чтобы выгрузка прошла через несколько чтений курсора
+	const products = 2500
+	ctx := context.Background()
+	var receptionID string
+	err = s.pool.QueryRow(ctx,
+		`INSERT INTO reception (date_time, pvz_id, status) VALUES (now(), $1, 'close') RETURNING id`,
+		pvzResp.PvzId,
+	).Scan(&receptionID)
+	s.Require().NoError(err)
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO product (date_time, type, reception_id)
+		SELECT now() + n * interval '1 millisecond', 'electronics', $1
+		FROM generate_series(1, $2::int) AS n
+	`, receptionID, products)
+	s.Require().NoError(err)
+
+	status, _, body := s.exportPvzHistory("format=csv", modToken)
+	s.Require().Equal(http.StatusOK, status)
+
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, products+1)
+
+	seen := make(map[string]bool, products)
+	for _, record := range records[1:] {
+		s.Require().False(seen[record[8]], "duplicate product %s", record[8])
+		seen[record[8]] = true
+	}
+}
+
+func (s *TestSuite) TestExportPvzHistory_EmployeeForbidden() {
+	status, _, _ := s.exportPvzHistory("format=csv", s.getToken("employee"))
+	s.Require().Equal(http.StatusForbidden, status)
+}
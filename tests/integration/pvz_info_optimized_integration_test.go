@@ -0,0 +1,144 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"order-pick-up-point/internal/models/dto"
+	"time"
+)
+
+// normalizePvzInfos приводит время к UTC: N+1 и json_agg возвращают одинаковые моменты
+// времени, но с разными часовыми поясами.
+func normalizePvzInfos(items []dto.PvzGet200ResponseInner) []dto.PvzGet200ResponseInner {
+	for i := range items {
+		items[i].Pvz.RegistrationDate = items[i].Pvz.RegistrationDate.UTC()
+		for j := range items[i].Receptions {
+			rec := &items[i].Receptions[j]
+			rec.Reception.DateTime = rec.Reception.DateTime.UTC()
+			if rec.Reception.ClosedAt != nil {
+				closedAt := rec.Reception.ClosedAt.UTC()
+				rec.Reception.ClosedAt = &closedAt
+			}
+			for k := range rec.Products {
+				rec.Products[k].DateTime = rec.Products[k].DateTime.UTC()
+			}
+		}
+	}
+	return items
+}
+
+// requirePvzInfoEqual проверяет, что /pvz и /pvz/optimized отдают одинаковую страницу и одинаковый курсор.
+func (s *TestSuite) requirePvzInfoEqual(query, token string) ([]dto.PvzGet200ResponseInner, string) {
+	expected, expectedCursor, expectedHasMore := s.getPvzPage("/pvz?"+query, token)
+	actual, actualCursor, actualHasMore := s.getPvzPage("/pvz/optimized?"+query, token)
+
+	s.Require().Equal(normalizePvzInfos(expected), normalizePvzInfos(actual), "query %s", query)
+	s.Require().Equal(expectedCursor, actualCursor)
+	s.Require().Equal(expectedHasMore, actualHasMore)
+	return actual, actualCursor
+}
+
+// seedPvzsWithReceptions создаёт ПВЗ: с несколькими приёмками и товарами, с пустой приёмкой и без приёмок.
+func (s *TestSuite) seedPvzsWithReceptions(receptionDate time.Time) []string {
+	modToken := s.getToken("moderator")
+	empToken := s.getToken("employee")
+
+	var ids []string
+	for i, receptions := range []int{2, 1, 0, 3} {
+		pvzResp, status, err := s.createPvz("Moscow", modToken)
+		s.Require().NoError(err)
+		s.Require().Equal(http.StatusCreated, status)
+		ids = append(ids, pvzResp.PvzId)
+
+		for r := 0; r < receptions; r++ {
+			_, status, err = s.createReception(pvzResp.PvzId, empToken, receptionDate.Add(time.Duration(r)*time.Hour))
+			s.Require().NoError(err)
+			s.Require().Equal(http.StatusCreated, status)
+
+			// у второго ПВЗ приёмка остаётся без товаров
+			if i != 1 {
+				for _, prodType := range []string{"electronics", "clothes", "shoes"} {
+					_, status, err = s.addProduct(pvzResp.PvzId, empToken, prodType)
+					s.Require().NoError(err)
+					s.Require().Equal(http.StatusCreated, status)
+				}
+			}
+
+			_, _, err = s.closeReception(pvzResp.PvzId, empToken)
+			s.Require().NoError(err)
+		}
+	}
+	return ids
+}
+
+func (s *TestSuite) TestPvzInfoOptimized_EqualsNPlusOne_AllPages() {
+	ids := s.seedPvzsWithReceptions(time.Now().Add(-24 * time.Hour))
+	token := s.getToken("moderator")
+
+	// постраничный обход по номерам страниц
+	seen := map[string]bool{}
+	for page := 1; page <= 3; page++ {
+		items, _ := s.requirePvzInfoEqual(fmt.Sprintf("page=%d&limit=2", page), token)
+		for _, item := range items {
+			seen[item.Pvz.Id] = true
+		}
+	}
+	s.Require().Len(seen, len(ids))
+
+	// тот же обход по курсору
+	items, cursor := s.requirePvzInfoEqual("page=1&limit=3", token)
+	s.Require().Len(items, 3)
+	s.Require().NotEmpty(cursor)
+	items, cursor = s.requirePvzInfoEqual("limit=3&cursor="+url.QueryEscape(cursor), token)
+	s.Require().Len(items, 1)
+	s.Require().Empty(cursor)
+}
+
+func (s *TestSuite) TestPvzInfoOptimized_PagesByPvzNotRows() {
+	s.seedPvzsWithReceptions(time.Now().Add(-24 * time.Hour))
+	token := s.getToken("moderator")
+
+	// последний созданный ПВЗ — первый на странице: 3 приёмки по 3 товара не режутся лимитом 1
+	items, _ := s.requirePvzInfoEqual("page=1&limit=1", token)
+	s.Require().Len(items, 1)
+	s.Require().Len(items[0].Receptions, 3)
+	for _, rec := range items[0].Receptions {
+		s.Require().Len(rec.Products, 3)
+	}
+
+	// ПВЗ без приёмок тоже попадает на страницу
+	items, _ = s.requirePvzInfoEqual("page=2&limit=1", token)
+	s.Require().Len(items, 1)
+	s.Require().Empty(items[0].Receptions)
+}
+
+func (s *TestSuite) TestPvzInfoOptimized_DateFilterKeepsPvzs() {
+	receptionDate := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	ids := s.seedPvzsWithReceptions(receptionDate)
+	token := s.getToken("moderator")
+
+	// под фильтр попадает только первая приёмка каждого ПВЗ
+	query := fmt.Sprintf("page=1&limit=10&startDate=%s&endDate=%s",
+		url.QueryEscape(receptionDate.Add(-time.Minute).Format(time.RFC3339)),
+		url.QueryEscape(receptionDate.Add(time.Minute).Format(time.RFC3339)),
+	)
+	items, _ := s.requirePvzInfoEqual(query, token)
+	s.Require().Len(items, len(ids))
+	for _, item := range items {
+		s.Require().LessOrEqual(len(item.Receptions), 1)
+	}
+
+	// фильтр без подходящих приёмок не удаляет ПВЗ из списка
+	query = fmt.Sprintf("page=1&limit=10&startDate=%s&endDate=%s",
+		url.QueryEscape(receptionDate.AddDate(1, 0, 0).Format(time.RFC3339)),
+		url.QueryEscape(receptionDate.AddDate(1, 0, 1).Format(time.RFC3339)),
+	)
+	items, _ = s.requirePvzInfoEqual(query, token)
+	s.Require().Len(items, len(ids))
+	for _, item := range items {
+		s.Require().Empty(item.Receptions)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+)
+
+func (s *TestSuite) TestReturnShipment_FullFlow_Success() {
+	pvzID, productID, clientID, empToken := s.prepareReceivedProduct()
+
+	var prepared dto.PrepareOrderResponse
+	status := s.postJSON(fmt.Sprintf("/pvz/%s/orders", pvzID), empToken,
+		dto.PrepareOrderRequest{ProductId: productID, RecipientId: clientID}, &prepared)
+	s.Require().Equal(http.StatusOK, status)
+
+	// Получатель отказался от заказа
+	status = s.postJSON(fmt.Sprintf("/pvz/%s/orders/%s/return", pvzID, productID), empToken, nil, nil)
+	s.Require().Equal(http.StatusOK, status)
+
+	var created dto.CreateReturnResponse
+	status = s.postJSON("/returns", empToken, dto.CreateReturnRequest{PvzId: pvzID}, &created)
+	s.Require().Equal(http.StatusCreated, status)
+	s.Require().NotEmpty(created.ReturnId)
+
+	var errResp dto.Error
+	status = s.postJSON("/returns", empToken, dto.CreateReturnRequest{PvzId: pvzID}, &errResp)
+	s.Require().Equal(http.StatusConflict, status)
+	s.Require().Equal(errs.ErrOpenReturnExists, errResp.Code)
+
+	var item dto.ReturnItemDTO
+	status = s.postJSON("/returns/items", empToken,
+		dto.AddReturnItemRequest{PvzId: pvzID, ProductId: productID, Reason: "refused"}, &item)
+	s.Require().Equal(http.StatusCreated, status)
+	s.Require().Equal(created.ReturnId, item.ReturnId)
+
+	// Повторно тот же товар добавить нельзя
+	status = s.postJSON("/returns/items", empToken,
+		dto.AddReturnItemRequest{PvzId: pvzID, ProductId: productID, Reason: "refused"}, &errResp)
+	s.Require().Equal(http.StatusConflict, status)
+	s.Require().Equal(errs.ErrInvalidProductStatus, errResp.Code)
+
+	var closed dto.CloseReturnResponse
+	status = s.postJSON(fmt.Sprintf("/pvz/%s/close_last_return", pvzID), empToken, nil, &closed)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal(created.ReturnId, closed.ReturnId)
+}
+
+func (s *TestSuite) TestReturnShipment_DeleteLastItem_RestoresStatus() {
+	pvzID, productID, _, empToken := s.prepareReceivedProduct()
+
+	status := s.postJSON("/returns", empToken, dto.CreateReturnRequest{PvzId: pvzID}, nil)
+	s.Require().Equal(http.StatusCreated, status)
+
+	status = s.postJSON("/returns/items", empToken,
+		dto.AddReturnItemRequest{PvzId: pvzID, ProductId: productID, Reason: "damaged"}, nil)
+	s.Require().Equal(http.StatusCreated, status)
+
+	status = s.postJSON(fmt.Sprintf("/pvz/%s/delete_last_return_item", pvzID), empToken, nil, nil)
+	s.Require().Equal(http.StatusOK, status)
+
+	var errResp dto.Error
+	status = s.postJSON(fmt.Sprintf("/pvz/%s/delete_last_return_item", pvzID), empToken, nil, &errResp)
+	s.Require().Equal(http.StatusUnprocessableEntity, status)
+	s.Require().Equal(errs.ErrNoReturnItemsToDelete, errResp.Code)
+
+	// Товар снова в статусе received и может быть добавлен повторно
+	status = s.postJSON("/returns/items", empToken,
+		dto.AddReturnItemRequest{PvzId: pvzID, ProductId: productID, Reason: "damaged"}, nil)
+	s.Require().Equal(http.StatusCreated, status)
+}
+
+func (s *TestSuite) TestReturnShipment_UnknownPvz_NotFound() {
+	var errResp dto.Error
+	status := s.postJSON("/returns", s.getToken("employee"),
+		dto.CreateReturnRequest{PvzId: "00000000-0000-0000-0000-000000000000"}, &errResp)
+	s.Require().Equal(http.StatusNotFound, status)
+	s.Require().Equal(errs.ErrPvzNotFound, errResp.Code)
+}
@@ -4,6 +4,9 @@ package integration
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"database/sql"
 	"github.com/gin-gonic/gin"
 	"github.com/go-testfixtures/testfixtures/v3"
@@ -11,6 +14,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
 	"net/http/httptest"
+	"order-pick-up-point/internal/activity"
 	"order-pick-up-point/internal/app"
 	"order-pick-up-point/internal/config"
 	controller "order-pick-up-point/internal/controller/http"
@@ -24,6 +28,11 @@ import (
 	"time"
 )
 
+const (
+	testKeyID            = "test-key"
+	testEmailMaxFailures = 3
+)
+
 type TestSuite struct {
 	suite.Suite
 	psqlContainer *testutil.PostgreSQLContainer
@@ -31,6 +40,9 @@ type TestSuite struct {
 	grpcClient    grpc.ClientConnInterface
 	txManager     db.TxManager
 	pool          *pgxpool.Pool
+
+	activityHub      *activity.Hub
+	activityListener *db.PvzActivityListener
 }
 
 func (s *TestSuite) SetupSuite() {
@@ -72,20 +84,52 @@ func (s *TestSuite) SetupSuite() {
 	pvzRepo := db.NewPvzRepository(txManager, log)
 	receptionRepo := db.NewReceptionRepository(txManager, log)
 	productRepo := db.NewProductRepository(txManager, log)
-
-	repo := db.NewRepository(userRepo, pvzRepo, receptionRepo, productRepo)
-
-	tokenService := jwt.NewTokenService(cfg.JWT.SecretKey, cfg.JWT.TokenExpiry)
+	orderRepo := db.NewOrderRepository(txManager, log)
+	returnRepo := db.NewReturnRepository(txManager, log)
+	auditRepo := db.NewAuditRepository(txManager, log)
+	outboxRepo := db.NewOutboxRepository(txManager, log)
+	webhookRepo := db.NewWebhookRepository(txManager, log)
+	tokenRepo := db.NewTokenRepository(txManager, log)
+	loginAttemptRepo := db.NewLoginAttemptRepository(txManager, log)
+
+	repo := db.NewRepository(userRepo, pvzRepo, receptionRepo, productRepo, orderRepo, returnRepo, auditRepo, outboxRepo, webhookRepo, tokenRepo, loginAttemptRepo)
+
+	// Тесты подписывают токены ES256, как в проде с настроенными ключами
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	signingKey, err := jwt.NewKey(testKeyID, signer)
+	s.Require().NoError(err)
+	jwtKeys, err := jwt.NewKeySet(testKeyID, signingKey)
+	s.Require().NoError(err)
+	// Тесты получают токены через /dummyLogin, поэтому он включён независимо от env в конфиге
+	tokenService := jwt.NewTokenService(jwtKeys, cfg.JWT.Issuer, cfg.JWT.Audience, cfg.JWT.TokenExpiry, true)
 	passwordHasher := password.NewBCryptHasher(0)
 
-	authService := httpServ.NewAuthService(repo, txManager, tokenService, passwordHasher, log, cfg.Allowed.Roles)
-	pvzService := httpServ.NewPvzService(repo, txManager, log, cfg.Allowed.Cities, cfg.Allowed.ProductTypes)
+	// Без задержек между попытками, чтобы неудачные входы в тестах не тормозили следующие
+	loginProtection := httpServ.LoginProtectionOptions{
+		Enabled:          true,
+		EmailMaxFailures: testEmailMaxFailures,
+		IPMaxFailures:    100,
+		Lockout:          time.Minute,
+		FailureWindow:    time.Hour,
+	}
+	authService := httpServ.NewAuthService(repo, txManager, tokenService, passwordHasher, log, cfg.Allowed.Roles,
+		time.Duration(cfg.JWT.RefreshTokenExpiry)*time.Second, loginProtection)
+	s.activityHub = activity.NewHub(activity.DefaultBuffer)
+	s.activityListener = db.NewPvzActivityListener(pgPool, log)
+	s.activityListener.Start(context.Background(), s.activityHub.Publish, s.activityHub.Resync)
+
+	pvzService := httpServ.NewPvzService(repo, txManager, log, cfg.Allowed.Cities, cfg.Allowed.ProductTypes, cfg.Allowed.StoragePeriods, s.activityHub)
+	webhookService := httpServ.NewWebhookService(repo, txManager, log)
+	auditService := httpServ.NewAuditService(repo, log)
 
 	authController := controller.NewAuthController(authService)
 	pvzController := controller.NewPvzController(pvzService)
+	webhookController := controller.NewWebhookController(webhookService)
+	auditController := controller.NewAuditController(auditService)
 
 	router := gin.Default()
-	app.SetupRoutes(router, authController, pvzController, tokenService)
+	app.SetupRoutes(router, authController, pvzController, webhookController, auditController, tokenService, repo, app.DummyLoginOptions{Enabled: true})
 
 	s.server = httptest.NewServer(router)
 }
@@ -98,7 +142,7 @@ func (s *TestSuite) SetupTest() {
 
 	// Очищаем все таблицы и сбрасываем идентификаторы
 	_, err = db.Exec(`
-        TRUNCATE TABLE users, pvz, reception, product RESTART IDENTITY CASCADE;
+        TRUNCATE TABLE users, refresh_token, revoked_token, pvz, reception, product, audit_log, outbox_event, webhook_subscription, login_attempt RESTART IDENTITY CASCADE;
     `)
 	s.Require().NoError(err)
 }
@@ -107,6 +151,8 @@ func (s *TestSuite) TearDownSuite() {
 	ctx, ctxCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer ctxCancel()
 
+	s.activityHub.Close()
+	s.Require().NoError(s.activityListener.Stop(ctx))
 	s.Require().NoError(s.psqlContainer.Terminate(ctx))
 
 	s.server.Close()
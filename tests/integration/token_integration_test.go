@@ -0,0 +1,132 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/pkg/jwt"
+)
+
+// refreshTokens обменивает refresh-токен на /token/refresh
+func (s *TestSuite) refreshTokens(refreshToken string) (*dto.TokenResponse, *dto.Error, int) {
+	body, err := json.Marshal(dto.RefreshTokenRequest{RefreshToken: refreshToken})
+	s.Require().NoError(err)
+
+	resp, err := s.server.Client().Post(s.server.URL+"/token/refresh", "application/json", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var tokenResp dto.TokenResponse
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&tokenResp))
+		return &tokenResp, nil, resp.StatusCode
+	}
+	var errResp dto.Error
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&errResp))
+	return nil, &errResp, resp.StatusCode
+}
+
+// authorizedStatus выполняет GET /my/orders с токеном и возвращает код ответа
+func (s *TestSuite) authorizedStatus(token string) int {
+	req, err := http.NewRequest("GET", s.server.URL+"/my/orders", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (s *TestSuite) logout(token string) int {
+	req, err := http.NewRequest("POST", s.server.URL+"/logout", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (s *TestSuite) TestToken_RefreshRotatesAndDetectsReuse() {
+	_, _, err := s.registerUser(dto.RegisterPostRequest{Email: "refresh@example.com", Password: "secret", Role: "client"})
+	s.Require().NoError(err)
+	login, _, err := s.loginUser(dto.LoginPostRequest{Email: "refresh@example.com", Password: "secret"})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(login.RefreshToken)
+	s.Require().Positive(login.ExpiresIn)
+
+	rotated, _, status := s.refreshTokens(login.RefreshToken)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().NotEqual(login.RefreshToken, rotated.RefreshToken)
+	s.Require().Equal(http.StatusOK, s.authorizedStatus(rotated.Token))
+
+	// Повторное использование старого токена отзывает всю сессию
+	_, errResp, status := s.refreshTokens(login.RefreshToken)
+	s.Require().Equal(http.StatusUnauthorized, status)
+	s.Require().Equal(errs.ErrRefreshTokenReused, errResp.Code)
+
+	_, errResp, status = s.refreshTokens(rotated.RefreshToken)
+	s.Require().Equal(http.StatusUnauthorized, status)
+	s.Require().Equal(errs.ErrInvalidRefreshToken, errResp.Code)
+	s.Require().Equal(http.StatusUnauthorized, s.authorizedStatus(rotated.Token))
+	s.Require().Equal(http.StatusUnauthorized, s.authorizedStatus(login.Token))
+}
+
+func (s *TestSuite) TestToken_LogoutRevokesSession() {
+	_, _, err := s.registerUser(dto.RegisterPostRequest{Email: "logout@example.com", Password: "secret", Role: "client"})
+	s.Require().NoError(err)
+	login, _, err := s.loginUser(dto.LoginPostRequest{Email: "logout@example.com", Password: "secret"})
+	s.Require().NoError(err)
+
+	s.Require().Equal(http.StatusNoContent, s.logout(login.Token))
+	s.Require().Equal(http.StatusUnauthorized, s.authorizedStatus(login.Token))
+
+	_, errResp, status := s.refreshTokens(login.RefreshToken)
+	s.Require().Equal(http.StatusUnauthorized, status)
+	s.Require().Equal(errs.ErrInvalidRefreshToken, errResp.Code)
+
+	// Logout токена /dummyLogin отзывает только сам токен
+	dummy := s.getToken("client")
+	s.Require().Equal(http.StatusNoContent, s.logout(dummy))
+	s.Require().Equal(http.StatusUnauthorized, s.authorizedStatus(dummy))
+}
+
+func (s *TestSuite) TestToken_InvalidRefreshToken() {
+	_, errResp, status := s.refreshTokens("unknown")
+	s.Require().Equal(http.StatusUnauthorized, status)
+	s.Require().Equal(errs.ErrInvalidRefreshToken, errResp.Code)
+}
+
+func (s *TestSuite) TestToken_JWKSVerifiesIssuedTokens() {
+	resp, err := s.server.Client().Get(s.server.URL + "/.well-known/jwks.json")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var set jwt.JWKS
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&set))
+	s.Require().Len(set.Keys, 1)
+	s.Require().Equal(testKeyID, set.Keys[0].Kid)
+	s.Require().Equal("EC", set.Keys[0].Kty)
+	s.Require().Equal(jwt.AlgorithmES256, set.Keys[0].Alg)
+
+	// Заголовок и claims токена читаются без проверки подписи: её проверяет сервис
+	token := s.getToken("client")
+	var claims jwt.CustomClaims
+	parsed, _, err := jwtlib.NewParser().ParseUnverified(token, &claims)
+	s.Require().NoError(err)
+	s.Require().Equal(testKeyID, parsed.Header["kid"])
+	s.Require().Equal(jwt.AlgorithmES256, parsed.Method.Alg())
+	s.Require().Equal("order-pick-up-point", claims.Issuer)
+	s.Require().Equal(jwtlib.ClaimStrings{"order-pick-up-point"}, claims.Audience)
+	s.Require().Equal(claims.UserID, claims.Subject)
+	s.Require().NotEmpty(claims.ID)
+}
@@ -0,0 +1,136 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"order-pick-up-point/internal/errs"
+	"order-pick-up-point/internal/models/dto"
+)
+
+func (s *TestSuite) TestUsers_ListAndGet() {
+	s.registerAndLogin("first@example.com", "client")
+	s.registerAndLogin("second@example.com", "employee")
+	thirdID, _ := s.registerAndLogin("third@example.com", "employee")
+	moderator := s.getToken("moderator")
+
+	// Пользователи отдаются от новых к старым, курсор ведёт на следующую страницу
+	req, err := http.NewRequest("GET", s.server.URL+"/users?role=employee&limit=1", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer "+moderator)
+	resp, err := s.server.Client().Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().Equal("true", resp.Header.Get("X-Has-More"))
+	cursor := resp.Header.Get("X-Next-Cursor")
+	s.Require().NotEmpty(cursor)
+
+	var page []dto.User
+	s.Require().Equal(http.StatusOK, s.getJSON("/users?role=employee&limit=1&cursor="+cursor, moderator, &page))
+	s.Require().Len(page, 1)
+	s.Require().Equal("second@example.com", page[0].Email)
+
+	var found []dto.User
+	s.Require().Equal(http.StatusOK, s.getJSON("/users?email=FIRST", moderator, &found))
+	s.Require().Len(found, 1)
+	s.Require().Equal("client", found[0].Role)
+	s.Require().True(found[0].Active)
+
+	var user dto.User
+	s.Require().Equal(http.StatusOK, s.getJSON("/users/"+thirdID, moderator, &user))
+	s.Require().Equal("third@example.com", user.Email)
+
+	s.Require().Equal(http.StatusForbidden, s.getJSON("/users", s.getToken("employee"), nil))
+	s.Require().Equal(http.StatusBadRequest, s.getJSON("/users?role=admin", moderator, nil))
+}
+
+func (s *TestSuite) TestUsers_ChangeRoleRevokesSessions() {
+	userID, token := s.registerAndLogin("promoted@example.com", "client")
+	moderator := s.getToken("moderator")
+
+	var errResp dto.Error
+	s.Require().Equal(http.StatusBadRequest, s.sendJSON("PATCH", "/users/"+userID+"/role", moderator, dto.ChangeRoleRequest{Role: "admin"}, &errResp))
+	s.Require().Equal(errs.ErrInvalidRoleCode, errResp.Code)
+
+	var user dto.User
+	s.Require().Equal(http.StatusOK, s.sendJSON("PATCH", "/users/"+userID+"/role", moderator, dto.ChangeRoleRequest{Role: "employee"}, &user))
+	s.Require().Equal("employee", user.Role)
+
+	// Токен со старой ролью больше не действует
+	s.Require().Equal(http.StatusUnauthorized, s.getJSON("/my/orders", token, nil))
+
+	var entries []dto.AuditEntryDTO
+	s.Require().Equal(http.StatusOK, s.getJSON("/audit?action=user.role_change", moderator, &entries))
+	s.Require().Len(entries, 1)
+	s.Require().Equal(userID, entries[0].EntityId)
+}
+
+func (s *TestSuite) TestUsers_DeactivateAndReactivate() {
+	userID, token := s.registerAndLogin("leaving@example.com", "client")
+	moderator := s.getToken("moderator")
+
+	var user dto.User
+	s.Require().Equal(http.StatusOK, s.postJSON("/users/"+userID+"/deactivate", moderator, nil, &user))
+	s.Require().False(user.Active)
+
+	s.Require().Equal(http.StatusUnauthorized, s.getJSON("/my/orders", token, nil))
+
+	status, errResp, _ := s.postLogin("leaving@example.com", "secret")
+	s.Require().Equal(http.StatusForbidden, status)
+	s.Require().Equal(errs.ErrUserDeactivated, errResp.Code)
+
+	// Неверный пароль не выдаёт, что учётная запись отключена
+	status, errResp, _ = s.postLogin("leaving@example.com", "wrong")
+	s.Require().Equal(http.StatusUnauthorized, status)
+	s.Require().Equal(errs.ErrInvalidCredentials, errResp.Code)
+
+	s.Require().Equal(http.StatusOK, s.postJSON("/users/"+userID+"/reactivate", moderator, nil, &user))
+	s.Require().True(user.Active)
+
+	status, _, _ = s.postLogin("leaving@example.com", "secret")
+	s.Require().Equal(http.StatusOK, status)
+}
+
+func (s *TestSuite) TestUsers_ModeratorCannotDeactivateSelf() {
+	moderatorID, moderator := s.registerAndLogin("self@example.com", "moderator")
+
+	var errResp dto.Error
+	s.Require().Equal(http.StatusBadRequest, s.postJSON("/users/"+moderatorID+"/deactivate", moderator, nil, &errResp))
+	s.Require().Equal(errs.ErrInvalidRequestCode, errResp.Code)
+}
+
+func (s *TestSuite) TestUsers_ResetAndChangePassword() {
+	userID, token := s.registerAndLogin("forgetful@example.com", "client")
+	moderator := s.getToken("moderator")
+
+	var reset dto.PasswordResetResponse
+	s.Require().Equal(http.StatusOK, s.postJSON("/users/"+userID+"/reset_password", moderator, nil, &reset))
+	s.Require().NotEmpty(reset.TemporaryPassword)
+
+	s.Require().Equal(http.StatusUnauthorized, s.getJSON("/my/orders", token, nil))
+	_, status, _ := s.loginUser(dto.LoginPostRequest{Email: "forgetful@example.com", Password: "secret"})
+	s.Require().Equal(http.StatusUnauthorized, status)
+
+	tokens, status, err := s.loginUser(dto.LoginPostRequest{Email: "forgetful@example.com", Password: reset.TemporaryPassword})
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().True(tokens.PasswordChangeRequired)
+
+	var errResp dto.Error
+	s.Require().Equal(http.StatusBadRequest, s.postJSON("/my/password", tokens.Token, dto.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "newsecret"}, &errResp))
+	s.Require().Equal(errs.ErrInvalidCurrentPassword, errResp.Code)
+
+	s.Require().Equal(http.StatusNoContent, s.postJSON("/my/password", tokens.Token, dto.ChangePasswordRequest{CurrentPassword: reset.TemporaryPassword, NewPassword: "newsecret"}, nil))
+
+	// Текущая сессия продолжает работать
+	s.Require().Equal(http.StatusOK, s.getJSON("/my/orders", tokens.Token, nil))
+
+	tokens, status, err = s.loginUser(dto.LoginPostRequest{Email: "forgetful@example.com", Password: "newsecret"})
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().False(tokens.PasswordChangeRequired)
+
+	// Dummy-токен не привязан к учётной записи
+	s.Require().Equal(http.StatusForbidden, s.postJSON("/my/password", s.getToken("client"), dto.ChangePasswordRequest{CurrentPassword: "secret", NewPassword: "newsecret"}, nil))
+}
@@ -0,0 +1,225 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"order-pick-up-point/internal/models/dto"
+	"order-pick-up-point/internal/models/entity"
+	"order-pick-up-point/internal/outbox"
+	"order-pick-up-point/internal/storage/db"
+	"order-pick-up-point/internal/worker"
+	"order-pick-up-point/pkg/logger"
+	"order-pick-up-point/pkg/webhook"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// webhookReceiver — внешний получатель вебхуков, проверяющий подпись каждого запроса
+type webhookReceiver struct {
+	server   *httptest.Server
+	secret   string
+	failing  atomic.Bool
+	mu       sync.Mutex
+	messages []outbox.Message
+	invalid  int
+}
+
+func newWebhookReceiver(secret string) *webhookReceiver {
+	r := &webhookReceiver{secret: secret}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		err := webhook.Verify(r.secret, req.Header.Get(webhook.HeaderSignature), req.Header.Get(webhook.HeaderTimestamp),
+			body, time.Now(), 5*time.Minute)
+		if err != nil {
+			r.invalid++
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var msg outbox.Message
+		if err := json.Unmarshal(body, &msg); err != nil || msg.ID != req.Header.Get(webhook.HeaderEventID) {
+			r.invalid++
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.messages = append(r.messages, msg)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return r
+}
+
+func (r *webhookReceiver) received() ([]outbox.Message, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]outbox.Message(nil), r.messages...), r.invalid
+}
+
+func (s *TestSuite) newWebhookWorker(maxAttempts int) *worker.WebhookWorker {
+	log := logger.NewLogger("dev")
+	return worker.NewWebhookWorker(db.NewWebhookRepository(db.NewTxManager(s.pool, log), log), log, worker.WebhookWorkerOptions{
+		Interval:    time.Minute,
+		BatchSize:   5,
+		MaxAttempts: maxAttempts,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  time.Hour,
+		Timeout:     5 * time.Second,
+		// получатель — httptest-сервер на loopback
+		AllowPrivateNetworks: true,
+	})
+}
+
+// issueOrder готовит и выдаёт заказ получателю
+func (s *TestSuite) issueOrder() (pvzID, productID string) {
+	pvzID, productID, clientID, empToken := s.prepareReceivedProduct()
+
+	status := s.postJSON(fmt.Sprintf("/pvz/%s/orders", pvzID), empToken,
+		dto.PrepareOrderRequest{ProductId: productID, RecipientId: clientID}, nil)
+	s.Require().Equal(http.StatusOK, status)
+	status = s.postJSON(fmt.Sprintf("/pvz/%s/orders/%s/issue", pvzID, productID), empToken,
+		dto.IssueOrderRequest{PickupCode: s.requestPickupCode(productID)}, nil)
+	s.Require().Equal(http.StatusOK, status)
+	return pvzID, productID
+}
+
+func (s *TestSuite) TestWebhook_SignedDeliveryOfSubscribedEvents() {
+	receiver := newWebhookReceiver("0123456789abcdef0123")
+	defer receiver.server.Close()
+	modToken := s.getToken("moderator")
+
+	var created dto.WebhookDTO
+	status := s.postJSON("/webhooks", modToken, dto.CreateWebhookRequest{
+		Url: receiver.server.URL, EventTypes: []string{entity.EventOrderIssued}, Secret: receiver.secret,
+	}, &created)
+	s.Require().Equal(http.StatusCreated, status)
+	s.Require().Equal(receiver.secret, created.Secret)
+
+	pvzID, productID := s.issueOrder()
+	s.newWebhookWorker(3).Deliver(context.Background())
+
+	// из всех событий жизненного цикла подписка получает только выдачу заказа
+	messages, invalid := receiver.received()
+	s.Require().Zero(invalid)
+	s.Require().Len(messages, 1)
+	s.Require().Equal(entity.EventOrderIssued, messages[0].Type)
+	s.Require().Equal(productID, messages[0].AggregateID)
+	s.Require().Equal(pvzID, messages[0].PvzID)
+
+	var deliveries []dto.WebhookDeliveryDTO
+	status = s.getJSON("/webhooks/"+created.Id+"/deliveries", modToken, &deliveries)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(deliveries, 1)
+	s.Require().Equal(entity.WebhookDeliveryDelivered, deliveries[0].Status)
+	s.Require().Equal(messages[0].ID, deliveries[0].EventId)
+
+	var delivery dto.WebhookDeliveryDTO
+	status = s.getJSON(fmt.Sprintf("/webhooks/%s/deliveries/%d", created.Id, deliveries[0].Id), modToken, &delivery)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(delivery.History, 1)
+	s.Require().NotNil(delivery.History[0].StatusCode)
+	s.Require().Equal(http.StatusNoContent, *delivery.History[0].StatusCode)
+
+	// доставленное событие повторно не отправляется
+	s.newWebhookWorker(3).Deliver(context.Background())
+	messages, _ = receiver.received()
+	s.Require().Len(messages, 1)
+}
+
+func (s *TestSuite) TestWebhook_FailedDeliveryAndManualRetry() {
+	receiver := newWebhookReceiver("0123456789abcdef0123")
+	defer receiver.server.Close()
+	receiver.failing.Store(true)
+	modToken := s.getToken("moderator")
+
+	var created dto.WebhookDTO
+	status := s.postJSON("/webhooks", modToken, dto.CreateWebhookRequest{
+		Url: receiver.server.URL, EventTypes: []string{entity.EventOrderIssued}, Secret: receiver.secret,
+	}, &created)
+	s.Require().Equal(http.StatusCreated, status)
+
+	s.issueOrder()
+	s.newWebhookWorker(1).Deliver(context.Background())
+
+	var deliveries []dto.WebhookDeliveryDTO
+	status = s.getJSON("/webhooks/"+created.Id+"/deliveries?status=failed", modToken, &deliveries)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(deliveries, 1)
+	s.Require().Equal(1, deliveries[0].Attempts)
+	s.Require().NotNil(deliveries[0].LastStatusCode)
+	s.Require().Equal(http.StatusServiceUnavailable, *deliveries[0].LastStatusCode)
+
+	// получатель восстановился, модератор возвращает доставку в очередь
+	receiver.failing.Store(false)
+	retryPath := fmt.Sprintf("/webhooks/%s/deliveries/%d/retry", created.Id, deliveries[0].Id)
+	var retried dto.WebhookDeliveryDTO
+	status = s.postJSON(retryPath, modToken, nil, &retried)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal(entity.WebhookDeliveryPending, retried.Status)
+
+	var entries []dto.AuditEntryDTO
+	s.Require().Equal(http.StatusOK, s.getJSON("/audit?action=webhook.delivery_retry", modToken, &entries))
+	s.Require().Len(entries, 1)
+	s.Require().Equal(created.Id, entries[0].EntityId)
+
+	s.newWebhookWorker(1).Deliver(context.Background())
+	messages, _ := receiver.received()
+	s.Require().Len(messages, 1)
+
+	var delivery dto.WebhookDeliveryDTO
+	status = s.getJSON(fmt.Sprintf("/webhooks/%s/deliveries/%d", created.Id, deliveries[0].Id), modToken, &delivery)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal(entity.WebhookDeliveryDelivered, delivery.Status)
+	s.Require().Len(delivery.History, 2)
+
+	// доставленное событие нельзя отправить повторно вручную
+	var errResp dto.Error
+	status = s.postJSON(retryPath, modToken, nil, &errResp)
+	s.Require().Equal(http.StatusConflict, status)
+	s.Require().Equal("DELIVERY_NOT_RETRYABLE", errResp.Code)
+}
+
+func (s *TestSuite) TestWebhook_ValidationAndAccess() {
+	modToken := s.getToken("moderator")
+
+	var errResp dto.Error
+	status := s.postJSON("/webhooks", modToken, dto.CreateWebhookRequest{
+		Url: "ftp://example.com/hook", EventTypes: []string{entity.EventOrderIssued},
+	}, &errResp)
+	s.Require().Equal(http.StatusBadRequest, status)
+	s.Require().Equal("INVALID_WEBHOOK", errResp.Code)
+
+	status = s.postJSON("/webhooks", s.getToken("employee"), dto.CreateWebhookRequest{
+		Url: "https://example.com/hook", EventTypes: []string{entity.EventOrderIssued},
+	}, nil)
+	s.Require().Equal(http.StatusForbidden, status)
+
+	var created dto.WebhookDTO
+	status = s.postJSON("/webhooks", modToken, dto.CreateWebhookRequest{
+		Url: "https://example.com/hook", EventTypes: []string{entity.EventOrderIssued},
+	}, &created)
+	s.Require().Equal(http.StatusCreated, status)
+	s.Require().NotEmpty(created.Secret)
+
+	var got dto.WebhookDTO
+	status = s.getJSON("/webhooks/"+created.Id, modToken, &got)
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Empty(got.Secret)
+
+	status = s.sendJSON("DELETE", "/webhooks/"+created.Id, modToken, nil, nil)
+	s.Require().Equal(http.StatusNoContent, status)
+	status = s.getJSON("/webhooks/"+created.Id, modToken, &errResp)
+	s.Require().Equal(http.StatusNotFound, status)
+	s.Require().Equal("WEBHOOK_NOT_FOUND", errResp.Code)
+}